@@ -163,6 +163,9 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("onionpass") {
 			*cx.Config.OnionProxyPass = c.String("onionpass")
 		}
+		if c.IsSet("externalonion") {
+			*cx.Config.ExternalOnion = c.String("externalonion")
+		}
 		if c.IsSet("torisolation") {
 			*cx.Config.TorIsolation = c.Bool("torisolation")
 		}
@@ -227,6 +230,9 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("nocheckpoints") {
 			*cx.Config.DisableCheckpoints = c.Bool("nocheckpoints")
 		}
+		if c.IsSet("forkschedule") {
+			*cx.Config.ForkSchedule = c.String("forkschedule")
+		}
 		if c.IsSet("dbtype") {
 			*cx.Config.DbType = c.String("dbtype")
 		}
@@ -239,6 +245,9 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("upnp") {
 			*cx.Config.UPNP = c.Bool("upnp")
 		}
+		if c.IsSet("v2transport") {
+			*cx.Config.V2Transport = c.Bool("v2transport")
+		}
 		if c.IsSet("minrelaytxfee") {
 			*cx.Config.MinRelayTxFee = c.Float64("minrelaytxfee")
 		}
@@ -282,6 +291,15 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("controller") {
 			*cx.Config.Controller = c.String("controller")
 		}
+		if c.IsSet("controllerpriority") {
+			*cx.Config.ControllerPriority = c.Int("controllerpriority")
+		}
+		if c.IsSet("stratum") {
+			*cx.Config.StratumListener = c.String("stratum")
+		}
+		if c.IsSet("stratumuser") {
+			*cx.Config.StratumUsers = c.StringSlice("stratumuser")
+		}
 		if c.IsSet("miningaddrs") {
 			*cx.Config.MiningAddrs = c.StringSlice("miningaddrs")
 		}
@@ -290,6 +308,9 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 			Debug("--------- set minerpass", *cx.Config.MinerPass)
 			cx.StateCfg.Save = true
 		}
+		if c.IsSet("workername") {
+			*cx.Config.WorkerName = c.String("workername")
+		}
 		if c.IsSet("blockminsize") {
 			*cx.Config.BlockMinSize = c.Int("blockminsize")
 		}
@@ -336,12 +357,18 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("noaddrindex") {
 			*cx.Config.AddrIndex = c.Bool("noaddrindex")
 		}
+		if c.IsSet("spendindex") {
+			*cx.Config.SpendIndex = c.Bool("spendindex")
+		}
 		if c.IsSet("relaynonstd") {
 			*cx.Config.RelayNonStd = c.Bool("relaynonstd")
 		}
 		if c.IsSet("rejectnonstd") {
 			*cx.Config.RejectNonStd = c.Bool("rejectnonstd")
 		}
+		if c.IsSet("rejectreplacement") {
+			*cx.Config.RejectReplacement = c.Bool("rejectreplacement")
+		}
 		if c.IsSet("noinitialload") {
 			*cx.Config.NoInitialLoad = c.Bool("noinitialload")
 		}
@@ -23,6 +23,7 @@ func Configure(cx *conte.Xt, commandName string, initial bool) {
 	initDictionary(cx.Config)
 	initParams(cx)
 	initDataDir(cx.Config)
+	initLanguage(cx)
 	initTLSStuffs(cx.Config, cx.StateCfg)
 	initConfigFile(cx.Config)
 	initLogDir(cx.Config)
@@ -35,6 +36,7 @@ func Configure(cx *conte.Xt, commandName string, initial bool) {
 	normalizeAddresses(cx.Config)
 	setRelayReject(cx.Config)
 	validateDBtype(cx.Config)
+	validateForkSchedule(cx)
 	validateProfilePort(cx.Config)
 	validateBanDuration(cx.Config)
 	validateWhitelists(cx.Config, cx.StateCfg)
@@ -49,13 +51,13 @@ func Configure(cx *conte.Xt, commandName string, initial bool) {
 	// if the user set the save flag, or file doesn't exist save the file now
 	if cx.StateCfg.Save || !apputil.FileExists(*cx.Config.ConfigFile) {
 		cx.StateCfg.Save = false
-		if commandName == "kopach" {
-			return
+		if commandName != "kopach" {
+			Debug("saving configuration")
+			save.Pod(cx.Config)
 		}
-		Debug("saving configuration")
-		save.Pod(cx.Config)
 	}
 	if cx.ActiveNet.Name == netparams.TestNet3Params.Name {
 		fork.IsTestnet = true
 	}
+	initReload(cx)
 }
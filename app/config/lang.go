@@ -2,16 +2,33 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/util/lang"
 )
 
+// LangDir is the folder in the data directory holding the catalogue files of the languages of the user interface.
+const LangDir = "lang"
+
 func appLang(lang string) string {
 	if lang == "" || lang == "en_US" {
-		homeLang := os.Getenv("LANG")
+		// take the language of the locale of the system, such as es for es_ES.UTF-8
+		homeLang := os.Getenv("LC_ALL")
+		if homeLang == "" {
+			homeLang = os.Getenv("LANG")
+		}
+		if i := strings.IndexAny(homeLang, "_.@"); i >= 0 {
+			homeLang = homeLang[:i]
+		}
 		switch homeLang {
-		case "sr_RS":
+		case "sr":
 			lang = "rs"
-		default:
+		case "", "C", "POSIX":
 			lang = "en"
+		default:
+			lang = homeLang
 		}
 	}
 	return lang
@@ -20,3 +37,11 @@ func appLang(lang string) string {
 func Lang(lang string) string {
 	return appLang(lang)
 }
+
+// initLanguage loads the catalogue files in the data directory and shows the user interface in the configured language.
+func initLanguage(cx *conte.Xt) {
+	if err := lang.LoadDir(filepath.Join(*cx.Config.DataDir, LangDir)); Check(err) {
+	}
+	if err := cx.Language.SetLanguage(*cx.Config.Language); Check(err) {
+	}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/app/apputil"
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/pod"
+	"github.com/p9c/pod/pkg/util/interrupt"
+)
+
+// reloadInterval is how often the configuration file is checked for changes
+const reloadInterval = time.Second
+
+// reloadSignals are the signals that make pod reload its configuration, SIGHUP on the platforms that have it
+var reloadSignals []os.Signal
+
+var reloadOnce sync.Once
+
+// initReload lets the configuration be reloaded while running, registering the hooks every part of pod handles the same
+// way and watching for the configuration file to change or the process to receive SIGHUP.
+func initReload(cx *conte.Xt) {
+	if !apputil.FileExists(*cx.Config.ConfigFile) {
+		Debug("there is no configuration file to reload")
+		return
+	}
+	if err := cx.Hooks.Load(cx.Config); Check(err) {
+		return
+	}
+	reloadOnce.Do(
+		func() {
+			cx.Hooks.Register(
+				"loglevel", func([]pod.Change) {
+					initLogLevel(cx.Config)
+				},
+			)
+			cx.Hooks.Register(
+				"language", func([]pod.Change) {
+					initLanguage(cx)
+				},
+			)
+			cx.Hooks.Register(
+				pod.Restart, func(changes []pod.Change) {
+					if *cx.Config.PipeLog {
+						// processes run by another one are restarted by it, as it gets the same changes
+						Info("settings changed that take effect when restarted:", fieldNames(changes))
+						return
+					}
+					Warn("restarting for changed settings:", fieldNames(changes))
+					interrupt.RequestRestart()
+				},
+			)
+			go watchConfig(cx)
+		},
+	)
+}
+
+// watchConfig reloads the configuration when its file changes or the process receives a reload signal, until pod shuts
+// down.
+func watchConfig(cx *conte.Xt) {
+	sig := make(chan os.Signal, 1)
+	if len(reloadSignals) > 0 {
+		signal.Notify(sig, reloadSignals...)
+		defer signal.Stop(sig)
+	}
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	last := fileStamp(*cx.Config.ConfigFile)
+	for {
+		select {
+		case <-ticker.C:
+			if stamp := fileStamp(*cx.Config.ConfigFile); stamp != last {
+				last = stamp
+				reloadConfig(cx)
+			}
+		case s := <-sig:
+			Info("reloading configuration on", s)
+			reloadConfig(cx)
+		case <-cx.KillAll.Wait():
+			return
+		}
+	}
+}
+
+// stamp identifies a version of a file by its size and the time it was written
+type stamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func fileStamp(path string) (s stamp) {
+	if fi, err := os.Stat(path); err == nil {
+		s = stamp{size: fi.Size(), modTime: fi.ModTime()}
+	}
+	return
+}
+
+func reloadConfig(cx *conte.Xt) {
+	changes, err := cx.Hooks.Reload()
+	if Check(err) {
+		return
+	}
+	if len(changes) > 0 {
+		Info("reloaded configuration, changed", fieldNames(changes))
+	}
+}
+
+// fieldNames returns the names of the settings of changes
+func fieldNames(changes []pod.Change) (names []string) {
+	for _, c := range changes {
+		names = append(names, c.Field)
+	}
+	return
+}
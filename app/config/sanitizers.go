@@ -33,6 +33,7 @@ import (
 	"github.com/p9c/pod/cmd/node/state"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/hardfork"
 	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/util/logi"
 )
@@ -442,6 +443,20 @@ func validateDBtype(cfg *pod.Config) {
 	}
 }
 
+func validateForkSchedule(cx *conte.Xt) {
+	// Load the fork schedule that replaces the compiled in one.
+	Trace("validating fork schedule")
+	if *cx.Config.ForkSchedule == "" {
+		return
+	}
+	if err := hardfork.LoadScheduleFile(*cx.Config.ForkSchedule, cx.ActiveNet); err != nil {
+		err = fmt.Errorf("%s: invalid fork schedule: %v", funcName, err)
+		Error(err)
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		interrupt.Request()
+	}
+}
+
 func validateProfilePort(cfg *pod.Config) {
 	// Validate profile port number
 	Trace("validating profile port number")
@@ -725,6 +740,19 @@ func validateOnions(cfg *pod.Config) {
 	if !*cfg.Onion {
 		*cfg.OnionProxy = ""
 	}
+	// The external onion has to be the address of an onion service, with or without a port.
+	if *cfg.ExternalOnion != "" {
+		host := *cfg.ExternalOnion
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !strings.HasSuffix(host, ".onion") {
+			str := "%s: external onion '%s' is not an onion address"
+			err := fmt.Errorf(str, funcName, *cfg.ExternalOnion)
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			*cfg.ExternalOnion = ""
+		}
+	}
 	
 }
 
@@ -0,0 +1,12 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+func init() {
+	reloadSignals = []os.Signal{syscall.SIGHUP}
+}
@@ -46,6 +46,8 @@ type Xt struct {
 	ActiveNet *netparams.Params
 	// Language libraries
 	Language *lang.Lexicon
+	// Hooks applies the settings changed in the configuration file while running
+	Hooks *pod.Hooks
 	// DataDir is the default data dir
 	DataDir string
 	// Node is the run state of the node
@@ -135,6 +137,7 @@ func GetNewContext(appName, appLang, subtext string) *Xt {
 		ConfigMap:        configMap,
 		StateCfg:         new(state.Config),
 		Language:         lang.ExportLanguage(appLang),
+		Hooks:            pod.NewHooks(),
 		DataDir:          appdata.Dir(appName, false),
 		// WalletChan:       make(chan *wallet.Wallet),
 		NodeChan:         make(chan *chainrpc.Server),
@@ -151,7 +154,7 @@ func GetNewContext(appName, appLang, subtext string) *Xt {
 func GetContext(cx *Xt) *chainrpc.Context {
 	return &chainrpc.Context{
 		Config: cx.Config, StateCfg: cx.StateCfg, ActiveNet: cx.ActiveNet,
-		Hashrate: cx.Hashrate,
+		Hashrate: cx.Hashrate, Hooks: cx.Hooks,
 	}
 }
 
@@ -34,6 +34,8 @@ type Xt struct {
 	ActiveNet *netparams.Params
 	// Language libraries
 	Language *lang.Lexicon
+	// Hooks applies the settings changed in the configuration file while running
+	Hooks *pod.Hooks
 	// DataDir is the default data dir
 	DataDir string
 	// Node is the run state of the node
@@ -61,6 +63,7 @@ func GetNewContext(appName, appLang, subtext string) *Xt {
 		Config:   pod.EmptyConfig(),
 		StateCfg: new(state.Config),
 		Language: lang.ExportLanguage(appLang),
+		Hooks:    pod.NewHooks(),
 		DataDir:  appdata.Dir(appName, false),
 	}
 }
@@ -90,6 +90,7 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 							cx.StateCfg.DropAddrIndex = true
 							cx.StateCfg.DropTxIndex = true
 							cx.StateCfg.DropCfIndex = true
+							cx.StateCfg.DropSpendIndex = true
 							return nodeHandle(cx)(c)
 							// return nil
 						},
@@ -106,6 +107,45 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 						au.SubCommands(),
 						nil,
 					),
+					au.Command("dropspendindex",
+						"drop the spent output index",
+						func(c *cli.Context) error {
+							cx.StateCfg.DropSpendIndex = true
+							return nodeHandle(cx)(c)
+						},
+						au.SubCommands(),
+						nil,
+					),
+					au.Command("importblocks",
+						"import the blocks in a bootstrap file, resuming an interrupted import of the same file",
+						nodeImportBlocksHandle(cx),
+						au.SubCommands(),
+						[]cli.Flag{
+							cli.StringFlag{
+								Name:  "file, f",
+								Usage: "bootstrap file to import, bootstrap.dat in the network data directory when empty",
+							},
+						},
+					),
+					au.Command("exportblocks",
+						"export the blocks of the best chain to a bootstrap file",
+						nodeExportBlocksHandle(cx),
+						au.SubCommands(),
+						[]cli.Flag{
+							cli.StringFlag{
+								Name:  "file, f",
+								Usage: "bootstrap file to write, bootstrap.dat in the network data directory when empty",
+							},
+							cli.IntFlag{
+								Name:  "start, s",
+								Usage: "height of the first block to export",
+							},
+							cli.IntFlag{
+								Name:  "end, e",
+								Usage: "height of the last block to export, 0 = the chain tip",
+							},
+						},
+					),
 					au.Command("resetchain",
 						"reset the chain",
 						func(c *cli.Context) (err error) {
@@ -150,7 +190,29 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 			au.Command("shell", "start combined wallet/node shell",
 				ShellHandle(cx), au.SubCommands(), nil, "s"),
 			au.Command("kopach", "standalone miner for clusters",
-				KopachHandle(cx), au.SubCommands(), nil, "k"),
+				KopachHandle(cx), au.SubCommands(
+					au.Command("bench",
+						"measure the hash rate of each algorithm with a range of thread counts and the expected"+
+							" share of blocks found with each at the current targets",
+						kopachBenchHandle(cx),
+						au.SubCommands(),
+						[]cli.Flag{
+							cli.IntFlag{
+								Name:  "threads, t",
+								Usage: "largest number of threads to measure with, 0 = all cores",
+							},
+							cli.DurationFlag{
+								Name:  "duration, d",
+								Value: time.Second * 2,
+								Usage: "how long to measure each algorithm and thread count for",
+							},
+							cli.StringFlag{
+								Name:  "output, o",
+								Usage: "file to write the results to as JSON, they are printed as JSON when empty",
+							},
+						},
+					),
+				), nil, "k"),
 			au.Command(
 				"worker",
 				"single thread parallelcoin miner controlled with binary IPC interface on stdin/stdout; "+
@@ -160,6 +222,20 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				au.SubCommands(),
 				nil,
 			),
+			au.Command("lang",
+				"list the languages of the user interface and how many messages each has no translation for, or the"+
+					" untranslated messages of the language given",
+				langHandle(cx),
+				au.SubCommands(
+					au.Command("template",
+						"write a catalogue file of the language given with every message to translate into the lang"+
+							" folder of the data directory, keeping the translations it already has",
+						langTemplateHandle(cx),
+						au.SubCommands(),
+						nil,
+					),
+				),
+				nil),
 			au.Command("init",
 				"steps through creation of new wallet and initialization for a network with these specified "+
 					"in the main",
@@ -187,7 +263,7 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 			cli.StringFlag{
 				Name:        "lang, L",
 				Value:       *cx.Config.Language,
-				Usage:       "sets the data directory base for a pod instance",
+				Usage:       "sets the language of the user interface",
 				EnvVar:      "POD_LANGUAGE",
 				Destination: cx.Config.Language,
 			},
@@ -303,6 +379,12 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"Password for onion proxy server",
 				genPassword(),
 				cx.Config.OnionProxyPass),
+			au.String(
+				"externalonion",
+				"Onion service address of this node to advertise to"+
+					" peers (eg. <56 characters>.onion:11047)",
+				"",
+				cx.Config.ExternalOnion),
 			au.Bool(
 				"torisolation",
 				"Enable Tor stream isolation by randomizing user credentials"+
@@ -407,6 +489,12 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"Disable built-in checkpoints.  Don't do this unless"+
 					" you know what you're doing.",
 				cx.Config.DisableCheckpoints),
+			au.String(
+				"forkschedule",
+				"File with the hard fork schedule to use instead of the"+
+					" compiled in one, not allowed on mainnet",
+				"",
+				cx.Config.ForkSchedule),
 			au.String(
 				"dbtype",
 				"Database backend to use for the Block Chain",
@@ -427,6 +515,10 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"upnp",
 				"Use UPnP to map our listening port outside of NAT",
 				cx.Config.UPNP),
+			au.Bool(
+				"v2transport",
+				"Encrypt peer connections with the v2 transport (BIP 324) where the peer supports it",
+				cx.Config.V2Transport),
 			au.Float64(
 				"minrelaytxfee",
 				"The minimum transaction fee in DUO/kB to be"+
@@ -479,6 +571,23 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 					" and other node peers",
 				":0",
 				cx.Config.Controller),
+			au.Int(
+				"controllerpriority",
+				"priority of the miner controller in the election between"+
+					" controllers on the LAN, workers prefer the highest",
+				0,
+				cx.Config.ControllerPriority),
+			au.String(
+				"stratum",
+				"address the stratum server for external mining software"+
+					" listens on, disabled when empty",
+				"",
+				cx.Config.StratumListener),
+			au.StringSlice(
+				"stratumuser",
+				"user:password pair that miners authorize with on the stratum"+
+					" listener, which must differ from the RPC credentials",
+				cx.Config.StratumUsers),
 			au.Bool(
 				"autoports",
 				"uses random automatic ports for p2p, rpc and controller",
@@ -494,6 +603,13 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"password to authorise sending work to a miner",
 				genPassword(),
 				cx.Config.MinerPass),
+			au.String(
+				"workername",
+				"name the shares of this miner are recorded under by the"+
+					" mining controller, a random name kept in the data"+
+					" directory is used when empty",
+				"",
+				cx.Config.WorkerName),
 			au.Int(
 				"blockminsize",
 				"Minimum block size in bytes to be used when"+
@@ -556,12 +672,21 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"Disable address-based transaction index which makes the searchrawtransactions RPC available",
 				cx.Config.AddrIndex,
 			),
+			au.Bool(
+				"spendindex",
+				"Maintain an index of the transaction spending each output which makes the gettxspendingprevout RPC available",
+				cx.Config.SpendIndex,
+			),
 			au.Bool(
 				"relaynonstd",
 				"Relay non-standard transactions regardless of the default settings for the active network.",
 				cx.Config.RelayNonStd), au.Bool("rejectnonstd",
 				"Reject non-standard transactions regardless of the default settings for the active network.",
 				cx.Config.RejectNonStd),
+			au.Bool(
+				"rejectreplacement",
+				"Reject transactions that replace transactions in the mempool, even those signalling replacement by fee.",
+				cx.Config.RejectReplacement),
 			au.Bool(
 				"noinitialload",
 				"Defer wallet creation/opening on startup and enable loading wallets over RPC (loading not yet implemented)",
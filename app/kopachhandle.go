@@ -1,7 +1,11 @@
 package app
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"text/tabwriter"
 	
 	"github.com/p9c/pod/pkg/util/interrupt"
 	
@@ -10,6 +14,7 @@ import (
 	"github.com/urfave/cli"
 	
 	"github.com/p9c/pod/cmd/kopach"
+	"github.com/p9c/pod/cmd/kopach/bench"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
 	
@@ -37,3 +42,50 @@ func KopachHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
 		return
 	}
 }
+
+// kopachBenchHandle measures the hash rate of the mining algorithms and prints a summary, writing the results to the
+// output file given, or printing them as JSON without a file
+func kopachBenchHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		if cx.ActiveNet.Name == netparams.TestNet3Params.Name {
+			fork.IsTestnet = true
+		}
+		interrupt.AddHandler(
+			func() {
+				cx.KillAll.Q()
+			},
+		)
+		r := bench.Run(bench.Config{Threads: bench.ThreadCounts(c.Int("threads")), Duration: c.Duration("duration")},
+			cx.KillAll)
+		var out []byte
+		if out, err = json.MarshalIndent(r, "", "  "); Check(err) {
+			return
+		}
+		output := c.String("output")
+		if output == "" {
+			fmt.Println(string(out))
+			return
+		}
+		if err = ioutil.WriteFile(output, out, 0644); Check(err) {
+			return
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "algo\tversion\tthreads\thashes/s")
+		for _, a := range r.Algos {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\n", a.Algo, a.Version, a.Threads, a.HashesPerSecond)
+		}
+		fmt.Fprintln(tw, "\ndivhash with\t\tthreads\thashes/s")
+		for _, f := range r.Functions {
+			fmt.Fprintf(tw, "%s\t\t%d\t%.2f\n", f.Algo, f.Threads, f.HashesPerSecond)
+		}
+		fmt.Fprintln(tw, "\nalgo\tbits\tshare\tseconds/block")
+		for _, s := range r.Shares {
+			fmt.Fprintf(tw, "%s\t%08x\t%.2f%%\t%.4g\n", s.Algo, s.Bits, s.Share*100, s.SecondsPerBlock)
+		}
+		if err = tw.Flush(); Check(err) {
+		}
+		Info("benchmark results written to", output)
+		return
+	}
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/p9c/pod/app/config"
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/util/lang"
+)
+
+// cliComponent is the component of the language catalogue holding the help of the command line.
+const cliComponent = "cli"
+
+// walkApp calls fn with the ID and the help text of every command and flag of the command line, which fn may change.
+// Commands are named CMD_ followed by their path, such as CMD_NODE_DROPADDRINDEX, and flags FLAG_ followed by the path
+// of their command and their name, such as FLAG_NODE_IMPORTBLOCKS_FILE.
+func walkApp(a *cli.App, fn func(id string, usage *string)) {
+	walkFlags("FLAG", a.Flags, fn)
+	walkCommands("", a.Commands, fn)
+}
+
+func walkCommands(path string, cmds []cli.Command, fn func(id string, usage *string)) {
+	for i := range cmds {
+		p := path + "_" + messageID(cmds[i].Name)
+		fn("CMD"+p, &cmds[i].Usage)
+		walkFlags("FLAG"+p, cmds[i].Flags, fn)
+		walkCommands(p, cmds[i].Subcommands, fn)
+	}
+}
+
+func walkFlags(prefix string, flags []cli.Flag, fn func(id string, usage *string)) {
+	for i := range flags {
+		v := reflect.ValueOf(flags[i])
+		byValue := v.Kind() != reflect.Ptr
+		if byValue {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			v = p
+		}
+		usage := v.Elem().FieldByName("Usage")
+		if !usage.IsValid() || usage.Kind() != reflect.String {
+			continue
+		}
+		name := strings.Split(flags[i].GetName(), ",")[0]
+		fn(prefix+"_"+messageID(name), usage.Addr().Interface().(*string))
+		if byValue {
+			// flags held by value are replaced by the changed copy
+			flags[i] = v.Elem().Interface().(cli.Flag)
+		}
+	}
+}
+
+// messageID returns the ID of the message of a command or flag name.
+func messageID(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", " ", "").Replace(name))
+}
+
+// localizeApp adds the help of the command line to the language catalogue and shows it in the language given in the
+// environment or the locale of the system, using the catalogue files of the default data directory, as the help is
+// printed before the configuration is read.
+func localizeApp(cx *conte.Xt) {
+	var defs []lang.Text
+	walkApp(cx.App, func(id string, usage *string) {
+		defs = append(defs, lang.Text{ID: id, Definition: *usage})
+	})
+	lang.Register(lang.Com{
+		Component: cliComponent,
+		Languages: []lang.Language{{Code: lang.DefaultLanguage, Definitions: defs}},
+	})
+	if err := lang.LoadDir(filepath.Join(argsDataDir(os.Args, *cx.Config.DataDir), config.LangDir)); Check(err) {
+	}
+	if err := cx.Language.SetLanguage(config.Lang(os.Getenv("POD_LANGUAGE"))); err != nil {
+		// the help stays in English for the languages there are no messages in
+		Debug(err)
+		return
+	}
+	cx.App.Description = cx.Language.RenderText("goApp_DESCRIPTION")
+	cx.App.Copyright = cx.Language.RenderText("goApp_COPYRIGHT")
+	walkApp(cx.App, func(id string, usage *string) {
+		*usage = cx.Language.RenderText(cliComponent + "_" + id)
+	})
+}
+
+// argsDataDir returns the data directory given in the arguments or the environment, as the datadir flag sets it, or the
+// default data directory.
+func argsDataDir(args []string, def string) string {
+	if dir := os.Getenv("POD_DATADIR"); dir != "" {
+		def = dir
+	}
+	for i := 1; i < len(args); i++ {
+		switch name := strings.TrimLeft(args[i], "-"); {
+		case (name == "D" || name == "datadir") && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(name, "D=") || strings.HasPrefix(name, "datadir="):
+			return name[strings.IndexByte(name, '=')+1:]
+		}
+	}
+	return def
+}
+
+// langHandle lists the languages of the user interface with the number of messages each has no translation for, or
+// the untranslated messages of the language given.
+func langHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		if code := c.Args().First(); code != "" {
+			en := lang.ExportLanguage(lang.DefaultLanguage)
+			for _, id := range lang.Missing(code) {
+				fmt.Fprintf(tw, "%s\t%q\n", id, en.RenderText(id))
+			}
+			return tw.Flush()
+		}
+		for _, code := range lang.Languages() {
+			fmt.Fprintf(tw, "%s\t%d untranslated\n", code, len(lang.Missing(code)))
+		}
+		return tw.Flush()
+	}
+}
+
+// langTemplateHandle writes the catalogue file of a language for translators to the data directory, keeping the
+// translations it already has.
+func langTemplateHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		code := c.Args().First()
+		if code == "" {
+			return errors.New("the code of the language is required, such as es")
+		}
+		var path string
+		if path, err = lang.WriteTemplate(filepath.Join(*cx.Config.DataDir, config.LangDir), code); Check(err) {
+			return
+		}
+		fmt.Println("wrote", path)
+		return
+	}
+}
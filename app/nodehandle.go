@@ -1,11 +1,15 @@
 package app
 
 import (
+	"path/filepath"
+	
 	"github.com/urfave/cli"
 	
 	"github.com/p9c/pod/app/apputil"
 	"github.com/p9c/pod/app/config"
 	"github.com/p9c/pod/cmd/walletmain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
 	qu "github.com/p9c/pod/pkg/util/quit"
 	
 	"github.com/p9c/pod/app/conte"
@@ -68,3 +72,38 @@ func nodeHandle(cx *conte.Xt) func(c *cli.Context) error {
 		return nil
 	}
 }
+
+// bootstrapFile returns the bootstrap file given to importblocks or exportblocks, by default bootstrap.dat in the data
+// directory of the network
+func bootstrapFile(cx *conte.Xt, c *cli.Context) string {
+	if f := c.String("file"); f != "" {
+		return f
+	}
+	return filepath.Join(*cx.Config.DataDir, cx.ActiveNet.Name, "bootstrap.dat")
+}
+
+// nodeImportBlocksHandle adds the blocks in a bootstrap file to the block database
+func nodeImportBlocksHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		if cx.ActiveNet.Name == netparams.TestNet3Params.Name {
+			fork.IsTestnet = true
+		}
+		if err = node.ImportBlocks(cx, bootstrapFile(cx, c)); Check(err) {
+		}
+		return
+	}
+}
+
+// nodeExportBlocksHandle writes the blocks of the best chain to a bootstrap file
+func nodeExportBlocksHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		if cx.ActiveNet.Name == netparams.TestNet3Params.Name {
+			fork.IsTestnet = true
+		}
+		if err = node.ExportBlocks(cx, bootstrapFile(cx, c), int32(c.Int("start")), int32(c.Int("end"))); Check(err) {
+		}
+		return
+	}
+}
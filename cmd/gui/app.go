@@ -100,7 +100,7 @@ func (wg *WalletGUI) GetAppWidget() (a *gui.App) {
 								SpaceEvenly().
 								AlignMiddle().
 								Rigid(
-									wg.H4("are you sure?").Color(wg.MainApp.BodyColorGet()).Alignment(text.Middle).Fn,
+									wg.H4(wg.T("ARE_YOU_SURE")).Color(wg.MainApp.BodyColorGet()).Alignment(text.Middle).Fn,
 								).
 								Rigid(
 									wg.Flex().
@@ -116,7 +116,7 @@ func (wg *WalletGUI) GetAppWidget() (a *gui.App) {
 													},
 												),
 											).Color("Light").TextScale(5).Text(
-												"yes!!!",
+												wg.T("YES"),
 											).Fn,
 										).
 										Flexed(0.5, gui.EmptyMaxWidth()).
@@ -183,16 +183,16 @@ func (wg *WalletGUI) GetAppWidget() (a *gui.App) {
 	a.SideBar(
 		[]l.Widget{
 			// wg.SideBarButton(" ", " ", 11),
-			wg.SideBarButton("home", "home", 0),
-			wg.SideBarButton("send", "send", 1),
-			wg.SideBarButton("receive", "receive", 2),
-			wg.SideBarButton("history", "history", 3),
+			wg.SideBarButton("HOME", "home", 0),
+			wg.SideBarButton("SEND", "send", 1),
+			wg.SideBarButton("RECEIVE", "receive", 2),
+			wg.SideBarButton("HISTORY", "history", 3),
 			// wg.SideBarButton("explorer", "explorer", 6),
 			// wg.SideBarButton("mining", "mining", 7),
-			wg.SideBarButton("console", "console", 9),
-			wg.SideBarButton("settings", "settings", 5),
+			wg.SideBarButton("CONSOLE", "console", 9),
+			wg.SideBarButton("SETTINGS", "settings", 5),
 			// wg.SideBarButton("log", "log", 10),
-			wg.SideBarButton("help", "help", 8),
+			wg.SideBarButton("HELP", "help", 8),
 			// wg.SideBarButton(" ", " ", 11),
 			// wg.SideBarButton("quit", "quit", 11),
 		},
@@ -304,8 +304,10 @@ func (wg *WalletGUI) Page(title string, widget gui.Widgets) func(gtx l.Context)
 	}
 }
 
-func (wg *WalletGUI) SideBarButton(title, page string, index int) func(gtx l.Context) l.Dimensions {
+// SideBarButton shows the button of a page in the side bar, labelled with the message with the given ID
+func (wg *WalletGUI) SideBarButton(id, page string, index int) func(gtx l.Context) l.Dimensions {
 	return func(gtx l.Context) l.Dimensions {
+		title := wg.T(id)
 		var scale float32
 		scale = gui.Scales["H6"]
 		var color string
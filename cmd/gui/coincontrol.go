@@ -0,0 +1,166 @@
+package gui
+
+import (
+	"sort"
+	"sync"
+
+	l "gioui.org/layout"
+	uberatomic "go.uber.org/atomic"
+
+	"github.com/p9c/pod/pkg/gui"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// coinSelectionStrategies are the coin selection strategies offered by the coin control dialog, the default first.
+var coinSelectionStrategies = []string{"largest-first", "branch-and-bound", "privacy", "consolidate-dust"}
+
+// coinSelectionLabels are the IDs of the messages naming the coin selection strategies.
+var coinSelectionLabels = map[string]string{
+	"largest-first":    "STRATEGY_LARGEST_FIRST",
+	"branch-and-bound": "STRATEGY_BRANCH_AND_BOUND",
+	"privacy":          "STRATEGY_PRIVACY",
+	"consolidate-dust": "STRATEGY_CONSOLIDATE_DUST",
+}
+
+// CoinControl is the coin control dialog of the send page. It chooses the unspent outputs of the wallet that a payment
+// spends, either one by one or by picking the strategy the wallet chooses them with.
+type CoinControl struct {
+	wg         *WalletGUI
+	open       *uberatomic.Bool
+	toggle     *gui.Clickable
+	strategy   *gui.Enum
+	checkables []*gui.Checkable
+	mx         sync.Mutex
+	unspent    []btcjson.ListUnspentResult
+	selected   []*gui.Bool
+}
+
+// GetCoinControl creates the coin control dialog, closed and with the default strategy picked.
+func (wg *WalletGUI) GetCoinControl() (cc *CoinControl) {
+	cc = &CoinControl{
+		wg:       wg,
+		open:     uberatomic.NewBool(false),
+		toggle:   wg.Clickable(),
+		strategy: wg.Enum().SetValue(coinSelectionStrategies[0]),
+	}
+	for range coinSelectionStrategies {
+		cc.checkables = append(cc.checkables, wg.Checkable())
+	}
+	return
+}
+
+// Button shows the button that opens and closes the dialog. Opening it loads the unspent outputs of the wallet.
+func (cc *CoinControl) Button() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		wg := cc.wg
+		return wg.ButtonLayout(
+			cc.toggle.SetClick(
+				func() {
+					Debug("clicked coin control button")
+					if cc.open.Toggle() {
+						return
+					}
+					go cc.refresh()
+				},
+			),
+		).
+			Background("Primary").
+			Embed(
+				wg.Inset(
+					0.5,
+					wg.H6(wg.T("COINS")).Color("Light").Fn,
+				).
+					Fn,
+			).
+			Fn(gtx)
+	}
+}
+
+// Fn shows the strategies and the unspent outputs to choose from while the dialog is open, and nothing otherwise.
+func (cc *CoinControl) Fn(gtx l.Context) l.Dimensions {
+	if !cc.open.Load() {
+		return l.Dimensions{}
+	}
+	wg := cc.wg
+	f := wg.VFlex().
+		Rigid(
+			wg.Inset(0.25,
+				wg.Caption(wg.T("COIN_CONTROL")).Color("DocText").Fn,
+			).Fn,
+		)
+	for i, name := range coinSelectionStrategies {
+		f = f.Rigid(wg.RadioButton(cc.checkables[i], cc.strategy, name, wg.T(coinSelectionLabels[name])).Fn)
+	}
+	cc.mx.Lock()
+	defer cc.mx.Unlock()
+	for i, u := range cc.unspent {
+		label := wg.TN("COIN", int(u.Confirmations), wg.Amount(u.Amount), u.Address)
+		f = f.Rigid(
+			wg.CheckBox(cc.selected[i]).
+				TextColor("DocText").
+				TextScale(1).
+				Text(label).
+				IconScale(1).
+				Fn,
+		)
+	}
+	return wg.Fill("DocBg", l.W, 0, 0, wg.Inset(0.25, f.Fn).Fn).Fn(gtx)
+}
+
+// Options returns the coin control options of a payment: the outputs checked in the dialog, or the strategy picked when
+// none are. It returns nil while the dialog is closed or the default strategy is picked.
+func (cc *CoinControl) Options() *btcjson.CoinControlOpts {
+	if !cc.open.Load() {
+		return nil
+	}
+	cc.mx.Lock()
+	defer cc.mx.Unlock()
+	var inputs []btcjson.TransactionInput
+	for i, u := range cc.unspent {
+		if cc.selected[i].GetValue() {
+			inputs = append(inputs, btcjson.TransactionInput{Txid: u.TxID, Vout: u.Vout})
+		}
+	}
+	if len(inputs) != 0 {
+		return &btcjson.CoinControlOpts{Inputs: inputs}
+	}
+	if strategy := cc.strategy.Value(); strategy != coinSelectionStrategies[0] {
+		return &btcjson.CoinControlOpts{Strategy: &strategy}
+	}
+	return nil
+}
+
+// Reset clears the choice of outputs after a payment and loads the outputs left to spend.
+func (cc *CoinControl) Reset() {
+	cc.strategy.SetValue(coinSelectionStrategies[0])
+	if cc.open.Load() {
+		cc.refresh()
+	}
+}
+
+// refresh loads the spendable outputs of the default account, which is the one payments are sent from, largest first.
+func (cc *CoinControl) refresh() {
+	wg := cc.wg
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	all, err := wg.WalletClient.ListUnspentMin(0)
+	if Check(err) {
+		return
+	}
+	var unspent []btcjson.ListUnspentResult
+	for _, u := range all {
+		if u.Spendable && u.Account == "default" {
+			unspent = append(unspent, u)
+		}
+	}
+	sort.SliceStable(unspent, func(i, j int) bool { return unspent[i].Amount > unspent[j].Amount })
+	cc.mx.Lock()
+	cc.unspent = unspent
+	cc.selected = cc.selected[:0]
+	for range unspent {
+		cc.selected = append(cc.selected, wg.Bool(false))
+	}
+	cc.mx.Unlock()
+	wg.invalidate <- struct{}{}
+}
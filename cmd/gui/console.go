@@ -21,6 +21,7 @@ import (
 
 type Console struct {
 	*gui.Window
+	wg             *WalletGUI
 	output         []l.Widget
 	outputList     *gui.List
 	editor         *gui.Editor
@@ -40,6 +41,7 @@ func (wg *WalletGUI) ConsolePage() *Console {
 	Debug("running ConsolePage")
 	c := &Console{
 		Window:         wg.Window,
+		wg:             wg,
 		editor:         wg.Editor().SingleLine().Submit(true),
 		clearClickable: wg.Clickable(),
 		copyClickable:  wg.Clickable(),
@@ -255,9 +257,9 @@ func (wg *WalletGUI) ConsolePage() *Console {
 		ButtonInset(0.25)
 	c.output = append(
 		c.output, func(gtx l.Context) l.Dimensions {
-			return c.Theme.Flex().AlignStart().Rigid(c.H6("Welcome to the Parallelcoin RPC console").Color("DocText").Fn).Fn(gtx)
+			return c.Theme.Flex().AlignStart().Rigid(c.H6(wg.T("CONSOLE_WELCOME")).Color("DocText").Fn).Fn(gtx)
 		}, func(gtx l.Context) l.Dimensions {
-			return c.Theme.Flex().AlignStart().Rigid(c.Caption("Type 'help' to get available commands and 'clear' or 'cls' to clear the screen").Color("DocText").Fn).Fn(gtx)
+			return c.Theme.Flex().AlignStart().Rigid(c.Caption(wg.T("CONSOLE_USAGE")).Color("DocText").Fn).Fn(gtx)
 		},
 	)
 	return c
@@ -296,7 +298,7 @@ func (c *Console) Fn(gtx l.Context) l.Dimensions {
 				c.Theme.Flex().
 					Flexed(
 						1,
-						c.TextInput(c.editor.SetSubmit(c.submitFunc), "enter an rpc command").
+						c.TextInput(c.editor.SetSubmit(c.submitFunc), c.wg.T("CONSOLE_HINT")).
 							Color("DocText").
 							Fn,
 					).
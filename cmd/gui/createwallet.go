@@ -32,7 +32,7 @@ func (wg *WalletGUI) CreateWalletPage(gtx l.Context) l.Dimensions {
 						AlignMiddle().
 						SpaceSides().
 						Rigid(
-							wg.H4("create new wallet").
+							wg.H4(wg.T("CREATE_NEW_WALLET")).
 								Color("PanelText").
 								Fn,
 						).
@@ -105,13 +105,13 @@ func (wg *WalletGUI) CreateWalletPage(gtx l.Context) l.Dimensions {
 									).
 										IconColor("Primary").
 										TextColor("DocText").
-										Text("Use testnet?").
+										Text(wg.T("USE_TESTNET")).
 										Fn(gtx)
 								},
 							).Fn,
 						).
 						Rigid(
-							wg.Body1("your seed").
+							wg.Body1(wg.T("YOUR_SEED")).
 								Color("PanelText").
 								Fn,
 						).
@@ -149,10 +149,7 @@ func (wg *WalletGUI) CreateWalletPage(gtx l.Context) l.Dimensions {
 									).
 										IconColor("Primary").
 										TextColor("DocText").
-										Text(
-											"I have stored the seed and password safely " +
-												"and understand it cannot be recovered",
-										).
+										Text(wg.T("SEED_STORED")).
 										Fn(gtx)
 								},
 							).Fn,
@@ -239,7 +236,7 @@ func (wg *WalletGUI) CreateWalletPage(gtx l.Context) l.Dimensions {
 											).
 											CornerRadius(0).
 											Inset(0.5).
-											Text("create wallet").
+											Text(wg.T("CREATE_WALLET")).
 											Fn,
 									).
 									Fn(gtx)
@@ -14,7 +14,7 @@ func (wg *WalletGUI) HelpPage() func(gtx l.Context) l.Dimensions {
 		return wg.VFlex().AlignMiddle().
 			Flexed(0.5, gui.EmptyMaxWidth()).
 			Rigid(
-				wg.H5("ParallelCoin Pod Gio Wallet").Alignment(text.Middle).Fn,
+				wg.H5(wg.T("HELP_TITLE")).Alignment(text.Middle).Fn,
 			).
 			Rigid(
 				wg.Fill("DocBg", l.Center, wg.TextSize.V, 0, wg.Inset(0.5,
@@ -25,7 +25,7 @@ func (wg *WalletGUI) HelpPage() func(gtx l.Context) l.Dimensions {
 							wg.VFlex().AlignMiddle().
 								Rigid(
 									wg.Inset(0.25,
-										wg.Caption("Built from git repository:").
+										wg.Caption(wg.T("BUILT_FROM")).
 											Font("bariol bold").Fn,
 									).Fn,
 								).
@@ -40,7 +40,7 @@ func (wg *WalletGUI) HelpPage() func(gtx l.Context) l.Dimensions {
 							wg.VFlex().AlignMiddle().
 								Rigid(
 									wg.Inset(0.25,
-										wg.Caption("GitRef:").
+										wg.Caption(wg.T("GIT_REF")).
 											Font("bariol bold").Fn,
 									).Fn,
 								).
@@ -55,7 +55,7 @@ func (wg *WalletGUI) HelpPage() func(gtx l.Context) l.Dimensions {
 							wg.VFlex().AlignMiddle().
 								Rigid(
 									wg.Inset(0.25,
-										wg.Caption("GitCommit:").
+										wg.Caption(wg.T("GIT_COMMIT")).
 											Font("bariol bold").Fn,
 									).Fn,
 								).
@@ -70,7 +70,7 @@ func (wg *WalletGUI) HelpPage() func(gtx l.Context) l.Dimensions {
 							wg.VFlex().AlignMiddle().
 								Rigid(
 									wg.Inset(0.25,
-										wg.Caption("BuildTime:").
+										wg.Caption(wg.T("BUILD_TIME")).
 											Font("bariol bold").Fn,
 									).Fn,
 								).
@@ -85,7 +85,7 @@ func (wg *WalletGUI) HelpPage() func(gtx l.Context) l.Dimensions {
 							wg.VFlex().AlignMiddle().
 								Rigid(
 									wg.Inset(0.25,
-										wg.Caption("Tag:").
+										wg.Caption(wg.T("TAG")).
 											Font("bariol bold").Fn,
 									).Fn,
 								).
@@ -102,7 +102,7 @@ func (wg *WalletGUI) HelpPage() func(gtx l.Context) l.Dimensions {
 								Fn,
 						).
 						Rigid(
-							wg.Caption("powered by Gio").Fn,
+							wg.Caption(wg.T("POWERED_BY")).Fn,
 						).
 						Fn,
 				).Fn).Fn,
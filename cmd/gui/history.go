@@ -2,8 +2,11 @@ package gui
 
 import (
 	l "gioui.org/layout"
+	"gioui.org/text"
 	
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/gui"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 )
 
 func (wg *WalletGUI) HistoryPage() l.Widget {
@@ -66,6 +69,7 @@ func (wg *WalletGUI) HistoryPage() l.Widget {
 
 func (wg *WalletGUI) HistoryPageView() l.Widget {
 	return wg.VFlex().
+		Rigid(wg.HistoryLabelEditor()).
 		Rigid(
 			// wg.Fill("DocBg", l.Center, wg.TextSize.V, 0,
 			// 	wg.Inset(0.25,
@@ -79,7 +83,7 @@ func (wg *WalletGUI) HistoryPageStatusFilter() l.Widget {
 	return wg.Flex().AlignMiddle().
 		Rigid(
 			wg.Inset(0.25,
-				wg.Caption("show").Fn,
+				wg.Caption(wg.T("SHOW")).Fn,
 			).Fn,
 		).
 		Rigid(
@@ -88,7 +92,7 @@ func (wg *WalletGUI) HistoryPageStatusFilter() l.Widget {
 					return wg.CheckBox(wg.bools["showGenerate"]).
 						TextColor("DocText").
 						TextScale(1).
-						Text("generate").
+						Text(wg.T("CATEGORY_GENERATE")).
 						IconScale(1).
 						Fn(gtx)
 				},
@@ -100,7 +104,7 @@ func (wg *WalletGUI) HistoryPageStatusFilter() l.Widget {
 					return wg.CheckBox(wg.bools["showSent"]).
 						TextColor("DocText").
 						TextScale(1).
-						Text("sent").
+						Text(wg.T("SENT")).
 						IconScale(1).
 						Fn(gtx)
 				},
@@ -112,7 +116,7 @@ func (wg *WalletGUI) HistoryPageStatusFilter() l.Widget {
 					return wg.CheckBox(wg.bools["showReceived"]).
 						TextColor("DocText").
 						TextScale(1).
-						Text("received").
+						Text(wg.T("RECEIVED")).
 						IconScale(1).
 						Fn(gtx)
 				},
@@ -124,7 +128,7 @@ func (wg *WalletGUI) HistoryPageStatusFilter() l.Widget {
 					return wg.CheckBox(wg.bools["showImmature"]).
 						TextColor("DocText").
 						TextScale(1).
-						Text("immature").
+						Text(wg.T("CATEGORY_IMMATURE")).
 						IconScale(1).
 						Fn(gtx)
 				},
@@ -132,3 +136,183 @@ func (wg *WalletGUI) HistoryPageStatusFilter() l.Widget {
 		).
 		Fn
 }
+
+// HistoryLabelEditor shows an editor for the comment of the transaction selected in the history list, and nothing
+// while none is selected.
+func (wg *WalletGUI) HistoryLabelEditor() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if wg.historySelected.Load() == "" {
+			return l.Dimensions{}
+		}
+		return wg.Fill("DocBg", l.W, 0, 0,
+			wg.Inset(0.25,
+				wg.Flex().AlignMiddle().
+					Flexed(1,
+						wg.Inset(0.25,
+							wg.inputs["historyLabel"].Fn,
+						).Fn,
+					).
+					Rigid(
+						wg.ButtonLayout(
+							wg.clickables["historyLabelSave"].
+								SetClick(
+									func() {
+										Debug("clicked save comment button")
+										txID := wg.historySelected.Load()
+										label := wg.inputs["historyLabel"].GetText()
+										go wg.saveTxLabel(txID, label)
+									},
+								),
+						).
+							Background("Primary").
+							Embed(
+								wg.Inset(
+									0.5,
+									wg.H6(wg.T("SAVE")).Color("Light").Fn,
+								).
+									Fn,
+							).
+							Fn,
+					).
+					Rigid(wg.HistorySpeedUpButton()).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+// HistorySpeedUpButton shows a button that replaces the transaction selected in the history list with one paying a
+// higher fee, while it is an unconfirmed send that can still be replaced, and nothing otherwise.
+func (wg *WalletGUI) HistorySpeedUpButton() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		txID := wg.historySelected.Load()
+		if !wg.canSpeedUp(txID) {
+			return l.Dimensions{}
+		}
+		return wg.Inset(0.25,
+			wg.ButtonLayout(
+				wg.clickables["historySpeedUp"].
+					SetClick(
+						func() {
+							Debug("clicked speed up button")
+							go wg.speedUpTx(txID)
+						},
+					),
+			).
+				Background("Primary").
+				Embed(
+					wg.Inset(
+						0.5,
+						wg.H6(wg.T("SPEED_UP")).Color("Light").Fn,
+					).
+						Fn,
+				).
+				Fn,
+		).Fn(gtx)
+	}
+}
+
+// canSpeedUp returns whether a transaction in the history list is an unconfirmed send that signals it can be replaced
+// by one paying a higher fee.
+func (wg *WalletGUI) canSpeedUp(txID string) bool {
+	wg.txMx.Lock()
+	defer wg.txMx.Unlock()
+	for i := range wg.txHistoryList {
+		tx := &wg.txHistoryList[i]
+		if tx.TxID == txID && tx.Category == "send" {
+			return tx.Confirmations == 0 && tx.BIP125Replaceable == "yes"
+		}
+	}
+	return false
+}
+
+// speedUpTx replaces an unconfirmed transaction with one paying the least higher fee that the network accepts and
+// refreshes the transaction lists to show the replacement in its place.
+func (wg *WalletGUI) speedUpTx(txID string) {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	txHash, err := chainhash.NewHashFromStr(txID)
+	if Check(err) {
+		return
+	}
+	var res *btcjson.BumpFeeResult
+	if res, err = wg.WalletClient.BumpFee(txHash, nil); Check(err) {
+		// TODO: indicate this to the user somehow
+		return
+	}
+	Debug("replaced transaction", txID, "with", res.TxID, "raising the fee from", res.OrigFee, "to", res.Fee)
+	wg.historySelected.Store("")
+	wg.inputs["historyLabel"].SetText("")
+	wg.processWalletBlockNotification()
+	wg.invalidate <- struct{}{}
+}
+
+// saveTxLabel stores the comment of a transaction in the wallet and shows it in the transaction lists without waiting
+// for them to be fetched again.
+func (wg *WalletGUI) saveTxLabel(txID, label string) {
+	if !wg.WalletAndClientRunning() {
+		return
+	}
+	txHash, err := chainhash.NewHashFromStr(txID)
+	if Check(err) {
+		return
+	}
+	if err = wg.WalletClient.SetTxLabel(txHash, label); Check(err) {
+		// TODO: indicate this to the user somehow
+		return
+	}
+	wg.historySelected.Store("")
+	wg.inputs["historyLabel"].SetText("")
+	wg.txMx.Lock()
+	for _, list := range [][]btcjson.ListTransactionsResult{wg.txHistoryList, wg.txRecentList} {
+		for i := range list {
+			if list[i].TxID == txID {
+				list[i].Comment = label
+			}
+		}
+	}
+	wg.txMx.Unlock()
+	wg.RecentTransactions(10, "recent")
+	wg.RecentTransactions(-1, "history")
+	wg.invalidate <- struct{}{}
+}
+
+// TxCommentRow shows the comment kept with a transaction and the label of the address it paid. Rows in the history
+// list can be clicked to edit the comment.
+func (wg *WalletGUI) TxCommentRow(listName string, i int, txs btcjson.ListTransactionsResult) l.Widget {
+	comment := txs.Comment
+	if comment == "" && listName == "history" {
+		comment = wg.T("ADD_COMMENT")
+	}
+	row := wg.Inset(0.25,
+		wg.Flex().
+			Flexed(1,
+				wg.Caption(comment).Color("PanelText").MaxLines(1).Fn,
+			).
+			Rigid(
+				wg.Caption(txs.Label).
+					Color("PanelText").
+					MaxLines(1).
+					Alignment(text.End).
+					Fn,
+			).Fn,
+	).Fn
+	if listName != "history" {
+		return wg.Fill("DocBg", l.W, 0, 0, row).Fn
+	}
+	return func(gtx l.Context) l.Dimensions {
+		return wg.ButtonLayout(
+			wg.historyClickables[i].SetClick(
+				func() {
+					Debug("clicked history list item", i)
+					wg.historySelected.Store(txs.TxID)
+					wg.inputs["historyLabel"].SetText(txs.Comment)
+				},
+			),
+		).
+			Background("DocBg").
+			Embed(row).
+			Fn(gtx)
+	}
+}
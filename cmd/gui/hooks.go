@@ -0,0 +1,59 @@
+package gui
+
+import (
+	"github.com/p9c/pod/pkg/pod"
+)
+
+// registerHooks lets the GUI take up the settings changed in the configuration file or on the settings page while it
+// is running, starting and stopping the node, wallet and miner it runs as they are turned on and off.
+func (wg *WalletGUI) registerHooks() {
+	cfg := wg.cx.Config
+	wg.cx.Hooks.Register(
+		"node", func([]pod.Change) {
+			switch {
+			case *cfg.NodeOff && wg.node.Running():
+				Debug("node turned off")
+				wg.node.Stop()
+			case !*cfg.NodeOff && !wg.node.Running() && wg.ready.Load():
+				Debug("node turned on")
+				wg.node.Start()
+			}
+		},
+	)
+	wg.cx.Hooks.Register(
+		"wallet", func([]pod.Change) {
+			switch {
+			case *cfg.WalletOff && wg.wallet.Running():
+				Debug("wallet turned off")
+				wg.wallet.Stop()
+			case !*cfg.WalletOff && !wg.wallet.Running() && wg.ready.Load():
+				Debug("wallet turned on")
+				wg.wallet.Start()
+			}
+		},
+	)
+	// the miner takes up changes of the number of threads itself, so it is only started and stopped here
+	miner := func([]pod.Change) {
+		wg.incdecs["generatethreads"].SetCurrent(*cfg.GenThreads)
+		switch {
+		case (!*cfg.Generate || *cfg.GenThreads == 0) && wg.miner.Running():
+			Debug("miner turned off")
+			wg.miner.Stop()
+		case *cfg.Generate && *cfg.GenThreads != 0 && !wg.miner.Running():
+			Debug("miner turned on")
+			wg.miner.Start()
+		}
+		wg.invalidate <- struct{}{}
+	}
+	wg.cx.Hooks.Register("generate", miner)
+	wg.cx.Hooks.Register("genthreads", miner)
+	wg.cx.Hooks.Register(
+		"theme", func([]pod.Change) {
+			wg.Colors.SetTheme(*wg.Dark)
+			// the transaction lists are built when they change rather than with each frame
+			wg.RecentTransactions(10, "recent")
+			wg.RecentTransactions(-1, "history")
+			wg.invalidate <- struct{}{}
+		},
+	)
+}
@@ -0,0 +1,87 @@
+package gui
+
+// guiComponent is the component of the language catalogue holding the messages of the wallet GUI.
+const guiComponent = "gui"
+
+// inputHints are the IDs of the messages shown in the text inputs while they are empty.
+var inputHints = map[string]string{
+	"receiveAmount":  "AMOUNT",
+	"receiveMessage": "DESCRIPTION",
+	"sendAddress":    "ADDRESS",
+	"sendAmount":     "AMOUNT",
+	"sendMessage":    "DESCRIPTION",
+	"historyLabel":   "COMMENT",
+	"console":        "CONSOLE_HINT",
+	"walletSeed":     "WALLET_SEED",
+}
+
+// passwordHints are the IDs of the messages shown in the password inputs while they are empty.
+var passwordHints = map[string]string{
+	"passEditor":        "PASSWORD",
+	"confirmPassEditor": "CONFIRM_PASSWORD",
+	"publicPassEditor":  "PUBLIC_PASSWORD",
+}
+
+// T returns a message of the GUI in the language of the user interface, with the arguments formatted into it.
+func (wg *WalletGUI) T(id string, a ...interface{}) string {
+	return wg.cx.Language.RenderText(guiComponent+"_"+id, a...)
+}
+
+// TN returns the form of a message of the GUI for a count in the language of the user interface, with the count and
+// then the arguments formatted into it.
+func (wg *WalletGUI) TN(id string, n int, a ...interface{}) string {
+	return wg.cx.Language.RenderPlural(guiComponent+"_"+id, n, a...)
+}
+
+// Amount returns an amount in DUO with all of its decimals as the language of the user interface writes numbers.
+func (wg *WalletGUI) Amount(duo float64) string {
+	return wg.cx.Language.FormatNumber(duo, 8)
+}
+
+// category returns the name of a category of transactions in the language of the user interface.
+func (wg *WalletGUI) category(c string) string {
+	switch c {
+	case "generate":
+		return wg.T("CATEGORY_GENERATE")
+	case "immature":
+		return wg.T("CATEGORY_IMMATURE")
+	case "receive":
+		return wg.T("CATEGORY_RECEIVE")
+	case "send":
+		return wg.T("CATEGORY_SEND")
+	case "orphan":
+		return wg.T("CATEGORY_ORPHAN")
+	}
+	return wg.T("CATEGORY_UNKNOWN")
+}
+
+// translate puts the messages kept by widgets into the language of the user interface. Messages rendered with each
+// frame change with it by themselves.
+func (wg *WalletGUI) translate() {
+	for name, id := range inputHints {
+		if in, ok := wg.inputs[name]; ok {
+			in.SetHint(wg.T(id))
+		}
+	}
+	for name, id := range passwordHints {
+		if p, ok := wg.passwords[name]; ok {
+			p.SetHint(wg.T(id))
+		}
+	}
+	if wg.unlockPassword != nil {
+		wg.unlockPassword.SetHint(wg.T("UNLOCK_PASSWORD"))
+	}
+}
+
+// watchLanguage shows the GUI in the language of the user interface, and again whenever it is changed.
+func (wg *WalletGUI) watchLanguage() {
+	wg.translate()
+	wg.cx.Language.OnChange(func(code string) {
+		Debug("language changed to", code)
+		wg.translate()
+		// the transaction lists are built when they change rather than with each frame
+		wg.RecentTransactions(10, "recent")
+		wg.RecentTransactions(-1, "history")
+		wg.invalidate <- struct{}{}
+	})
+}
@@ -31,7 +31,7 @@ func (wg *WalletGUI) getLoadingPage() (a *gui.App) {
 					gui.WidgetSize{
 						Widget:
 						func(gtx l.Context) l.Dimensions {
-							return a.Flex().Flexed(1, a.Direction().Center().Embed(a.H1("loading").Fn).Fn).Fn(gtx)
+							return a.Flex().Flexed(1, a.Direction().Center().Embed(a.H1(wg.T("LOADING")).Fn).Fn).Fn(gtx)
 						},
 					},
 				},
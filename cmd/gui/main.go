@@ -79,6 +79,8 @@ type WalletGUI struct {
 	statusBarButtons             []*gui.Clickable
 	receiveAddressbookClickables []*gui.Clickable
 	sendAddressbookClickables    []*gui.Clickable
+	historyClickables            []*gui.Clickable
+	historySelected              *uberatomic.String
 	quitClickable                *gui.Clickable
 	bools                        BoolMap
 	lists                        ListMap
@@ -117,6 +119,7 @@ func (wg *WalletGUI) Run() (err error) {
 	wg.Syncing = uberatomic.NewBool(false)
 	wg.stateLoaded = uberatomic.NewBool(false)
 	wg.currentReceiveRegenerate = uberatomic.NewBool(true)
+	wg.historySelected = uberatomic.NewString("")
 	// wg.currentReceiveGetNew = uberatomic.NewBool(false)
 	wg.ready = uberatomic.NewBool(false)
 	// wg.th = gui.NewTheme(p9fonts.Collection(), wg.quit)
@@ -163,6 +166,8 @@ func (wg *WalletGUI) Run() (err error) {
 	wg.State = GetNewState(wg.cx.ActiveNet, wg.MainApp.ActivePageGetAtomic())
 	wg.unlockPage = wg.getWalletUnlockAppWidget()
 	wg.loadingPage = wg.getLoadingPage()
+	wg.watchLanguage()
+	wg.registerHooks()
 	// wg.Watcher()
 	if !apputil.FileExists(*wg.cx.Config.WalletFile) {
 		Info("wallet file does not exist", *wg.cx.Config.WalletFile)
@@ -281,15 +286,17 @@ func (wg *WalletGUI) GetInputs() InputMap {
 	// seedString := hex.EncodeToString(seed)
 	seedString := "f4d2c4c542bb52512ed9e6bbfa2d000e576a0c8b4ebd1acafd7efa37247366bc"
 	return InputMap{
-		"receiveAmount":  wg.Input("", "Amount", "DocText", "PanelBg", "DocBg", func(amt string) {}),
-		"receiveMessage": wg.Input("", "Description", "DocText", "PanelBg", "DocBg", func(pass string) {}),
+		"receiveAmount":  wg.Input("", "", "DocText", "PanelBg", "DocBg", func(amt string) {}),
+		"receiveMessage": wg.Input("", "", "DocText", "PanelBg", "DocBg", func(pass string) {}),
 		
-		"sendAddress": wg.Input("", "Parallelcoin Address", "DocText", "PanelBg", "DocBg", func(amt string) {}),
-		"sendAmount":  wg.Input("", "Amount", "DocText", "PanelBg", "DocBg", func(amt string) {}),
-		"sendMessage": wg.Input("", "Description", "DocText", "PanelBg", "DocBg", func(pass string) {}),
+		"sendAddress": wg.Input("", "", "DocText", "PanelBg", "DocBg", func(amt string) {}),
+		"sendAmount":  wg.Input("", "", "DocText", "PanelBg", "DocBg", func(amt string) {}),
+		"sendMessage": wg.Input("", "", "DocText", "PanelBg", "DocBg", func(pass string) {}),
 		
-		"console":    wg.Input("", "enter rpc command", "DocText", "Transparent", "PanelBg", func(pass string) {}),
-		"walletSeed": wg.Input(seedString, "wallet seed", "DocText", "Transparent", "PanelBg", func(pass string) {}),
+		"historyLabel": wg.Input("", "", "DocText", "PanelBg", "DocBg", func(txt string) {}),
+		
+		"console":    wg.Input("", "", "DocText", "Transparent", "PanelBg", func(pass string) {}),
+		"walletSeed": wg.Input(seedString, "", "DocText", "Transparent", "PanelBg", func(pass string) {}),
 	}
 }
 
@@ -297,10 +304,10 @@ func (wg *WalletGUI) GetPasswords() {
 	pass := ""
 	passConfirm := ""
 	wg.passwords = PasswordMap{
-		"passEditor":        wg.Password("password", &pass, "Primary", "DocText", "DocBg", func(pass string) {}),
-		"confirmPassEditor": wg.Password("confirm", &passConfirm, "Primary", "DocText", "DocBg", func(pass string) {}),
+		"passEditor":        wg.Password("", &pass, "Primary", "DocText", "DocBg", func(pass string) {}),
+		"confirmPassEditor": wg.Password("", &passConfirm, "Primary", "DocText", "DocBg", func(pass string) {}),
 		"publicPassEditor": wg.Password(
-			"public password (optional)",
+			"",
 			wg.cx.Config.WalletPass,
 			"Primary",
 			"DocText",
@@ -400,6 +407,8 @@ func (wg *WalletGUI) GetClickables() ClickableMap {
 		"transactions50":          wg.Clickable(),
 		"txPageForward":           wg.Clickable(),
 		"txPageBack":              wg.Clickable(),
+		"historyLabelSave":        wg.Clickable(),
+		"historySpeedUp":          wg.Clickable(),
 	}
 }
 
@@ -18,7 +18,7 @@ func (wg *WalletGUI) balanceCard(corners int) func(gtx l.Context) l.Dimensions {
 	return wg.VFlex().AlignMiddle().
 		Rigid(
 			// wg.ButtonInset(0.25,
-			wg.H5("balances").Alignment(text.Middle).Fn,
+			wg.H5(wg.T("BALANCES")).Alignment(text.Middle).Fn,
 			// ).Fn,
 		).
 		Rigid(
@@ -32,7 +32,7 @@ func (wg *WalletGUI) balanceCard(corners int) func(gtx l.Context) l.Dimensions {
 									wg.Inset(0.25,
 										wg.Flex().AlignBaseline().
 											Rigid(
-												wg.Body1("confirmed").Color("Light").Fn,
+												wg.Body1(wg.T("CONFIRMED")).Color("Light").Fn,
 											).
 											Rigid(
 												wg.H6(" ").Fn,
@@ -44,7 +44,7 @@ func (wg *WalletGUI) balanceCard(corners int) func(gtx l.Context) l.Dimensions {
 									
 									wg.Flex().AlignBaseline().
 										Rigid(
-											wg.Body1("unconfirmed").Color("Light").Fn,
+											wg.Body1(wg.T("UNCONFIRMED")).Color("Light").Fn,
 										).
 										Rigid(
 											wg.H6(" ").Fn,
@@ -56,7 +56,7 @@ func (wg *WalletGUI) balanceCard(corners int) func(gtx l.Context) l.Dimensions {
 									wg.Inset(0.5,
 										wg.Flex().AlignBaseline().
 											Rigid(
-												wg.H6("total").Color("Light").Fn,
+												wg.H6(wg.T("TOTAL")).Color("Light").Fn,
 											).
 											Rigid(
 												wg.H6(" ").Fn,
@@ -77,9 +77,8 @@ func (wg *WalletGUI) balanceCard(corners int) func(gtx l.Context) l.Dimensions {
 												wg.H6(" ").Fn,
 											).
 											Rigid(
-												wg.Caption(leftPadTo(14, 14,
-													fmt.Sprintf("%6.8f",
-														wg.State.balance.Load())),
+												wg.Caption(leftPadTo(14, 20,
+													wg.Amount(wg.State.balance.Load())),
 												).Color("Light").Font("go regular").Fn,
 											).Fn,
 									).Fn,
@@ -91,9 +90,8 @@ func (wg *WalletGUI) balanceCard(corners int) func(gtx l.Context) l.Dimensions {
 												wg.H6(" ").Fn,
 											).
 											Rigid(
-												wg.Caption(leftPadTo(14, 14,
-													fmt.Sprintf("%6.8f",
-														wg.State.balanceUnconfirmed.Load())),
+												wg.Caption(leftPadTo(14, 20,
+													wg.Amount(wg.State.balanceUnconfirmed.Load())),
 												).Color("Light").Font("go regular").Fn,
 											).Fn,
 									).Fn,
@@ -106,7 +104,7 @@ func (wg *WalletGUI) balanceCard(corners int) func(gtx l.Context) l.Dimensions {
 											).
 											Rigid(
 												wg.H6(
-													leftPadTo(14, 14, fmt.Sprintf("%6.8f", wg.State.balance.Load()+wg.
+													leftPadTo(14, 20, wg.Amount(wg.State.balance.Load()+wg.
 														State.balanceUnconfirmed.Load())),
 												).Color("Light").Fn,
 											).Fn,
@@ -147,7 +145,7 @@ func (wg *WalletGUI) OverviewPage() l.Widget {
 						wg.VFlex().AlignMiddle().
 							Rigid(
 								wg.Inset(0.25,
-									wg.H5("recent transactions").Fn).Fn,
+									wg.H5(wg.T("RECENT_TRANSACTIONS")).Fn).Fn,
 							).
 							Flexed(1,
 								// wg.Inset(0.5,
@@ -178,7 +176,7 @@ func (wg *WalletGUI) OverviewPage() l.Widget {
 						wg.VFlex().SpaceSides().AlignMiddle().
 							Rigid(
 								wg.Inset(0.25,
-									wg.H5("recent transactions").Fn,
+									wg.H5(wg.T("RECENT_TRANSACTIONS")).Fn,
 								).Fn,
 							).
 							Flexed(1,
@@ -219,6 +217,11 @@ func (wg *WalletGUI) RecentTransactions(n int, listName string) l.Widget {
 			return l.Dimensions{Size: gtx.Constraints.Max}
 		}
 	}
+	if listName == "history" {
+		for len(wg.historyClickables) < len(wga) {
+			wg.historyClickables = append(wg.historyClickables, wg.WidgetPool.GetClickable())
+		}
+	}
 	Debug(">>>>>>>>>>>>>>>> iterating transactions", n, listName)
 	for x := range wga {
 		if x > n && n > 0 {
@@ -240,7 +243,7 @@ func (wg *WalletGUI) RecentTransactions(n int, listName string) l.Widget {
 				wg.Inset(0.25,
 					wg.Flex().
 						Rigid(
-							wg.Body1(fmt.Sprintf("%-6.8f DUO", txs.Amount)).Color("PanelText").Fn,
+							wg.Body1(wg.Amount(txs.Amount)+" DUO").Color("PanelText").Fn,
 						).
 						Flexed(1,
 							wg.Inset(0.25,
@@ -311,7 +314,7 @@ func (wg *WalletGUI) RecentTransactions(n int, listName string) l.Widget {
 										},
 									).
 									Rigid(
-										wg.Caption(txs.Category+" ").Fn,
+										wg.Caption(wg.category(txs.Category)+" ").Fn,
 									).
 									Fn,
 							).
@@ -332,6 +335,9 @@ func (wg *WalletGUI) RecentTransactions(n int, listName string) l.Widget {
 				).Fn,
 			).Fn,
 		)
+		if listName == "history" || txs.Comment != "" || txs.Label != "" {
+			out = append(out, wg.TxCommentRow(listName, i, txs))
+		}
 	}
 	le := func(gtx l.Context, index int) l.Dimensions {
 		return out[index](gtx)
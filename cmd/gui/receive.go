@@ -181,7 +181,7 @@ func (rp *ReceivePage) GetAddressbookHistoryCards(bg string) (widgets []l.Widget
 }
 
 func (rp *ReceivePage) QRMessage() l.Widget {
-	return rp.wg.Body2("Scan to send or click to copy").Alignment(text.Middle).Fn
+	return rp.wg.Body2(rp.wg.T("SCAN_TO_SEND")).Alignment(text.Middle).Fn
 }
 
 func (rp *ReceivePage) GetQRText() string {
@@ -235,7 +235,7 @@ func (rp *ReceivePage) AddressbookHeader() l.Widget {
 		1,
 		wg.Inset(
 			0.25,
-			wg.H6("Receive Address History").Alignment(text.Middle).Fn,
+			wg.H6(wg.T("RECEIVE_HISTORY")).Alignment(text.Middle).Fn,
 		).Fn,
 	).Fn
 }
@@ -320,7 +320,7 @@ func (rp *ReceivePage) RegenerateButton() l.Widget {
 			Embed(
 				wg.Inset(
 					0.5,
-					wg.H6("regenerate").Color("Light").Fn,
+					wg.H6(wg.T("REGENERATE")).Color("Light").Fn,
 				).
 					Fn,
 			).
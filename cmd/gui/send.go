@@ -18,13 +18,15 @@ import (
 type SendPage struct {
 	wg                 *WalletGUI
 	inputWidth, break1 float32
+	coinControl        *CoinControl
 }
 
 func (wg *WalletGUI) GetSendPage() (sp *SendPage) {
 	sp = &SendPage{
-		wg:         wg,
-		inputWidth: 20,
-		break1:     48,
+		wg:          wg,
+		inputWidth:  20,
+		break1:      48,
+		coinControl: wg.GetCoinControl(),
 	}
 	wg.inputs["sendAddress"].SetPasteFunc = sp.pasteFunction
 	wg.inputs["sendAmount"].SetPasteFunc = sp.pasteFunction
@@ -68,7 +70,14 @@ func (sp *SendPage) SmallList(gtx l.Context) l.Dimensions {
 			).
 			Rigid(
 				sp.SaveButton(),
+			).
+			Rigid(
+				wg.Inset(0.5, gui.EmptySpace(0, 0)).Fn,
+			).
+			Rigid(
+				sp.coinControl.Button(),
 			).Fn,
+		sp.coinControl.Fn,
 		sp.AddressbookHeader(),
 	}
 	smallWidgets = append(smallWidgets, sp.GetAddressbookHistoryCards("DocBg")...)
@@ -102,7 +111,14 @@ func (sp *SendPage) MediumList(gtx l.Context) l.Dimensions {
 			).
 			Rigid(
 				sp.SaveButton(),
+			).
+			Rigid(
+				wg.Inset(0.5, gui.EmptySpace(0, 0)).Fn,
+			).
+			Rigid(
+				sp.coinControl.Button(),
 			).Fn,
+		sp.coinControl.Fn,
 	}
 	sendLE := func(gtx l.Context, index int) l.Dimensions {
 		return wg.Inset(0.25, sendFormWidget[index]).Fn(gtx)
@@ -200,11 +216,19 @@ func (sp *SendPage) SendButton() l.Widget {
 									return
 								}
 								var txid *chainhash.Hash
-								if txid, err = wg.WalletClient.SendToAddress(addr, am); Check(err) {
+								if opts := sp.coinControl.Options(); opts != nil {
+									txid, err = wg.WalletClient.SendToAddressCoinControl(addr, am,
+										wg.inputs["sendMessage"].GetText(), "", opts)
+								} else {
+									txid, err = wg.WalletClient.SendToAddressComment(addr, am,
+										wg.inputs["sendMessage"].GetText(), "")
+								}
+								if Check(err) {
 									// TODO: indicate send failure to user somehow
 									return
 								}
 								Debug("transaction successful", txid)
+								sp.coinControl.Reset()
 								// prevent accidental double clicks recording the same entry again
 								wg.inputs["sendAmount"].SetText("")
 								wg.inputs["sendMessage"].SetText("")
@@ -218,7 +242,7 @@ func (sp *SendPage) SendButton() l.Widget {
 			Embed(
 				wg.Inset(
 					0.5,
-					wg.H6("send").Color("Light").Fn,
+					wg.H6(wg.T("SEND")).Color("Light").Fn,
 				).
 					Fn,
 			).
@@ -266,6 +290,13 @@ func (sp *SendPage) SaveButton() l.Widget {
 							Amount:  ua,
 							Created: time.Now(),
 						})
+						// keep the label in the wallet too, so transactions paying the address show it
+						if wg.WalletAndClientRunning() {
+							go func() {
+								if err := wg.WalletClient.SetLabel(ad, msg); Check(err) {
+								}
+							}()
+						}
 						// prevent accidental double clicks recording the same entry again
 						wg.inputs["sendAmount"].SetText("")
 						wg.inputs["sendMessage"].SetText("")
@@ -277,7 +308,7 @@ func (sp *SendPage) SaveButton() l.Widget {
 			Embed(
 				wg.Inset(
 					0.5,
-					wg.H6("save").Color("Light").Fn,
+					wg.H6(wg.T("SAVE")).Color("Light").Fn,
 				).
 					Fn,
 			).
@@ -295,7 +326,7 @@ func (sp *SendPage) PasteButton() l.Widget {
 			Embed(
 				wg.Inset(
 					0.5,
-					wg.H6("paste").Color("Light").Fn,
+					wg.H6(wg.T("PASTE")).Color("Light").Fn,
 				).
 					Fn,
 			).
@@ -358,7 +389,7 @@ func (sp *SendPage) AddressbookHeader() l.Widget {
 		1,
 		wg.Inset(
 			0.25,
-			wg.H6("Send Address History").Alignment(text.Middle).Fn,
+			wg.H6(wg.T("SEND_HISTORY")).Alignment(text.Middle).Fn,
 		).Fn,
 	).Fn
 }
@@ -3,7 +3,6 @@ package gui
 import (
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"time"
@@ -125,7 +124,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 	exitButton := wg.WidgetPool.GetClickable()
 	unlockButton := wg.WidgetPool.GetClickable()
 	wg.unlockPassword = wg.Password(
-		"enter password", &password, "DocText",
+		"", &password, "DocText",
 		"DocBg", "PanelBg", func(pass string) {
 			go wg.unlockWallet(pass)
 		},
@@ -197,7 +196,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 																						).Fn,
 																					).
 																					Rigid(
-																						wg.H2("locked").Color("DocText").Fn,
+																						wg.H2(wg.T("LOCKED")).Color("DocText").Fn,
 																					).
 																					Fn(gtx)
 																				return dims
@@ -217,8 +216,8 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 																		Rigid(wg.Inset(0.5, gui.EmptySpace(0, 0)).Fn).
 																		Rigid(
 																			wg.Body1(
-																				fmt.Sprintf(
-																					"%v idle timeout",
+																				wg.T(
+																					"IDLE_TIMEOUT",
 																					time.Duration(wg.incdecs["idleTimeout"].GetCurrent())*time.Second,
 																				),
 																			).
@@ -229,7 +228,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 																		Rigid(
 																			wg.Flex().
 																				Rigid(
-																					wg.Body1("Idle timeout in seconds:").Color(
+																					wg.Body1(wg.T("IDLE_TIMEOUT_SECONDS")).Color(
 																						"DocText",
 																					).Fn,
 																				).
@@ -284,7 +283,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 																											).Fn,
 																										).
 																										Rigid(
-																											wg.H6("exit").Color("DocText").Fn,
+																											wg.H6(wg.T("EXIT")).Color("DocText").Fn,
 																										).
 																										Rigid(
 																											wg.Inset(
@@ -341,7 +340,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 																											).Fn,
 																										).
 																										Rigid(
-																											wg.H6("unlock").Color("Light").Fn,
+																											wg.H6(wg.T("UNLOCK")).Color("Light").Fn,
 																										).
 																										Rigid(
 																											wg.Inset(
@@ -405,7 +404,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 								SpaceEvenly().
 								AlignMiddle().
 								Rigid(
-									wg.H4("are you sure?").Color(wg.unlockPage.BodyColorGet()).Alignment(text.Middle).Fn,
+									wg.H4(wg.T("ARE_YOU_SURE")).Color(wg.unlockPage.BodyColorGet()).Alignment(text.Middle).Fn,
 								).
 								Rigid(
 									wg.Flex().
@@ -420,7 +419,7 @@ func (wg *WalletGUI) getWalletUnlockAppWidget() (a *gui.App) {
 													},
 												),
 											).Color("Light").TextScale(5).Text(
-												"yes!!!",
+												wg.T("YES"),
 											).Fn,
 										).
 										Flexed(0.5, gui.EmptyMaxWidth()).
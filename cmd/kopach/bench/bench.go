@@ -0,0 +1,258 @@
+// Package bench measures the hash rate of the Plan 9 proof of work algorithms on synthetic block headers, for sizing
+// mining hardware and checking for performance regressions in the hash code.
+package bench
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/forkhash"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	qu "github.com/p9c/pod/pkg/util/quit"
+)
+
+// Functions are the hash functions DivHash can be wrapped around, measured on their own to find out which part of the
+// hash code a change in performance comes from
+var Functions = map[string]func([]byte) []byte{
+	"argon2i": forkhash.Argon2i,
+	"blake2b": forkhash.Blake2b,
+	"blake3":  forkhash.Blake3,
+	"keccak":  forkhash.Keccak,
+	"scrypt":  forkhash.Scrypt,
+	"sha256d": chainhash.DoubleHashB,
+	"skein":   forkhash.Skein,
+	"stribog": forkhash.Stribog,
+	"x11":     forkhash.X11,
+}
+
+// Config is the settings of a benchmark run
+type Config struct {
+	// Threads are the thread counts each algorithm is measured with
+	Threads []int
+	// Duration is how long each measurement runs for
+	Duration time.Duration
+}
+
+// ThreadCounts returns the powers of two up to max, and max itself
+func ThreadCounts(max int) (counts []int) {
+	if max < 1 {
+		max = runtime.NumCPU()
+	}
+	for n := 1; n < max; n *= 2 {
+		counts = append(counts, n)
+	}
+	return append(counts, max)
+}
+
+// Result is the hash rate of an algorithm with a number of threads
+type Result struct {
+	Algo            string  `json:"algo"`
+	Version         int32   `json:"version,omitempty"`
+	Threads         int     `json:"threads"`
+	Hashes          uint64  `json:"hashes"`
+	Seconds         float64 `json:"seconds"`
+	HashesPerSecond float64 `json:"hashespersecond"`
+}
+
+// Share is the expected part of the blocks found with an algorithm by this machine mining all of the algorithms in
+// turn, as the kopach workers do, at the targets of the algorithms
+type Share struct {
+	Algo            string  `json:"algo"`
+	Version         int32   `json:"version"`
+	Bits            uint32  `json:"bits"`
+	HashesPerSecond float64 `json:"hashespersecond"`
+	// SecondsPerBlock is the expected time to find a block mining only this algorithm
+	SecondsPerBlock float64 `json:"secondsperblock"`
+	Share           float64 `json:"share"`
+}
+
+// Report is the outcome of a benchmark run
+type Report struct {
+	Time      time.Time `json:"time"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	CPUs      int       `json:"cpus"`
+	HashReps  int       `json:"hashreps"`
+	Height    int32     `json:"height"`
+	Duration  float64   `json:"duration"`
+	Algos     []Result  `json:"algos"`
+	Functions []Result  `json:"functions"`
+	Shares    []Share   `json:"shares"`
+}
+
+// Height returns a height at which the Plan 9 algorithms are in force
+func Height() int32 {
+	if fork.IsTestnet {
+		return fork.List[1].TestnetStart
+	}
+	return fork.List[1].ActivationHeight
+}
+
+// Algos returns the names of the Plan 9 algorithms in order of block version
+func Algos() (algos []string) {
+	for _, a := range fork.AlgoSlices[1] {
+		algos = append(algos, a.Name)
+	}
+	return
+}
+
+// Run measures every Plan 9 algorithm with each thread count, and each hash function wrapped in DivHash with one
+// thread. It stops early and returns what has been measured when quit is closed.
+func Run(cfg Config, quit qu.C) (r *Report) {
+	height := Height()
+	r = &Report{
+		Time:     time.Now(),
+		GOOS:     runtime.GOOS,
+		GOARCH:   runtime.GOARCH,
+		CPUs:     runtime.NumCPU(),
+		HashReps: forkhash.HashReps,
+		Height:   height,
+		Duration: cfg.Duration.Seconds(),
+	}
+	for _, algo := range Algos() {
+		version := fork.P9Algos[algo].Version
+		for _, threads := range cfg.Threads {
+			Info("measuring", algo, "with", threads, "threads")
+			res := measure(
+				threads, cfg.Duration, quit, func(h *wire.BlockHeader) {
+					h.Version = version
+					h.BlockHashWithAlgos(height)
+				},
+			)
+			res.Algo, res.Version = algo, version
+			r.Algos = append(r.Algos, res)
+			if closed(quit) {
+				return
+			}
+		}
+	}
+	names := make([]string, 0, len(Functions))
+	for name := range Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		hf := Functions[name]
+		Info("measuring divhash with", name)
+		res := measure(
+			1, cfg.Duration, quit, func(h *wire.BlockHeader) {
+				forkhash.DivHash(hf, serialize(h), forkhash.HashReps)
+			},
+		)
+		res.Algo = name
+		r.Functions = append(r.Functions, res)
+		if closed(quit) {
+			return
+		}
+	}
+	r.Shares = Shares(r.Algos)
+	return
+}
+
+// closed returns true if the quit channel has been closed
+func closed(quit qu.C) bool {
+	select {
+	case <-quit.Wait():
+		return true
+	default:
+		return false
+	}
+}
+
+// serialize returns the bytes of a block header that are hashed for its proof of work
+func serialize(h *wire.BlockHeader) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, wire.MaxBlockHeaderPayload))
+	if err := h.Serialize(buf); Check(err) {
+	}
+	return buf.Bytes()
+}
+
+// measure runs hash on a synthetic header in each of the threads for the duration and counts the hashes. Each thread
+// has its own header with a random previous block and merkle root, and increments the nonce for every hash
+func measure(threads int, duration time.Duration, quit qu.C, hash func(h *wire.BlockHeader)) (res Result) {
+	var hashes atomic.Uint64
+	stop := qu.T()
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < threads; i++ {
+		h := &wire.BlockHeader{Timestamp: start, Bits: fork.FirstPowLimitBits}
+		if _, err := rand.Read(h.PrevBlock[:]); Check(err) {
+		}
+		if _, err := rand.Read(h.MerkleRoot[:]); Check(err) {
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop.Wait():
+					return
+				default:
+				}
+				hash(h)
+				h.Nonce++
+				hashes.Inc()
+			}
+		}()
+	}
+	select {
+	case <-time.After(duration):
+	case <-quit.Wait():
+	}
+	stop.Q()
+	wg.Wait()
+	res.Threads = threads
+	res.Hashes = hashes.Load()
+	res.Seconds = time.Since(start).Seconds()
+	if res.Seconds > 0 {
+		res.HashesPerSecond = float64(res.Hashes) / res.Seconds
+	}
+	return
+}
+
+// maxHash is the number of possible hashes
+var maxHash = new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 256))
+
+// Shares works out the expected share of the blocks found with each algorithm at the targets in P9Algos, using the
+// best hash rate measured for each algorithm. The chance of a hash meeting a target is the target over the number of
+// possible hashes, so the rate blocks are found at with an algorithm is its hash rate times that chance.
+func Shares(results []Result) (shares []Share) {
+	best := make(map[string]Result)
+	for _, res := range results {
+		if b, ok := best[res.Algo]; !ok || res.HashesPerSecond > b.HashesPerSecond {
+			best[res.Algo] = res
+		}
+	}
+	var total float64
+	rates := make(map[string]float64)
+	for _, algo := range Algos() {
+		res, ok := best[algo]
+		if !ok {
+			continue
+		}
+		params := fork.P9Algos[algo]
+		chance, _ := new(big.Float).Quo(new(big.Float).SetInt(fork.CompactToBig(params.MinBits)), maxHash).Float64()
+		rates[algo] = res.HashesPerSecond * chance
+		total += rates[algo]
+		s := Share{Algo: algo, Version: params.Version, Bits: params.MinBits, HashesPerSecond: res.HashesPerSecond}
+		if rates[algo] > 0 {
+			s.SecondsPerBlock = 1 / rates[algo]
+		}
+		shares = append(shares, s)
+	}
+	if total > 0 {
+		for i := range shares {
+			shares[i].Share = rates[shares[i].Algo] / total
+		}
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package bench
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	qu "github.com/p9c/pod/pkg/util/quit"
+)
+
+// TestThreadCounts ensures the thread counts double up to the maximum and include it.
+func TestThreadCounts(t *testing.T) {
+	got := ThreadCounts(6)
+	want := []int{1, 2, 4, 6}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+// TestShares ensures the block shares follow the hash rates when the targets are the same, using the best hash rate
+// of each algorithm.
+func TestShares(t *testing.T) {
+	algos := Algos()
+	var results []Result
+	for i, algo := range algos {
+		results = append(results,
+			Result{Algo: algo, Threads: 1, HashesPerSecond: float64(i + 1)},
+			Result{Algo: algo, Threads: 2, HashesPerSecond: float64(2 * (i + 1))},
+		)
+	}
+	shares := Shares(results)
+	if len(shares) != len(algos) {
+		t.Fatalf("got %d shares, want %d", len(shares), len(algos))
+	}
+	var total, rates float64
+	for i := range algos {
+		rates += float64(2 * (i + 1))
+	}
+	for i, s := range shares {
+		if s.HashesPerSecond != float64(2*(i+1)) {
+			t.Errorf("%s: used %v hashes per second, want the best of %v", s.Algo, s.HashesPerSecond, 2*(i+1))
+		}
+		if s.Bits != fork.P9Algos[s.Algo].MinBits {
+			t.Errorf("%s: got bits %08x, want %08x", s.Algo, s.Bits, fork.P9Algos[s.Algo].MinBits)
+		}
+		if want := s.HashesPerSecond / rates; math.Abs(s.Share-want) > 1e-9 {
+			t.Errorf("%s: got share %v, want %v", s.Algo, s.Share, want)
+		}
+		total += s.Share
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("shares add up to %v", total)
+	}
+}
+
+// TestRun makes a very short run to check every algorithm and hash function is measured.
+func TestRun(t *testing.T) {
+	r := Run(Config{Threads: []int{1}, Duration: time.Millisecond * 10}, qu.T())
+	if len(r.Algos) != len(Algos()) || len(r.Functions) != len(Functions) || len(r.Shares) != len(Algos()) {
+		t.Fatalf("got %d algorithms, %d functions and %d shares", len(r.Algos), len(r.Functions), len(r.Shares))
+	}
+	for _, res := range append(r.Algos, r.Functions...) {
+		if res.Hashes == 0 || res.HashesPerSecond <= 0 {
+			t.Errorf("%s: no hashes measured", res.Algo)
+		}
+	}
+}
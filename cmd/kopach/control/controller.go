@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -24,9 +25,12 @@ import (
 	"github.com/p9c/pod/app/conte"
 	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/kopach/control/job"
+	"github.com/p9c/pod/cmd/kopach/control/ledger"
 	"github.com/p9c/pod/cmd/kopach/control/p2padvt"
 	"github.com/p9c/pod/cmd/kopach/control/pause"
+	"github.com/p9c/pod/cmd/kopach/control/share"
 	"github.com/p9c/pod/cmd/kopach/control/sol"
+	"github.com/p9c/pod/cmd/kopach/control/stratum"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/fork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
@@ -34,6 +38,7 @@ import (
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/comm/transport"
 	rav "github.com/p9c/pod/pkg/data/ring"
+	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
@@ -65,11 +70,14 @@ type Controller struct {
 	buffer        *ring.Ring
 	began         time.Time
 	otherNodes    map[string]time.Time
-	listenPort    int
+	listenPort    atomic.Int32
 	hashCount     atomic.Uint64
 	hashSampleBuf *rav.BufferUint64
 	lastNonce     int32
 	walletClient  *rpcclient.Client
+	stratum       *stratum.Server
+	ledger        *ledger.Ledger
+	currentJob    atomic.Value
 }
 
 func Run(cx *conte.Xt) (quit qu.C) {
@@ -92,9 +100,17 @@ func Run(cx *conte.Xt) (quit qu.C) {
 		buffer:        ring.New(BufferSize),
 		began:         time.Now(),
 		otherNodes:    make(map[string]time.Time),
-		listenPort:    int(util.GetActualPort(*cx.Config.Controller)),
 		hashSampleBuf: rav.NewBufferUint64(100),
 	}
+	ctrl.listenPort.Store(int32(util.GetActualPort(*cx.Config.Controller)))
+	// the port identifies the jobs of this controller, the priority is read from the configuration for each
+	// advertisment
+	cx.Hooks.Register(
+		"controller", func([]pod.Change) {
+			ctrl.listenPort.Store(int32(util.GetActualPort(*cx.Config.Controller)))
+			Debug("controller port is now", ctrl.listenPort.Load())
+		},
+	)
 	ctrl.isMining.Store(true)
 	// maintain connection to wallet if it is available
 	var err error
@@ -159,7 +175,10 @@ func Run(cx *conte.Xt) (quit qu.C) {
 		return
 	}
 	// var pauseShards [][]byte
-	if ctrl.pauseShards = transport.GetShards(p2padvt.Get(cx)); Check(err) {
+	// pauses sent when a block is found carry an advertisment saying the controller is still mining
+	pauseAdvt := p2padvt.GetAdvt(cx)
+	pauseAdvt.Mining = true
+	if ctrl.pauseShards = ctrl.multiConn.GetShards(gotiny.Marshal(pauseAdvt)); Check(err) {
 	} else {
 		ctrl.active.Store(true)
 	}
@@ -168,13 +187,36 @@ func Run(cx *conte.Xt) (quit qu.C) {
 		func() {
 			Debug("miner controller shutting down")
 			ctrl.active.Store(false)
-			if err = ctrl.multiConn.SendMany(pause.Magic, ctrl.pauseShards); Check(err) {
+			// the pause sent on shutdown says the controller is no longer mining, so workers move to another
+			// controller without waiting for this one to time out
+			if err = ctrl.multiConn.SendMany(
+				pause.Magic, ctrl.multiConn.GetShards(gotiny.Marshal(p2padvt.GetAdvt(cx))),
+			); Check(err) {
 			}
 			if err = ctrl.multiConn.Close(); Check(err) {
 			}
 			ctrl.quit.Q()
 		},
 	)
+	if ctrl.ledger, err = ledger.Open(filepath.Join(*cx.Config.DataDir, cx.ActiveNet.Name, "shares.db")); Check(err) {
+		Warn("share accounting is disabled")
+	} else {
+		cx.RPCServer.Cfg.ShareLedger.Store(ctrl.ledger)
+	}
+	if *cx.Config.StratumListener != "" {
+		users := stratumUsers(cx)
+		ctrl.stratum = stratum.New(
+			&stratum.Config{
+				Listener: *cx.Config.StratumListener,
+				Submit:   ctrl.submitStratumBlock,
+				Accepted: ctrl.recordShare,
+				Users:    users,
+			},
+		)
+		if err = ctrl.stratum.Start(); Check(err) {
+			ctrl.stratum = nil
+		}
+	}
 	Debug("sending broadcasts to:", UDP4MulticastAddress)
 	
 	// go advertiser(ctrl)
@@ -184,8 +226,6 @@ func Run(cx *conte.Xt) (quit qu.C) {
 	// 	ctrl.active.Store(true)
 	// }
 	ticker := time.NewTicker(time.Second * time.Duration(factor))
-	advt := p2padvt.Get(cx)
-	ad := transport.GetShards(advt)
 	once := false
 	go func() {
 	out:
@@ -210,10 +250,13 @@ func Run(cx *conte.Xt) (quit qu.C) {
 					// } else {
 					// }
 				}
-				// send out advertisment
+				// send out advertisment, rebuilt each time as workers elect the controller to mine for by its chain
+				// height and mining state
 				// todo: big question: how to deal with change of IP address
 				var err error
-				if err = ctrl.multiConn.SendMany(p2padvt.Magic, ad); Check(err) {
+				advt := p2padvt.GetAdvt(cx)
+				advt.Mining = ctrl.isMining.Load() && ctrl.active.Load()
+				if err = ctrl.multiConn.SendMany(p2padvt.Magic, ctrl.multiConn.GetShards(gotiny.Marshal(advt))); Check(err) {
 				}
 				if ctrl.isMining.Load() {
 					Debug("rebroadcasting")
@@ -241,6 +284,13 @@ func Run(cx *conte.Xt) (quit qu.C) {
 			}
 		}
 		ctrl.active.Store(false)
+		if ctrl.stratum != nil {
+			ctrl.stratum.Stop()
+		}
+		if ctrl.ledger != nil {
+			if err := ctrl.ledger.Close(); Check(err) {
+			}
+		}
 		// panic("aren't we stopped???")
 		Debug("controller exiting")
 	}()
@@ -327,6 +377,7 @@ var handlersMulticast = transport.Handlers{
 	string(sol.Magic):      processSolMsg,
 	string(p2padvt.Magic):  processAdvtMsg,
 	string(hashrate.Magic): processHashrateMsg,
+	string(share.Magic):    processShareMsg,
 }
 
 func processAdvtMsg(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
@@ -387,7 +438,7 @@ func processSolMsg(ctx interface{}, src net.Addr, dst string, b []byte,) (err er
 	// Debugs(s)
 	// j := sol.LoadSolContainer(b)
 	senderPort := s.Port
-	if int(senderPort) != c.listenPort {
+	if senderPort != c.listenPort.Load() {
 		Debug("solution not from current controller")
 		return
 	}
@@ -445,6 +496,7 @@ func processSolMsg(ctx interface{}, src net.Addr, dst string, b []byte,) (err er
 		return
 	}
 	block := util.NewBlock(msgBlock)
+	height := c.cx.RealNode.Chain.BestSnapshot().Height + 1
 	var isOrphan bool
 	Debug("submitting block for processing")
 	if isOrphan, err = c.cx.RealNode.SyncManager.ProcessBlock(block, blockchain.BFNone); Check(err) {
@@ -465,6 +517,19 @@ func processSolMsg(ctx interface{}, src net.Addr, dst string, b []byte,) (err er
 		}
 	}
 	Trace("the block was accepted")
+	algo := fork.GetAlgoName(msgBlock.Header.Version, height)
+	c.recordShare(
+		&ledger.Share{
+			Worker:            s.ID,
+			Algo:              algo,
+			Height:            height,
+			Hash:              msgBlock.Header.BlockHashWithAlgos(height),
+			Difficulty:        ledger.Difficulty(algo, height, share.Target(msgBlock.Header.Bits)),
+			NetworkDifficulty: ledger.Difficulty(algo, height, fork.CompactToBig(msgBlock.Header.Bits)),
+			Time:              time.Now(),
+			Block:             true,
+		},
+	)
 	Tracec(
 		func() string {
 			bmb := block.MsgBlock()
@@ -492,6 +557,109 @@ func processSolMsg(ctx interface{}, src net.Addr, dst string, b []byte,) (err er
 	return
 }
 
+// stratumUsers returns the users miners authorize with on the stratum listener, read from user:password pairs in the
+// configuration. Stratum sends passwords in the clear over a port that is open to external miners, so a pair that
+// reuses the password of an RPC user is refused, as it would hand control of the node to anyone who can read it.
+func stratumUsers(cx *conte.Xt) map[string]string {
+	users := make(map[string]string)
+	for _, pair := range *cx.Config.StratumUsers {
+		i := strings.Index(pair, ":")
+		if i <= 0 || i == len(pair)-1 {
+			Warn("ignoring stratum user", pair, "which is not a user:password pair")
+			continue
+		}
+		user, pass := pair[:i], pair[i+1:]
+		if (*cx.Config.Password != "" && pass == *cx.Config.Password) ||
+			(*cx.Config.LimitPass != "" && pass == *cx.Config.LimitPass) {
+			Warn("ignoring stratum user", user, "as its password is an RPC password")
+			continue
+		}
+		users[user] = pass
+	}
+	return users
+}
+
+// submitStratumBlock processes a block solution found by a miner connected to the stratum server
+func (c *Controller) submitStratumBlock(block *util.Block) (err error) {
+	Debug("sending pause to workers")
+	if err = c.multiConn.SendMany(pause.Magic, c.pauseShards); Check(err) {
+	}
+	var isOrphan bool
+	if isOrphan, err = c.cx.RealNode.SyncManager.ProcessBlock(block, blockchain.BFNone); err != nil {
+		if _, ok := err.(blockchain.RuleError); !ok {
+			Warn("unexpected error while processing block submitted via stratum:", err)
+			return
+		}
+		Warn("block submitted via stratum rejected:", err)
+		return
+	}
+	if isOrphan {
+		Warn("block submitted via stratum is an orphan")
+	}
+	return
+}
+
+// shares found by workers, which are recorded in the share ledger
+func processShareMsg(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
+	c := ctx.(*Controller)
+	if !c.active.Load() || c.ledger == nil {
+		return
+	}
+	var s share.Share
+	gotiny.Unmarshal(b, &s)
+	if s.Port != c.listenPort.Load() {
+		Debug("share not from current controller")
+		return
+	}
+	var header *wire.BlockHeader
+	if header, err = s.GetHeader(); Check(err) {
+		return
+	}
+	j, ok := c.currentJob.Load().(*job.Job)
+	if !ok || !header.PrevBlock.IsEqual(j.PrevBlockHash) || s.Height != j.Height {
+		Debug("share from", s.ID, "is stale")
+		return
+	}
+	bits, ok := j.Bitses[header.Version]
+	merkleRoot, mok := j.MerkleRoots[header.Version]
+	if !ok || !mok || header.Bits != bits || !header.MerkleRoot.IsEqual(merkleRoot) {
+		Debug("share from", s.ID, "is not for the current job")
+		return
+	}
+	hash := header.BlockHashWithAlgos(j.Height)
+	if blockchain.HashToBig(&hash).Cmp(share.Target(bits)) > 0 {
+		Debug("share from", s.ID, "does not meet the share target")
+		return
+	}
+	algo := fork.GetAlgoName(header.Version, j.Height)
+	c.recordShare(
+		&ledger.Share{
+			Worker:            s.ID,
+			Algo:              algo,
+			Height:            j.Height,
+			Hash:              hash,
+			Difficulty:        ledger.Difficulty(algo, j.Height, share.Target(bits)),
+			NetworkDifficulty: ledger.Difficulty(algo, j.Height, fork.CompactToBig(bits)),
+			Time:              time.Now(),
+		},
+	)
+	return
+}
+
+// recordShare adds a share to the share ledger, if it is open
+func (c *Controller) recordShare(s *ledger.Share) {
+	if c.ledger == nil {
+		return
+	}
+	if err := c.ledger.Add(s); err != nil {
+		if err == ledger.ErrDuplicate {
+			Debug("share", s.Hash, "from", s.Worker, "was already recorded")
+			return
+		}
+		Error(err)
+	}
+}
+
 // hashrate reports from workers
 func processHashrateMsg(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
 	c := ctx.(*Controller)
@@ -527,7 +695,7 @@ func (c *Controller) sendNewBlockTemplate() (err error) {
 	var fMC []byte
 	ccb, fMC, txs = job.Get(c.cx, util.NewBlock(msgB))
 	c.coinbases.Store(ccb)
-	jobShards := transport.GetShards(fMC)
+	jobShards := c.multiConn.GetShards(fMC)
 	shardsLen := len(jobShards)
 	if shardsLen < 1 {
 		Warn("jobShards", shardsLen)
@@ -538,6 +706,12 @@ func (c *Controller) sendNewBlockTemplate() (err error) {
 	if err != nil {
 		Error(err)
 	}
+	var j job.Job
+	gotiny.Unmarshal(fMC, &j)
+	c.currentJob.Store(&j)
+	if c.stratum != nil {
+		c.stratum.SetWork(stratum.NewWork(&j, *ccb, txs, msgB.Header.Timestamp))
+	}
 	c.prevHash.Store(&template.Block.Header.PrevBlock)
 	c.transactions.Store(txs)
 	c.lastGenerated.Store(time.Now().UnixNano())
@@ -0,0 +1,29 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/urfave/cli"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/pod"
+)
+
+// TestStratumUsers ensures stratum users are read from the configuration and that RPC passwords are never accepted.
+func TestStratumUsers(t *testing.T) {
+	cfg, _ := pod.EmptyConfig()
+	*cfg.Password, *cfg.LimitPass = "rpcpass", "limitpass"
+	*cfg.StratumUsers = cli.StringSlice{
+		"miner:minerpass", "rig:with:colon", "nopass:", ":nouser", "garbage", "admin:rpcpass", "guest:limitpass",
+	}
+	users := stratumUsers(&conte.Xt{Config: cfg})
+	want := map[string]string{"miner": "minerpass", "rig": "with:colon"}
+	if len(users) != len(want) {
+		t.Fatalf("got users %v, want %v", users, want)
+	}
+	for user, pass := range want {
+		if users[user] != pass {
+			t.Errorf("user %s: got password %q, want %q", user, users[user], pass)
+		}
+	}
+}
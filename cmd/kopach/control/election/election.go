@@ -0,0 +1,175 @@
+// Package election chooses the controller kopach workers mine for when there are several on the LAN. Controllers
+// broadcast their priority and chain height in their advertisments, and every controller that is mining sends out
+// jobs, so the ones not elected are hot standbys a worker can switch to as soon as the leader stops advertising, falls
+// behind the rest of the network or a better one appears.
+package election
+
+import (
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/cmd/kopach/control/job"
+	"github.com/p9c/pod/cmd/kopach/control/p2padvt"
+)
+
+// DefaultTimeout is how long a controller is considered alive after its last advertisment or job
+const DefaultTimeout = time.Second * 3
+
+// Candidate is the last known state of a controller
+type Candidate struct {
+	Address  string
+	Priority int32
+	Height   int32
+	Current  bool
+	Mining   bool
+	Seen     time.Time
+	// Job is the last job received from the controller, kept so a worker can start on it straight away when switching
+	Job *job.Job
+}
+
+// Election tracks the controllers on the LAN and which of them is the leader
+type Election struct {
+	mx         sync.Mutex
+	timeout    time.Duration
+	candidates map[string]*Candidate
+	leader     string
+}
+
+// New creates an election where controllers are dropped after they have not been heard from for the timeout
+func New(timeout time.Duration) *Election {
+	return &Election{timeout: timeout, candidates: make(map[string]*Candidate)}
+}
+
+// Advertised updates a controller from its advertisment, and returns true if the leader changed
+func (e *Election) Advertised(a *p2padvt.Advertisment, now time.Time) (changed bool) {
+	addr := a.Address()
+	if addr == "" {
+		return
+	}
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	c := e.candidate(addr)
+	c.Priority, c.Current, c.Mining, c.Seen = a.Priority, a.Current, a.Mining, now
+	if a.Height > c.Height {
+		c.Height = a.Height
+	}
+	return e.elect(now)
+}
+
+// Job records a job from a controller. Mine is true if the job is from the leader and is not behind the network tip,
+// and changed is true if the leader changed. The job counts as a sign of life of the controller, and it raises the height of the
+// controller if the new block arrived before the next advertisment.
+func (e *Election) Job(j *job.Job, now time.Time) (mine, changed bool) {
+	addr := p2padvt.ControllerAddress(j.IPs, j.ControllerPort)
+	if addr == "" {
+		return
+	}
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	c, ok := e.candidates[addr]
+	if !ok {
+		// controllers are only elected once they have advertised their priority and state
+		return
+	}
+	c.Seen, c.Job = now, j
+	if j.Height-1 > c.Height {
+		c.Height = j.Height - 1
+	}
+	changed = e.elect(now)
+	mine = addr == e.leader && j.Height-1 >= e.tip(now)
+	return
+}
+
+// Expire drops the controllers that have not been heard from within the timeout, and returns true if the leader
+// changed
+func (e *Election) Expire(now time.Time) (changed bool) {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	for addr, c := range e.candidates {
+		if now.Sub(c.Seen) > e.timeout {
+			delete(e.candidates, addr)
+		}
+	}
+	return e.elect(now)
+}
+
+// Leader returns the address of the elected controller and its last job if it is not behind the network tip. The
+// address is empty when no controller is fit to mine for.
+func (e *Election) Leader(now time.Time) (addr string, j *job.Job) {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	if c, ok := e.candidates[e.leader]; ok {
+		addr = c.Address
+		if c.Job != nil && c.Job.Height-1 >= e.tip(now) {
+			j = c.Job
+		}
+	}
+	return
+}
+
+// Candidates returns a copy of the state of the known controllers
+func (e *Election) Candidates() (candidates []Candidate) {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	for _, c := range e.candidates {
+		candidates = append(candidates, *c)
+	}
+	return
+}
+
+func (e *Election) candidate(addr string) (c *Candidate) {
+	var ok bool
+	if c, ok = e.candidates[addr]; !ok {
+		c = &Candidate{Address: addr}
+		e.candidates[addr] = c
+	}
+	return
+}
+
+// tip is the highest chain height of the live controllers
+func (e *Election) tip(now time.Time) (height int32) {
+	for _, c := range e.candidates {
+		if now.Sub(c.Seen) <= e.timeout && c.Height > height {
+			height = c.Height
+		}
+	}
+	return
+}
+
+// eligible returns true if a controller can be mined for: it is alive, synced, sending out work and not behind the tip
+func (e *Election) eligible(c *Candidate, tip int32, now time.Time) bool {
+	return now.Sub(c.Seen) <= e.timeout && c.Current && c.Mining && c.Height >= tip
+}
+
+// better returns true if controller a should be elected over controller b. Ties are broken by address so that all the
+// workers on the LAN elect the same controller
+func better(a, b *Candidate) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	return a.Address < b.Address
+}
+
+// elect chooses the leader and returns true if it changed. The leader is kept while it is eligible and no eligible
+// controller has a higher priority, so that controllers of the same priority that find out about new blocks at
+// slightly different times do not make workers switch back and forth.
+func (e *Election) elect(now time.Time) (changed bool) {
+	tip := e.tip(now)
+	var best *Candidate
+	for _, c := range e.candidates {
+		if e.eligible(c, tip, now) && (best == nil || better(c, best)) {
+			best = c
+		}
+	}
+	leader := ""
+	if best != nil {
+		leader = best.Address
+		if current, ok := e.candidates[e.leader]; ok && e.eligible(current, tip, now) &&
+			current.Priority >= best.Priority {
+			leader = current.Address
+		}
+	}
+	changed = leader != e.leader
+	e.leader = leader
+	return
+}
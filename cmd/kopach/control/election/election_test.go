@@ -0,0 +1,106 @@
+package election
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/cmd/kopach/control/job"
+	"github.com/p9c/pod/cmd/kopach/control/p2padvt"
+)
+
+// testAdvt returns an advertisment of a synced controller that is mining
+func testAdvt(ip byte, priority, height int32) *p2padvt.Advertisment {
+	return &p2padvt.Advertisment{
+		IPs:        []net.TCPAddr{{IP: net.IPv4(10, 0, 0, ip)}},
+		Controller: 11048,
+		Priority:   priority,
+		Height:     height,
+		Current:    true,
+		Mining:     true,
+	}
+}
+
+// testJob returns a job for the block after the given height from the controller of an advertisment
+func testJob(a *p2padvt.Advertisment, height int32) *job.Job {
+	return &job.Job{IPs: a.IPs, ControllerPort: a.Controller, Height: height + 1}
+}
+
+// TestElection runs workers' view of the LAN through controllers appearing, falling behind and going away.
+func TestElection(t *testing.T) {
+	now := time.Now()
+	e := New(DefaultTimeout)
+	a, b, c := testAdvt(1, 0, 100), testAdvt(2, 0, 100), testAdvt(3, 1, 100)
+	// jobs from controllers that have not advertised are not mined
+	if mine, _ := e.Job(testJob(a, 100), now); mine {
+		t.Errorf("mining a job from an unknown controller")
+	}
+	if !e.Advertised(a, now) {
+		t.Fatalf("expected the first controller to be elected")
+	}
+	if leader, _ := e.Leader(now); leader != a.Address() {
+		t.Fatalf("leader is %q, want %q", leader, a.Address())
+	}
+	// a standby of the same priority does not take over
+	if e.Advertised(b, now) {
+		t.Errorf("leader changed to a controller of the same priority")
+	}
+	if mine, _ := e.Job(testJob(b, 100), now); mine {
+		t.Errorf("mining a job from a standby controller")
+	}
+	if mine, _ := e.Job(testJob(a, 100), now); !mine {
+		t.Errorf("not mining a job from the leader")
+	}
+	// a controller with a higher priority takes over, and its last job is ready to mine straight away
+	jc := testJob(c, 100)
+	e.Advertised(c, now)
+	if mine, _ := e.Job(jc, now); !mine {
+		t.Errorf("not mining a job from the controller with the highest priority")
+	}
+	if leader, j := e.Leader(now); leader != c.Address() || j != jc {
+		t.Errorf("leader is %q with job %v, want %q with its last job", leader, j, c.Address())
+	}
+	// when another controller hears of a new block first the leader is behind, and the workers switch over using the
+	// last job of the standby, whose height is raised by the job before the next advertisment
+	jb := testJob(b, 101)
+	mine, changed := e.Job(jb, now)
+	if !changed || !mine {
+		t.Errorf("job from a controller ahead of the leader: got changed %v mine %v", changed, mine)
+	}
+	if leader, j := e.Leader(now); leader != b.Address() || j != jb {
+		t.Errorf("leader is %q with job %v, want %q with its last job", leader, j, b.Address())
+	}
+	if mine, _ := e.Job(testJob(c, 100), now); mine {
+		t.Errorf("mining a job from a controller that is behind the tip")
+	}
+	// once it catches up the controller with the highest priority is elected again
+	c.Height = 101
+	if !e.Advertised(c, now) {
+		t.Errorf("expected the controller with the highest priority to be elected when it caught up")
+	}
+	// a controller that is shutting down advertises it is no longer mining
+	c.Mining = false
+	e.Advertised(c, now)
+	if leader, _ := e.Leader(now); leader != b.Address() {
+		t.Errorf("leader is %q after the leader stopped, want %q", leader, b.Address())
+	}
+	// controllers that are not synced are never elected
+	b.Current = false
+	e.Advertised(b, now)
+	if leader, _ := e.Leader(now); leader != "" {
+		t.Errorf("leader is %q, want none as no controller is synced and at the tip", leader)
+	}
+	// controllers that stop advertising are dropped, if there are none left there is no leader
+	a.Height = 101
+	e.Advertised(a, now)
+	if leader, _ := e.Leader(now); leader != a.Address() {
+		t.Errorf("leader is %q, want %q", leader, a.Address())
+	}
+	later := now.Add(DefaultTimeout + time.Second)
+	if !e.Expire(later) {
+		t.Errorf("expected the leader to expire")
+	}
+	if leader, _ := e.Leader(later); leader != "" || len(e.Candidates()) != 0 {
+		t.Errorf("leader is %q with %d candidates after all expired", leader, len(e.Candidates()))
+	}
+}
@@ -0,0 +1,313 @@
+// Package ledger is a persistent record of the shares found by the miners working for a kopach controller, for keeping
+// track of which worker found what and splitting block rewards between them.
+package ledger
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+const (
+	// MaxShares is how many of the most recent shares the ledger keeps. Older shares are pruned as new ones are
+	// added, so this is also the longest window a reward can be split over.
+	MaxShares = 100000
+	// pruneInterval is how many shares are added between prunes, so the ledger is not pruned for every share
+	pruneInterval = 1000
+)
+
+var (
+	sharesBucket = []byte("shares")
+	hashesBucket = []byte("hashes")
+	// ErrDuplicate is returned when a share with the same hash has already been recorded
+	ErrDuplicate = errors.New("share has already been recorded")
+)
+
+// Share is a single share found by a worker. Difficulties are relative to the minimum difficulty of the algorithm the
+// share was found with, the same way stratum share difficulty is counted.
+type Share struct {
+	Worker            string         `json:"worker"`
+	Algo              string         `json:"algo"`
+	Height            int32          `json:"height"`
+	Hash              chainhash.Hash `json:"hash"`
+	Difficulty        float64        `json:"difficulty"`
+	NetworkDifficulty float64        `json:"networkdifficulty"`
+	Time              time.Time      `json:"time"`
+	Block             bool           `json:"block"`
+}
+
+// Weight is the fraction of a block solution the share represents. Weighing shares this way makes shares of all the
+// algorithms count the same relative to the block difficulty of their algorithm.
+func (s *Share) Weight() float64 {
+	if s.NetworkDifficulty <= 0 {
+		return 0
+	}
+	return s.Difficulty / s.NetworkDifficulty
+}
+
+// Difficulty returns the difficulty of a target relative to the minimum difficulty of the algorithm at the height
+func Difficulty(algo string, height int32, target *big.Int) float64 {
+	if target.Sign() <= 0 {
+		return 0
+	}
+	d, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(fork.GetMinDiff(algo, height)), new(big.Float).SetInt(target),
+	).Float64()
+	return d
+}
+
+// Ledger stores shares in a bolt database
+type Ledger struct {
+	db *bolt.DB
+}
+
+// Open opens the ledger at the given path, creating it if it does not exist
+func Open(path string) (l *Ledger, err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	var db *bolt.DB
+	if db, err = bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second}); err != nil {
+		return
+	}
+	if err = db.Update(
+		func(tx *bolt.Tx) (err error) {
+			if _, err = tx.CreateBucketIfNotExists(sharesBucket); err != nil {
+				return
+			}
+			_, err = tx.CreateBucketIfNotExists(hashesBucket)
+			return
+		},
+	); err != nil {
+		_ = db.Close()
+		return
+	}
+	return &Ledger{db: db}, nil
+}
+
+// Close closes the ledger database
+func (l *Ledger) Close() error {
+	return l.db.Close()
+}
+
+// Add records a share. A share with a hash that has already been recorded is rejected with ErrDuplicate, except when
+// the new record marks the share as a block solution, which updates the existing record. Every so often the shares
+// beyond the most recent MaxShares are pruned.
+func (l *Ledger) Add(s *Share) error {
+	v, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return l.db.Update(
+		func(tx *bolt.Tx) (err error) {
+			shares, hashes := tx.Bucket(sharesBucket), tx.Bucket(hashesBucket)
+			key := hashes.Get(s.Hash[:])
+			if key != nil && !s.Block {
+				return ErrDuplicate
+			}
+			if key == nil {
+				var seq uint64
+				if seq, err = shares.NextSequence(); err != nil {
+					return
+				}
+				key = make([]byte, 8)
+				binary.BigEndian.PutUint64(key, seq)
+				if err = hashes.Put(s.Hash[:], key); err != nil {
+					return
+				}
+				if err = shares.Put(key, v); err != nil {
+					return
+				}
+				if seq%pruneInterval == 0 {
+					_, err = prune(tx, MaxShares)
+				}
+				return
+			}
+			return shares.Put(key, v)
+		},
+	)
+}
+
+// Prune removes all but the most recent keep shares from the ledger and returns how many were removed
+func (l *Ledger) Prune(keep int) (n int, err error) {
+	err = l.db.Update(
+		func(tx *bolt.Tx) (err error) {
+			n, err = prune(tx, keep)
+			return
+		},
+	)
+	return
+}
+
+// prune removes all but the most recent keep shares, along with their hashes. Shares are keyed by consecutive sequence
+// numbers and only the oldest are ever removed, so the keys run without gaps from the first to the last.
+func prune(tx *bolt.Tx, keep int) (n int, err error) {
+	shares, hashes := tx.Bucket(sharesBucket), tx.Bucket(hashesBucket)
+	c := shares.Cursor()
+	first, _ := c.First()
+	last, _ := c.Last()
+	if first == nil {
+		return
+	}
+	start, end := binary.BigEndian.Uint64(first), binary.BigEndian.Uint64(last)
+	if end-start+1 <= uint64(keep) {
+		return
+	}
+	for seq := start; seq <= end-uint64(keep); seq++ {
+		k := make([]byte, 8)
+		binary.BigEndian.PutUint64(k, seq)
+		var s Share
+		if err = json.Unmarshal(shares.Get(k), &s); err != nil {
+			return
+		}
+		if err = hashes.Delete(s.Hash[:]); err != nil {
+			return
+		}
+		if err = shares.Delete(k); err != nil {
+			return
+		}
+		n++
+	}
+	return
+}
+
+// Count returns the number of shares in the ledger
+func (l *Ledger) Count() (n int) {
+	_ = l.db.View(
+		func(tx *bolt.Tx) error {
+			n = tx.Bucket(sharesBucket).Stats().KeyN
+			return nil
+		},
+	)
+	return
+}
+
+// ForEach calls fn with each share from the most recent back, until fn returns false or there are no more shares
+func (l *Ledger) ForEach(fn func(s *Share) bool) error {
+	return l.db.View(
+		func(tx *bolt.Tx) (err error) {
+			c := tx.Bucket(sharesBucket).Cursor()
+			for k, v := c.Last(); k != nil; k, v = c.Prev() {
+				var s Share
+				if err = json.Unmarshal(v, &s); err != nil {
+					return
+				}
+				if !fn(&s) {
+					break
+				}
+			}
+			return
+		},
+	)
+}
+
+// WorkerStats is the summary of the shares found by a worker
+type WorkerStats struct {
+	Worker     string
+	Shares     int64
+	Difficulty float64
+	Weight     float64
+	Algos      map[string]int64
+	Blocks     []chainhash.Hash
+	LastShare  time.Time
+}
+
+// Workers returns the totals of the shares of each worker in the ledger, sorted by worker
+func (l *Ledger) Workers() (stats []WorkerStats, err error) {
+	workers := make(map[string]*WorkerStats)
+	if err = l.ForEach(
+		func(s *Share) bool {
+			ws, ok := workers[s.Worker]
+			if !ok {
+				ws = &WorkerStats{Worker: s.Worker, Algos: make(map[string]int64), LastShare: s.Time}
+				workers[s.Worker] = ws
+			}
+			ws.Shares++
+			ws.Difficulty += s.Difficulty
+			ws.Weight += s.Weight()
+			ws.Algos[s.Algo]++
+			if s.Block {
+				ws.Blocks = append(ws.Blocks, s.Hash)
+			}
+			return true
+		},
+	); err != nil {
+		return
+	}
+	for _, ws := range workers {
+		stats = append(stats, *ws)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Worker < stats[j].Worker })
+	return
+}
+
+// Payout is the part of a reward a worker gets in a PPLNS split
+type Payout struct {
+	Worker   string
+	Shares   int64
+	Weight   float64
+	Fraction float64
+	Amount   int64
+}
+
+// PPLNS splits a reward between the workers that found the last n shares in the ledger (pay per last N shares), in
+// proportion to the weight of their shares. All of the reward is handed out, the amount left over by rounding goes to
+// the worker with the largest part. The payouts are sorted by worker.
+func (l *Ledger) PPLNS(n int, reward int64) (payouts []Payout, err error) {
+	var shares []*Share
+	if err = l.ForEach(
+		func(s *Share) bool {
+			shares = append(shares, s)
+			return len(shares) < n
+		},
+	); err != nil {
+		return
+	}
+	return Split(shares, reward), nil
+}
+
+// Split divides a reward between the workers of a set of shares in proportion to the weight of their shares
+func Split(shares []*Share, reward int64) (payouts []Payout) {
+	workers := make(map[string]*Payout)
+	var total float64
+	for _, s := range shares {
+		p, ok := workers[s.Worker]
+		if !ok {
+			p = &Payout{Worker: s.Worker}
+			workers[s.Worker] = p
+		}
+		p.Shares++
+		p.Weight += s.Weight()
+		total += s.Weight()
+	}
+	if total <= 0 {
+		return
+	}
+	for _, p := range workers {
+		payouts = append(payouts, *p)
+	}
+	sort.Slice(payouts, func(i, j int) bool { return payouts[i].Worker < payouts[j].Worker })
+	largest := 0
+	remainder := reward
+	for i := range payouts {
+		payouts[i].Fraction = payouts[i].Weight / total
+		payouts[i].Amount = int64(math.Floor(payouts[i].Fraction * float64(reward)))
+		remainder -= payouts[i].Amount
+		if payouts[i].Weight > payouts[largest].Weight {
+			largest = i
+		}
+	}
+	payouts[largest].Amount += remainder
+	return
+}
@@ -0,0 +1,157 @@
+package ledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+func openTestLedger(t *testing.T) (l *Ledger, teardown func()) {
+	dir, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l, err = Open(filepath.Join(dir, "shares.db")); err != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatalf("Open: %v", err)
+	}
+	return l, func() {
+		if err := l.Close(); err != nil {
+			t.Error(err)
+		}
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// TestLedger ensures shares are recorded once per hash, summarised per worker and split by the most recent shares.
+func TestLedger(t *testing.T) {
+	l, teardown := openTestLedger(t)
+	defer teardown()
+	shares := []*Share{
+		{Worker: "a", Algo: "sha256d", Hash: chainhash.Hash{1}, Difficulty: 1, NetworkDifficulty: 4},
+		{Worker: "b", Algo: "scrypt", Hash: chainhash.Hash{2}, Difficulty: 2, NetworkDifficulty: 4},
+		{Worker: "a", Algo: "scrypt", Hash: chainhash.Hash{3}, Difficulty: 1, NetworkDifficulty: 2},
+		{Worker: "c", Algo: "sha256d", Hash: chainhash.Hash{4}, Difficulty: 1, NetworkDifficulty: 4},
+	}
+	for i, s := range shares {
+		s.Time = time.Unix(int64(1000+i), 0)
+		if err := l.Add(s); err != nil {
+			t.Fatalf("Add %d: %v", i, err)
+		}
+	}
+	if err := l.Add(shares[0]); err != ErrDuplicate {
+		t.Errorf("Add: got %v for a duplicate share, want %v", err, ErrDuplicate)
+	}
+	// marking a recorded share as a block updates it rather than adding another share
+	block := *shares[1]
+	block.Block = true
+	if err := l.Add(&block); err != nil {
+		t.Fatalf("Add block: %v", err)
+	}
+	if n := l.Count(); n != len(shares) {
+		t.Errorf("Count: got %d, want %d", n, len(shares))
+	}
+	workers, err := l.Workers()
+	if err != nil {
+		t.Fatalf("Workers: %v", err)
+	}
+	if len(workers) != 3 {
+		t.Fatalf("Workers: got %d workers, want 3", len(workers))
+	}
+	a := workers[0]
+	if a.Worker != "a" || a.Shares != 2 || a.Difficulty != 2 || a.Weight != 0.75 {
+		t.Errorf("Workers: unexpected totals for worker a: %+v", a)
+	}
+	if a.Algos["sha256d"] != 1 || a.Algos["scrypt"] != 1 {
+		t.Errorf("Workers: unexpected algorithm counts for worker a: %v", a.Algos)
+	}
+	if !a.LastShare.Equal(time.Unix(1002, 0)) {
+		t.Errorf("Workers: got last share %v for worker a, want %v", a.LastShare, time.Unix(1002, 0))
+	}
+	if b := workers[1]; len(b.Blocks) != 1 || b.Blocks[0] != shares[1].Hash {
+		t.Errorf("Workers: got blocks %v for worker b, want %v", b.Blocks, shares[1].Hash)
+	}
+	// the last three shares have weights of 0.5 for b, 0.5 for a and 0.25 for c
+	payouts, err := l.PPLNS(3, 1000)
+	if err != nil {
+		t.Fatalf("PPLNS: %v", err)
+	}
+	want := []Payout{
+		{Worker: "a", Shares: 1, Weight: 0.5, Fraction: 0.4, Amount: 400},
+		{Worker: "b", Shares: 1, Weight: 0.5, Fraction: 0.4, Amount: 400},
+		{Worker: "c", Shares: 1, Weight: 0.25, Fraction: 0.2, Amount: 200},
+	}
+	if len(payouts) != len(want) {
+		t.Fatalf("PPLNS: got %d payouts, want %d", len(payouts), len(want))
+	}
+	for i := range want {
+		if payouts[i] != want[i] {
+			t.Errorf("PPLNS: got payout %+v, want %+v", payouts[i], want[i])
+		}
+	}
+}
+
+// TestPrune ensures pruning keeps the most recent shares and forgets the hashes of the others.
+func TestPrune(t *testing.T) {
+	l, teardown := openTestLedger(t)
+	defer teardown()
+	for i := 0; i < 10; i++ {
+		if err := l.Add(&Share{Worker: "a", Hash: chainhash.Hash{byte(i)}, Difficulty: 1, NetworkDifficulty: 1}); err != nil {
+			t.Fatalf("Add %d: %v", i, err)
+		}
+	}
+	for _, keep := range []int{4, 4, 1} {
+		want := l.Count() - keep
+		if want < 0 {
+			want = 0
+		}
+		n, err := l.Prune(keep)
+		if err != nil {
+			t.Fatalf("Prune %d: %v", keep, err)
+		}
+		if n != want || l.Count() != keep {
+			t.Fatalf("Prune %d: removed %d leaving %d, want %d leaving %d", keep, n, l.Count(), want, keep)
+		}
+	}
+	var hashes []chainhash.Hash
+	if err := l.ForEach(func(s *Share) bool { hashes = append(hashes, s.Hash); return true }); err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != 1 || hashes[0] != (chainhash.Hash{9}) {
+		t.Fatalf("ForEach: got %v after pruning, want only the most recent share", hashes)
+	}
+	// a pruned share is forgotten, while a kept one is still a duplicate
+	if err := l.Add(&Share{Worker: "a", Hash: chainhash.Hash{0}}); err != nil {
+		t.Errorf("Add pruned share: %v", err)
+	}
+	if err := l.Add(&Share{Worker: "a", Hash: chainhash.Hash{9}}); err != ErrDuplicate {
+		t.Errorf("Add kept share: got %v, want %v", err, ErrDuplicate)
+	}
+}
+
+// TestSplit ensures the whole reward is handed out when it does not divide evenly.
+func TestSplit(t *testing.T) {
+	shares := []*Share{
+		{Worker: "a", Difficulty: 1, NetworkDifficulty: 1},
+		{Worker: "b", Difficulty: 1, NetworkDifficulty: 1},
+		{Worker: "c", Difficulty: 2, NetworkDifficulty: 1},
+	}
+	payouts := Split(shares, 1001)
+	var total int64
+	for _, p := range payouts {
+		total += p.Amount
+	}
+	if total != 1001 {
+		t.Errorf("Split: handed out %d, want 1001", total)
+	}
+	if payouts[2].Amount != 501 {
+		t.Errorf("Split: got %d for the largest part, want 501", payouts[2].Amount)
+	}
+	if Split(nil, 1000) != nil {
+		t.Errorf("Split: expected no payouts without shares")
+	}
+}
@@ -1,6 +1,7 @@
 package p2padvt
 
 import (
+	"fmt"
 	"github.com/niubaoshu/gotiny"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/routeable"
@@ -9,11 +10,34 @@ import (
 	"github.com/p9c/pod/app/conte"
 )
 
-var Magic = []byte{'a', 'd', 'v', 1}
+// Magic is the marker for advertisment packets. The version was raised when the election fields were added, as they
+// change the encoding
+var Magic = []byte{'a', 'd', 'v', 2}
 
+// Advertisment is the message nodes broadcast every second so that nodes on the LAN can connect to each other and
+// workers can elect the controller to mine for. Priority is set by the node operator, Height is the height of the best
+// block of the node, Current is whether the node considers itself synced and Mining is whether the controller is
+// sending out work
 type Advertisment struct {
 	IPs                  []net.TCPAddr
 	P2P, RPC, Controller uint16
+	Priority             int32
+	Height               int32
+	Current              bool
+	Mining               bool
+}
+
+// Address returns the address of the controller that sent the advertisment
+func (a *Advertisment) Address() string {
+	return ControllerAddress(a.IPs, a.Controller)
+}
+
+// ControllerAddress returns the address a controller is identified by given its addresses and controller port
+func ControllerAddress(ips []net.TCPAddr, port uint16) string {
+	if len(ips) < 1 {
+		return ""
+	}
+	return net.JoinHostPort(ips[0].IP.String(), fmt.Sprint(port))
 }
 
 //
@@ -34,6 +58,7 @@ func Get(cx *conte.Xt) []byte {
 		RPC:        RPC,
 		Controller: Controller,
 	}
+	adv.SetChainState(cx)
 	// Debugs(adv)
 	ad := gotiny.Marshal(&adv)
 	// Debugs(ad)
@@ -54,6 +79,7 @@ func GetAdvt(cx *conte.Xt) *Advertisment {
 		RPC:        util.GetActualPort((*cx.Config.RPCListeners)[0]),
 		Controller: util.GetActualPort(*cx.Config.Controller),
 	}
+	adv.SetChainState(cx)
 	return adv
 	// return simplebuffer.Serializers{
 	// 	IPs.GetListenable(),
@@ -63,6 +89,17 @@ func GetAdvt(cx *conte.Xt) *Advertisment {
 	// }
 }
 
+// SetChainState fills in the priority and the chain state of the node
+func (a *Advertisment) SetChainState(cx *conte.Xt) {
+	if cx.Config.ControllerPriority != nil {
+		a.Priority = int32(*cx.Config.ControllerPriority)
+	}
+	if cx.RealNode != nil && cx.RealNode.Chain != nil {
+		a.Height = cx.RealNode.Chain.BestSnapshot().Height
+		a.Current = cx.IsCurrent()
+	}
+}
+
 //
 // // GetIPs decodes the IPs from the advertisment
 // func (j *Container) GetIPs() []*net.IP {
@@ -0,0 +1,56 @@
+// Package share is the message kopach workers send to the controller for each hash they find that meets the share
+// target, a lower difficulty than the block target, so the controller can account for the work done by each worker.
+package share
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/niubaoshu/gotiny"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// Magic is the marker for packets containing a share
+var Magic = []byte{'s', 'h', 'r', 1}
+
+// TargetShift is the power of two the share target is easier than the block target of the same algorithm, so on
+// average there is one block solution for every 1024 shares, whatever the algorithm.
+const TargetShift = 10
+
+// maxTarget is the largest possible hash
+var maxTarget = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+type Share struct {
+	Port   int32
+	ID     string
+	Height int32
+	Header []byte
+}
+
+// Get returns the serialized share message for a block header found by the worker with the given id
+func Get(port int32, id string, height int32, h *wire.BlockHeader) []byte {
+	var buf bytes.Buffer
+	var err error
+	if err = h.Serialize(&buf); Check(err) {
+	}
+	s := Share{Port: port, ID: id, Height: height, Header: buf.Bytes()}
+	return gotiny.Marshal(&s)
+}
+
+// GetHeader decodes the block header of the share
+func (s *Share) GetHeader() (h *wire.BlockHeader, err error) {
+	h = &wire.BlockHeader{}
+	err = h.Deserialize(bytes.NewReader(s.Header))
+	return
+}
+
+// Target returns the target a share must meet for the given block difficulty bits
+func Target(bits uint32) (target *big.Int) {
+	target = new(big.Int).Lsh(fork.CompactToBig(bits), TargetShift)
+	if target.Cmp(maxTarget) > 0 {
+		target.Set(maxTarget)
+	}
+	return
+}
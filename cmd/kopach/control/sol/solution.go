@@ -13,17 +13,19 @@ var Magic = []byte{'s', 'o', 'l', 1}
 
 type Solution struct {
 	Port int32
+	// ID is the id of the kopach instance whose worker found the solution
+	ID string
 	// *wire.MsgBlock
 	Bytes []byte
 }
 
-func Get(port int32, mb *wire.MsgBlock) []byte {
+func Get(port int32, id string, mb *wire.MsgBlock) []byte {
 	var buf []byte
 	wr := bytes.NewBuffer(buf)
 	var err error
 	if err = mb.Serialize(wr); Check(err) {
 	}
-	s := Solution{Port: port, Bytes: wr.Bytes()} // MsgBlock: mb}
+	s := Solution{Port: port, ID: id, Bytes: wr.Bytes()} // MsgBlock: mb}
 	return gotiny.Marshal(&s)
 }
 
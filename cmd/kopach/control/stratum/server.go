@@ -0,0 +1,560 @@
+package stratum
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/cmd/kopach/control/ledger"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/util"
+	qu "github.com/p9c/pod/pkg/util/quit"
+)
+
+// Stratum error codes as used by the common pool software
+const (
+	ErrCodeOther          = 20
+	ErrCodeJobNotFound    = 21
+	ErrCodeDuplicateShare = 22
+	ErrCodeLowDifficulty  = 23
+	ErrCodeUnauthorized   = 24
+	ErrCodeNotSubscribed  = 25
+)
+
+const (
+	// maxLineLength is the longest request line a client may send before being disconnected
+	maxLineLength = 16384
+	// DefaultReadTimeout is how long a miner may send nothing before being disconnected
+	DefaultReadTimeout = time.Minute * 10
+	// DefaultMaxConnections is how many miners can be connected at once
+	DefaultMaxConnections = 256
+)
+
+// Config is the configuration for a stratum server
+type Config struct {
+	// Listener is the address the server accepts miner connections on
+	Listener string
+	// Difficulty is the share difficulty sessions start with, 1 being the minimum difficulty of the algorithm
+	Difficulty float64
+	// Submit is called with each share that meets the network target
+	Submit func(block *util.Block) error
+	// Accepted is called with each accepted share, after it has been submitted if it is a block solution
+	Accepted func(s *ledger.Share)
+	// Users are the user names and passwords miners authorize with. The user name is the part of the worker name
+	// before the first dot, so one user can run several named workers, and the password is the first of the comma
+	// separated fields of the password that is not an option. With no users no miner can authorize.
+	Users map[string]string
+	// ReadTimeout is how long a miner may send nothing before being disconnected
+	ReadTimeout time.Duration
+	// MaxConnections is how many miners can be connected at once, further connections are closed straight away
+	MaxConnections int
+}
+
+// Server is a stratum v1 server handing out jobs built from the work of a kopach controller
+type Server struct {
+	cfg        *Config
+	listener   net.Listener
+	mx         sync.Mutex
+	work       *Work
+	jobs       map[string]*Job
+	current    map[int32]*Job
+	jobID      uint64
+	extraNonce uint32
+	sessions   map[*session]struct{}
+	slots      chan struct{}
+	quit       qu.C
+}
+
+// New creates a new stratum server
+func New(cfg *Config) *Server {
+	if cfg.Difficulty <= 0 {
+		cfg.Difficulty = 1
+	}
+	if cfg.ReadTimeout <= 0 {
+		cfg.ReadTimeout = DefaultReadTimeout
+	}
+	if cfg.MaxConnections <= 0 {
+		cfg.MaxConnections = DefaultMaxConnections
+	}
+	return &Server{
+		cfg:      cfg,
+		jobs:     make(map[string]*Job),
+		current:  make(map[int32]*Job),
+		sessions: make(map[*session]struct{}),
+		slots:    make(chan struct{}, cfg.MaxConnections),
+		quit:     qu.T(),
+	}
+}
+
+// Start opens the listener and starts accepting miner connections
+func (s *Server) Start() (err error) {
+	if s.listener, err = net.Listen("tcp", s.cfg.Listener); Check(err) {
+		return
+	}
+	Info("stratum server listening on", s.listener.Addr())
+	if len(s.cfg.Users) == 0 {
+		Warn("no users are configured for the stratum server, miners will not be able to authorize")
+	}
+	go func() {
+		for {
+			conn, err := s.listener.Accept()
+			if err != nil {
+				select {
+				case <-s.quit.Wait():
+				default:
+					Error(err)
+				}
+				return
+			}
+			select {
+			case s.slots <- struct{}{}:
+			default:
+				Debug("too many stratum miners connected, refusing", conn.RemoteAddr())
+				if err = conn.Close(); err != nil {
+					Trace(err)
+				}
+				continue
+			}
+			go func() {
+				s.Serve(conn)
+				<-s.slots
+			}()
+		}
+	}()
+	return
+}
+
+// Addr returns the address the server is listening on
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Stop closes the listener and disconnects all the miners
+func (s *Server) Stop() {
+	s.quit.Q()
+	if s.listener != nil {
+		if err := s.listener.Close(); Check(err) {
+		}
+	}
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for sess := range s.sessions {
+		if err := sess.conn.Close(); Check(err) {
+		}
+	}
+}
+
+// SetWork replaces the work jobs are built from and sends new jobs to all the miners. When the work builds on a new
+// block the jobs of the previous work are dropped and miners are told to abandon them.
+func (s *Server) SetWork(w *Work) {
+	s.mx.Lock()
+	clean := s.work == nil || s.work.PrevBlock != w.PrevBlock
+	if clean {
+		s.jobs = make(map[string]*Job)
+	}
+	s.work = w
+	s.current = make(map[int32]*Job)
+	sessions := make([]*session, 0, len(s.sessions))
+	for sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	s.mx.Unlock()
+	for _, sess := range sessions {
+		if sess.isAuthorized() {
+			sess.sendJob(clean)
+		}
+	}
+}
+
+// job returns the current job for a block version, creating it if it has not been requested yet
+func (s *Server) job(version int32) (j *Job, err error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.work == nil {
+		return nil, errors.New("no work available yet")
+	}
+	if j = s.current[version]; j != nil {
+		return
+	}
+	s.jobID++
+	if j, err = NewJob(strconv.FormatUint(s.jobID, 16), s.work, version); err != nil {
+		return
+	}
+	s.current[version] = j
+	s.jobs[j.ID] = j
+	return
+}
+
+// Serve runs a stratum session on a connection until it is closed
+func (s *Server) Serve(conn net.Conn) {
+	s.mx.Lock()
+	s.extraNonce++
+	sess := &session{
+		srv:         s,
+		conn:        conn,
+		extraNonce1: make([]byte, ExtraNonce1Size),
+		difficulty:  s.cfg.Difficulty,
+	}
+	binary.BigEndian.PutUint32(sess.extraNonce1, s.extraNonce)
+	s.sessions[sess] = struct{}{}
+	s.mx.Unlock()
+	Debug("stratum miner connected from", conn.RemoteAddr())
+	defer func() {
+		s.mx.Lock()
+		delete(s.sessions, sess)
+		s.mx.Unlock()
+		if err := conn.Close(); err != nil {
+			Trace(err)
+		}
+		Debug("stratum miner disconnected", conn.RemoteAddr())
+	}()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 1024), maxLineLength)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(s.cfg.ReadTimeout)); err != nil {
+			Debug(err)
+			return
+		}
+		if !scanner.Scan() {
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			Debug("invalid stratum request from", conn.RemoteAddr(), err)
+			return
+		}
+		sess.handle(&req)
+	}
+}
+
+type request struct {
+	ID     interface{}       `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID     interface{} `json:"id"`
+	Result interface{} `json:"result"`
+	Error  interface{} `json:"error"`
+}
+
+type notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// session is the state of a single miner connection
+type session struct {
+	srv         *Server
+	conn        net.Conn
+	writeMx     sync.Mutex
+	mx          sync.Mutex
+	extraNonce1 []byte
+	subscribed  bool
+	authorized  bool
+	worker      string
+	algo        string
+	version     int32
+	difficulty  float64
+}
+
+func (sess *session) send(msg interface{}) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		Error(err)
+		return
+	}
+	sess.writeMx.Lock()
+	defer sess.writeMx.Unlock()
+	if _, err = sess.conn.Write(append(b, '\n')); err != nil {
+		Debug(err)
+	}
+}
+
+func (sess *session) reply(id interface{}, result interface{}) {
+	sess.send(&response{ID: id, Result: result})
+}
+
+func (sess *session) fail(id interface{}, code int, msg string) {
+	sess.send(&response{ID: id, Result: nil, Error: []interface{}{code, msg, nil}})
+}
+
+func (sess *session) isAuthorized() bool {
+	sess.mx.Lock()
+	defer sess.mx.Unlock()
+	return sess.authorized
+}
+
+func (sess *session) handle(req *request) {
+	switch req.Method {
+	case "mining.subscribe":
+		sess.mx.Lock()
+		sess.subscribed = true
+		sess.mx.Unlock()
+		id := hex.EncodeToString(sess.extraNonce1)
+		sess.reply(
+			req.ID, []interface{}{
+				[][]string{{"mining.set_difficulty", id}, {"mining.notify", id}},
+				hex.EncodeToString(sess.extraNonce1),
+				ExtraNonce2Size,
+			},
+		)
+	case "mining.extranonce.subscribe":
+		sess.reply(req.ID, true)
+	case "mining.authorize":
+		var user, pass string
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params[0], &user); err != nil {
+				sess.fail(req.ID, ErrCodeOther, "invalid worker name")
+				return
+			}
+		}
+		if len(req.Params) > 1 {
+			_ = json.Unmarshal(req.Params[1], &pass)
+		}
+		if !sess.srv.authorize(user, pass) {
+			Debug("stratum miner", user, "from", sess.conn.RemoteAddr(), "failed to authorize")
+			sess.fail(req.ID, ErrCodeUnauthorized, "Unauthorized worker")
+			return
+		}
+		sess.mx.Lock()
+		if !sess.subscribed {
+			sess.mx.Unlock()
+			sess.fail(req.ID, ErrCodeNotSubscribed, "Not subscribed")
+			return
+		}
+		sess.worker = user
+		sess.authorized = true
+		sess.parseOptions(pass)
+		sess.mx.Unlock()
+		sess.reply(req.ID, true)
+		sess.sendJob(true)
+	case "mining.suggest_difficulty":
+		var diff float64
+		if len(req.Params) < 1 || json.Unmarshal(req.Params[0], &diff) != nil || diff <= 0 {
+			sess.fail(req.ID, ErrCodeOther, "invalid difficulty")
+			return
+		}
+		sess.mx.Lock()
+		sess.difficulty = diff
+		sess.mx.Unlock()
+		sess.reply(req.ID, true)
+		sess.send(&notification{Method: "mining.set_difficulty", Params: []interface{}{diff}})
+	case "mining.submit":
+		if err := sess.submit(req.Params); err != nil {
+			code := ErrCodeOther
+			switch err {
+			case ErrStale:
+				code = ErrCodeJobNotFound
+			case ErrDuplicate:
+				code = ErrCodeDuplicateShare
+			case ErrLowDifficulty:
+				code = ErrCodeLowDifficulty
+			case errUnauthorized:
+				code = ErrCodeUnauthorized
+			}
+			sess.fail(req.ID, code, err.Error())
+			return
+		}
+		sess.reply(req.ID, true)
+	default:
+		sess.fail(req.ID, ErrCodeOther, "unknown method "+req.Method)
+	}
+}
+
+var errUnauthorized = errors.New("unauthorized worker")
+
+// authorize returns true if the user of a worker name is one of the configured users and the password field has its
+// password
+func (s *Server) authorize(worker, pass string) bool {
+	user := strings.SplitN(worker, ".", 2)[0]
+	want, ok := s.cfg.Users[user]
+	if user == "" || !ok {
+		return false
+	}
+	for _, field := range strings.Split(pass, ",") {
+		if field = strings.TrimSpace(field); !strings.Contains(field, "=") {
+			return subtle.ConstantTimeCompare([]byte(field), []byte(want)) == 1
+		}
+	}
+	return false
+}
+
+// parseOptions reads the options a miner can put in the password field after the password, separated by commas:
+// algo=<name> selects the algorithm to mine, v=<version> selects a block version directly, and d=<difficulty> sets the
+// share difficulty. Must be called with the session lock held.
+func (sess *session) parseOptions(pass string) {
+	for _, opt := range strings.Split(pass, ",") {
+		kv := strings.SplitN(strings.TrimSpace(opt), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "algo", "a":
+			sess.algo = kv[1]
+		case "v":
+			if v, err := strconv.ParseInt(kv[1], 10, 32); err == nil {
+				sess.version = int32(v)
+			}
+		case "d":
+			if d, err := strconv.ParseFloat(kv[1], 64); err == nil && d > 0 {
+				sess.difficulty = d
+			}
+		}
+	}
+}
+
+// blockVersion returns the block version the session mines at the given height. An explicitly requested version is
+// used as long as the work has a target for it, otherwise the version of the requested algorithm, which falls back to
+// the first algorithm of the current hard fork when it is not known, and to the lowest version the work has a target
+// for when there is none for that algorithm.
+func (sess *session) blockVersion(w *Work) (version int32) {
+	sess.mx.Lock()
+	defer sess.mx.Unlock()
+	if _, ok := w.Bits[sess.version]; ok && sess.version != 0 {
+		return sess.version
+	}
+	algo := sess.algo
+	if algo == "" {
+		algo = fork.SHA256d
+	}
+	version = fork.GetAlgoVer(algo, w.Height)
+	if _, ok := w.Bits[version]; ok {
+		return
+	}
+	first := true
+	for v := range w.Bits {
+		if first || v < version {
+			version, first = v, false
+		}
+	}
+	return
+}
+
+func (sess *session) sendJob(clean bool) {
+	sess.srv.mx.Lock()
+	w := sess.srv.work
+	sess.srv.mx.Unlock()
+	if w == nil {
+		return
+	}
+	j, err := sess.srv.job(sess.blockVersion(w))
+	if err != nil {
+		Error(err)
+		return
+	}
+	sess.mx.Lock()
+	diff := sess.difficulty
+	sess.mx.Unlock()
+	sess.send(&notification{Method: "mining.set_difficulty", Params: []interface{}{diff}})
+	branch := make([]string, len(j.Branch))
+	for i := range j.Branch {
+		branch[i] = hex.EncodeToString(j.Branch[i][:])
+	}
+	sess.send(
+		&notification{
+			Method: "mining.notify",
+			Params: []interface{}{
+				j.ID,
+				PrevHashHex(&w.PrevBlock),
+				hex.EncodeToString(j.Coinb1),
+				hex.EncodeToString(j.Coinb2),
+				branch,
+				fmt.Sprintf("%08x", uint32(j.Version)),
+				fmt.Sprintf("%08x", j.Bits),
+				fmt.Sprintf("%08x", j.Time),
+				clean,
+			},
+		},
+	)
+}
+
+// submit checks a share sent with mining.submit, and hands it to the chain if it is a block solution
+func (sess *session) submit(params []json.RawMessage) (err error) {
+	sess.mx.Lock()
+	authorized, worker, difficulty := sess.authorized, sess.worker, sess.difficulty
+	sess.mx.Unlock()
+	if !authorized {
+		return errUnauthorized
+	}
+	if len(params) < 5 {
+		return errors.New("expected 5 parameters")
+	}
+	var p [5]string
+	for i := range p {
+		if err = json.Unmarshal(params[i], &p[i]); err != nil {
+			return errors.New("invalid parameters")
+		}
+	}
+	if p[0] != worker {
+		return errUnauthorized
+	}
+	sess.srv.mx.Lock()
+	j, ok := sess.srv.jobs[p[1]]
+	sess.srv.mx.Unlock()
+	if !ok {
+		return ErrStale
+	}
+	share := &Share{ExtraNonce1: sess.extraNonce1}
+	if share.ExtraNonce2, err = hex.DecodeString(p[2]); err != nil {
+		return errors.New("invalid extranonce2")
+	}
+	var ntime, nonce uint64
+	if ntime, err = strconv.ParseUint(p[3], 16, 32); err != nil {
+		return errors.New("invalid ntime")
+	}
+	if nonce, err = strconv.ParseUint(p[4], 16, 32); err != nil {
+		return errors.New("invalid nonce")
+	}
+	share.Time, share.Nonce = uint32(ntime), uint32(nonce)
+	var block *util.Block
+	var hash chainhash.Hash
+	if block, hash, err = j.Check(share, ShareTarget(j.Algo, j.Height, difficulty)); err != nil {
+		Debug("rejected share from", worker, err)
+		return
+	}
+	Trace("accepted share from", worker, "for job", j.ID)
+	if block != nil {
+		Info("stratum miner", worker, "found block", block.Hash(), "at height", j.Height, "with", j.Algo)
+		if sess.srv.cfg.Submit != nil {
+			if err = sess.srv.cfg.Submit(block); Check(err) {
+				// the share was still valid work, the block just did not make it into the chain
+				err = nil
+			}
+		}
+	}
+	if sess.srv.cfg.Accepted != nil {
+		sess.srv.cfg.Accepted(
+			&ledger.Share{
+				Worker:            worker,
+				Algo:              j.Algo,
+				Height:            j.Height,
+				Hash:              hash,
+				Difficulty:        difficulty,
+				NetworkDifficulty: ledger.Difficulty(j.Algo, j.Height, fork.CompactToBig(j.Bits)),
+				Time:              time.Now(),
+				Block:             block != nil,
+			},
+		)
+	}
+	return
+}
@@ -0,0 +1,281 @@
+package stratum
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// testHeight is a height before the first hard fork, so the test shares are hashed with sha256d
+const testHeight = 1000
+
+// testTx returns a distinct transaction for use as a block transaction
+func testTx(n uint32) *util.Tx {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}, Index: n}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(int64(n), []byte{0x51}))
+	return util.NewTx(tx)
+}
+
+// testWork returns work with a single block version and the given number of transactions besides the coinbase
+func testWork(txCount int, bits uint32, prev chainhash.Hash) *Work {
+	version := fork.GetAlgoVer(fork.SHA256d, testHeight)
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(
+		wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{0x02, 0xe8, 0x03}, nil),
+	)
+	coinbase.AddTxOut(wire.NewTxOut(5000000000, []byte{0x51}))
+	w := &Work{
+		Height:    testHeight,
+		PrevBlock: prev,
+		Timestamp: time.Now().Truncate(time.Second),
+		Bits:      blockchain.TargetBits{version: bits},
+		Coinbases: map[int32]*util.Tx{version: util.NewTx(coinbase)},
+	}
+	for i := 0; i < txCount; i++ {
+		w.Txs = append(w.Txs, testTx(uint32(i)))
+	}
+	return w
+}
+
+// TestMerkleBranch ensures the merkle root computed from the coinbase and the branch matches the merkle root of the
+// whole block.
+func TestMerkleBranch(t *testing.T) {
+	for txCount := 0; txCount < 8; txCount++ {
+		w := testWork(txCount, 0x207fffff, chainhash.Hash{})
+		j, err := NewJob("1", w, 2)
+		if err != nil {
+			t.Fatalf("%d: NewJob: %v", txCount, err)
+		}
+		share := &Share{
+			ExtraNonce1: []byte{1, 2, 3, 4},
+			ExtraNonce2: []byte{5, 6, 7, 8},
+			Time:        j.Time,
+		}
+		header, coinbase, err := j.Header(share)
+		if err != nil {
+			t.Fatalf("%d: Header: %v", txCount, err)
+		}
+		txs := append([]*util.Tx{util.NewTx(coinbase)}, w.Txs...)
+		store := blockchain.BuildMerkleTreeStore(txs, false)
+		if !header.MerkleRoot.IsEqual(store[len(store)-1]) {
+			t.Errorf("%d: merkle root %v, want %v", txCount, header.MerkleRoot, store[len(store)-1])
+		}
+	}
+}
+
+type testClient struct {
+	t       *testing.T
+	conn    net.Conn
+	scanner *bufio.Scanner
+	id      int
+}
+
+type testMessage struct {
+	ID     *int              `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	Result json.RawMessage   `json:"result"`
+	Error  []interface{}     `json:"error"`
+}
+
+func (c *testClient) call(method string, params ...interface{}) {
+	c.id++
+	b, _ := json.Marshal(map[string]interface{}{"id": c.id, "method": method, "params": params})
+	if _, err := c.conn.Write(append(b, '\n')); err != nil {
+		c.t.Fatalf("%s: %v", method, err)
+	}
+}
+
+func (c *testClient) read() (msg *testMessage) {
+	if !c.scanner.Scan() {
+		c.t.Fatalf("connection closed: %v", c.scanner.Err())
+	}
+	msg = &testMessage{}
+	if err := json.Unmarshal(c.scanner.Bytes(), msg); err != nil {
+		c.t.Fatalf("invalid message %s: %v", c.scanner.Text(), err)
+	}
+	return
+}
+
+// errorCode reads the response to the last call and returns its error code, or zero if it succeeded
+func (c *testClient) errorCode() int {
+	msg := c.read()
+	if msg.ID == nil || *msg.ID != c.id {
+		c.t.Fatalf("expected response to request %d, got %+v", c.id, msg)
+	}
+	if msg.Error == nil {
+		return 0
+	}
+	return int(msg.Error[0].(float64))
+}
+
+// TestServer runs a miner session through subscribing, authorizing and submitting shares and a block.
+func TestServer(t *testing.T) {
+	var submitted *util.Block
+	srv := New(
+		&Config{
+			Submit: func(block *util.Block) error { submitted = block; return nil },
+			Users:  map[string]string{"user": "pass"},
+		},
+	)
+	// a target that half of all hashes meet, so that a block is found quickly
+	srv.SetWork(testWork(3, 0x207fffff, chainhash.Hash{1}))
+	server, client := net.Pipe()
+	defer client.Close()
+	go srv.Serve(server)
+	c := &testClient{t: t, conn: client, scanner: bufio.NewScanner(client)}
+	// shares cannot be submitted before authorizing
+	c.call("mining.submit", "user.rig1", "1", "00000000", "00000000", "00000000")
+	if code := c.errorCode(); code != ErrCodeUnauthorized {
+		t.Fatalf("submit before authorize: got error code %d, want %d", code, ErrCodeUnauthorized)
+	}
+	c.call("mining.subscribe", "test/1.0")
+	msg := c.read()
+	var subscribe []json.RawMessage
+	if err := json.Unmarshal(msg.Result, &subscribe); err != nil || len(subscribe) != 3 {
+		t.Fatalf("unexpected subscribe result %s", msg.Result)
+	}
+	var extraNonce1 string
+	if err := json.Unmarshal(subscribe[1], &extraNonce1); err != nil {
+		t.Fatal(err)
+	}
+	// only configured users with their password can authorize
+	for _, login := range [][2]string{{"other.rig1", "pass"}, {"user.rig1", "wrong,d=0.0001"}, {"user.rig1", "d=0.0001"}} {
+		c.call("mining.authorize", login[0], login[1])
+		if code := c.errorCode(); code != ErrCodeUnauthorized {
+			t.Fatalf("authorize %s with %q: got error code %d, want %d", login[0], login[1], code, ErrCodeUnauthorized)
+		}
+	}
+	c.call("mining.authorize", "user.rig1", "pass,d=0.0001")
+	if code := c.errorCode(); code != 0 {
+		t.Fatalf("authorize failed with error code %d", code)
+	}
+	if msg = c.read(); msg.Method != "mining.set_difficulty" {
+		t.Fatalf("expected mining.set_difficulty, got %+v", msg)
+	}
+	if msg = c.read(); msg.Method != "mining.notify" || len(msg.Params) != 9 {
+		t.Fatalf("expected mining.notify, got %+v", msg)
+	}
+	var jobID, ntime string
+	_ = json.Unmarshal(msg.Params[0], &jobID)
+	_ = json.Unmarshal(msg.Params[7], &ntime)
+	// rebuild the header the way a miner would and look for a nonce that solves the block
+	srv.mx.Lock()
+	j := srv.jobs[jobID]
+	srv.mx.Unlock()
+	en1, _ := hex.DecodeString(extraNonce1)
+	var nonce uint32
+	for ; ; nonce++ {
+		header, _, err := j.Header(&Share{ExtraNonce1: en1, ExtraNonce2: []byte{0, 0, 0, 1}, Time: j.Time, Nonce: nonce})
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := header.BlockHashWithAlgos(testHeight)
+		if blockchain.HashToBig(&hash).Cmp(fork.CompactToBig(j.Bits)) <= 0 {
+			break
+		}
+	}
+	c.call("mining.submit", "user.rig1", jobID, "00000001", ntime, fmt.Sprintf("%08x", nonce))
+	if code := c.errorCode(); code != 0 {
+		t.Fatalf("submitting a solution failed with error code %d", code)
+	}
+	if submitted == nil {
+		t.Fatalf("block was not submitted")
+	}
+	if len(submitted.MsgBlock().Transactions) != 4 {
+		t.Errorf("block has %d transactions, want 4", len(submitted.MsgBlock().Transactions))
+	}
+	store := blockchain.BuildMerkleTreeStore(submitted.Transactions(), false)
+	if !submitted.MsgBlock().Header.MerkleRoot.IsEqual(store[len(store)-1]) {
+		t.Errorf("submitted block has an invalid merkle root")
+	}
+	c.call("mining.submit", "user.rig1", jobID, "00000001", ntime, fmt.Sprintf("%08x", nonce))
+	if code := c.errorCode(); code != ErrCodeDuplicateShare {
+		t.Errorf("duplicate share: got error code %d, want %d", code, ErrCodeDuplicateShare)
+	}
+	c.call("mining.submit", "user.rig1", "nonexistent", "00000001", ntime, fmt.Sprintf("%08x", nonce))
+	if code := c.errorCode(); code != ErrCodeJobNotFound {
+		t.Errorf("unknown job: got error code %d, want %d", code, ErrCodeJobNotFound)
+	}
+	// new work on another block makes the old jobs stale, and is sent out to the miner straight away
+	go srv.SetWork(testWork(0, 0x207fffff, chainhash.Hash{2}))
+	if msg = c.read(); msg.Method != "mining.set_difficulty" {
+		t.Fatalf("expected mining.set_difficulty, got %+v", msg)
+	}
+	if msg = c.read(); msg.Method != "mining.notify" {
+		t.Fatalf("expected mining.notify, got %+v", msg)
+	}
+	var clean bool
+	_ = json.Unmarshal(msg.Params[8], &clean)
+	if !clean {
+		t.Errorf("expected clean jobs on a new block")
+	}
+	c.call("mining.submit", "user.rig1", jobID, "00000002", ntime, fmt.Sprintf("%08x", nonce))
+	if code := c.errorCode(); code != ErrCodeJobNotFound {
+		t.Errorf("stale job: got error code %d, want %d", code, ErrCodeJobNotFound)
+	}
+}
+
+// TestLimits checks miners are disconnected after sending nothing for the read timeout, and that connections beyond the
+// maximum are closed straight away.
+func TestLimits(t *testing.T) {
+	srv := New(&Config{Listener: "127.0.0.1:0", ReadTimeout: time.Millisecond * 100, MaxConnections: 1})
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	first, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	// wait for the first connection to be accepted so the second is over the limit
+	time.Sleep(time.Millisecond * 20)
+	second, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	buf := make([]byte, 1)
+	if err = second.SetReadDeadline(time.Now().Add(time.Millisecond * 50)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = second.Read(buf); err == nil || isTimeout(err) {
+		t.Errorf("expected the connection over the limit to be closed, got %v", err)
+	}
+	if err = first.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = first.Read(buf); err == nil || isTimeout(err) {
+		t.Errorf("expected an idle connection to be closed, got %v", err)
+	}
+}
+
+// isTimeout returns true if the error is a network timeout
+func isTimeout(err error) bool {
+	ne, ok := err.(net.Error)
+	return ok && ne.Timeout()
+}
+
+// TestShareTarget ensures shares are measured against the minimum difficulty of their algorithm.
+func TestShareTarget(t *testing.T) {
+	diff1 := fork.GetMinDiff(fork.SHA256d, testHeight)
+	if ShareTarget(fork.SHA256d, testHeight, 1).Cmp(diff1) != 0 {
+		t.Errorf("difficulty 1 share target is not the minimum difficulty")
+	}
+	half := ShareTarget(fork.SHA256d, testHeight, 2)
+	if half.Lsh(half, 1).Cmp(diff1) > 0 {
+		t.Errorf("difficulty 2 share target is more than half the minimum difficulty")
+	}
+}
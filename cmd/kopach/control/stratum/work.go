@@ -0,0 +1,273 @@
+package stratum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/cmd/kopach/control/job"
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// ExtraNonce1Size is the number of bytes of extranonce handed out to each session by the server.
+	ExtraNonce1Size = 4
+	// ExtraNonce2Size is the number of bytes of extranonce each miner rolls through itself.
+	ExtraNonce2Size = 4
+	// maxFutureTime is how far ahead of the local clock a share's timestamp may be.
+	maxFutureTime = 2 * time.Hour
+)
+
+var (
+	// ErrStale is returned for shares that were found on a job that no longer builds on the best block.
+	ErrStale = errors.New("job not found")
+	// ErrDuplicate is returned for a share that has already been submitted.
+	ErrDuplicate = errors.New("duplicate share")
+	// ErrLowDifficulty is returned for shares that do not meet the session share target.
+	ErrLowDifficulty = errors.New("low difficulty share")
+)
+
+// Work is the data a set of stratum jobs is built from. It carries the same difficulty targets as the job.Job sent to
+// kopach workers, along with the per-version coinbases and the transactions of the block template they were made for.
+type Work struct {
+	Height    int32
+	PrevBlock chainhash.Hash
+	Timestamp time.Time
+	Bits      blockchain.TargetBits
+	Coinbases map[int32]*util.Tx
+	Txs       []*util.Tx
+}
+
+// NewWork assembles the work for a job that was sent out to kopach workers.
+func NewWork(j *job.Job, coinbases map[int32]*util.Tx, txs []*util.Tx, timestamp time.Time) *Work {
+	return &Work{
+		Height:    j.Height,
+		PrevBlock: *j.PrevBlockHash,
+		Timestamp: timestamp,
+		Bits:      j.Bitses,
+		Coinbases: coinbases,
+		Txs:       txs,
+	}
+}
+
+// Job is a stratum job for one block version (and so one algorithm) of a Work. The coinbase is split around the
+// extranonce so that miners can build their own coinbase and merkle root from the branch.
+type Job struct {
+	ID       string
+	Version  int32
+	Algo     string
+	Height   int32
+	Bits     uint32
+	Time     uint32
+	Coinb1   []byte
+	Coinb2   []byte
+	Branch   []chainhash.Hash
+	work     *Work
+	coinbase *wire.MsgTx
+	mx       sync.Mutex
+	shares   map[string]struct{}
+}
+
+// NewJob builds the stratum job for the given block version of the work.
+func NewJob(id string, w *Work, version int32) (j *Job, err error) {
+	bits, ok := w.Bits[version]
+	if !ok {
+		return nil, fmt.Errorf("no difficulty target for block version %d", version)
+	}
+	cb, ok := w.Coinbases[version]
+	if !ok {
+		return nil, fmt.Errorf("no coinbase for block version %d", version)
+	}
+	// Replace the coinbase script with one that has room for the extranonce right after the block height, so the
+	// serialized coinbase can be split around it.
+	heightScript, err := txscript.NewScriptBuilder().AddInt64(int64(w.Height)).Script()
+	if err != nil {
+		return nil, err
+	}
+	script, err := txscript.NewScriptBuilder().AddInt64(int64(w.Height)).
+		AddData(make([]byte, ExtraNonce1Size+ExtraNonce2Size)).AddData([]byte(mining.CoinbaseFlags)).Script()
+	if err != nil {
+		return nil, err
+	}
+	coinbase := cb.MsgTx().Copy()
+	coinbase.TxIn[0].SignatureScript = script
+	var buf bytes.Buffer
+	if err = coinbase.SerializeNoWitness(&buf); err != nil {
+		return nil, err
+	}
+	// version, input count, previous outpoint and script length come before the script, and the extranonce follows the
+	// height push and the push opcode for the extranonce itself.
+	split := 4 + wire.VarIntSerializeSize(uint64(len(coinbase.TxIn))) + 36 +
+		wire.VarIntSerializeSize(uint64(len(script))) + len(heightScript) + 1
+	serialized := buf.Bytes()
+	txHashes := make([]*chainhash.Hash, len(w.Txs))
+	for i := range w.Txs {
+		txHashes[i] = w.Txs[i].Hash()
+	}
+	j = &Job{
+		ID:       id,
+		Version:  version,
+		Algo:     fork.GetAlgoName(version, w.Height),
+		Height:   w.Height,
+		Bits:     bits,
+		Time:     uint32(w.Timestamp.Unix()),
+		Coinb1:   serialized[:split],
+		Coinb2:   serialized[split+ExtraNonce1Size+ExtraNonce2Size:],
+		Branch:   MerkleBranch(txHashes),
+		work:     w,
+		coinbase: coinbase,
+		shares:   make(map[string]struct{}),
+	}
+	return
+}
+
+// MerkleBranch returns the hashes needed to compute the merkle root of a block from the hash of its coinbase, given the
+// hashes of the rest of the transactions in the block.
+func MerkleBranch(txHashes []*chainhash.Hash) (branch []chainhash.Hash) {
+	// The first entry of each level is the unknown hash that depends on the coinbase.
+	level := append([]*chainhash.Hash{nil}, txHashes...)
+	for len(level) > 1 {
+		branch = append(branch, *level[1])
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		next := []*chainhash.Hash{nil}
+		for i := 2; i < len(level); i += 2 {
+			next = append(next, blockchain.HashMerkleBranches(level[i], level[i+1]))
+		}
+		level = next
+	}
+	return
+}
+
+// MerkleRoot computes the merkle root from the coinbase hash and a merkle branch.
+func MerkleRoot(coinbaseHash *chainhash.Hash, branch []chainhash.Hash) *chainhash.Hash {
+	root := coinbaseHash
+	for i := range branch {
+		root = blockchain.HashMerkleBranches(root, &branch[i])
+	}
+	return root
+}
+
+// Share is a solution submitted by a miner for a job.
+type Share struct {
+	ExtraNonce1 []byte
+	ExtraNonce2 []byte
+	Time        uint32
+	Nonce       uint32
+}
+
+// Header returns the block header for the share and the coinbase transaction it commits to.
+func (j *Job) Header(s *Share) (header *wire.BlockHeader, coinbase *wire.MsgTx, err error) {
+	if len(s.ExtraNonce1) != ExtraNonce1Size || len(s.ExtraNonce2) != ExtraNonce2Size {
+		return nil, nil, errors.New("incorrect size of extranonce")
+	}
+	raw := make([]byte, 0, len(j.Coinb1)+ExtraNonce1Size+ExtraNonce2Size+len(j.Coinb2))
+	raw = append(raw, j.Coinb1...)
+	raw = append(raw, s.ExtraNonce1...)
+	raw = append(raw, s.ExtraNonce2...)
+	raw = append(raw, j.Coinb2...)
+	coinbase = wire.NewMsgTx(wire.TxVersion)
+	if err = coinbase.DeserializeNoWitness(bytes.NewReader(raw)); err != nil {
+		return nil, nil, err
+	}
+	// The witness reserved value of the template coinbase is not part of the hash, but is needed for the block.
+	coinbase.TxIn[0].Witness = j.coinbase.TxIn[0].Witness
+	coinbaseHash := chainhash.DoubleHashH(raw)
+	header = &wire.BlockHeader{
+		Version:    j.Version,
+		PrevBlock:  j.work.PrevBlock,
+		MerkleRoot: *MerkleRoot(&coinbaseHash, j.Branch),
+		Timestamp:  time.Unix(int64(s.Time), 0),
+		Bits:       j.Bits,
+		Nonce:      s.Nonce,
+	}
+	return
+}
+
+// Check validates a share against the given share target and returns the block hash of the share. The returned block
+// is not nil when the share also meets the network target of the job, and so is a solution that should be submitted to
+// the chain.
+func (j *Job) Check(s *Share, shareTarget *big.Int) (block *util.Block, hash chainhash.Hash, err error) {
+	if time.Unix(int64(s.Time), 0).Before(time.Unix(int64(j.Time), 0)) ||
+		time.Unix(int64(s.Time), 0).After(time.Now().Add(maxFutureTime)) {
+		err = errors.New("ntime out of range")
+		return
+	}
+	key := hex.EncodeToString(s.ExtraNonce1) + hex.EncodeToString(s.ExtraNonce2) +
+		fmt.Sprintf("%08x%08x", s.Time, s.Nonce)
+	j.mx.Lock()
+	_, seen := j.shares[key]
+	j.mx.Unlock()
+	if seen {
+		err = ErrDuplicate
+		return
+	}
+	var header *wire.BlockHeader
+	var coinbase *wire.MsgTx
+	if header, coinbase, err = j.Header(s); err != nil {
+		return
+	}
+	hash = header.BlockHashWithAlgos(j.Height)
+	bigHash := blockchain.HashToBig(&hash)
+	isBlock := bigHash.Cmp(fork.CompactToBig(j.Bits)) <= 0
+	if !isBlock && bigHash.Cmp(shareTarget) > 0 {
+		err = ErrLowDifficulty
+		return
+	}
+	// Two identical shares may have been checked at the same time, only the first one to get here counts.
+	j.mx.Lock()
+	_, seen = j.shares[key]
+	j.shares[key] = struct{}{}
+	j.mx.Unlock()
+	if seen {
+		err = ErrDuplicate
+		return
+	}
+	if !isBlock {
+		return
+	}
+	msgBlock := wire.NewMsgBlock(header)
+	if err = msgBlock.AddTransaction(coinbase); err != nil {
+		return
+	}
+	for _, tx := range j.work.Txs {
+		if err = msgBlock.AddTransaction(tx.MsgTx()); err != nil {
+			return
+		}
+	}
+	block = util.NewBlock(msgBlock)
+	block.SetHeight(j.Height)
+	return
+}
+
+// ShareTarget returns the target a share must meet for the given stratum difficulty. Difficulty 1 is the minimum
+// difficulty of the algorithm, so that each algorithm is measured against its own scale.
+func ShareTarget(algo string, height int32, difficulty float64) *big.Int {
+	diff1 := fork.GetMinDiff(algo, height)
+	if difficulty <= 0 {
+		return diff1
+	}
+	target, _ := new(big.Float).Quo(new(big.Float).SetInt(diff1), big.NewFloat(difficulty)).Int(nil)
+	return target
+}
+
+// PrevHashHex encodes a block hash in the word swapped byte order used by stratum mining.notify.
+func PrevHashHex(hash *chainhash.Hash) string {
+	var swapped [chainhash.HashSize]byte
+	for i := 0; i < chainhash.HashSize; i += 4 {
+		binary.BigEndian.PutUint32(swapped[i:], binary.LittleEndian.Uint32(hash[i:]))
+	}
+	return hex.EncodeToString(swapped[:])
+}
@@ -5,8 +5,10 @@ import (
 	"crypto/rand"
 	"fmt"
 	"github.com/p9c/pod/cmd/kopach/control/p2padvt"
+	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 	
@@ -25,6 +27,7 @@ import (
 	"github.com/p9c/pod/app/conte"
 	"github.com/p9c/pod/cmd/kopach/client"
 	"github.com/p9c/pod/cmd/kopach/control"
+	"github.com/p9c/pod/cmd/kopach/control/election"
 	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/kopach/control/job"
 	"github.com/p9c/pod/cmd/kopach/control/pause"
@@ -32,6 +35,7 @@ import (
 	"github.com/p9c/pod/pkg/comm/stdconn/worker"
 	"github.com/p9c/pod/pkg/comm/transport"
 	rav "github.com/p9c/pod/pkg/data/ring"
+	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
 
@@ -65,8 +69,9 @@ type Worker struct {
 	sendAddresses       []*net.UDPAddr
 	clients             []*client.Client
 	workers             []*worker.Worker
+	// FirstSender is the address of the elected controller the workers are mining for
 	FirstSender         atomic.String
-	lastSent            atomic.Int64
+	election            *election.Election
 	Status              atomic.String
 	HashTick            chan HashCount
 	LastHash            *chainhash.Hash
@@ -108,7 +113,14 @@ func (w *Worker) Start() {
 	
 }
 
+// Stop stops the workers and shuts down the miner.
 func (w *Worker) Stop() {
+	w.stopWorkers()
+	w.quit.Q()
+}
+
+// stopWorkers stops the workers, leaving the miner running to start them again.
+func (w *Worker) stopWorkers() {
 	var err error
 	for i := range w.clients {
 		if err = w.clients[i].Pause(); Check(err) {
@@ -126,18 +138,46 @@ func (w *Worker) Stop() {
 		Debug("stopped worker", i)
 	}
 	w.active.Store(false)
-	w.quit.Q()
+}
+
+// workerID returns the name the controller records the shares of this miner under. It is the configured worker name,
+// or else a random name that is kept in the data directory, so that shares are credited to the same worker across
+// restarts either way.
+func workerID(cx *conte.Xt) (id string, err error) {
+	if *cx.Config.WorkerName != "" {
+		return *cx.Config.WorkerName, nil
+	}
+	path := filepath.Join(*cx.Config.DataDir, "workerid")
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err == nil {
+		if id = strings.TrimSpace(string(b)); id != "" {
+			return
+		}
+	} else if !os.IsNotExist(err) {
+		return
+	}
+	randomBytes := make([]byte, 8)
+	if _, err = rand.Read(randomBytes); err != nil {
+		return
+	}
+	id = fmt.Sprintf("%x", randomBytes)
+	if err = os.MkdirAll(*cx.Config.DataDir, 0700); err != nil {
+		return
+	}
+	err = ioutil.WriteFile(path, []byte(id+"\n"), 0600)
+	return
 }
 
 func Handle(cx *conte.Xt) func(c *cli.Context) error {
 	return func(c *cli.Context) (err error) {
 		Debug("miner controller starting")
 		// ctx, cancel := context.WithCancel(context.Background())
-		randomBytes := make([]byte, 4)
-		if _, err = rand.Read(randomBytes); Check(err) {
+		var id string
+		if id, err = workerID(cx); Check(err) {
+			return
 		}
 		w := &Worker{
-			id: fmt.Sprintf("%x", randomBytes),
+			id: id,
 			cx: cx,
 			// ctx:           ctx,
 			quit:          cx.KillAll,
@@ -148,8 +188,8 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 			solutions:     make([]SolutionData, 0, 2048),
 			Update:        qu.T(),
 			hashSampleBuf: ring.NewBufferUint64(1000),
+			election:      election.New(election.DefaultTimeout),
 		}
-		w.lastSent.Store(time.Now().UnixNano())
 		w.active.Store(false)
 		Debug("opening broadcast channel listener")
 		w.conn, err = transport.NewBroadcastChannel(
@@ -165,12 +205,27 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 		// start up the workers
 		if *cx.Config.Generate {
 			w.Start()
-			interrupt.AddHandler(
-				func() {
-					w.Stop()
-				},
-			)
 		}
+		interrupt.AddHandler(
+			func() {
+				w.Stop()
+			},
+		)
+		// generating and the number of threads changed in the configuration are taken up by the work loop
+		cx.Hooks.Register(
+			"generate", func([]pod.Change) {
+				if *cx.Config.Generate {
+					w.StartChan <- struct{}{}
+				} else {
+					w.StopChan <- struct{}{}
+				}
+			},
+		)
+		cx.Hooks.Register(
+			"genthreads", func([]pod.Change) {
+				w.SetThreads <- *cx.Config.GenThreads
+			},
+		)
 		// controller watcher thread
 		go func() {
 			Debug("starting controller watcher")
@@ -180,22 +235,10 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 				select {
 				case <-ticker.C:
 					Debug("kopach control ticker")
-					// if the last message sent was 3 seconds ago the server is almost certainly disconnected or crashed
-					// so clear FirstSender
-					since := time.Now().Sub(time.Unix(0, w.lastSent.Load()))
-					wasSending := since > time.Second*3 && w.FirstSender.Load() != ""
-					if wasSending {
-						Debug("previous current controller has stopped broadcasting", since, w.FirstSender.Load())
-						// when this string is clear other broadcasts will be listened to
-						w.FirstSender.Store("")
-						// pause the workers
-						for i := range w.clients {
-							Debug("sending pause to worker", i)
-							err := w.clients[i].Pause()
-							if err != nil {
-								Error(err)
-							}
-						}
+					// controllers that have not been heard from for 3 seconds are almost certainly disconnected or
+					// crashed, if it was the elected one the workers move to the next best or are paused
+					if w.election.Expire(time.Now()) {
+						w.follow()
 					}
 					w.hashrate = w.HashReport()
 					if interrupt.Requested() {
@@ -206,17 +249,19 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 					Debug("received signal on StartChan")
 					*cx.Config.Generate = true
 					save.Pod(cx.Config)
-					w.Start()
+					if !w.active.Load() {
+						w.Start()
+					}
 				case <-w.StopChan.Wait():
 					Debug("received signal on StopChan")
 					*cx.Config.Generate = false
 					save.Pod(cx.Config)
-					w.Stop()
+					w.stopWorkers()
 				case s := <-w.PassChan:
 					Debug("received signal on PassChan", s)
 					*cx.Config.MinerPass = s
 					save.Pod(cx.Config)
-					w.Stop()
+					w.stopWorkers()
 					w.Start()
 				case n := <-w.SetThreads:
 					Debug("received signal on SetThreads", n)
@@ -230,7 +275,7 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 						if n > int(maxThreads) {
 							n = int(maxThreads)
 						}
-						w.Stop()
+						w.stopWorkers()
 						w.Start()
 					}
 				case <-w.quit.Wait():
@@ -284,54 +329,60 @@ var handlers = transport.Handlers{
 	) (err error) {
 		Debug("received job")
 		w := ctx.(*Worker)
-		if !w.active.Load() {
-			Debug("not active")
-			return
-		}
-		
 		// Debugs(b)
 		var jr job.Job
 		gotiny.Unmarshal(b, &jr)
 		// Debugs(jr)
-		
-		ips := jr.IPs
-		w.height = jr.Height
-		cP := jr.ControllerPort
-		addr := net.JoinHostPort(ips[0].String(), fmt.Sprint(cP))
-		firstSender := w.FirstSender.Load()
-		otherSent := firstSender != addr && firstSender != ""
-		if otherSent {
-			Trace("ignoring other controller job")
-			// ignore other controllers while one is active and received first
+		// jobs are tracked while not active so mining can start on the elected controller straight away
+		mine, changed := w.election.Job(&jr, time.Now())
+		if !w.active.Load() {
+			Debug("not active")
+			return
+		}
+		if changed {
+			// following the new leader starts the workers on its last job, which may be this one
+			w.follow()
+			return
+		}
+		if !mine {
+			Trace("ignoring job from controller that is not elected or is behind")
 			return
 		}
-		Debug("now listening to controller at", addr)
-		w.FirstSender.Store(addr)
-		w.lastSent.Store(time.Now().UnixNano())
+		w.height = jr.Height
 		for i := range w.clients {
 			if err = w.clients[i].NewJob(&jr); Check(err) {
 			}
 		}
 		return
 	},
+	string(p2padvt.Magic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
+		w := ctx.(*Worker)
+		var advt p2padvt.Advertisment
+		gotiny.Unmarshal(b, &advt)
+		if w.election.Advertised(&advt, time.Now()) {
+			w.follow()
+		}
+		return
+	},
 	string(pause.Magic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
 		w := ctx.(*Worker)
 		var advt p2padvt.Advertisment
 		gotiny.Unmarshal(b, &advt)
-		// p := pause.LoadPauseContainer(b)
-		fs := w.FirstSender.Load()
-		ni := advt.IPs
-		// ni := p.GetIPs()[0].String()
-		np := advt.Controller
-		// np := p.GetControllerListenerPort()
-		ns := net.JoinHostPort(strings.Split(ni[0].String(), ":")[0], fmt.Sprint(np))
-		Debug("received pause from server at", ns)
-		if fs == ns {
+		addr := advt.Address()
+		Debug("received pause from server at", addr)
+		if !advt.Mining {
+			// a controller that is shutting down says it is no longer mining so the workers switch to another straight
+			// away
+			if w.election.Advertised(&advt, time.Now()) {
+				w.follow()
+			}
+			return
+		}
+		// otherwise a block was found and the elected controller will send a new job shortly
+		if addr == w.FirstSender.Load() {
 			for i := range w.clients {
-				Debug("sending pause to worker", i, fs, ns)
-				err := w.clients[i].Pause()
-				if err != nil {
-					Error(err)
+				Debug("sending pause to worker", i, addr)
+				if err = w.clients[i].Pause(); Check(err) {
 				}
 			}
 		}
@@ -389,6 +440,30 @@ var handlers = transport.Handlers{
 	// },
 }
 
+// follow switches the workers to the elected controller, starting them on its last job straight away so there is no
+// gap in mining, or pauses them when there is no controller fit to mine for
+func (w *Worker) follow() {
+	leader, j := w.election.Leader(time.Now())
+	w.FirstSender.Store(leader)
+	if leader == "" {
+		Info("no controller to mine for, pausing workers")
+		for i := range w.clients {
+			if err := w.clients[i].Pause(); Check(err) {
+			}
+		}
+		return
+	}
+	Info("now mining for controller at", leader)
+	if j == nil {
+		return
+	}
+	w.height = j.Height
+	for i := range w.clients {
+		if err := w.clients[i].NewJob(j); Check(err) {
+		}
+	}
+}
+
 func (w *Worker) HashReport() float64 {
 	Debug("generating hash report")
 	w.hashSampleBuf.Add(w.hashCount.Load())
@@ -12,6 +12,7 @@ import (
 	qu "github.com/p9c/pod/pkg/util/quit"
 	
 	"github.com/p9c/pod/cmd/kopach/control/hashrate"
+	"github.com/p9c/pod/cmd/kopach/control/share"
 	"github.com/p9c/pod/cmd/kopach/control/sol"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/fork"
@@ -236,7 +237,7 @@ out:
 						hashReport := hashrate.Get(w.roller.RoundsPerAlgo.Load(), nextAlgo, nH, w.id)
 						err := w.dispatchConn.SendMany(
 							hashrate.Magic,
-							transport.GetShards(hashReport),
+							w.dispatchConn.GetShards(hashReport),
 						)
 						if err != nil {
 							Error(err)
@@ -247,10 +248,10 @@ out:
 					if bigHash.Cmp(fork.CompactToBig(mb.Header.Bits)) <= 0 {
 						Debug("found solution", nH)
 						// srs := sol.GetSolContainer(w.senderPort.Load(), mb)
-						srs := sol.Get(int32(w.senderPort.Load()), mb)
+						srs := sol.Get(int32(w.senderPort.Load()), w.id, mb)
 						err := w.dispatchConn.SendMany(
 							sol.Magic,
-							transport.GetShards(srs),
+							w.dispatchConn.GetShards(srs),
 						)
 						if err != nil {
 							Error(err)
@@ -258,6 +259,16 @@ out:
 						Debug("sent solution")
 						break running
 					}
+					if bigHash.Cmp(share.Target(mb.Header.Bits)) <= 0 {
+						shr := share.Get(int32(w.senderPort.Load()), w.id, nH, &mb.Header)
+						err := w.dispatchConn.SendMany(
+							share.Magic,
+							w.dispatchConn.GetShards(shr),
+						)
+						if err != nil {
+							Error(err)
+						}
+					}
 					mb.Header.Version = nextAlgo
 					mb.Header.Bits = w.bitses.Load().(blockchain.TargetBits)[mb.Header.Version]
 					mb.Header.Nonce++
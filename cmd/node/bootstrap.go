@@ -0,0 +1,105 @@
+package node
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/cmd/node/bootstrap"
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/rpc/chainrpc"
+	"github.com/p9c/pod/pkg/util/interrupt"
+	qu "github.com/p9c/pod/pkg/util/quit"
+)
+
+// ImportBlocks adds the blocks in a bootstrap file to the block database. An interrupted import saves its progress and
+// is resumed the next time the same file is imported.
+func ImportBlocks(cx *conte.Xt, filename string) (err error) {
+	var db database.DB
+	var chain *blockchain.BlockChain
+	quit := qu.T()
+	if db, chain, err = openChain(cx, quit); err != nil {
+		return
+	}
+	defer func() {
+		if err := db.Close(); Check(err) {
+		}
+	}()
+	Info("importing blocks from", filename)
+	im := &bootstrap.Importer{Chain: chain, Net: cx.ActiveNet.Net, FastAdd: !*cx.Config.DisableCheckpoints}
+	var res bootstrap.Result
+	if res, err = im.Import(filename, quit); err != nil {
+		return
+	}
+	if res.Interrupted {
+		Warn("import interrupted, run importblocks again with the same file to resume")
+		return
+	}
+	Infof(
+		"import finished: read %d blocks, imported %d, %d were already in the chain, height is now %d",
+		res.Read, res.Imported, res.Skipped, res.Height,
+	)
+	return
+}
+
+// ExportBlocks writes the blocks of the best chain from start to end to a bootstrap file, up to the tip if end is zero
+func ExportBlocks(cx *conte.Xt, filename string, start, end int32) (err error) {
+	var db database.DB
+	var chain *blockchain.BlockChain
+	quit := qu.T()
+	if db, chain, err = openChain(cx, quit); err != nil {
+		return
+	}
+	defer func() {
+		if err := db.Close(); Check(err) {
+		}
+	}()
+	var f *os.File
+	if f, err = os.Create(filename); err != nil {
+		return
+	}
+	defer func() {
+		if err := f.Close(); Check(err) {
+		}
+	}()
+	Info("exporting blocks to", filename)
+	w := bufio.NewWriter(f)
+	if _, err = bootstrap.Export(chain, w, cx.ActiveNet.Net, start, end, quit); err != nil {
+		return
+	}
+	return w.Flush()
+}
+
+// openChain loads the block database and the chain without the optional indexes, which catch up with the blocks added
+// by an import the next time the node starts. The quit channel is closed when an interrupt is received.
+func openChain(cx *conte.Xt, quit qu.C) (db database.DB, chain *blockchain.BlockChain, err error) {
+	if db, err = loadBlockDB(cx); err != nil {
+		return
+	}
+	interrupt.AddHandler(func() { quit.Q() })
+	var checkpoints []chaincfg.Checkpoint
+	if !*cx.Config.DisableCheckpoints {
+		checkpoints = chainrpc.MergeCheckpoints(cx.ActiveNet.Checkpoints, cx.StateCfg.AddedCheckpoints)
+	}
+	if chain, err = blockchain.New(
+		&blockchain.Config{
+			DB:          db,
+			Interrupt:   quit,
+			ChainParams: cx.ActiveNet,
+			Checkpoints: checkpoints,
+			TimeSource:  blockchain.NewMedianTime(),
+			SigCache:    txscript.NewSigCache(uint(*cx.Config.SigCacheMaxSize)),
+			HashCache:   txscript.NewHashCache(uint(*cx.Config.SigCacheMaxSize)),
+		},
+	); err != nil {
+		if err := db.Close(); Check(err) {
+		}
+		return nil, nil, err
+	}
+	chain.DifficultyAdjustments = make(map[string]float64)
+	chain.DifficultyBits.Store(make(blockchain.TargetBits))
+	return
+}
@@ -0,0 +1,138 @@
+// Package bootstrap reads and writes bootstrap files, which hold the blocks of a chain in order as a sequence of
+// records, each one being the network magic and the length of the block as little endian uint32s followed by the
+// serialized block, the same as the bootstrap.dat files of bitcoind and btcd.
+package bootstrap
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// RecordHeaderSize is the size of the network magic and length that precede each block in a bootstrap file
+const RecordHeaderSize = 8
+
+// Writer writes blocks to a bootstrap file
+type Writer struct {
+	w   io.Writer
+	net wire.BitcoinNet
+	buf [RecordHeaderSize]byte
+}
+
+// NewWriter returns a Writer that writes records for the given network to w
+func NewWriter(w io.Writer, net wire.BitcoinNet) *Writer {
+	return &Writer{w: w, net: net}
+}
+
+// WriteBlock writes a serialized block as one record
+func (w *Writer) WriteBlock(raw []byte) (err error) {
+	binary.LittleEndian.PutUint32(w.buf[0:4], uint32(w.net))
+	binary.LittleEndian.PutUint32(w.buf[4:8], uint32(len(raw)))
+	if _, err = w.w.Write(w.buf[:]); err != nil {
+		return
+	}
+	_, err = w.w.Write(raw)
+	return
+}
+
+// Reader reads blocks from a bootstrap file
+type Reader struct {
+	r      io.Reader
+	net    wire.BitcoinNet
+	offset int64
+	buf    [RecordHeaderSize]byte
+}
+
+// NewReader returns a Reader that reads records for the given network from r, which is positioned at offset
+func NewReader(r io.Reader, net wire.BitcoinNet, offset int64) *Reader {
+	return &Reader{r: r, net: net, offset: offset}
+}
+
+// Offset returns the position of the next record in the file
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// ReadBlock reads the next serialized block. It returns io.EOF when the file ends cleanly between records and
+// io.ErrUnexpectedEOF if it ends part of the way through one.
+func (r *Reader) ReadBlock() (raw []byte, err error) {
+	if _, err = io.ReadFull(r.r, r.buf[:]); err != nil {
+		return
+	}
+	net := wire.BitcoinNet(binary.LittleEndian.Uint32(r.buf[0:4]))
+	if net != r.net {
+		return nil, fmt.Errorf("record at offset %d is for network %v, not %v", r.offset, net, r.net)
+	}
+	size := binary.LittleEndian.Uint32(r.buf[4:8])
+	if size < wire.MaxBlockHeaderPayload || size > wire.MaxBlockPayload {
+		return nil, fmt.Errorf("record at offset %d has invalid block size %d", r.offset, size)
+	}
+	raw = make([]byte, size)
+	if _, err = io.ReadFull(r.r, raw); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	r.offset += RecordHeaderSize + int64(size)
+	return
+}
+
+// Progress is how far an import has got through a bootstrap file, saved alongside it so an interrupted import can
+// resume where it stopped instead of reading the file again from the start
+type Progress struct {
+	// Offset is the position in the file after the last block that was processed
+	Offset int64 `json:"offset"`
+	// Blocks is the number of blocks read from the file up to the offset
+	Blocks int64 `json:"blocks"`
+	// Hash is the hash of the last block that was processed, which must be in the chain for the offset to be used
+	Hash string `json:"hash"`
+}
+
+// ProgressFile returns the name of the file the progress of importing a bootstrap file is saved in
+func ProgressFile(filename string) string {
+	return filename + ".progress"
+}
+
+// LoadProgress reads the saved progress of importing a bootstrap file, returning nil if there is none
+func LoadProgress(filename string) (p *Progress, err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(ProgressFile(filename)); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	p = &Progress{}
+	if err = json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("%s: %v", ProgressFile(filename), err)
+	}
+	return
+}
+
+// Save writes the progress of importing a bootstrap file
+func (p *Progress) Save(filename string) (err error) {
+	var b []byte
+	if b, err = json.Marshal(p); err != nil {
+		return
+	}
+	// write to a temporary file first so an interruption while saving doesn't leave a truncated one
+	tmp := ProgressFile(filename) + ".tmp"
+	if err = ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return
+	}
+	return os.Rename(tmp, ProgressFile(filename))
+}
+
+// RemoveProgress deletes the saved progress of importing a bootstrap file once it has been imported completely
+func RemoveProgress(filename string) (err error) {
+	if err = os.Remove(ProgressFile(filename)); os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,102 @@
+package bootstrap
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+func TestReadWrite(t *testing.T) {
+	blocks := [][]byte{
+		bytes.Repeat([]byte{1}, wire.MaxBlockHeaderPayload),
+		bytes.Repeat([]byte{2}, 1000),
+		bytes.Repeat([]byte{3}, 200),
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf, wire.TestNet3)
+	for _, b := range blocks {
+		if err := w.WriteBlock(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file := buf.Bytes()
+	r := NewReader(bytes.NewReader(file), wire.TestNet3, 0)
+	var offset int64
+	for i, want := range blocks {
+		got, err := r.ReadBlock()
+		if err != nil {
+			t.Fatalf("block %d: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("block %d: read %d bytes that differ from the %d written", i, len(got), len(want))
+		}
+		offset += RecordHeaderSize + int64(len(want))
+		if r.Offset() != offset {
+			t.Fatalf("block %d: offset %d, expected %d", i, r.Offset(), offset)
+		}
+	}
+	if _, err := r.ReadBlock(); err != io.EOF {
+		t.Fatalf("expected io.EOF at the end of the file, got %v", err)
+	}
+	// resuming part of the way through
+	second := int64(RecordHeaderSize + len(blocks[0]))
+	r = NewReader(bytes.NewReader(file[second:]), wire.TestNet3, second)
+	if got, err := r.ReadBlock(); err != nil || !bytes.Equal(got, blocks[1]) {
+		t.Fatalf("resumed read did not return the second block: %v", err)
+	}
+	// the wrong network
+	r = NewReader(bytes.NewReader(file), wire.MainNet, 0)
+	if _, err := r.ReadBlock(); err == nil {
+		t.Fatal("read a block for the wrong network")
+	}
+	// truncated part of the way through a block
+	r = NewReader(bytes.NewReader(file[:second-10]), wire.TestNet3, 0)
+	if _, err := r.ReadBlock(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected io.ErrUnexpectedEOF for a truncated block, got %v", err)
+	}
+	// invalid sizes
+	for _, size := range []int{wire.MaxBlockHeaderPayload - 1, wire.MaxBlockPayload + 1} {
+		buf.Reset()
+		if err := NewWriter(&buf, wire.TestNet3).WriteBlock(make([]byte, size)); err != nil {
+			t.Fatal(err)
+		}
+		r = NewReader(&buf, wire.TestNet3, 0)
+		if _, err := r.ReadBlock(); err == nil {
+			t.Fatalf("read a block of invalid size %d", size)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "bootstrap.dat")
+	p, err := LoadProgress(filename)
+	if err != nil || p != nil {
+		t.Fatalf("expected no progress for a new file, got %v %v", p, err)
+	}
+	want := Progress{Offset: 12345, Blocks: 67, Hash: "00000000000000000000000000000000000000000000000000000000000000ff"}
+	if err = want.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	if p, err = LoadProgress(filename); err != nil || p == nil || *p != want {
+		t.Fatalf("loaded progress %v %v, expected %v", p, err, want)
+	}
+	if err = RemoveProgress(filename); err != nil {
+		t.Fatal(err)
+	}
+	if p, err = LoadProgress(filename); err != nil || p != nil {
+		t.Fatalf("expected no progress after removing it, got %v %v", p, err)
+	}
+	if err = RemoveProgress(filename); err != nil {
+		t.Fatalf("removing progress that doesn't exist: %v", err)
+	}
+}
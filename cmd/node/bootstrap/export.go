@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/wire"
+	qu "github.com/p9c/pod/pkg/util/quit"
+)
+
+// Export writes the blocks of the best chain from start to end inclusive to w as a bootstrap file, up to the tip if
+// end is zero. It returns the number of blocks written, which is less than requested if quit is closed.
+func Export(chain *blockchain.BlockChain, w io.Writer, net wire.BitcoinNet, start, end int32, quit qu.C) (
+	n int64, err error,
+) {
+	best := chain.BestSnapshot().Height
+	if end == 0 {
+		end = best
+	}
+	if start < 0 || end < start || end > best {
+		return 0, fmt.Errorf("can't export blocks %d to %d, the chain is at height %d", start, end, best)
+	}
+	bw := NewWriter(w, net)
+	lastReport := time.Now()
+	for height := start; height <= end; height++ {
+		select {
+		case <-quit.Wait():
+			Warnf("export interrupted after block %d", height-1)
+			return
+		default:
+		}
+		block, err := chain.BlockByHeight(height)
+		if err != nil {
+			return n, err
+		}
+		var raw []byte
+		if raw, err = block.Bytes(); err != nil {
+			return n, err
+		}
+		if err = bw.WriteBlock(raw); err != nil {
+			return n, err
+		}
+		n++
+		if time.Since(lastReport) >= DefaultProgressInterval {
+			lastReport = time.Now()
+			Infof("exported %d blocks, height %d of %d", n, height, end)
+		}
+	}
+	Infof("exported %d blocks, heights %d to %d", n, start, end)
+	return
+}
@@ -0,0 +1,190 @@
+package bootstrap
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+	qu "github.com/p9c/pod/pkg/util/quit"
+)
+
+// DefaultProgressInterval is how often an import or export logs its progress, and an import saves it
+const DefaultProgressInterval = 10 * time.Second
+
+// Importer adds the blocks in a bootstrap file to a chain
+type Importer struct {
+	Chain *blockchain.BlockChain
+	Net   wire.BitcoinNet
+	// FastAdd skips the expensive transaction checks on blocks up to the latest checkpoint
+	FastAdd bool
+	// ProgressInterval is how often progress is logged and saved, DefaultProgressInterval if zero
+	ProgressInterval time.Duration
+}
+
+// Result is the outcome of an import
+type Result struct {
+	// Read is the number of blocks read from the file, including those read before resuming
+	Read int64
+	// Imported is the number of blocks added to the chain
+	Imported int64
+	// Skipped is the number of blocks that were already in the chain
+	Skipped int64
+	// Height is the height of the best chain at the end of the import
+	Height int32
+	// Interrupted is true if the import stopped before the end of the file, and can be resumed
+	Interrupted bool
+}
+
+// Import processes the blocks in a bootstrap file in order. Blocks already in the chain are skipped, and if a previous
+// import of the file was interrupted it carries on from where it stopped. Progress is saved periodically and when quit
+// is closed, and removed once the whole file has been imported.
+func (im *Importer) Import(filename string, quit qu.C) (res Result, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer func() {
+		if err := f.Close(); Check(err) {
+		}
+	}()
+	var fi os.FileInfo
+	if fi, err = f.Stat(); err != nil {
+		return
+	}
+	var offset int64
+	var last chainhash.Hash
+	var p *Progress
+	if p, err = LoadProgress(filename); err != nil {
+		return
+	}
+	if p != nil {
+		var hash *chainhash.Hash
+		var have bool
+		if hash, err = chainhash.NewHashFromStr(p.Hash); err == nil {
+			have, err = im.Chain.HaveBlock(hash)
+		}
+		if err != nil || !have || p.Offset > fi.Size() {
+			Warn("saved progress of importing", filename, "does not match the chain, starting from the beginning")
+			err = nil
+		} else {
+			if _, err = f.Seek(p.Offset, io.SeekStart); err != nil {
+				return
+			}
+			offset, res.Read, last = p.Offset, p.Blocks, *hash
+			Infof("resuming import of %s after block %d at offset %d", filename, p.Blocks, p.Offset)
+		}
+	}
+	interval := im.ProgressInterval
+	if interval == 0 {
+		interval = DefaultProgressInterval
+	}
+	fastHeight := int32(-1)
+	if cp := im.Chain.LatestCheckpoint(); im.FastAdd && cp != nil {
+		fastHeight = cp.Height
+	}
+	r := NewReader(bufio.NewReaderSize(f, wire.MaxBlockPayload), im.Net, offset)
+	// pos is the offset after the last block that was handled, which is where a resumed import carries on from
+	pos, blocks := offset, res.Read
+	save := func() {
+		if pos == offset {
+			return
+		}
+		p := &Progress{Offset: pos, Blocks: blocks, Hash: last.String()}
+		if err := p.Save(filename); Check(err) {
+		}
+	}
+	// an import that stops early can be resumed from the last block that was handled
+	defer func() {
+		if err != nil || res.Interrupted {
+			save()
+		}
+	}()
+	start, lastReport := time.Now(), time.Now()
+	report := func() {
+		res.Height = im.Chain.BestSnapshot().Height
+		rate := float64(res.Imported) / time.Since(start).Seconds()
+		var done float64
+		if fi.Size() > 0 {
+			done = float64(pos) * 100 / float64(fi.Size())
+		}
+		Infof(
+			"imported %d blocks (%d already in the chain), height %d, %.1f blocks/s, %.1f%% of the file",
+			res.Imported, res.Skipped, res.Height, rate, done,
+		)
+	}
+	for {
+		select {
+		case <-quit.Wait():
+			res.Interrupted = true
+			report()
+			return
+		default:
+		}
+		var raw []byte
+		if raw, err = r.ReadBlock(); err != nil {
+			if err == io.EOF {
+				err = nil
+				break
+			}
+			return
+		}
+		res.Read++
+		var header wire.BlockHeader
+		if err = header.Deserialize(bytes.NewReader(raw)); err != nil {
+			return
+		}
+		hash := header.BlockHash()
+		var have bool
+		if have, err = im.Chain.HaveBlock(&hash); err != nil {
+			return
+		}
+		if have {
+			res.Skipped++
+			last, pos, blocks = hash, r.Offset(), res.Read
+			continue
+		}
+		if have, err = im.Chain.HaveBlock(&header.PrevBlock); err != nil {
+			return
+		}
+		if !have {
+			return res, fmt.Errorf(
+				"block %d (%v) at offset %d does not connect to the chain, the previous block %v is unknown",
+				res.Read, hash, pos, header.PrevBlock,
+			)
+		}
+		var block *util.Block
+		if block, err = util.NewBlockFromBytes(raw); err != nil {
+			return
+		}
+		height := im.Chain.BestSnapshot().Height + 1
+		flags := blockchain.BFNone
+		if height <= fastHeight {
+			flags |= blockchain.BFFastAdd
+		}
+		var isOrphan bool
+		if _, isOrphan, err = im.Chain.ProcessBlock(0, block, flags, height); err != nil {
+			return res, fmt.Errorf("processing block %d (%v): %v", res.Read, hash, err)
+		}
+		if isOrphan {
+			return res, fmt.Errorf("block %d (%v) was processed as an orphan", res.Read, hash)
+		}
+		res.Imported++
+		last, pos, blocks = hash, r.Offset(), res.Read
+		if time.Since(lastReport) >= interval {
+			lastReport = time.Now()
+			save()
+			report()
+		}
+	}
+	report()
+	if err = RemoveProgress(filename); Check(err) {
+	}
+	return
+}
@@ -22,6 +22,9 @@ COMMANDS:
      dropaddrindex  drop the address search index
      droptxindex    drop the address search index
      dropcfindex    drop the address search index
+     dropspendindex drop the spent output index
+     importblocks   import the blocks in a bootstrap file, resuming an interrupted import of the same file
+     exportblocks   export the blocks of the best chain to a bootstrap file
 
 GLOBAL OPTIONS:
    --help, -h  show help
@@ -0,0 +1,98 @@
+package node
+
+import (
+	"errors"
+
+	"github.com/urfave/cli"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/pod"
+	"github.com/p9c/pod/pkg/rpc/chainrpc"
+	"github.com/p9c/pod/pkg/util/interrupt"
+)
+
+// registerHooks lets the node take up the settings changed in the configuration file while it is running.
+func registerHooks(cx *conte.Xt, server *chainrpc.Node) {
+	cx.Hooks.Register(
+		"addpeer", func(changes []pod.Change) {
+			for _, c := range changes {
+				old, _ := c.Old.(cli.StringSlice)
+				peers, _ := c.New.(cli.StringSlice)
+				added, removed := comparePeers(cx, old, peers)
+				for _, addr := range added {
+					Info("connecting to added peer", addr)
+					if err := queryNode(server, func(reply chan error) interface{} {
+						return chainrpc.ConnectNodeMsg{Addr: addr, Permanent: true, Reply: reply}
+					}); Check(err) {
+					}
+				}
+				for _, addr := range removed {
+					Info("removing peer", addr)
+					if err := queryNode(server, func(reply chan error) interface{} {
+						return chainrpc.RemoveNodeMsg{
+							Cmp:   func(sp *chainrpc.NodePeer) bool { return sp.Addr() == addr },
+							Reply: reply,
+						}
+					}); Check(err) {
+					}
+				}
+			}
+		},
+	)
+	cx.Hooks.Register(
+		"node", func([]pod.Change) {
+			if *cx.Config.NodeOff {
+				Info("the node was turned off, shutting down")
+				cx.NodeKill.Q()
+			}
+		},
+	)
+	// the optional indexes are set up when the node starts, so it restarts to build an index that was turned on, and an
+	// index that was turned off is dropped once the node has stopped, as it would otherwise be left out of date
+	indexHook := func(drop *bool) func(changes []pod.Change) {
+		return func(changes []pod.Change) {
+			for _, c := range changes {
+				if on, _ := c.New.(bool); !on {
+					*drop = true
+				}
+			}
+			Warn("restarting the node for changed indexes")
+			interrupt.RequestRestart()
+		}
+	}
+	cx.Hooks.Register("dropaddrindex", indexHook(&cx.StateCfg.DropAddrIndex))
+	cx.Hooks.Register("droptxindex", indexHook(&cx.StateCfg.DropTxIndex))
+	cx.Hooks.Register("dropspendindex", indexHook(&cx.StateCfg.DropSpendIndex))
+}
+
+// comparePeers returns the peers in a new list of peers to add that were not in the old one, and those that were taken
+// out of it.
+func comparePeers(cx *conte.Xt, old, peers []string) (added, removed []string) {
+	was := make(map[string]bool)
+	for _, addr := range old {
+		was[chainrpc.NormalizeAddress(addr, cx.ActiveNet.DefaultPort)] = true
+	}
+	for _, addr := range peers {
+		addr = chainrpc.NormalizeAddress(addr, cx.ActiveNet.DefaultPort)
+		if was[addr] {
+			delete(was, addr)
+			continue
+		}
+		added = append(added, addr)
+	}
+	for addr := range was {
+		removed = append(removed, addr)
+	}
+	return
+}
+
+// queryNode sends a message made with a reply channel to the peer handler of the node and returns its reply.
+func queryNode(server *chainrpc.Node, msg func(reply chan error) interface{}) error {
+	reply := make(chan error)
+	select {
+	case server.Query <- msg(reply):
+		return <-reply
+	case <-server.Quit.Wait():
+		return errors.New("the node is shutting down")
+	}
+}
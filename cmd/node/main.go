@@ -7,6 +7,7 @@ import (
 	// _ "net/http/pprof"
 	"os"
 	"runtime/pprof"
+	"sync"
 	
 	"github.com/p9c/pod/pkg/util/logi"
 	qu "github.com/p9c/pod/pkg/util/quit"
@@ -90,13 +91,20 @@ func Main(cx *conte.Xt) (err error) {
 		Error(err)
 		return
 	}
+	var closeOnce sync.Once
 	closeDb := func() {
-		// ensure the database is synced and closed on shutdown
-		Trace("gracefully shutting down the database")
-		func() {
-			if err := db.Close(); Check(err) {
-			}
-		}()
+		closeOnce.Do(
+			func() {
+				// indexes turned off while running are dropped once the server has stopped, before the database is
+				// closed
+				if err := dropIndexes(cx, db, qu.T()); Check(err) {
+				}
+				// ensure the database is synced and closed on shutdown
+				Trace("gracefully shutting down the database")
+				if err := db.Close(); Check(err) {
+				}
+			},
+		)
 	}
 	defer closeDb()
 	interrupt.AddHandler(closeDb)
@@ -104,25 +112,9 @@ func Main(cx *conte.Xt) (err error) {
 	if interrupt.Requested() {
 		return nil
 	}
-	// drop indexes and exit if requested. NOTE: The order is important here because dropping the tx index also drops
-	// the address index since it relies on it
-	if cx.StateCfg.DropAddrIndex {
-		Warn("dropping address index")
-		if err = indexers.DropAddrIndex(db, interrupt.ShutdownRequestChan); Check(err) {
-			return
-		}
-	}
-	if cx.StateCfg.DropTxIndex {
-		Warn("dropping transaction index")
-		if err = indexers.DropTxIndex(db, interrupt.ShutdownRequestChan); Check(err) {
-			return
-		}
-	}
-	if cx.StateCfg.DropCfIndex {
-		Warn("dropping cfilter index")
-		if err = indexers.DropCfIndex(db, interrupt.ShutdownRequestChan); Check(err) {
-			return
-		}
+	// drop indexes if requested
+	if err = dropIndexes(cx, db, interrupt.ShutdownRequestChan); Check(err) {
+		return
 	}
 	// return now if an interrupt signal was triggered
 	if interrupt.Requested() {
@@ -136,6 +128,7 @@ func Main(cx *conte.Xt) (err error) {
 	}
 	server.Start()
 	cx.RealNode = server
+	registerHooks(cx, server)
 	if len(server.RPCServers) > 0 && *cx.Config.CAPI {
 		Debug("starting cAPI.....")
 		// chainrpc.RunAPI(server.RPCServers[0], cx.NodeKill)
@@ -215,6 +208,41 @@ func Main(cx *conte.Xt) (err error) {
 	return nil
 }
 
+// dropIndexes drops the indexes the state configuration asks to, and clears the requests so they are not dropped
+// again. NOTE: The order is important here because dropping the tx index also drops the address index since it relies
+// on it
+func dropIndexes(cx *conte.Xt, db database.DB, interruptChan qu.C) (err error) {
+	if cx.StateCfg.DropAddrIndex {
+		Warn("dropping address index")
+		if err = indexers.DropAddrIndex(db, interruptChan); Check(err) {
+			return
+		}
+		cx.StateCfg.DropAddrIndex = false
+	}
+	if cx.StateCfg.DropTxIndex {
+		Warn("dropping transaction index")
+		if err = indexers.DropTxIndex(db, interruptChan); Check(err) {
+			return
+		}
+		cx.StateCfg.DropTxIndex = false
+	}
+	if cx.StateCfg.DropCfIndex {
+		Warn("dropping cfilter index")
+		if err = indexers.DropCfIndex(db, interruptChan); Check(err) {
+			return
+		}
+		cx.StateCfg.DropCfIndex = false
+	}
+	if cx.StateCfg.DropSpendIndex {
+		Warn("dropping spent output index")
+		if err = indexers.DropSpendIndex(db, interruptChan); Check(err) {
+			return
+		}
+		cx.StateCfg.DropSpendIndex = false
+	}
+	return
+}
+
 // loadBlockDB loads (or creates when needed) the block database taking into account the selected database backend and
 // returns a handle to it. It also additional logic such warning the user if there are multiple databases which consume
 // space on the file system and ensuring the regression test database is clean when in regression test mode.
@@ -0,0 +1,204 @@
+package mempool
+
+import (
+	"fmt"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// PackageStats summarises a transaction together with either all of its unconfirmed ancestors or all of its
+// descendants in the memory pool. As in the reference implementation, the totals include the transaction itself, so a
+// transaction without any relatives in the pool has a count of one. Sizes are virtual sizes.
+type PackageStats struct {
+	Count int64
+	Size  int64
+	Fees  int64
+}
+
+// Ancestors returns the descriptors of all the transactions in the main pool that the transaction with the given hash
+// spends outputs of, directly or indirectly. The transaction itself is not included. This function is safe for
+// concurrent access.
+func (mp *TxPool) Ancestors(hash *chainhash.Hash) ([]*TxDesc, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	txD, exists := mp.pool[*hash]
+	if !exists {
+		return nil, fmt.Errorf("transaction is not in the pool")
+	}
+	return descSlice(mp.ancestors(txD)), nil
+}
+
+// Descendants returns the descriptors of all the transactions in the main pool that spend outputs of the transaction
+// with the given hash, directly or indirectly. The transaction itself is not included. This function is safe for
+// concurrent access.
+func (mp *TxPool) Descendants(hash *chainhash.Hash) ([]*TxDesc, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	txD, exists := mp.pool[*hash]
+	if !exists {
+		return nil, fmt.Errorf("transaction is not in the pool")
+	}
+	return descSlice(mp.descendants(txD)), nil
+}
+
+// PackageStats returns the ancestor and descendant package statistics for the transaction with the given hash. This
+// function is safe for concurrent access.
+func (mp *TxPool) PackageStats(hash *chainhash.Hash) (ancestors, descendants PackageStats, err error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	txD, exists := mp.pool[*hash]
+	if !exists {
+		err = fmt.Errorf("transaction is not in the pool")
+		return
+	}
+	ancestors = packageStats(txD, mp.ancestors(txD))
+	descendants = packageStats(txD, mp.descendants(txD))
+	return
+}
+
+// MempoolEntry returns the transaction with the given hash from the main pool as a fully populated json result,
+// including its ancestor and descendant package statistics. This function is safe for concurrent access.
+func (mp *TxPool) MempoolEntry(hash *chainhash.Hash) (*btcjson.GetMempoolEntryResult, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	txD, exists := mp.pool[*hash]
+	if !exists {
+		return nil, fmt.Errorf("transaction is not in the pool")
+	}
+	return mp.mempoolEntry(txD), nil
+}
+
+// mempoolEntry builds the getmempoolentry result for the given descriptor. This function MUST be called with the
+// mempool lock held (for reads).
+func (mp *TxPool) mempoolEntry(txD *TxDesc) *btcjson.GetMempoolEntryResult {
+	tx := txD.Tx
+	// Use zero for the current priority if one or more of the input transactions can't be found for some reason.
+	var currentPriority float64
+	if utxos, err := mp.fetchInputUtxos(tx); err == nil {
+		currentPriority = mining.CalcPriority(tx.MsgTx(), utxos, mp.cfg.BestHeight()+1)
+	}
+	ancestors := packageStats(txD, mp.ancestors(txD))
+	descendants := packageStats(txD, mp.descendants(txD))
+	entry := &btcjson.GetMempoolEntryResult{
+		Size:             int32(tx.MsgTx().SerializeSize()),
+		VSize:            int32(GetTxVirtualSize(tx)),
+		Fee:              util.Amount(txD.Fee).ToDUO(),
+		ModifiedFee:      util.Amount(txD.Fee).ToDUO(),
+		Time:             txD.Added.Unix(),
+		Height:           int64(txD.Height),
+		StartingPriority: txD.StartingPriority,
+		CurrentPriority:  currentPriority,
+		DescendantCount:  descendants.Count,
+		DescendantSize:   descendants.Size,
+		DescendantFees:   util.Amount(descendants.Fees).ToDUO(),
+		AncestorCount:    ancestors.Count,
+		AncestorSize:     ancestors.Size,
+		AncestorFees:     util.Amount(ancestors.Fees).ToDUO(),
+		Depends:          make([]string, 0),
+		SpentBy:          make([]string, 0),
+	}
+	for _, parent := range mp.parents(txD) {
+		entry.Depends = append(entry.Depends, parent.Tx.Hash().String())
+	}
+	for _, child := range mp.children(txD) {
+		entry.SpentBy = append(entry.SpentBy, child.Tx.Hash().String())
+	}
+	return entry
+}
+
+// parents returns the transactions in the main pool that the given transaction directly spends outputs of. This
+// function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) parents(txD *TxDesc) []*TxDesc {
+	var parents []*TxDesc
+	seen := make(map[chainhash.Hash]struct{})
+	for _, txIn := range txD.Tx.MsgTx().TxIn {
+		prevHash := txIn.PreviousOutPoint.Hash
+		if _, ok := seen[prevHash]; ok {
+			continue
+		}
+		seen[prevHash] = struct{}{}
+		if parent, exists := mp.pool[prevHash]; exists {
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// children returns the transactions in the main pool that directly spend outputs of the given transaction. This
+// function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) children(txD *TxDesc) []*TxDesc {
+	var children []*TxDesc
+	seen := make(map[chainhash.Hash]struct{})
+	txHash := txD.Tx.Hash()
+	for i := range txD.Tx.MsgTx().TxOut {
+		redeemer, exists := mp.outpoints[wire.OutPoint{Hash: *txHash, Index: uint32(i)}]
+		if !exists {
+			continue
+		}
+		if _, ok := seen[*redeemer.Hash()]; ok {
+			continue
+		}
+		seen[*redeemer.Hash()] = struct{}{}
+		if child, exists := mp.pool[*redeemer.Hash()]; exists {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// ancestors returns all the in-pool ancestors of the given transaction keyed by hash, not including the transaction
+// itself. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) ancestors(txD *TxDesc) map[chainhash.Hash]*TxDesc {
+	return mp.walkPackage(txD, mp.parents)
+}
+
+// descendants returns all the in-pool descendants of the given transaction keyed by hash, not including the
+// transaction itself. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) descendants(txD *TxDesc) map[chainhash.Hash]*TxDesc {
+	return mp.walkPackage(txD, mp.children)
+}
+
+// walkPackage collects every transaction reachable from the given one by repeatedly following the relatives returned
+// by next. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) walkPackage(txD *TxDesc, next func(*TxDesc) []*TxDesc) map[chainhash.Hash]*TxDesc {
+	found := make(map[chainhash.Hash]*TxDesc)
+	queue := next(txD)
+	for len(queue) > 0 {
+		relative := queue[0]
+		queue = queue[1:]
+		if _, ok := found[*relative.Tx.Hash()]; ok {
+			continue
+		}
+		found[*relative.Tx.Hash()] = relative
+		queue = append(queue, next(relative)...)
+	}
+	return found
+}
+
+// packageStats totals the given transaction together with the passed set of relatives.
+func packageStats(txD *TxDesc, relatives map[chainhash.Hash]*TxDesc) PackageStats {
+	stats := PackageStats{
+		Count: 1,
+		Size:  GetTxVirtualSize(txD.Tx),
+		Fees:  txD.Fee,
+	}
+	for _, relative := range relatives {
+		stats.Count++
+		stats.Size += GetTxVirtualSize(relative.Tx)
+		stats.Fees += relative.Fee
+	}
+	return stats
+}
+
+// descSlice converts a set of descriptors into a slice.
+func descSlice(descs map[chainhash.Hash]*TxDesc) []*TxDesc {
+	out := make([]*TxDesc, 0, len(descs))
+	for _, desc := range descs {
+		out = append(out, desc)
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestAncestorsDescendants ensures the ancestor and descendant packages of transactions in the pool are found and
+// summarised correctly.
+func TestAncestorsDescendants(t *testing.T) {
+	t.Parallel()
+	harness, spendableOuts, err := newPoolHarness(&netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	// Create transactions with the following spending structure, where d spends an output of both b and c.
+	//
+	// 	a -> b -> d
+	// 	 \-> c -/
+	a, err := harness.CreateSignedTx([]spendableOutput{spendableOuts[0]}, 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	b, err := harness.CreateSignedTx([]spendableOutput{txOutToSpendableOut(a, 0)}, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	c, err := harness.CreateSignedTx([]spendableOutput{txOutToSpendableOut(a, 1)}, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	d, err := harness.CreateSignedTx(
+		[]spendableOutput{txOutToSpendableOut(b, 0), txOutToSpendableOut(c, 0)}, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	for _, tx := range []*util.Tx{a, b, c, d} {
+		if _, err = harness.txPool.ProcessTransaction(nil, tx, false, false, 0); err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept valid transaction: %v", err)
+		}
+		testPoolMembership(tc, tx, false, true)
+	}
+	tests := []struct {
+		name        string
+		tx          *util.Tx
+		ancestors   []*util.Tx
+		descendants []*util.Tx
+		depends     int
+		spentBy     int
+	}{
+		{name: "a", tx: a, descendants: []*util.Tx{b, c, d}, spentBy: 2},
+		{name: "b", tx: b, ancestors: []*util.Tx{a}, descendants: []*util.Tx{d}, depends: 1, spentBy: 1},
+		{name: "c", tx: c, ancestors: []*util.Tx{a}, descendants: []*util.Tx{d}, depends: 1, spentBy: 1},
+		{name: "d", tx: d, ancestors: []*util.Tx{a, b, c}, depends: 2},
+	}
+	for _, test := range tests {
+		ancestors, err := harness.txPool.Ancestors(test.tx.Hash())
+		if err != nil {
+			t.Fatalf("%s: Ancestors: unexpected error: %v", test.name, err)
+		}
+		if !sameTxns(ancestors, test.ancestors) {
+			t.Errorf("%s: Ancestors: got %d transactions, want %d", test.name, len(ancestors), len(test.ancestors))
+		}
+		descendants, err := harness.txPool.Descendants(test.tx.Hash())
+		if err != nil {
+			t.Fatalf("%s: Descendants: unexpected error: %v", test.name, err)
+		}
+		if !sameTxns(descendants, test.descendants) {
+			t.Errorf("%s: Descendants: got %d transactions, want %d", test.name, len(descendants),
+				len(test.descendants))
+		}
+		entry, err := harness.txPool.MempoolEntry(test.tx.Hash())
+		if err != nil {
+			t.Fatalf("%s: MempoolEntry: unexpected error: %v", test.name, err)
+		}
+		if entry.AncestorCount != int64(len(test.ancestors)+1) {
+			t.Errorf("%s: got ancestor count %d, want %d", test.name, entry.AncestorCount, len(test.ancestors)+1)
+		}
+		if entry.DescendantCount != int64(len(test.descendants)+1) {
+			t.Errorf("%s: got descendant count %d, want %d", test.name, entry.DescendantCount,
+				len(test.descendants)+1)
+		}
+		wantSize := GetTxVirtualSize(test.tx)
+		for _, tx := range test.ancestors {
+			wantSize += GetTxVirtualSize(tx)
+		}
+		if entry.AncestorSize != wantSize {
+			t.Errorf("%s: got ancestor size %d, want %d", test.name, entry.AncestorSize, wantSize)
+		}
+		if len(entry.Depends) != test.depends {
+			t.Errorf("%s: got %d depends, want %d", test.name, len(entry.Depends), test.depends)
+		}
+		if len(entry.SpentBy) != test.spentBy {
+			t.Errorf("%s: got %d spent by, want %d", test.name, len(entry.SpentBy), test.spentBy)
+		}
+	}
+	// Transactions that are not in the pool are reported as an error.
+	if _, err = harness.txPool.MempoolEntry(&chainhash.Hash{}); err == nil {
+		t.Errorf("MempoolEntry: expected an error for a transaction not in the pool")
+	}
+	// Once b is removed along with its redeemers, a only has c as a descendant.
+	harness.txPool.RemoveTransaction(b, true)
+	descendants, err := harness.txPool.Descendants(a.Hash())
+	if err != nil {
+		t.Fatalf("Descendants: unexpected error: %v", err)
+	}
+	if !sameTxns(descendants, []*util.Tx{c}) {
+		t.Errorf("Descendants: got %d transactions after removal, want 1", len(descendants))
+	}
+}
+
+// sameTxns returns whether the passed descriptors hold exactly the given transactions in any order.
+func sameTxns(descs []*TxDesc, txns []*util.Tx) bool {
+	if len(descs) != len(txns) {
+		return false
+	}
+	want := make(map[chainhash.Hash]struct{}, len(txns))
+	for _, tx := range txns {
+		want[*tx.Hash()] = struct{}{}
+	}
+	for _, desc := range descs {
+		if _, ok := want[*desc.Tx.Hash()]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -80,6 +80,9 @@ type Policy struct {
 	MaxSigOpCostPerTx int
 	// MinRelayTxFee defines the minimum transaction fee in DUO/kB to be considered a non-zero fee.
 	MinRelayTxFee util.Amount
+	// RejectReplacement defines whether to reject transactions that would replace transactions in the pool, even when
+	// those signal replacement as allowed by BIP 125.
+	RejectReplacement bool
 }
 
 // Tag represents an identifier to use for tagging orphan transactions. The caller may choose any scheme it desires
@@ -467,18 +470,28 @@ func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint, tx *util.Tx
 }
 
 // checkPoolDoubleSpend checks whether or not the passed transaction is attempting to spend coins already spent by other
-// transactions in the pool. Note it does not check for double spends against transactions already in the main chain.
-// This function MUST be called with the mempool lock held (for reads).
-func (mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) error {
+// transactions in the pool. Such a transaction is only allowed as a candidate replacement, when all the transactions it
+// double spends signal replacement and the policy accepts replacements, which is reported by the returned bool. Whether
+// it pays enough to replace them is checked separately by validateReplacement. Note it does not check for double spends
+// against transactions already in the main chain. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) (bool, error) {
+	var isReplacement bool
 	for _, txIn := range tx.MsgTx().TxIn {
-		if txR, exists := mp.outpoints[txIn.PreviousOutPoint]; exists {
-			str := fmt.Sprintf("output %v already spent by "+
-				"transaction %v in the memory pool",
-				txIn.PreviousOutPoint, txR.Hash())
-			return txRuleError(wire.RejectDuplicate, str)
+		txR, exists := mp.outpoints[txIn.PreviousOutPoint]
+		if !exists {
+			continue
+		}
+		if txD, inPool := mp.pool[*txR.Hash()]; inPool && !mp.cfg.Policy.RejectReplacement &&
+			mp.signalsReplacement(txD) {
+			isReplacement = true
+			continue
 		}
+		str := fmt.Sprintf("output %v already spent by "+
+			"transaction %v in the memory pool",
+			txIn.PreviousOutPoint, txR.Hash())
+		return false, txRuleError(wire.RejectDuplicate, str)
 	}
-	return nil
+	return isReplacement, nil
 }
 
 // fetchInputUtxos loads utxo details about the input transactions referenced by the passed transaction. First it loads
@@ -649,8 +662,9 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	// ultimately result in a double spend. This check is intended to be quick and therefore only detects double spends
 	// within the transaction pool itself. The transaction could still be double spending coins from the main chain at
 	// this point. There is a more in-depth check that happens later after fetching the referenced transaction inputs
-	// from the main chain which examines the actual spend data and prevents double spends.
-	err = mp.checkPoolDoubleSpend(tx)
+	// from the main chain which examines the actual spend data and prevents double spends. Transactions replacing ones
+	// that signal replacement are let through here and checked once their fee is known.
+	isReplacement, err := mp.checkPoolDoubleSpend(tx)
 	if err != nil {
 		Error(err)
 		return nil, nil, err
@@ -802,6 +816,15 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 			mp.cfg.Policy.FreeTxRelayLimit*10*1000,
 		)
 	}
+	// A replacement must pay enough more than the transactions it replaces to be worth relaying and mining instead.
+	var evicted map[chainhash.Hash]*TxDesc
+	if isReplacement {
+		evicted, err = mp.validateReplacement(tx, txFee)
+		if err != nil {
+			Error(err)
+			return nil, nil, err
+		}
+	}
 	// Verify crypto signatures for each input and reject the transaction if any don't verify.
 	err = blockchain.ValidateTransactionScripts(b, tx, utxoView,
 		txscript.StandardVerifyFlags, mp.cfg.SigCache,
@@ -813,6 +836,11 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 		}
 		return nil, nil, err
 	}
+	// Evict the replaced transactions along with their descendants now the replacement is known to be valid.
+	for hash, desc := range evicted {
+		Debug("replacing transaction", hash, "with", txHash)
+		mp.removeTransaction(desc.Tx, true)
+	}
 	// Add to transaction pool.
 	txD := mp.addTransaction(utxoView, tx, bestHeight, txFee)
 	Debugf(
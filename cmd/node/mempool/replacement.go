@@ -0,0 +1,147 @@
+package mempool
+
+import (
+	"fmt"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// MaxRBFSequence is the highest input sequence number that signals a transaction may be replaced in the memory pool
+	// by one paying a higher fee, as defined by BIP 125.
+	MaxRBFSequence = wire.MaxTxInSequenceNum - 2
+	// MaxReplacementEvictions is the most transactions a replacement may evict from the pool, counting the transactions
+	// it conflicts with and all of their descendants.
+	MaxReplacementEvictions = 100
+)
+
+// SignalsReplacement returns whether a transaction explicitly opts in to replacement by having an input with a sequence
+// number no greater than MaxRBFSequence. A transaction also becomes replaceable in the pool by spending an unconfirmed
+// transaction that signals, which this function does not know about.
+func SignalsReplacement(msgTx *wire.MsgTx) bool {
+	for _, txIn := range msgTx.TxIn {
+		if txIn.Sequence <= MaxRBFSequence {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReplaceable returns whether the transaction with the given hash in the main pool may be replaced, either because it
+// signals replacement itself or because one of its unconfirmed ancestors does. This function is safe for concurrent
+// access.
+func (mp *TxPool) IsReplaceable(hash *chainhash.Hash) (bool, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	txD, exists := mp.pool[*hash]
+	if !exists {
+		return false, fmt.Errorf("transaction is not in the pool")
+	}
+	return mp.signalsReplacement(txD), nil
+}
+
+// signalsReplacement returns whether a transaction in the pool signals replacement, directly or through any of its
+// in-pool ancestors. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) signalsReplacement(txD *TxDesc) bool {
+	if SignalsReplacement(txD.Tx.MsgTx()) {
+		return true
+	}
+	for _, ancestor := range mp.ancestors(txD) {
+		if SignalsReplacement(ancestor.Tx.MsgTx()) {
+			return true
+		}
+	}
+	return false
+}
+
+// txConflicts returns the transactions in the main pool that spend any of the outputs the passed transaction spends,
+// keyed by hash. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) txConflicts(tx *util.Tx) map[chainhash.Hash]*TxDesc {
+	conflicts := make(map[chainhash.Hash]*TxDesc)
+	for _, txIn := range tx.MsgTx().TxIn {
+		spender, exists := mp.outpoints[txIn.PreviousOutPoint]
+		if !exists {
+			continue
+		}
+		if txD, exists := mp.pool[*spender.Hash()]; exists {
+			conflicts[*spender.Hash()] = txD
+		}
+	}
+	return conflicts
+}
+
+// validateReplacement checks that a transaction paying the given fee may replace the transactions in the pool it
+// conflicts with under the rules of BIP 125, and returns every transaction that it would evict: the conflicts along
+// with all of their descendants. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) validateReplacement(tx *util.Tx, txFee int64) (map[chainhash.Hash]*TxDesc, error) {
+	txHash := tx.Hash()
+	conflicts := mp.txConflicts(tx)
+	// Every transaction being replaced must have opted in, either directly or by spending one that has.
+	evicted := make(map[chainhash.Hash]*TxDesc)
+	conflictParents := make(map[chainhash.Hash]struct{})
+	txSize := GetTxVirtualSize(tx)
+	for hash, conflict := range conflicts {
+		if !mp.signalsReplacement(conflict) {
+			str := fmt.Sprintf("transaction %v spends outputs already spent by transaction %v in the memory "+
+				"pool, which does not signal replacement", txHash, hash)
+			return nil, txRuleError(wire.RejectDuplicate, str)
+		}
+		// The replacement must pay a higher fee rate than each transaction it directly replaces, or miners would be
+		// worse off mining it in their place.
+		if txFee*GetTxVirtualSize(conflict.Tx) <= conflict.Fee*txSize {
+			str := fmt.Sprintf("replacement transaction %v has an insufficient fee rate: %d/%d <= %d/%d",
+				txHash, txFee, txSize, conflict.Fee, GetTxVirtualSize(conflict.Tx))
+			return nil, txRuleError(wire.RejectInsufficientFee, str)
+		}
+		evicted[hash] = conflict
+		for descHash, desc := range mp.descendants(conflict) {
+			evicted[descHash] = desc
+		}
+		for _, txIn := range conflict.Tx.MsgTx().TxIn {
+			conflictParents[txIn.PreviousOutPoint.Hash] = struct{}{}
+		}
+	}
+	if len(evicted) > MaxReplacementEvictions {
+		str := fmt.Sprintf("replacement transaction %v would evict %d transactions, more than the limit of %d",
+			txHash, len(evicted), MaxReplacementEvictions)
+		return nil, txRuleError(wire.RejectNonstandard, str)
+	}
+	// The replacement may not spend the outputs of a transaction it evicts, as it would then depend on itself, and may
+	// not add unconfirmed inputs, as its higher fee could be outweighed by the low fee rate of its new ancestors.
+	for _, txIn := range tx.MsgTx().TxIn {
+		prevHash := txIn.PreviousOutPoint.Hash
+		if _, ok := evicted[prevHash]; ok {
+			str := fmt.Sprintf("replacement transaction %v spends an output of transaction %v, which it replaces",
+				txHash, prevHash)
+			return nil, txRuleError(wire.RejectInvalid, str)
+		}
+		if _, ok := mp.pool[prevHash]; !ok {
+			continue
+		}
+		if _, ok := conflictParents[prevHash]; !ok {
+			str := fmt.Sprintf("replacement transaction %v spends new unconfirmed input %v",
+				txHash, txIn.PreviousOutPoint)
+			return nil, txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	// The replacement must pay for all the transactions it evicts, and on top of that for its own relay at the
+	// minimum relay fee rate.
+	var evictedFees int64
+	for _, desc := range evicted {
+		evictedFees += desc.Fee
+	}
+	if txFee < evictedFees {
+		str := fmt.Sprintf("replacement transaction %v pays %d fees, less than the %d paid by the transactions "+
+			"it replaces", txHash, txFee, evictedFees)
+		return nil, txRuleError(wire.RejectInsufficientFee, str)
+	}
+	minFee := calcMinRequiredTxRelayFee(txSize, mp.cfg.Policy.MinRelayTxFee)
+	if txFee-evictedFees < minFee {
+		str := fmt.Sprintf("replacement transaction %v pays %d more fees than the transactions it replaces, "+
+			"under the required %d", txHash, txFee-evictedFees, minFee)
+		return nil, txRuleError(wire.RejectInsufficientFee, str)
+	}
+	return evicted, nil
+}
@@ -0,0 +1,118 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// createTxWithFee creates a signed transaction spending the passed output to a single output of the harness, paying
+// the given fee and using the given sequence number for its input.
+func (p *poolHarness) createTxWithFee(input spendableOutput, fee int64, sequence uint32) (*util.Tx, error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: input.outPoint,
+		Sequence:         sequence,
+	})
+	tx.AddTxOut(&wire.TxOut{
+		PkScript: p.payScript,
+		Value:    int64(input.amount) - fee,
+	})
+	sigScript, err := txscript.SignatureScript(tx, 0, p.payScript, txscript.SigHashAll, p.signKey, true)
+	if err != nil {
+		return nil, err
+	}
+	tx.TxIn[0].SignatureScript = sigScript
+	return util.NewTx(tx), nil
+}
+
+// TestReplacement ensures transactions signalling replacement can be replaced, together with their descendants, only by
+// transactions paying enough more, and that transactions which do not signal cannot be replaced.
+func TestReplacement(t *testing.T) {
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	accept := func(tx *util.Tx) {
+		if _, err := harness.txPool.ProcessTransaction(nil, tx, false, false, 0); err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx %v: %v", tx.Hash(), err)
+		}
+	}
+	reject := func(tx *util.Tx) {
+		if _, err := harness.txPool.ProcessTransaction(nil, tx, false, false, 0); err == nil {
+			t.Fatalf("ProcessTransaction: accepted tx %v that should have been rejected", tx.Hash())
+		}
+	}
+	original, err := harness.createTxWithFee(outputs[0], 1000, MaxRBFSequence)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	accept(original)
+	child, err := harness.createTxWithFee(txOutToSpendableOut(original, 0), 1000, wire.MaxTxInSequenceNum)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	accept(child)
+	// The child does not signal itself, but inherits replaceability from its parent.
+	if replaceable, err := harness.txPool.IsReplaceable(child.Hash()); err != nil || !replaceable {
+		t.Fatalf("IsReplaceable: got %v, %v for child of a signalling transaction", replaceable, err)
+	}
+	// A replacement must pay for both the original and its child on top of its own relay fee.
+	cheap, err := harness.createTxWithFee(outputs[0], 1500, wire.MaxTxInSequenceNum)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	reject(cheap)
+	testPoolMembership(tc, original, false, true)
+	testPoolMembership(tc, child, false, true)
+	replacement, err := harness.createTxWithFee(outputs[0], 5000, wire.MaxTxInSequenceNum)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	accept(replacement)
+	testPoolMembership(tc, original, false, false)
+	testPoolMembership(tc, child, false, false)
+	testPoolMembership(tc, replacement, false, true)
+	if spend := harness.txPool.CheckSpend(outputs[0].outPoint); spend != replacement {
+		t.Fatalf("expected %v to be spent by the replacement, got %v", outputs[0].outPoint, spend)
+	}
+	// The replacement does not signal, so it cannot be replaced in turn however much is paid.
+	again, err := harness.createTxWithFee(outputs[0], 50000, MaxRBFSequence)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	reject(again)
+	testPoolMembership(tc, replacement, false, true)
+}
+
+// TestRejectReplacement ensures no transaction is replaced when the policy rejects replacements.
+func TestRejectReplacement(t *testing.T) {
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	harness.txPool.cfg.Policy.RejectReplacement = true
+	tc := &testContext{t, harness}
+	original, err := harness.createTxWithFee(outputs[0], 1000, MaxRBFSequence)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	if _, err = harness.txPool.ProcessTransaction(nil, original, false, false, 0); err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	replacement, err := harness.createTxWithFee(outputs[0], 50000, MaxRBFSequence)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	if _, err = harness.txPool.ProcessTransaction(nil, replacement, false, false, 0); err == nil {
+		t.Fatalf("ProcessTransaction: accepted a replacement against policy")
+	}
+	testPoolMembership(tc, original, false, true)
+	testPoolMembership(tc, replacement, false, false)
+}
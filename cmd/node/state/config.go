@@ -21,6 +21,7 @@ type Config struct {
 	DropAddrIndex       bool
 	DropTxIndex         bool
 	DropCfIndex         bool
+	DropSpendIndex      bool
 	Save                bool
 	// Miner               *worker.Worker
 }
@@ -546,3 +546,28 @@ func TestResolveCFHeadersMismatch(t *testing.T) {
 		})
 	}
 }
+
+// TestResolveExtFilterMismatch checks that peers sending an extended filter that differs from the one built from the
+// block are found to be bad.
+func TestResolveExtFilterMismatch(t *testing.T) {
+	t.Parallel()
+	extFilter, err := builder.BuildExtFilter(block)
+	if err != nil {
+		t.Fatalf("Couldn't build extended filter: %v", err)
+	}
+	badPeers, err := resolveCFHeaderMismatch(
+		block, wire.GCSFilterExtended, map[string]*gcs.Filter{
+			"a": extFilter,
+			"b": correctFilter,
+			"c": fakeFilter1,
+		},
+	)
+	if err != nil {
+		t.Fatalf("Couldn't resolve extended filter mismatch: %v", err)
+	}
+	sort.Strings(badPeers)
+	if len(badPeers) != 2 || badPeers[0] != "b" || badPeers[1] != "c" {
+		t.Fatalf("Banned wrong peers.\nExpected: %#v\nGot: %#v",
+			[]string{"b", "c"}, badPeers)
+	}
+}
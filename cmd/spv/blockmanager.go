@@ -471,6 +471,8 @@ waitForHeaders:
 	b.getCheckpointedCFHeaders(
 		goodCheckpoints, store, fType,
 	)
+	// The extended cfheaders follow on from the regular ones.
+	b.syncExtCFHeaders()
 	// Now we check the headers again. If the block headers are not yet current, then we go back to the loop waiting for
 	// them to finish.
 	if !b.BlockHeadersSynced() {
@@ -509,6 +511,9 @@ waitForHeaders:
 			}
 		}
 		b.newHeadersSignal.L.Unlock()
+		// The extended cfheaders are fetched first, so they are already stored by the time the regular cfheaders
+		// notify subscribers of the new blocks.
+		b.syncExtCFHeaders()
 		// At this point, we know that there're a set of new filter headers to fetch, so we'll grab them now.
 		if err = b.getUncheckpointedCFHeaders(
 			store, fType,
@@ -529,6 +534,41 @@ waitForHeaders:
 	}
 }
 
+// syncExtCFHeaders brings the extended cfheader chain up to the block header tip. There are no checkpoints for it, so
+// the extended cfheaders are fetched in batches from all peers, and any disagreement between them is settled against
+// the block as it is for regular cfheaders at the tip. A failure is only logged so it doesn't hold up the regular
+// cfheaders, and the extended chain is picked up again on the next round.
+func (b *blockManager) syncExtCFHeaders() {
+	fType := wire.GCSFilterExtended
+	store := b.server.ExtFilterHeaders
+	for {
+		// Quit if requested.
+		select {
+		case <-b.quit.Wait():
+			return
+		default:
+		}
+		_, prevHeight, err := store.ChainTip()
+		if err != nil {
+			Error(err)
+			return
+		}
+		if err = b.getUncheckpointedCFHeaders(store, fType); err != nil {
+			Debugf("couldn't get uncheckpointed headers for %v: %v", fType, err)
+			return
+		}
+		// Once a round doesn't extend the chain, it has caught up with the block headers.
+		_, height, err := store.ChainTip()
+		if err != nil {
+			Error(err)
+			return
+		}
+		if height == prevHeight {
+			return
+		}
+	}
+}
+
 // getUncheckpointedCFHeaders gets the next batch of cfheaders from the network, if it can, and resolves any conflicts
 // between them. It then writes any verified headers to the store.
 func (b *blockManager) getUncheckpointedCFHeaders(
@@ -876,6 +916,11 @@ func (b *blockManager) writeCFHeadersMsg(msg *wire.MsgCFHeaders,
 		Error(err)
 		return nil, err
 	}
+	// Only the regular filter headers drive the filter header tip and the block notifications. The extended chain is
+	// only consulted when a rescan checks an extended filter.
+	if msg.FilterType != wire.GCSFilterRegular {
+		return &lastHeader, nil
+	}
 	// Notify subscribers, and also update the filter header progress logger at the same time.
 	msgType := connectBasic
 	for i, header := range matchingBlockHeaders {
@@ -1128,6 +1173,24 @@ func resolveCFHeaderMismatch(block *wire.MsgBlock, fType wire.FilterType, filter
 				}
 			}
 		}
+	// The extended filter is built from the block alone, so each peer's filter can be compared with the one we build.
+	case wire.GCSFilterExtended:
+		extFilter, err := builder.BuildExtFilter(block)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		want, err := extFilter.NBytes()
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		for peerAddr, filter := range filtersFromPeers {
+			got, err := filter.NBytes()
+			if err != nil || !bytes.Equal(got, want) {
+				badPeers[peerAddr] = struct{}{}
+			}
+		}
 	default:
 		return nil, fmt.Errorf("unknown filter: %v", fType)
 	}
@@ -16,6 +16,8 @@ var (
 	filterBucket = []byte("filter-store")
 	// regBucket is the bucket that stores the regular filters.
 	regBucket = []byte("regular")
+	// extBucket is the bucket that stores the extended filters.
+	extBucket = []byte("extended")
 )
 
 // FilterType is a enum-like type that represents the various filter types currently defined.
@@ -24,6 +26,8 @@ type FilterType uint8
 const (
 	// RegularFilter is the filter type of regular filters which contain outputs and pkScript data pushes.
 	RegularFilter FilterType = iota
+	// ExtendedFilter is the filter type of extended filters which contain the outpoints spent and all data pushes.
+	ExtendedFilter
 )
 
 var (
@@ -83,6 +87,28 @@ func New(db walletdb.DB, params netparams.Params) (*FilterStore, error) {
 	if err != nil && err != walletdb.ErrBucketExists {
 		return nil, err
 	}
+	// The bucket for the extended filters is created separately, as it is missing from stores created before they were
+	// added.
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		filters := tx.ReadWriteBucket(filterBucket)
+		if filters.NestedReadWriteBucket(extBucket) != nil {
+			return nil
+		}
+		extFilters, err := filters.CreateBucket(extBucket)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		extFilter, err := builder.BuildExtFilter(params.GenesisBlock)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		return putFilter(extFilters, params.GenesisHash, extFilter)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &FilterStore{
 			db: db,
 		},
@@ -115,6 +141,8 @@ func (f *FilterStore) PutFilter(hash *chainhash.Hash,
 		switch fType {
 		case RegularFilter:
 			targetBucket = filters.NestedReadWriteBucket(regBucket)
+		case ExtendedFilter:
+			targetBucket = filters.NestedReadWriteBucket(extBucket)
 		default:
 			return fmt.Errorf("unknown filter type: %v", fType)
 		}
@@ -142,6 +170,8 @@ func (f *FilterStore) FetchFilter(blockHash *chainhash.Hash,
 		switch filterType {
 		case RegularFilter:
 			targetBucket = filters.NestedReadBucket(regBucket)
+		case ExtendedFilter:
+			targetBucket = filters.NestedReadBucket(extBucket)
 		default:
 			return fmt.Errorf("unknown filter type")
 		}
@@ -58,6 +58,13 @@ func TestGenesisFilterCreation(t *testing.T) {
 	if regGenesisFilter == nil {
 		t.Fatalf("regular genesis filter is nil")
 	}
+	extGenesisFilter, err := dB.FetchFilter(genesisHash, ExtendedFilter)
+	if err != nil {
+		t.Fatalf("unable to fetch extended genesis filter: %v", err)
+	}
+	if extGenesisFilter == nil {
+		t.Fatalf("extended genesis filter is nil")
+	}
 	
 }
 func genRandFilter(numElements uint32) (*gcs.Filter, error) {
@@ -115,4 +122,27 @@ func TestFilterStorage(t *testing.T) {
 	if !reflect.DeepEqual(regFilter, regFilterDB) {
 		t.Fatalf("regular filter doesn't match!")
 	}
+	// The same is done for the extended filter type, which is stored separately.
+	extFilter, err := genRandFilter(50)
+	if err != nil {
+		t.Fatalf("unable to create random filter: %v", err)
+	}
+	err = dB.PutFilter(&randHash, extFilter, ExtendedFilter)
+	if err != nil {
+		t.Fatalf("unable to store extended filter: %v", err)
+	}
+	extFilterDB, err := dB.FetchFilter(&randHash, ExtendedFilter)
+	if err != nil {
+		t.Fatalf("unable to retrieve ext filter: %v", err)
+	}
+	if !reflect.DeepEqual(extFilter, extFilterDB) {
+		t.Fatalf("extended filter doesn't match!")
+	}
+	regFilterDB, err = dB.FetchFilter(&randHash, RegularFilter)
+	if err != nil {
+		t.Fatalf("unable to retrieve reg filter: %v", err)
+	}
+	if !reflect.DeepEqual(regFilter, regFilterDB) {
+		t.Fatalf("regular filter was overwritten by the extended one!")
+	}
 }
@@ -26,7 +26,7 @@ func (h *headerStore) readRaw(seekDist uint64) ([]byte, error) {
 	switch h.indexType {
 	case Block:
 		headerSize = 80
-	case RegularFilter:
+	case RegularFilter, ExtendedFilter:
 		headerSize = 32
 	default:
 		return nil, fmt.Errorf("unknown index type: %v", h.indexType)
@@ -54,7 +54,7 @@ func (h *blockHeaderStore) readHeaderRange(startHeight uint32,
 	switch h.indexType {
 	case Block:
 		headerSize = 80
-	case RegularFilter:
+	case RegularFilter, ExtendedFilter:
 		headerSize = 32
 	default:
 		return nil, fmt.Errorf("unknown index type: %v", h.indexType)
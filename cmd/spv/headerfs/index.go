@@ -20,11 +20,9 @@ var (
 	// regFilterTip is the key which tracks the "tip" of the regular compact filter header chain. The value of this key
 	// will be the current block hash of the best known chain that the headers for regular filter are synced to.
 	regFilterTip = []byte("regular")
-	// // extFilterTip is the key which tracks the "tip" of the extended
-	// // compact filter header chain. The value of this key will be the
-	// // current block hash of the best known chain that the headers for
-	// // extended filter are synced to.
-	// extFilterTip = []byte("ext")
+	// extFilterTip is the key which tracks the "tip" of the extended compact filter header chain. The value of this key
+	// will be the current block hash of the best known chain that the headers for extended filter are synced to.
+	extFilterTip = []byte("ext")
 )
 var (
 	// ErrHeightNotFound is returned when a specified height isn't found in a target index.
@@ -41,6 +39,8 @@ const (
 	Block HeaderType = iota
 	// RegularFilter is a header type that represents the basic filter header type for the filter header chain.
 	RegularFilter
+	// ExtendedFilter is a header type that represents the extended filter header type for the filter header chain.
+	ExtendedFilter
 )
 
 // headerIndex is an index stored within the database that allows for random access into the on-disk header file. This,
@@ -124,6 +124,8 @@ func (h *headerIndex) addHeaders(batch headerBatch) error {
 			tipKey = bitcoinTip
 		case RegularFilter:
 			tipKey = regFilterTip
+		case ExtendedFilter:
+			tipKey = extFilterTip
 		default:
 			return fmt.Errorf("unknown index type: %v", h.indexType)
 		}
@@ -186,6 +188,8 @@ func (h *headerIndex) chainTip() (*chainhash.Hash, uint32, error) {
 			tipKey = bitcoinTip
 		case RegularFilter:
 			tipKey = regFilterTip
+		case ExtendedFilter:
+			tipKey = extFilterTip
 		default:
 			return fmt.Errorf("unknown chain tip index type: %v", h.indexType)
 		}
@@ -228,6 +232,8 @@ func (h *headerIndex) truncateIndex(newTip *chainhash.Hash, delete bool) error {
 			tipKey = bitcoinTip
 		case RegularFilter:
 			tipKey = regFilterTip
+		case ExtendedFilter:
+			tipKey = extFilterTip
 		default:
 			return fmt.Errorf("unknown index type: %v", h.indexType)
 		}
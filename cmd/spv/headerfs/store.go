@@ -12,6 +12,7 @@ import (
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/coding/gcs"
 	"github.com/p9c/pod/pkg/coding/gcs/builder"
 	"github.com/p9c/pod/pkg/db/walletdb"
 	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
@@ -72,6 +73,8 @@ func newHeaderStore(db walletdb.DB, filePath string,
 		flatFileName = "block_headers.bin"
 	case RegularFilter:
 		flatFileName = "reg_filter_headers.bin"
+	case ExtendedFilter:
+		flatFileName = "ext_filter_headers.bin"
 	default:
 		return nil, fmt.Errorf("unrecognized filter type: %v", hType)
 	}
@@ -507,26 +510,29 @@ func NewFilterHeaderStore(filePath string, db walletdb.DB,
 	// so we'll do so now.
 	if fileInfo.Size() == 0 {
 		var genesisFilterHash chainhash.Hash
+		var genesisFilter *gcs.Filter
 		switch filterType {
 		case RegularFilter:
-			basicFilter, err := builder.BuildBasicFilter(
+			genesisFilter, err = builder.BuildBasicFilter(
 				netParams.GenesisBlock, nil,
 			)
-			if err != nil {
-				Error(err)
-				return nil, err
-			}
-			genesisFilterHash, err = builder.MakeHeaderForFilter(
-				basicFilter,
-				netParams.GenesisBlock.Header.PrevBlock,
-			)
-			if err != nil {
-				Error(err)
-				return nil, err
-			}
+		case ExtendedFilter:
+			genesisFilter, err = builder.BuildExtFilter(netParams.GenesisBlock)
 		default:
 			return nil, fmt.Errorf("unknown filter type: %v", filterType)
 		}
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		genesisFilterHash, err = builder.MakeHeaderForFilter(
+			genesisFilter,
+			netParams.GenesisBlock.Header.PrevBlock,
+		)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
 		genesisHeader := FilterHeader{
 			HeaderHash: *netParams.GenesisHash,
 			FilterHash: genesisFilterHash,
@@ -17,6 +17,7 @@ import (
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/coding/gcs/builder"
 	"github.com/p9c/pod/pkg/db/walletdb"
 )
 
@@ -382,6 +383,87 @@ func TestFilterHeaderStoreRecovery(t *testing.T) {
 	}
 }
 
+// TestExtFilterHeaderStore tests that the extended filter header chain starts from the header of the extended genesis
+// filter, and is kept apart from the regular filter header chain sharing its database.
+func TestExtFilterHeaderStore(t *testing.T) {
+	cleanUp, db, tempDir, regStore, err := createTestFilterHeaderStore()
+	if cleanUp != nil {
+		defer cleanUp()
+	}
+	if err != nil {
+		t.Fatalf("unable to create new filter header store: %v", err)
+	}
+	extStore, err := NewFilterHeaderStore(tempDir, db, ExtendedFilter,
+		&netparams.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to create extended filter header store: %v", err)
+	}
+	genesisFilter, err := builder.BuildExtFilter(netparams.SimNetParams.GenesisBlock)
+	if err != nil {
+		t.Fatalf("unable to build genesis filter: %v", err)
+	}
+	genesisHeader, err := builder.MakeHeaderForFilter(genesisFilter,
+		netparams.SimNetParams.GenesisBlock.Header.PrevBlock)
+	if err != nil {
+		t.Fatalf("unable to make genesis filter header: %v", err)
+	}
+	extGenesis, err := extStore.FetchHeaderByHeight(0)
+	if err != nil {
+		t.Fatalf("unable to fetch extended genesis header: %v", err)
+	}
+	if *extGenesis != genesisHeader {
+		t.Fatalf("genesis header mismatch: expected %v, got %v",
+			genesisHeader, extGenesis)
+	}
+	regGenesis, err := regStore.FetchHeaderByHeight(0)
+	if err != nil {
+		t.Fatalf("unable to fetch regular genesis header: %v", err)
+	}
+	if *regGenesis == *extGenesis {
+		t.Fatalf("regular and extended genesis headers are the same")
+	}
+	blockHeaders := createTestFilterHeaderChain(10)
+	// We simulate the expected behavior of the block headers, including the genesis block, being written to disk
+	// before the filter headers are.
+	if err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		rootBucket := tx.ReadWriteBucket(indexBucket)
+		var genesisHeight [4]byte
+		err := rootBucket.Put(netparams.SimNetParams.GenesisHash[:], genesisHeight[:])
+		if err != nil {
+			return err
+		}
+		for _, header := range blockHeaders {
+			var heightBytes [4]byte
+			binary.BigEndian.PutUint32(heightBytes[:], header.Height)
+			err := rootBucket.Put(header.HeaderHash[:], heightBytes[:])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("unable to pre-load block index: %v", err)
+	}
+	// Only the extended chain is extended, so the regular chain must stay at its genesis header.
+	if err := extStore.WriteHeaders(blockHeaders...); err != nil {
+		t.Fatalf("unable to write filter headers: %v", err)
+	}
+	_, extHeight, err := extStore.ChainTip()
+	if err != nil {
+		t.Fatalf("unable to get chain tip: %v", err)
+	}
+	if extHeight != 10 {
+		t.Fatalf("extended tip height mismatch: expected %v, got %v", 10, extHeight)
+	}
+	_, regHeight, err := regStore.ChainTip()
+	if err != nil {
+		t.Fatalf("unable to get chain tip: %v", err)
+	}
+	if regHeight != 0 {
+		t.Fatalf("regular tip height mismatch: expected %v, got %v", 0, regHeight)
+	}
+}
+
 // TestBlockHeadersFetchHeaderAncestors tests that we're able to properly fetch the ancestors of a particular block,
 // going from a set distance back to the target block.
 func TestBlockHeadersFetchHeaderAncestors(t *testing.T) {
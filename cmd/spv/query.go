@@ -595,19 +595,27 @@ func (s *ChainService) putFilterToCache(blockHash *chainhash.Hash,
 }
 
 // GetCFilter gets a cfilter from the database. Failing that, it requests the cfilter from the network and writes it to
-// the database. If extended is true, an extended filter will be queried for. Otherwise, we'll fetch the regular filter.
+// the database. Filters fetched from the network are verified against the filter header chain of their type.
 func (s *ChainService) GetCFilter(blockHash chainhash.Hash,
 	filterType wire.FilterType, options ...QueryOption) (*gcs.Filter, error) {
-	// The only supported filter atm is the regular filter, so we'll reject all other filters.
-	if filterType != wire.GCSFilterRegular {
+	// Based on the filter type, we'll set up the database filter type to use.
+	var (
+		dbFilterType filterdb.FilterType
+		getHeader    func(*chainhash.Hash) (*chainhash.Hash, error)
+	)
+	switch filterType {
+	case wire.GCSFilterRegular:
+		dbFilterType = filterdb.RegularFilter
+		getHeader = s.RegFilterHeaders.FetchHeader
+	case wire.GCSFilterExtended:
+		dbFilterType = filterdb.ExtendedFilter
+		getHeader = s.ExtFilterHeaders.FetchHeader
+	default:
 		return nil, fmt.Errorf("unknown filter type: %v", filterType)
 	}
 	// Only get one CFilter at a time to avoid redundancy from mutliple rescans running at once.
 	s.mtxCFilter.Lock()
 	defer s.mtxCFilter.Unlock()
-	// Based on if extended is true or not, we'll set up our set of querying, and db-write functions.
-	getHeader := s.RegFilterHeaders.FetchHeader
-	dbFilterType := filterdb.RegularFilter
 	// First check the cache to see if we already have this filter. If so, then we can return it an exit early.
 	filter, err := s.getFilterFromCache(&blockHash, dbFilterType)
 	if err == nil && filter != nil {
@@ -693,24 +701,34 @@ func (s *ChainService) GetCFilter(blockHash chainhash.Hash,
 		},
 		options...,
 	)
-	if filter != nil {
-		// If we found a filter, put it in the cache and persistToDisk if the caller requested it.
-		err := s.putFilterToCache(&blockHash, dbFilterType, filter)
+	if err = s.storeFilter(&blockHash, filterType, dbFilterType, filter, options...); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// storeFilter puts a filter that was fetched from the network in the cache, and persists it to disk if the caller
+// requested it.
+func (s *ChainService) storeFilter(blockHash *chainhash.Hash, filterType wire.FilterType,
+	dbFilterType filterdb.FilterType, filter *gcs.Filter, options ...QueryOption) error {
+	if filter == nil {
+		return nil
+	}
+	err := s.putFilterToCache(blockHash, dbFilterType, filter)
+	if err != nil {
+		Warn("couldn't write filter to cache:", err)
+	}
+	qo := defaultQueryOptions()
+	qo.applyQueryOptions(options...)
+	if qo.persistToDisk {
+		err := s.FilterDB.PutFilter(blockHash, filter, dbFilterType)
 		if err != nil {
-			Warn("couldn't write filter to cache:", err)
-		}
-		qo := defaultQueryOptions()
-		qo.applyQueryOptions(options...)
-		if qo.persistToDisk {
-			err := s.FilterDB.PutFilter(&blockHash, filter, dbFilterType)
-			if err != nil {
-				Error(err)
-				return nil, err
-			}
-			Tracef("Wrote filter for block %s, type %d", blockHash, filterType)
+			Error(err)
+			return err
 		}
+		Tracef("Wrote filter for block %s, type %d", blockHash, filterType)
 	}
-	return filter, nil
+	return nil
 }
 
 // GetBlock gets a block by requesting it from the network, one peer at a time, until one answers. If the block is found
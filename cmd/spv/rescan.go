@@ -11,6 +11,7 @@ import (
 	qu "github.com/p9c/pod/pkg/util/quit"
 	
 	"github.com/p9c/pod/cmd/spv/headerfs"
+	blockchain "github.com/p9c/pod/pkg/chain"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
@@ -36,9 +37,13 @@ type rescanOptions struct {
 	watchAddrs   []util.Address
 	watchInputs  []InputWithScript
 	watchList    [][]byte
-	txIdx        uint32
-	update       <-chan *updateOptions
-	quit         qu.C
+	// watchOutPoints and watchPushes are matched against the extended filters, using the entries in extWatchList
+	watchOutPoints []wire.OutPoint
+	watchPushes    [][]byte
+	extWatchList   [][]byte
+	txIdx          uint32
+	update         <-chan *updateOptions
+	quit           qu.C
 }
 
 // RescanOption is a functional option argument to any of the rescan and notification subscription methods. These are
@@ -120,6 +125,25 @@ func WatchInputs(watchInputs ...InputWithScript) RescanOption {
 	}
 }
 
+// WatchOutPoints specifies outpoints to watch for on-chain spends when the script they pay to isn't known. They are
+// matched against the extended filters. Each call to this function adds to the list of outpoints being watched rather
+// than replacing the list.
+func WatchOutPoints(outPoints ...wire.OutPoint) RescanOption {
+	return func(ro *rescanOptions) {
+		ro.watchOutPoints = append(ro.watchOutPoints, outPoints...)
+	}
+}
+
+// WatchPushes specifies data to watch for in the data pushes of input and output scripts and witnesses, such as public
+// keys and script hashes. They are matched against the extended filters, and outputs with a matching push have their
+// outpoints watched for spends. Each call to this function adds to the list of data being watched rather than replacing
+// the list.
+func WatchPushes(pushes ...[]byte) RescanOption {
+	return func(ro *rescanOptions) {
+		ro.watchPushes = append(ro.watchPushes, pushes...)
+	}
+}
+
 // TxIdx specifies a hint transaction index into the block in which the UTXO is created (eg, coinbase is 0, next
 // transaction is 1, etc.)
 func TxIdx(txIdx uint32) RescanOption {
@@ -169,6 +193,10 @@ func (s *ChainService) rescan(options ...RescanOption) error {
 	for _, input := range ro.watchInputs {
 		ro.watchList = append(ro.watchList, input.PkScript)
 	}
+	for i := range ro.watchOutPoints {
+		ro.extWatchList = append(ro.extWatchList, builder.OutPointEntry(&ro.watchOutPoints[i]))
+	}
+	ro.extWatchList = append(ro.extWatchList, ro.watchPushes...)
 	// Check that we have either an end block or a quit channel.
 	if ro.endBlock != nil {
 		// If the end block hash is non-nil, then we'll query the database to find out the stop height.
@@ -547,7 +575,7 @@ func (s *ChainService) notifyBlock(ro *rescanOptions,
 	// Find relevant transactions based on watch list. If scanning is false, we can safely assume this block has no
 	// relevant transactions.
 	var relevantTxs []*util.Tx
-	if len(ro.watchList) != 0 && scanning {
+	if (len(ro.watchList) != 0 || len(ro.extWatchList) != 0) && scanning {
 		// If we have a non-empty watch list, then we need to see if it matches the rescan's filters, so we get the
 		// basic filter from the DB or network.
 		matched, err := s.blockFilterMatches(ro, &curStamp.Hash)
@@ -612,6 +640,10 @@ func (s *ChainService) extractBlockMatches(ro *rescanOptions,
 			Error(err)
 			return nil, err
 		}
+		// The same goes for outputs with a watched data push, whose outpoints are watched from then on.
+		if ro.paysWatchedPush(tx) {
+			pays = true
+		}
 		if pays {
 			relevant = true
 			if ro.ntfn.OnRecvTx != nil {
@@ -676,7 +708,10 @@ func (s *ChainService) matchBlockFilter(ro *rescanOptions, filter *gcs.Filter,
 		Error(err)
 		return false, err
 	}
-	return matched, nil
+	if matched {
+		return true, nil
+	}
+	return s.extFilterMatches(ro, blockHash)
 }
 
 // blockFilterMatches returns whether the block filter matches the watched items. If this returns false, it means the
@@ -703,16 +738,45 @@ func (s *ChainService) blockFilterMatches(ro *rescanOptions,
 			return matched, err
 		}
 	}
-	// We don't need the extended filter, since all of the things a rescan can watch for are currently added to the same
-	// watch list and available in the basic filter. In the future, we can watch for data pushes in input scripts (incl.
-	// P2SH and witness). In the meantime, we return false if the basic filter didn't match our watch list.
-	return false, nil
+	// Outpoints and data pushes are only in the extended filter, so it is checked if the basic filter didn't match.
+	return s.extFilterMatches(ro, blockHash)
+}
+
+// extFilterMatches returns whether the extended filter of a block matches the watched outpoints and data pushes. If the
+// extended filter header chain hasn't reached the block yet, or no peer serves a filter matching its header, the block
+// can't be ruled out, so it is treated as a match.
+func (s *ChainService) extFilterMatches(ro *rescanOptions,
+	blockHash *chainhash.Hash) (bool, error) {
+	if len(ro.extWatchList) == 0 {
+		return false, nil
+	}
+	if _, err := s.ExtFilterHeaders.FetchHeader(blockHash); err != nil {
+		Debugf("no extended filter header for block %v, fetching the block", blockHash)
+		return true, nil
+	}
+	eFilter, err := s.GetCFilter(*blockHash, wire.GCSFilterExtended, ro.queryOptions...)
+	if err != nil {
+		Error(err)
+		if err == headerfs.ErrHashNotFound {
+			// Block has been reorged out from under us.
+			return false, nil
+		}
+		return false, err
+	}
+	if eFilter == nil {
+		return true, nil
+	}
+	if eFilter.N() == 0 {
+		return false, nil
+	}
+	return eFilter.MatchAny(builder.DeriveKey(blockHash), ro.extWatchList)
 }
 
 // hasFilterHeadersByHeight checks whether both the basic and extended filter headers for a particular height are known.
 func (s *ChainService) hasFilterHeadersByHeight(height uint32) bool {
 	_, regFetchErr := s.RegFilterHeaders.FetchHeaderByHeight(height)
-	return regFetchErr == nil
+	_, extFetchErr := s.ExtFilterHeaders.FetchHeaderByHeight(height)
+	return regFetchErr == nil && extFetchErr == nil
 }
 
 // updateFilter atomically updates the filter and rewinds to the specified height if not 0.
@@ -734,6 +798,12 @@ func (ro *rescanOptions) updateFilter(update *updateOptions,
 	for _, txid := range update.txIDs {
 		ro.watchList = append(ro.watchList, txid[:])
 	}
+	ro.watchOutPoints = append(ro.watchOutPoints, update.outPoints...)
+	for i := range update.outPoints {
+		ro.extWatchList = append(ro.extWatchList, builder.OutPointEntry(&update.outPoints[i]))
+	}
+	ro.watchPushes = append(ro.watchPushes, update.pushes...)
+	ro.extWatchList = append(ro.extWatchList, update.pushes...)
 	// If we don't need to rewind, then we can exit early.
 	if update.rewind == 0 {
 		return false, nil
@@ -775,7 +845,8 @@ func (ro *rescanOptions) updateFilter(update *updateOptions,
 	return rewound, nil
 }
 
-// spendsWatchedInput returns whether the transaction matches the filter by spending a watched input.
+// spendsWatchedInput returns whether the transaction matches the filter by spending a watched input or outpoint, or
+// having a watched data push in an input script or witness.
 func (ro *rescanOptions) spendsWatchedInput(tx *util.Tx) bool {
 	for _, in := range tx.MsgTx().TxIn {
 		for _, input := range ro.watchInputs {
@@ -783,6 +854,62 @@ func (ro *rescanOptions) spendsWatchedInput(tx *util.Tx) bool {
 				return true
 			}
 		}
+		for _, outPoint := range ro.watchOutPoints {
+			if in.PreviousOutPoint == outPoint {
+				return true
+			}
+		}
+		if len(ro.watchPushes) == 0 || blockchain.IsCoinBaseTx(tx.MsgTx()) {
+			continue
+		}
+		if containsPush(in.SignatureScript, ro.watchPushes) {
+			return true
+		}
+		for _, item := range in.Witness {
+			for _, push := range ro.watchPushes {
+				if bytes.Equal(item, push) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// paysWatchedPush returns whether the transaction has an output with a watched data push. If that is the case, this
+// also updates the filter to watch the newly created output going forward.
+func (ro *rescanOptions) paysWatchedPush(tx *util.Tx) bool {
+	if len(ro.watchPushes) == 0 {
+		return false
+	}
+	anyMatchingOutputs := false
+	for outIdx, out := range tx.MsgTx().TxOut {
+		if !containsPush(out.PkScript, ro.watchPushes) {
+			continue
+		}
+		anyMatchingOutputs = true
+		outPoint := wire.OutPoint{
+			Hash:  *tx.Hash(),
+			Index: uint32(outIdx),
+		}
+		ro.watchOutPoints = append(ro.watchOutPoints, outPoint)
+		ro.extWatchList = append(ro.extWatchList, builder.OutPointEntry(&outPoint))
+	}
+	return anyMatchingOutputs
+}
+
+// containsPush returns whether any of the data pushes in a script is one of the watched pushes.
+func containsPush(script []byte, pushes [][]byte) bool {
+	data, err := txscript.PushedData(script)
+	if err != nil {
+		return false
+	}
+	for _, d := range data {
+		for _, push := range pushes {
+			if len(d) > 0 && bytes.Equal(d, push) {
+				return true
+			}
+		}
 	}
 	return false
 }
@@ -880,6 +1007,8 @@ func (r *Rescan) Start() <-chan error {
 type updateOptions struct {
 	addrs                    []util.Address
 	inputs                   []InputWithScript
+	outPoints                []wire.OutPoint
+	pushes                   [][]byte
 	txIDs                    []chainhash.Hash
 	rewind                   uint32
 	disableDisconnectedNtfns bool
@@ -906,6 +1035,20 @@ func AddInputs(inputs ...InputWithScript) UpdateOption {
 	}
 }
 
+// AddOutPoints adds outpoints to watch for spends of to the extended filter.
+func AddOutPoints(outPoints ...wire.OutPoint) UpdateOption {
+	return func(uo *updateOptions) {
+		uo.outPoints = append(uo.outPoints, outPoints...)
+	}
+}
+
+// AddPushes adds data pushes to watch for to the extended filter.
+func AddPushes(pushes ...[]byte) UpdateOption {
+	return func(uo *updateOptions) {
+		uo.pushes = append(uo.pushes, pushes...)
+	}
+}
+
 // Rewind rewinds the rescan to the specified height (meaning, disconnects down to the block immediately after the
 // specified height) and restarts it from that point with the (possibly) newly expanded filter. Especially useful when
 // called in the same Update() as one of the previous three options.
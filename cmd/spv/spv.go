@@ -39,6 +39,7 @@ type (
 		FilterDB         filterdb.FilterDatabase
 		BlockHeaders     headerfs.BlockHeaderStore
 		RegFilterHeaders *headerfs.FilterHeaderStore
+		ExtFilterHeaders *headerfs.FilterHeaderStore
 		FilterCache      *lru.Cache
 		BlockCache       *lru.Cache
 		// queryPeers will be called to send messages to one or more peers, expecting a response.
@@ -618,6 +619,11 @@ func (s *ChainService) rollBackToHeight(height uint32) (*waddrmgr.BlockStamp, er
 		Error(err)
 		return nil, err
 	}
+	_, extHeight, err := s.ExtFilterHeaders.ChainTip()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
 	for uint32(bs.Height) > height {
 		header, _, err := s.BlockHeaders.FetchHeader(&bs.Hash)
 		if err != nil {
@@ -634,6 +640,14 @@ func (s *ChainService) rollBackToHeight(height uint32) (*waddrmgr.BlockStamp, er
 			}
 			regHeight = uint32(newFilterTip.Height)
 		}
+		if uint32(bs.Height) <= extHeight {
+			newFilterTip, err := s.ExtFilterHeaders.RollbackLastBlock(newTip)
+			if err != nil {
+				Error(err)
+				return nil, err
+			}
+			extHeight = uint32(newFilterTip.Height)
+		}
 		bs, err = s.BlockHeaders.RollbackLastBlock()
 		if err != nil {
 			Error(err)
@@ -1037,6 +1051,13 @@ func NewChainService(cfg Config) (*ChainService, error) {
 		Error(err)
 		return nil, err
 	}
+	s.ExtFilterHeaders, err = headerfs.NewFilterHeaderStore(
+		cfg.DataDir, cfg.Database, headerfs.ExtendedFilter, &cfg.ChainParams,
+	)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
 	bm, err := newBlockManager(&s)
 	if err != nil {
 		Error(err)
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/p9c/pod/pkg/chain/diffsim"
+)
+
+const defaultScenario = "steady"
+
+// config defines the configuration options for diffsim. See loadConfig for details on the configuration load process.
+type config struct {
+	Scenario string `short:"s" long:"scenario" description:"Name of a built in scenario or path of a scenario in JSON format"`
+	Output   string `short:"o" long:"output" description:"File to write the CSV to instead of standard output"`
+	Blocks   int32  `short:"n" long:"blocks" description:"Number of blocks to mine instead of the number in the scenario"`
+	Seed     int64  `long:"seed" description:"Seed of the random numbers instead of the seed in the scenario"`
+	TestNet3 bool   `long:"testnet" description:"Use the activation height of the test network"`
+	List     bool   `short:"l" long:"list" description:"List the built in scenarios and exit"`
+}
+
+// loadConfig initializes and parses the config using command line options.
+func loadConfig() (*config, error) {
+	cfg := config{Scenario: defaultScenario}
+	parser := flags.NewParser(&cfg, flags.Default)
+	if _, err := parser.Parse(); err != nil {
+		if e, ok := err.(*flags.Error); !ok || e.Type != flags.ErrHelp {
+			parser.WriteHelp(os.Stderr)
+		}
+		return nil, err
+	}
+	if cfg.Blocks < 0 {
+		err := fmt.Errorf("loadConfig: the number of blocks can't be negative -- parsed [%v]", cfg.Blocks)
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		parser.WriteHelp(os.Stderr)
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// loadScenario returns the built in scenario of the configured name, or reads it from a file if there is none of that
+// name
+func loadScenario(cfg *config) (s *diffsim.Scenario, err error) {
+	if s, err = diffsim.Builtin(cfg.Scenario); err == nil {
+		return
+	}
+	var f *os.File
+	if f, err = os.Open(cfg.Scenario); err != nil {
+		return nil, fmt.Errorf("%q is not a built in scenario or a file: %v", cfg.Scenario, err)
+	}
+	defer func() {
+		if err := f.Close(); Check(err) {
+		}
+	}()
+	return diffsim.Load(f)
+}
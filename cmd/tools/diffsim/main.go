@@ -0,0 +1,66 @@
+// Command diffsim runs the Plan 9 difficulty adjustment on a simulated chain mined with the hash rates and attacks of a
+// scenario, and writes the block times and targets of every algorithm as CSV.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/p9c/pod/pkg/chain/diffsim"
+	"github.com/p9c/pod/pkg/chain/fork"
+)
+
+func main() {
+	cfg, err := loadConfig()
+	if err != nil {
+		os.Exit(1)
+	}
+	if cfg.List {
+		for _, s := range diffsim.Scenarios() {
+			fmt.Printf("%-14s %s\n", s.Name, s.Description)
+		}
+		return
+	}
+	fork.IsTestnet = cfg.TestNet3
+	var s *diffsim.Scenario
+	if s, err = loadScenario(cfg); err != nil {
+		Error(err)
+		os.Exit(1)
+	}
+	if cfg.Blocks > 0 {
+		s.Blocks = cfg.Blocks
+	}
+	if cfg.Seed != 0 {
+		s.Seed = cfg.Seed
+	}
+	Info("simulating", s.Blocks, "blocks of scenario", s.Name)
+	blocks, err := diffsim.Run(s)
+	stalled := errors.Is(err, diffsim.ErrStalled)
+	if err != nil && !stalled {
+		Error(err)
+		os.Exit(1)
+	}
+	if stalled {
+		// the blocks up to the stall show how it came about
+		Warn(err, "- writing the", len(blocks), "blocks mined before it")
+	}
+	var w io.Writer = os.Stdout
+	if cfg.Output != "" {
+		var f *os.File
+		if f, err = os.Create(cfg.Output); err != nil {
+			Error(err)
+			os.Exit(1)
+		}
+		defer func() {
+			if err := f.Close(); Check(err) {
+			}
+		}()
+		w = f
+	}
+	if err = diffsim.WriteCSV(w, blocks); err != nil {
+		Error(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,91 @@
+// Command forkschedule writes the compiled in hard fork schedule of a network as JSON, as a starting point for a
+// schedule file for a private testnet, and updates the checksum of a schedule file after it has been edited.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/hardfork"
+)
+
+// config defines the configuration options for forkschedule.
+type config struct {
+	TestNet3       bool   `long:"testnet" description:"Use the test network"`
+	RegressionTest bool   `long:"regtest" description:"Use the regression test network"`
+	SimNet         bool   `long:"simnet" description:"Use the simulation test network"`
+	Output         string `short:"o" long:"output" description:"File to write the schedule to instead of standard output"`
+	Seal           string `long:"seal" description:"Schedule file to update the checksum of and validate, written back in place unless an output is given"`
+}
+
+func main() {
+	cfg := config{}
+	parser := flags.NewParser(&cfg, flags.Default)
+	if _, err := parser.Parse(); err != nil {
+		if e, ok := err.(*flags.Error); !ok || e.Type != flags.ErrHelp {
+			parser.WriteHelp(os.Stderr)
+		}
+		os.Exit(1)
+	}
+	params := &netparams.MainNetParams
+	numNets := 0
+	if cfg.TestNet3 {
+		numNets++
+		params = &netparams.TestNet3Params
+		fork.IsTestnet = true
+	}
+	if cfg.RegressionTest {
+		numNets++
+		params = &netparams.RegressionTestParams
+	}
+	if cfg.SimNet {
+		numNets++
+		params = &netparams.SimNetParams
+	}
+	if numNets > 1 {
+		_, _ = fmt.Fprintln(os.Stderr, "the testnet, regtest, and simnet networks can't be used together -- choose one")
+		os.Exit(1)
+	}
+	s := hardfork.Active(params)
+	output := cfg.Output
+	if cfg.Seal != "" {
+		b, err := ioutil.ReadFile(cfg.Seal)
+		if err != nil {
+			Error(err)
+			os.Exit(1)
+		}
+		s = &hardfork.Schedule{}
+		if err = json.Unmarshal(b, s); err != nil {
+			Error(err)
+			os.Exit(1)
+		}
+		s.Seal()
+		if err = s.Validate(params); err != nil {
+			Error(err)
+			os.Exit(1)
+		}
+		if output == "" {
+			output = cfg.Seal
+		}
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		Error(err)
+		os.Exit(1)
+	}
+	b = append(b, '\n')
+	if output == "" {
+		_, _ = os.Stdout.Write(b)
+		return
+	}
+	if err = ioutil.WriteFile(output, b, 0644); err != nil {
+		Error(err)
+		os.Exit(1)
+	}
+}
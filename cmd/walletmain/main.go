@@ -63,6 +63,14 @@ func Main(cx *conte.Xt) (err error) {
 			cx.WalletKill.Q()
 		},
 	)
+	cx.Hooks.Register(
+		"wallet", func([]pod.Change) {
+			if *cx.Config.WalletOff {
+				Info("the wallet was turned off, shutting down")
+				interrupt.Request()
+			}
+		},
+	)
 	select {
 	case <-cx.WalletKill.Wait():
 		Warn("wallet killswitch activated")
@@ -767,6 +767,43 @@ func dbFetchHashByHeight(dbTx database.Tx, height int32) (*chainhash.Hash, error
 	return &hash, nil
 }
 
+// DBFetchBestState uses an existing database transaction to retrieve the hash and height of the tip of the main chain
+// as it is stored in the database.
+func DBFetchBestState(dbTx database.Tx) (*chainhash.Hash, int32, error) {
+	state, err := deserializeBestChainState(dbTx.Metadata().Get(chainStateKeyName))
+	if err != nil {
+		return nil, 0, err
+	}
+	return &state.hash, int32(state.height), nil
+}
+
+// DBFetchBlockByHeight uses an existing database transaction to load the block at the given height of the main chain as
+// it is stored in the database.
+func DBFetchBlockByHeight(dbTx database.Tx, height int32) (*util.Block, error) {
+	hash, err := dbFetchHashByHeight(dbTx, height)
+	if err != nil {
+		return nil, err
+	}
+	blockBytes, err := dbTx.FetchBlock(hash)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	block, err := util.NewBlockFromBytes(blockBytes)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	block.SetHeight(height)
+	return block, nil
+}
+
+// DBFetchSpendJournalEntry uses an existing database transaction to fetch the txouts spent by the given block from the
+// spend journal, which only holds entries for blocks in the main chain.
+func DBFetchSpendJournalEntry(dbTx database.Tx, block *util.Block) ([]SpentTxOut, error) {
+	return dbFetchSpendJournalEntry(dbTx, block)
+}
+
 // The best chain state consists of the best block hash and height, the total number of transactions up to and including
 // those in the best block, and the accumulated work sum up to and including the best block.
 //
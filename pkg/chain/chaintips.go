@@ -0,0 +1,132 @@
+package blockchain
+
+import (
+	"bytes"
+	"sort"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// TipStatus describes the validation state of the branch that ends in a chain tip.
+type TipStatus string
+
+const (
+	// TipActive is the tip of the current best chain.
+	TipActive TipStatus = "active"
+	// TipValidFork is a side chain tip where every block has been fully validated but which is not part of the best
+	// chain.
+	TipValidFork TipStatus = "valid-fork"
+	// TipValidHeaders is a side chain tip where all the blocks are available but at least one has not been fully
+	// validated yet.
+	TipValidHeaders TipStatus = "valid-headers"
+	// TipHeadersOnly is a side chain tip where at least one block in the branch has not had its data stored.
+	TipHeadersOnly TipStatus = "headers-only"
+	// TipInvalid is a side chain tip where the tip block or one of its ancestors has been found to be invalid, either
+	// by failing validation or by being marked invalid with InvalidateBlock.
+	TipInvalid TipStatus = "invalid"
+)
+
+// ChainTip describes the leaf of one of the branches in the block index.
+type ChainTip struct {
+	// Height is the height of the tip block.
+	Height int32
+	// Hash is the hash of the tip block.
+	Hash chainhash.Hash
+	// BranchLen is the number of blocks between the tip and the point where the branch forks from the best chain. It is
+	// zero for the active tip.
+	BranchLen int32
+	// Algo is the version (and thus the mining algorithm) of the tip block.
+	Algo int32
+	// Status is the validation state of the branch.
+	Status TipStatus
+}
+
+// ChainTips returns information about every known tip in the block index, including the tip of the best chain and the
+// tips of all side chains. The tips are returned ordered by descending height, and tips of the same height with the
+// active tip first and the others by hash. This function is safe for concurrent access.
+func (b *BlockChain) ChainTips() []ChainTip {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	tips := b.Index.tips()
+	bestTip := b.BestChain.Tip()
+	results := make([]ChainTip, 0, len(tips))
+	for _, tip := range tips {
+		ct := ChainTip{
+			Height: tip.height,
+			Hash:   tip.hash,
+			Algo:   tip.version,
+		}
+		if tip == bestTip {
+			ct.Status = TipActive
+			results = append(results, ct)
+			continue
+		}
+		forkNode := b.BestChain.FindFork(tip)
+		if forkNode != nil {
+			ct.BranchLen = tip.height - forkNode.height
+		}
+		ct.Status = b.branchStatus(tip, forkNode)
+		results = append(results, ct)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Height != results[j].Height {
+			return results[i].Height > results[j].Height
+		}
+		if (results[i].Status == TipActive) != (results[j].Status == TipActive) {
+			return results[i].Status == TipActive
+		}
+		return bytes.Compare(results[i].Hash[:], results[j].Hash[:]) < 0
+	})
+	return results
+}
+
+// branchStatus determines the status of the side chain running from the fork node up to and including the given tip.
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) branchStatus(tip, forkNode *BlockNode) TipStatus {
+	status := TipValidFork
+	for n := tip; n != nil && n != forkNode; n = n.parent {
+		nodeStatus := b.Index.NodeStatus(n)
+		switch {
+		case nodeStatus.KnownInvalid():
+			return TipInvalid
+		case !nodeStatus.HaveData():
+			status = TipHeadersOnly
+		case !nodeStatus.KnownValid() && status == TipValidFork:
+			status = TipValidHeaders
+		}
+	}
+	return status
+}
+
+// tips returns all the nodes in the block index that have no children. This function is safe for concurrent access.
+func (bi *blockIndex) tips() []*BlockNode {
+	bi.RLock()
+	defer bi.RUnlock()
+	parents := make(map[*BlockNode]struct{}, len(bi.index))
+	for _, node := range bi.index {
+		if node.parent != nil {
+			parents[node.parent] = struct{}{}
+		}
+	}
+	tips := make([]*BlockNode, 0, len(bi.index)-len(parents))
+	for _, node := range bi.index {
+		if _, isParent := parents[node]; !isParent {
+			tips = append(tips, node)
+		}
+	}
+	return tips
+}
+
+// descendants returns every node in the block index that has the given node as an ancestor, not including the node
+// itself. This function is safe for concurrent access.
+func (bi *blockIndex) descendants(node *BlockNode) []*BlockNode {
+	bi.RLock()
+	defer bi.RUnlock()
+	var nodes []*BlockNode
+	for _, n := range bi.index {
+		if n.height > node.height && n.Ancestor(node.height) == node {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
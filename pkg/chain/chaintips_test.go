@@ -0,0 +1,150 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// fakeBranch creates numNodes block nodes with the same difficulty bits chained onto the passed parent. Unlike
+// chainedNodes the nodes carry a non-zero amount of work.
+func fakeBranch(parent *BlockNode, numNodes int, bits uint32) []*BlockNode {
+	nodes := make([]*BlockNode, numNodes)
+	tip := parent
+	for i := range nodes {
+		header := wire.BlockHeader{
+			Version:   2,
+			PrevBlock: tip.hash,
+			Bits:      bits,
+			Timestamp: time.Unix(tip.timestamp+1, 0),
+			Nonce:     testNoncePrng.Uint32(),
+		}
+		nodes[i] = NewBlockNode(&header, tip)
+		tip = nodes[i]
+	}
+	return nodes
+}
+
+// TestChainTips ensures the tips of the block index are found and classified correctly.
+func TestChainTips(t *testing.T) {
+	// Construct a synthetic block index consisting of the following structure.
+	//
+	// 	genesis -> 1 -> 2 -> 3 -> 4 -> 5 -> 6 (active)
+	// 	                      \-> 3a -> 4a (valid-fork)
+	// 	                      \-> 3b (invalid) -> 4b -> 5b (invalid)
+	// 	                                \-> 4c (no data)
+	const bits = 0x207fffff
+	chain := newFakeChain(&netparams.MainNetParams)
+	mainNodes := fakeBranch(chain.BestChain.Genesis(), 6, bits)
+	forkA := fakeBranch(mainNodes[1], 2, bits)
+	forkB := fakeBranch(mainNodes[1], 3, bits)
+	forkC := fakeBranch(forkB[0], 1, bits)
+	for _, nodes := range [][]*BlockNode{mainNodes, forkA, forkB, forkC} {
+		for _, node := range nodes {
+			chain.Index.AddNode(node)
+			chain.Index.SetStatusFlags(node, statusDataStored|statusValid)
+		}
+	}
+	chain.Index.UnsetStatusFlags(forkC[0], statusDataStored|statusValid)
+	chain.Index.SetStatusFlags(forkB[0], statusValidateFailed)
+	for _, node := range chain.Index.descendants(forkB[0]) {
+		chain.Index.SetStatusFlags(node, statusInvalidAncestor)
+	}
+	chain.BestChain.SetTip(tstTip(mainNodes))
+	tests := []struct {
+		node      *BlockNode
+		branchLen int32
+		status    TipStatus
+	}{
+		{node: tstTip(mainNodes), branchLen: 0, status: TipActive},
+		{node: tstTip(forkB), branchLen: 3, status: TipInvalid},
+		{node: tstTip(forkA), branchLen: 2, status: TipValidFork},
+		{node: tstTip(forkC), branchLen: 2, status: TipInvalid},
+	}
+	tips := chain.ChainTips()
+	if len(tips) != len(tests) {
+		t.Fatalf("ChainTips: got %d tips, want %d", len(tips), len(tests))
+	}
+	for _, test := range tests {
+		var found bool
+		for _, tip := range tips {
+			if tip.Hash != test.node.hash {
+				continue
+			}
+			found = true
+			if tip.Height != test.node.height {
+				t.Errorf("tip %v: got height %d, want %d", tip.Hash, tip.Height, test.node.height)
+			}
+			if tip.BranchLen != test.branchLen {
+				t.Errorf("tip %v: got branch length %d, want %d", tip.Hash, tip.BranchLen, test.branchLen)
+			}
+			if tip.Status != test.status {
+				t.Errorf("tip %v: got status %s, want %s", tip.Hash, tip.Status, test.status)
+			}
+		}
+		if !found {
+			t.Errorf("tip %v at height %d was not returned", test.node.hash, test.node.height)
+		}
+	}
+	if tips[0].Status != TipActive {
+		t.Errorf("first tip should be the active one, got %s", tips[0].Status)
+	}
+	// Once 3b is no longer invalid, 4c is reported as headers-only since its data is missing.
+	chain.Index.UnsetStatusFlags(forkB[0], statusValidateFailed)
+	chain.Index.UnsetStatusFlags(forkC[0], statusInvalidAncestor)
+	for _, tip := range chain.ChainTips() {
+		if tip.Hash == tstTip(forkC).hash && tip.Status != TipHeadersOnly {
+			t.Errorf("tip %v: got status %s, want %s", tip.Hash, tip.Status, TipHeadersOnly)
+		}
+	}
+}
+
+// TestBestCandidate ensures the block chosen as the new best chain tip after invalidating or reconsidering blocks is
+// the valid block with the most work.
+func TestBestCandidate(t *testing.T) {
+	// Construct a synthetic block index consisting of the following structure.
+	//
+	// 	genesis -> 1 -> 2 -> 3
+	// 	                 \-> 2a -> 3a -> 4a -> 5a -> 6a
+	// 	                 \-> 2b -> 3b -> 4b -> 5b -> 6b -> 7b
+	const bits = 0x207fffff
+	chain := newFakeChain(&netparams.MainNetParams)
+	mainNodes := fakeBranch(chain.BestChain.Genesis(), 3, bits)
+	forkA := fakeBranch(mainNodes[0], 5, bits)
+	forkB := fakeBranch(mainNodes[0], 6, bits)
+	for _, nodes := range [][]*BlockNode{mainNodes, forkA, forkB} {
+		for _, node := range nodes {
+			chain.Index.AddNode(node)
+			chain.Index.SetStatusFlags(node, statusDataStored|statusValid)
+		}
+	}
+	chain.BestChain.SetTip(tstTip(mainNodes))
+	if got := findCommonAncestor(tstTip(forkA), tstTip(forkB)); got != mainNodes[0] {
+		t.Fatalf("findCommonAncestor: got %v, want %v", got.hash, mainNodes[0].hash)
+	}
+	if got := relativeWork(tstTip(forkB), tstTip(mainNodes)); got.Sign() <= 0 {
+		t.Fatalf("relativeWork: longer branch should have more work, got %v", got)
+	}
+	if got := chain.bestCandidate(tstTip(mainNodes)); got != tstTip(forkB) {
+		t.Errorf("bestCandidate: got height %d, want the tip of the longest branch", got.height)
+	}
+	// Invalidating 5b leaves 4b as the best block on that branch, so the 6a tip has the most work.
+	chain.Index.SetStatusFlags(forkB[3], statusValidateFailed)
+	for _, node := range chain.Index.descendants(forkB[3]) {
+		chain.Index.SetStatusFlags(node, statusInvalidAncestor)
+	}
+	if got := chain.bestCandidate(tstTip(mainNodes)); got != tstTip(forkA) {
+		t.Errorf("bestCandidate: got height %d, want the tip of the 6a branch", got.height)
+	}
+	// Removing the data for 3a means the most that branch can offer is 2a, so 4b is now the best.
+	chain.Index.UnsetStatusFlags(forkA[1], statusDataStored)
+	if got := chain.bestCandidate(tstTip(mainNodes)); got != forkB[2] {
+		t.Errorf("bestCandidate: got height %d, want 4b", got.height)
+	}
+	// When nothing has more work than the base, the base is returned.
+	if got := chain.bestCandidate(tstTip(forkB)); got != tstTip(forkB) {
+		t.Errorf("bestCandidate: got height %d, want the base block", got.height)
+	}
+}
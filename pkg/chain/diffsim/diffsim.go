@@ -0,0 +1,434 @@
+// Package diffsim runs the Plan 9 difficulty adjustment on synthetic block indexes, to see how it responds to changes
+// in hash rate and to attacks before they happen on a live network. Blocks are mined by a model of the miners of each
+// algorithm with a hash rate that follows a curve over time, and every target comes from the same functions the chain
+// uses to validate blocks. Runs are deterministic for a given scenario and seed.
+package diffsim
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// The kinds of events that can happen during a scenario
+const (
+	// Timewarp gives blocks timestamps that are off from the time they were found by an offset, as far as the
+	// median time past and the limit on timestamps in the future allow
+	Timewarp = "timewarp"
+	// HashAndLeave adds hash rate for a while, as a miner does who comes to mine an algorithm while its difficulty is
+	// low and leaves when it has gone up
+	HashAndLeave = "hashandleave"
+	// Dark stops an algorithm from being mined
+	Dark = "dark"
+)
+
+// DefaultStart is the time of the block the simulated blocks are built on, unless a scenario sets its own
+const DefaultStart = 1600000000
+
+// Point is the hash rate of an algorithm at a number of seconds after the start of a scenario. The rate is a multiple
+// of the hash rate that finds blocks of the algorithm at its target interval at the minimum difficulty.
+type Point struct {
+	At   int64   `json:"at"`
+	Rate float64 `json:"rate"`
+}
+
+// Curve is a hash rate that changes over time, linearly between its points. Before the first point and after the last
+// the rate is that of the point.
+type Curve []Point
+
+// Rate returns the hash rate at a number of seconds after the start
+func (c Curve) Rate(at int64) float64 {
+	if len(c) == 0 {
+		return 0
+	}
+	if at <= c[0].At {
+		return c[0].Rate
+	}
+	for i := 1; i < len(c); i++ {
+		if at < c[i].At {
+			a, b := c[i-1], c[i]
+			return a.Rate + (b.Rate-a.Rate)*float64(at-a.At)/float64(b.At-a.At)
+		}
+	}
+	return c[len(c)-1].Rate
+}
+
+// Event is something that happens to the blocks from Start up to but not including End, counted from the first block
+// of the scenario. An empty Algo applies the event to all of the algorithms.
+type Event struct {
+	Kind string `json:"kind"`
+	Algo string `json:"algo,omitempty"`
+	// Start and End are the blocks the event covers
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+	// Factor is the multiple of the hash rate that is added for HashAndLeave, and the share of the blocks that are
+	// warped for Timewarp, where 0 warps all of them
+	Factor float64 `json:"factor,omitempty"`
+	// Offset is the number of seconds added to the timestamps of warped blocks
+	Offset int64 `json:"offset,omitempty"`
+}
+
+// covers returns true if the event applies to the block at index i mined with algo
+func (e Event) covers(i int32, algo string) bool {
+	return i >= e.Start && i < e.End && (e.Algo == "" || e.Algo == algo)
+}
+
+// Scenario is the hash rate and events of a simulation
+type Scenario struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Blocks is the number of blocks to mine
+	Blocks int32 `json:"blocks"`
+	// Seed is the seed of the random numbers that decide when blocks are found and with which algorithm
+	Seed int64 `json:"seed"`
+	// Start is the unix time the simulated blocks are built on from, DefaultStart if it is not set
+	Start int64 `json:"start,omitempty"`
+	// Hashrate is the hash rate curve of each algorithm, algorithms without a curve are not mined
+	Hashrate map[string]Curve `json:"hashrate"`
+	Events   []Event          `json:"events,omitempty"`
+}
+
+// Load reads a scenario in JSON format and checks it is valid
+func Load(r io.Reader) (s *Scenario, err error) {
+	s = &Scenario{}
+	if err = json.NewDecoder(r).Decode(s); Check(err) {
+		return nil, err
+	}
+	if err = s.Validate(); Check(err) {
+		return nil, err
+	}
+	return
+}
+
+// Validate checks that the scenario only names algorithms and events that exist and its curves and events are in order
+func (s *Scenario) Validate() error {
+	if s.Blocks < 1 {
+		return fmt.Errorf("scenario %q has no blocks to mine", s.Name)
+	}
+	if len(s.Hashrate) == 0 {
+		return fmt.Errorf("scenario %q has no hash rate", s.Name)
+	}
+	for algo, c := range s.Hashrate {
+		if _, ok := fork.P9Algos[algo]; !ok {
+			return fmt.Errorf("scenario %q: unknown algorithm %q", s.Name, algo)
+		}
+		for i, p := range c {
+			if p.Rate < 0 || math.IsNaN(p.Rate) || math.IsInf(p.Rate, 0) {
+				return fmt.Errorf("scenario %q: %s hash rate %v is not valid", s.Name, algo, p.Rate)
+			}
+			if i > 0 && p.At <= c[i-1].At {
+				return fmt.Errorf("scenario %q: %s hash rate points are not in order of time", s.Name, algo)
+			}
+		}
+	}
+	for i, e := range s.Events {
+		switch e.Kind {
+		case Timewarp, HashAndLeave:
+		case Dark:
+			if e.Algo == "" {
+				return fmt.Errorf("scenario %q: event %d makes every algorithm go dark", s.Name, i)
+			}
+		default:
+			return fmt.Errorf("scenario %q: event %d is of unknown kind %q", s.Name, i, e.Kind)
+		}
+		if _, ok := fork.P9Algos[e.Algo]; e.Algo != "" && !ok {
+			return fmt.Errorf("scenario %q: event %d is for unknown algorithm %q", s.Name, i, e.Algo)
+		}
+		if e.End <= e.Start {
+			return fmt.Errorf("scenario %q: event %d ends before it starts", s.Name, i)
+		}
+		if e.Factor < 0 || (e.Kind == Timewarp && e.Factor > 1) {
+			return fmt.Errorf("scenario %q: event %d has factor %v out of range", s.Name, i, e.Factor)
+		}
+	}
+	return nil
+}
+
+// Block is a simulated block and the targets of every algorithm when it was found
+type Block struct {
+	Height int32
+	Algo   string
+	// Timestamp is the unix time in the block header
+	Timestamp int64
+	// Found is the number of seconds after the start the block was found at, which differs from its timestamp if it
+	// was warped or had to be later than the median time past
+	Found float64
+	// Interval is the number of seconds between the timestamp of the block and the one before it
+	Interval int64
+	// AlgoInterval is the number of seconds between the timestamp of the block and the one before it with the same
+	// algorithm, 0 for the first block of the algorithm
+	AlgoInterval int64
+	Warped       bool
+	// Bits are the targets of each algorithm the block could have been mined at
+	Bits map[string]uint32
+}
+
+// Algos returns the names of the Plan 9 algorithms in order of block version
+func Algos() (algos []string) {
+	for _, a := range fork.AlgoSlices[1] {
+		algos = append(algos, a.Name)
+	}
+	return
+}
+
+// Height returns the height the Plan 9 hard fork activates at, that the simulated blocks are built on
+func Height() int32 {
+	if fork.IsTestnet {
+		return fork.List[1].TestnetStart
+	}
+	return fork.List[1].ActivationHeight
+}
+
+// limit is the easiest target of the Plan 9 algorithms, at which the difficulty is 1
+var limit = new(big.Float).SetInt(fork.CompactToBig(fork.SecondPowLimitBits))
+
+// Difficulty returns how many times harder than the minimum difficulty a target is
+func Difficulty(bits uint32) float64 {
+	target := fork.CompactToBig(bits)
+	if target.Sign() <= 0 {
+		return math.Inf(1)
+	}
+	d, _ := new(big.Float).Quo(limit, new(big.Float).SetInt(target)).Float64()
+	return d
+}
+
+// Run mines the blocks of a scenario. Blocks are found at random with each algorithm at the rate its hash rate and
+// target give, and their timestamps are the time they were found except where a timewarp changes them, and they are
+// always after the median time past and not further in the future than the chain accepts. Run stops with an error if
+// the hash rate or targets of all the algorithms fall to zero, as no more blocks could be found, and returns the blocks
+// mined until then.
+func Run(s *Scenario) (blocks []Block, err error) {
+	if err = s.Validate(); Check(err) {
+		return
+	}
+	params := &netparams.MainNetParams
+	if fork.IsTestnet {
+		params = &netparams.TestNet3Params
+	}
+	chain := blockchain.NewSyntheticChain(params)
+	start := s.Start
+	if start == 0 {
+		start = DefaultStart
+	}
+	algos := Algos()
+	rng := rand.New(rand.NewSource(s.Seed))
+	tip := blockchain.NewRootBlockNode(
+		&wire.BlockHeader{
+			Version:   fork.P9Algos[algos[0]].Version,
+			Timestamp: time.Unix(start, 0),
+			Bits:      fork.SecondPowLimitBits,
+		}, Height(),
+	)
+	height := Height()
+	var now float64
+	lastStamp := start
+	lastAlgo := make(map[string]int64)
+	for i := int32(0); i < s.Blocks; i++ {
+		bits := make(map[string]uint32, len(algos))
+		rates := make([]float64, len(algos))
+		var total float64
+		for j, algo := range algos {
+			if bits[algo], _, err = chain.CalcNextRequiredDifficultyPlan9(tip, algo, false); Check(err) {
+				return
+			}
+			rates[j] = s.rate(i, algo, int64(now)) /
+				(float64(fork.P9Algos[algo].VersionInterval) * Difficulty(bits[algo]))
+			total += rates[j]
+		}
+		if total <= 0 {
+			err = fmt.Errorf("%w: scenario %q after height %d", ErrStalled, s.Name, height)
+			return
+		}
+		now += rng.ExpFloat64() / total
+		pick := rng.Float64() * total
+		j := 0
+		for ; j < len(algos)-1 && pick >= rates[j]; j++ {
+			pick -= rates[j]
+		}
+		algo := algos[j]
+		b := Block{Height: height + 1, Algo: algo, Found: now, Bits: bits}
+		b.Timestamp, b.Warped = s.timestamp(i, algo, start+int64(now), tip.CalcPastMedianTime().Unix(), rng)
+		// a block can't be more than the limit ahead of the time it was found, so if the median time past is too far
+		// ahead it is found later, when the limit allows it
+		if found := b.Timestamp - blockchain.MaxTimeOffsetSeconds; found > start+int64(now) {
+			now = float64(found - start)
+			b.Found = now
+		}
+		b.Interval = b.Timestamp - lastStamp
+		if last, ok := lastAlgo[algo]; ok {
+			b.AlgoInterval = b.Timestamp - last
+		}
+		lastStamp, lastAlgo[algo] = b.Timestamp, b.Timestamp
+		parent := tip.Header()
+		tip = blockchain.NewBlockNode(
+			&wire.BlockHeader{
+				Version:   fork.P9Algos[algo].Version,
+				PrevBlock: parent.BlockHash(),
+				Timestamp: time.Unix(b.Timestamp, 0),
+				Bits:      bits[algo],
+				Nonce:     uint32(i),
+			}, tip,
+		)
+		height++
+		blocks = append(blocks, b)
+	}
+	return
+}
+
+// rate returns the hash rate of an algorithm for the block at index i found at a number of seconds after the start
+func (s *Scenario) rate(i int32, algo string, at int64) (rate float64) {
+	rate = s.Hashrate[algo].Rate(at)
+	for _, e := range s.Events {
+		if !e.covers(i, algo) {
+			continue
+		}
+		switch e.Kind {
+		case Dark:
+			return 0
+		case HashAndLeave:
+			rate += s.Hashrate[algo].Rate(at) * e.Factor
+		}
+	}
+	return
+}
+
+// timestamp returns the timestamp a block at index i found at a unix time is given, and whether it was warped
+func (s *Scenario) timestamp(i int32, algo string, found, medianTime int64, rng *rand.Rand) (stamp int64, warped bool) {
+	stamp = found
+	for _, e := range s.Events {
+		if e.Kind != Timewarp || !e.covers(i, algo) {
+			continue
+		}
+		if e.Factor == 0 || rng.Float64() < e.Factor {
+			stamp, warped = found+e.Offset, true
+			if max := found + blockchain.MaxTimeOffsetSeconds; stamp > max {
+				stamp = max
+			}
+		}
+		break
+	}
+	if stamp <= medianTime {
+		stamp = medianTime + 1
+	}
+	return
+}
+
+// Header returns the names of the columns of the CSV output for the algorithms
+func Header(algos []string) (h []string) {
+	h = []string{"height", "algo", "timestamp", "found", "interval", "algointerval", "warped"}
+	for _, algo := range algos {
+		h = append(h, algo+"_bits", algo+"_difficulty")
+	}
+	return
+}
+
+// WriteCSV writes the block times and the targets of every algorithm of simulated blocks as CSV
+func WriteCSV(w io.Writer, blocks []Block) (err error) {
+	algos := Algos()
+	cw := csv.NewWriter(w)
+	if err = cw.Write(Header(algos)); Check(err) {
+		return
+	}
+	for _, b := range blocks {
+		row := []string{
+			strconv.Itoa(int(b.Height)),
+			b.Algo,
+			strconv.FormatInt(b.Timestamp, 10),
+			strconv.FormatFloat(b.Found, 'f', 3, 64),
+			strconv.FormatInt(b.Interval, 10),
+			strconv.FormatInt(b.AlgoInterval, 10),
+			strconv.FormatBool(b.Warped),
+		}
+		for _, algo := range algos {
+			row = append(row,
+				fmt.Sprintf("%08x", b.Bits[algo]),
+				strconv.FormatFloat(Difficulty(b.Bits[algo]), 'g', 8, 64),
+			)
+		}
+		if err = cw.Write(row); Check(err) {
+			return
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ErrStalled is returned by Run when no more blocks can be found
+var ErrStalled = errors.New("chain stalled")
+
+// ErrUnknownScenario is returned by Builtin for a name that is not one of the Scenarios
+var ErrUnknownScenario = errors.New("unknown scenario")
+
+// Builtin returns a copy of a built in scenario
+func Builtin(name string) (s *Scenario, err error) {
+	for _, sc := range Scenarios() {
+		if sc.Name == name {
+			return sc, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownScenario, name)
+}
+
+// steady is a hash rate that finds blocks of every algorithm at its target interval at the minimum difficulty, times
+// the multiple
+func steady(multiple float64) map[string]Curve {
+	h := make(map[string]Curve)
+	for _, algo := range Algos() {
+		h[algo] = Curve{{Rate: multiple}}
+	}
+	return h
+}
+
+// Scenarios returns the built in scenarios, in order of name
+func Scenarios() (s []*Scenario) {
+	first := Algos()[0]
+	growth := steady(1)
+	for algo := range growth {
+		growth[algo] = Curve{{At: 0, Rate: 1}, {At: 86400, Rate: 100}}
+	}
+	s = []*Scenario{
+		{
+			Name:        "steady",
+			Description: "the same hash rate throughout, at the minimum difficulty",
+			Blocks:      2000, Seed: 1, Hashrate: steady(1),
+		},
+		{
+			Name:        "growth",
+			Description: "the hash rate of every algorithm grows a hundred times over a day",
+			Blocks:      2000, Seed: 1, Hashrate: growth,
+		},
+		{
+			Name:        "timewarp",
+			Description: "a third of the blocks are timestamped an hour in the future for a while",
+			Blocks:      2000, Seed: 1, Hashrate: steady(10),
+			Events:      []Event{{Kind: Timewarp, Start: 500, End: 1500, Factor: 1.0 / 3, Offset: 3600}},
+		},
+		{
+			Name:        "hashandleave",
+			Description: "ten times the hash rate of " + first + " arrives and leaves again",
+			Blocks:      2000, Seed: 1, Hashrate: steady(10),
+			Events:      []Event{{Kind: HashAndLeave, Algo: first, Start: 500, End: 1000, Factor: 10}},
+		},
+		{
+			Name:        "dark",
+			Description: first + " is not mined for a while",
+			Blocks:      2000, Seed: 1, Hashrate: steady(10),
+			Events:      []Event{{Kind: Dark, Algo: first, Start: 500, End: 1500}},
+		},
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Name < s[j].Name })
+	return
+}
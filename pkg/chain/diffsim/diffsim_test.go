@@ -0,0 +1,127 @@
+package diffsim
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestCurve checks hash rates are interpolated between the points of a curve and held before and after them.
+func TestCurve(t *testing.T) {
+	c := Curve{{At: 100, Rate: 1}, {At: 200, Rate: 3}}
+	for at, want := range map[int64]float64{0: 1, 100: 1, 150: 2, 200: 3, 1000: 3} {
+		if got := c.Rate(at); got != want {
+			t.Errorf("rate at %d is %v, want %v", at, got, want)
+		}
+	}
+}
+
+// TestLoad checks scenarios are read from JSON and invalid ones are refused.
+func TestLoad(t *testing.T) {
+	algo := Algos()[0]
+	s, err := Load(strings.NewReader(`{"name":"test","blocks":10,"hashrate":{"` + algo +
+		`":[{"at":0,"rate":1}]},"events":[{"kind":"dark","algo":"` + algo + `","start":2,"end":4}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Blocks != 10 || len(s.Hashrate[algo]) != 1 || len(s.Events) != 1 {
+		t.Errorf("scenario not loaded: %+v", s)
+	}
+	for _, bad := range []string{
+		`{"name":"none","blocks":10}`,
+		`{"name":"algo","blocks":10,"hashrate":{"md5":[{"rate":1}]}}`,
+		`{"name":"order","blocks":10,"hashrate":{"` + algo + `":[{"at":10,"rate":1},{"at":5,"rate":1}]}}`,
+		`{"name":"kind","blocks":10,"hashrate":{"` + algo + `":[{"rate":1}]},"events":[{"kind":"x","start":0,"end":1}]}`,
+		`{"name":"dark","blocks":10,"hashrate":{"` + algo + `":[{"rate":1}]},"events":[{"kind":"dark","start":0,"end":1}]}`,
+	} {
+		if _, err = Load(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error loading %s", bad)
+		}
+	}
+}
+
+// median returns the median timestamp of the blocks
+func median(blocks []Block) int64 {
+	stamps := make([]int64, len(blocks))
+	for i := range blocks {
+		stamps[i] = blocks[i].Timestamp
+	}
+	sort.Slice(stamps, func(i, j int) bool { return stamps[i] < stamps[j] })
+	return stamps[len(stamps)/2]
+}
+
+// TestScenarios runs the built in scenarios with fewer blocks and checks the output is the same for the same seed, and
+// that the events do what they are meant to.
+func TestScenarios(t *testing.T) {
+	for _, s := range Scenarios() {
+		s.Blocks = 1200
+		a, err := Run(s)
+		// honest blocks after warped ones have negative intervals, which can take the averages of the difficulty
+		// adjustment below zero and stall the chain
+		if err != nil && !(s.Name == "timewarp" && errors.Is(err, ErrStalled)) {
+			t.Fatalf("%s: %v", s.Name, err)
+		}
+		b, _ := Run(s)
+		var ca, cb bytes.Buffer
+		if err = WriteCSV(&ca, a); err != nil {
+			t.Fatal(err)
+		}
+		if err = WriteCSV(&cb, b); err != nil {
+			t.Fatal(err)
+		}
+		if ca.String() != cb.String() {
+			t.Errorf("%s: runs with the same seed differ", s.Name)
+		}
+		rows, err := csv.NewReader(&ca).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != len(a)+1 || len(rows[0]) != len(Header(Algos())) {
+			t.Errorf("%s: got %d rows of %d columns", s.Name, len(rows), len(rows[0]))
+		}
+		for i, bl := range a {
+			if bl.Height != Height()+int32(i)+1 {
+				t.Fatalf("%s: block %d has height %d", s.Name, i, bl.Height)
+			}
+			if i >= 11 && bl.Timestamp <= median(a[i-11:i]) {
+				t.Errorf("%s: block %d timestamp %d is not after the median time past", s.Name, i, bl.Timestamp)
+			}
+			for _, e := range s.Events {
+				if !e.covers(int32(i), bl.Algo) {
+					continue
+				}
+				switch e.Kind {
+				case Dark:
+					t.Errorf("%s: block %d was mined with %s while it was dark", s.Name, i, bl.Algo)
+				case Timewarp:
+					if bl.Warped && bl.Timestamp <= int64(bl.Found)+DefaultStart {
+						t.Errorf("%s: warped block %d is not in the future", s.Name, i)
+					}
+				}
+			}
+		}
+		if s.Name == "hashandleave" {
+			e := s.Events[0]
+			if before, during := Difficulty(a[e.Start].Bits[e.Algo]), Difficulty(a[e.End-1].Bits[e.Algo]); during < before*2 {
+				t.Errorf("%s: difficulty of %s went from %v to %v while the hash rate was raised", s.Name, e.Algo, before,
+					during)
+			}
+		}
+	}
+}
+
+// TestStalled checks a run stops with an error when there is no hash rate left.
+func TestStalled(t *testing.T) {
+	algo := Algos()[0]
+	s := &Scenario{Name: "stalled", Blocks: 20, Hashrate: map[string]Curve{algo: {{At: 0, Rate: 1}, {At: 1, Rate: 0}}}}
+	blocks, err := Run(s)
+	if !errors.Is(err, ErrStalled) {
+		t.Fatalf("expected the chain to stall, got %v", err)
+	}
+	if len(blocks) >= int(s.Blocks) {
+		t.Errorf("mined %d blocks without hash rate", len(blocks))
+	}
+}
@@ -0,0 +1,410 @@
+package hardfork
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// ScheduleVersion is the version of the fork schedule file format
+const ScheduleVersion = 1
+
+// ErrMainnetSchedule is returned when a fork schedule is loaded for mainnet, which always uses the compiled in one
+var ErrMainnetSchedule = errors.New("the mainnet fork schedule can't be overridden")
+
+// ScheduleAlgo is an algorithm of a hard fork. The hash functions are compiled in, so only the minimum difficulty of an
+// algorithm can be changed, the rest identifies it.
+type ScheduleAlgo struct {
+	Name            string `json:"name"`
+	Version         int32  `json:"version"`
+	MinBits         string `json:"minbits"`
+	AlgoID          uint32 `json:"algoid"`
+	VersionInterval int    `json:"versioninterval"`
+}
+
+// ScheduleFork is a hard fork and when it activates
+type ScheduleFork struct {
+	Number             uint32         `json:"number"`
+	Name               string         `json:"name"`
+	ActivationHeight   int32          `json:"activationheight"`
+	TargetTimePerBlock int32          `json:"targettimeperblock"`
+	AveragingInterval  int32          `json:"averaginginterval"`
+	Algos              []ScheduleAlgo `json:"algos"`
+}
+
+// SchedulePayee is an address paid by the disbursement on the activation of the Plan 9 hard fork, with the amount in DUO
+type SchedulePayee struct {
+	Address string  `json:"address"`
+	Amount  float64 `json:"amount"`
+}
+
+// Schedule is the hard forks, disbursement and blacklist of a network. The checksum is the hex of the sha256 hash of
+// the JSON encoding of the schedule with an empty checksum, so that accidental changes to a file are caught.
+type Schedule struct {
+	Version     int             `json:"version"`
+	Network     string          `json:"network"`
+	Forks       []ScheduleFork  `json:"forks"`
+	Payees      []SchedulePayee `json:"payees"`
+	CorePubkeys []string        `json:"corepubkeys"`
+	CoreAmount  float64         `json:"coreamount"`
+	Blacklist   []string        `json:"blacklist"`
+	Checksum    string          `json:"checksum"`
+}
+
+// ScheduleFile is the file the active fork schedule was loaded from, empty if it is compiled in
+var ScheduleFile string
+
+var (
+	// loadOnce makes the fork schedule file load only when pod starts, as the chain reads the tables it replaces
+	// without locking
+	loadOnce sync.Once
+	loadErr  error
+)
+
+// tables are the hard fork heights and settings, disbursement and blacklist that a schedule replaces
+type tables struct {
+	forks             []fork.HardForks
+	p9AlgosNumeric    map[int32]fork.AlgoParams
+	payees            []Payee
+	testnetPayees     []Payee
+	coreKeys          [][]byte
+	testnetCoreKeys   [][]byte
+	coreAmount        util.Amount
+	testnetCoreAmount util.Amount
+	blacklist         []util.Address
+}
+
+// compiled is a copy of the compiled in tables, taken before any schedule is applied, that a schedule is applied on
+// top of
+var compiled = compiledTables()
+
+// compiledTables copies the tables a schedule replaces, including the algorithm maps that are changed in place
+func compiledTables() (t tables) {
+	for _, f := range fork.List {
+		algos := make(map[string]fork.AlgoParams, len(f.Algos))
+		for name, p := range f.Algos {
+			algos[name] = p
+		}
+		f.Algos = algos
+		t.forks = append(t.forks, f)
+	}
+	t.p9AlgosNumeric = make(map[int32]fork.AlgoParams, len(fork.P9AlgosNumeric))
+	for v, p := range fork.P9AlgosNumeric {
+		t.p9AlgosNumeric[v] = p
+	}
+	t.payees, t.testnetPayees = Payees, TestnetPayees
+	t.coreKeys, t.testnetCoreKeys = CorePubkeyBytes, TestnetCorePubkeyBytes
+	t.coreAmount, t.testnetCoreAmount = CoreAmount, TestnetCoreAmount
+	t.blacklist = Blacklist
+	return
+}
+
+// restore puts the compiled in tables back in force. The algorithm maps are written in place, as they are shared with
+// the algorithm lookups of the fork package.
+func (t *tables) restore() {
+	for i, f := range t.forks {
+		hf := &fork.List[i]
+		hf.Name, hf.ActivationHeight, hf.TestnetStart = f.Name, f.ActivationHeight, f.TestnetStart
+		hf.TargetTimePerBlock, hf.AveragingInterval = f.TargetTimePerBlock, f.AveragingInterval
+		for name, p := range f.Algos {
+			hf.Algos[name] = p
+		}
+	}
+	for v, p := range t.p9AlgosNumeric {
+		fork.P9AlgosNumeric[v] = p
+	}
+	Payees, TestnetPayees = t.payees, t.testnetPayees
+	CorePubkeyBytes, TestnetCorePubkeyBytes = t.coreKeys, t.testnetCoreKeys
+	CoreAmount, TestnetCoreAmount = t.coreAmount, t.testnetCoreAmount
+	Blacklist = t.blacklist
+}
+
+// testnet returns true if the network uses the testnet activation heights and disbursement
+func testnet(params *netparams.Params) bool {
+	return params.Net == wire.TestNet3
+}
+
+// Active returns the fork schedule in force for the network
+func Active(params *netparams.Params) (s *Schedule) {
+	s = &Schedule{Version: ScheduleVersion, Network: params.Name, Blacklist: []string{}}
+	for i := range fork.List {
+		f := fork.List[i]
+		sf := ScheduleFork{
+			Number:             f.Number,
+			Name:               f.Name,
+			ActivationHeight:   f.ActivationHeight,
+			TargetTimePerBlock: f.TargetTimePerBlock,
+			AveragingInterval:  f.AveragingInterval,
+		}
+		if testnet(params) {
+			sf.ActivationHeight = f.TestnetStart
+		}
+		for _, a := range fork.AlgoSlices[i] {
+			p := f.Algos[a.Name]
+			sf.Algos = append(sf.Algos, ScheduleAlgo{
+				Name:            a.Name,
+				Version:         p.Version,
+				MinBits:         fmt.Sprintf("%08x", p.MinBits),
+				AlgoID:          p.AlgoID,
+				VersionInterval: p.VersionInterval,
+			})
+		}
+		s.Forks = append(s.Forks, sf)
+	}
+	payees, keys, amount := Payees, CorePubkeyBytes, CoreAmount
+	if testnet(params) {
+		payees, keys, amount = TestnetPayees, TestnetCorePubkeyBytes, TestnetCoreAmount
+	}
+	for _, p := range payees {
+		s.Payees = append(s.Payees, SchedulePayee{Address: p.Address.EncodeAddress(), Amount: p.Amount.ToDUO()})
+	}
+	for _, k := range keys {
+		s.CorePubkeys = append(s.CorePubkeys, hex.EncodeToString(k))
+	}
+	s.CoreAmount = amount.ToDUO()
+	for _, a := range Blacklist {
+		s.Blacklist = append(s.Blacklist, a.EncodeAddress())
+	}
+	s.Seal()
+	return
+}
+
+// Sum returns the checksum of the schedule
+func (s *Schedule) Sum() string {
+	c := *s
+	c.Checksum = ""
+	b, err := json.Marshal(&c)
+	if err != nil {
+		Error(err)
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Seal sets the checksum of the schedule after it has been changed
+func (s *Schedule) Seal() {
+	s.Checksum = s.Sum()
+}
+
+// Validate checks the schedule is of a known version, for the network, intact, and consistent with the compiled in
+// hard forks and algorithms
+func (s *Schedule) Validate(params *netparams.Params) (err error) {
+	if s.Version != ScheduleVersion {
+		return fmt.Errorf("fork schedule version %d is not supported, expected %d", s.Version, ScheduleVersion)
+	}
+	if s.Network != params.Name {
+		return fmt.Errorf("fork schedule is for network %q, not %q", s.Network, params.Name)
+	}
+	if sum := s.Sum(); s.Checksum != sum {
+		return fmt.Errorf("fork schedule checksum %q does not match its contents, expected %q", s.Checksum, sum)
+	}
+	if len(s.Forks) != len(fork.List) {
+		return fmt.Errorf("fork schedule has %d hard forks, expected %d", len(s.Forks), len(fork.List))
+	}
+	for i, f := range s.Forks {
+		if f.Number != uint32(i) {
+			return fmt.Errorf("hard fork %d is numbered %d", i, f.Number)
+		}
+		if i == 0 && f.ActivationHeight != 0 {
+			return fmt.Errorf("hard fork 0 activates at height %d, expected 0", f.ActivationHeight)
+		}
+		if i > 0 && f.ActivationHeight <= s.Forks[i-1].ActivationHeight {
+			return fmt.Errorf("hard fork %d activates at height %d, not after hard fork %d", i, f.ActivationHeight, i-1)
+		}
+		if f.TargetTimePerBlock < 1 || f.AveragingInterval < 1 {
+			return fmt.Errorf("hard fork %d target time per block and averaging interval must be positive", i)
+		}
+		if len(f.Algos) != len(fork.List[i].Algos) {
+			return fmt.Errorf("hard fork %d has %d algorithms, expected %d", i, len(f.Algos), len(fork.List[i].Algos))
+		}
+		for _, a := range f.Algos {
+			p, ok := fork.List[i].Algos[a.Name]
+			if !ok || p.Version != a.Version || p.AlgoID != a.AlgoID || p.VersionInterval != a.VersionInterval {
+				return fmt.Errorf("hard fork %d algorithm %q is not one of the compiled in algorithms", i, a.Name)
+			}
+			var bits uint32
+			if bits, err = parseBits(a.MinBits); err != nil {
+				return fmt.Errorf("hard fork %d algorithm %q minimum bits: %v", i, a.Name, err)
+			}
+			if fork.CompactToBig(bits).Sign() <= 0 {
+				return fmt.Errorf("hard fork %d algorithm %q minimum bits %s is not a valid target", i, a.Name, a.MinBits)
+			}
+		}
+	}
+	for _, p := range s.Payees {
+		if _, err = util.DecodeAddress(p.Address, params); err != nil {
+			return fmt.Errorf("payee address %q: %v", p.Address, err)
+		}
+		if _, err = util.NewAmount(p.Amount); err != nil || p.Amount <= 0 {
+			return fmt.Errorf("payee %q amount %v is not valid", p.Address, p.Amount)
+		}
+	}
+	// the disbursement pays the core amount to a 3 of 4 multisig
+	if len(s.CorePubkeys) != 4 {
+		return fmt.Errorf("fork schedule has %d core public keys, expected 4", len(s.CorePubkeys))
+	}
+	for _, k := range s.CorePubkeys {
+		var b []byte
+		if b, err = hex.DecodeString(k); err != nil || len(b) != 33 {
+			return fmt.Errorf("core public key %q is not a compressed public key", k)
+		}
+	}
+	if _, err = util.NewAmount(s.CoreAmount); err != nil || s.CoreAmount <= 0 {
+		return fmt.Errorf("core amount %v is not valid", s.CoreAmount)
+	}
+	for _, a := range s.Blacklist {
+		if _, err = util.DecodeAddress(a, params); err != nil {
+			return fmt.Errorf("blacklisted address %q: %v", a, err)
+		}
+	}
+	return nil
+}
+
+// parseBits parses compact target bits in hex
+func parseBits(s string) (bits uint32, err error) {
+	var b uint64
+	if b, err = strconv.ParseUint(s, 16, 32); err != nil {
+		return
+	}
+	return uint32(b), nil
+}
+
+// LoadSchedule reads a fork schedule in JSON format and validates it for the network
+func LoadSchedule(r io.Reader, params *netparams.Params) (s *Schedule, err error) {
+	s = &Schedule{}
+	if err = json.NewDecoder(r).Decode(s); err != nil {
+		return nil, fmt.Errorf("reading fork schedule: %v", err)
+	}
+	if err = s.Validate(params); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// LoadScheduleFile reads a fork schedule from a file, validates it and puts it in force. It refuses to load a schedule
+// for mainnet. Only the first call loads the file, later ones return its result, as the schedule can only be changed by
+// restarting.
+func LoadScheduleFile(filename string, params *netparams.Params) (err error) {
+	if params.Net == wire.MainNet {
+		return ErrMainnetSchedule
+	}
+	loadOnce.Do(
+		func() {
+			loadErr = loadScheduleFile(filename, params)
+		},
+	)
+	if loadErr == nil && ScheduleFile != filename {
+		return fmt.Errorf("fork schedule %s is in force, %s is used after restarting", ScheduleFile, filename)
+	}
+	return loadErr
+}
+
+// loadScheduleFile reads, validates and applies a fork schedule file
+func loadScheduleFile(filename string, params *netparams.Params) (err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer func() {
+		if err := f.Close(); Check(err) {
+		}
+	}()
+	var s *Schedule
+	if s, err = LoadSchedule(f, params); err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	if err = s.Apply(params); err != nil {
+		return
+	}
+	ScheduleFile = filename
+	return
+}
+
+// Apply puts a validated schedule in force, replacing the compiled in hard fork heights and settings, disbursement and
+// blacklist. The schedule is applied on top of the compiled in tables, so a schedule applied before it leaves nothing
+// behind.
+func (s *Schedule) Apply(params *netparams.Params) (err error) {
+	if params.Net == wire.MainNet {
+		return ErrMainnetSchedule
+	}
+	var payees []Payee
+	for _, p := range s.Payees {
+		var pay Payee
+		if pay.Address, err = util.DecodeAddress(p.Address, params); err != nil {
+			return
+		}
+		if pay.Amount, err = util.NewAmount(p.Amount); err != nil {
+			return
+		}
+		payees = append(payees, pay)
+	}
+	var keys [][]byte
+	for _, k := range s.CorePubkeys {
+		var b []byte
+		if b, err = hex.DecodeString(k); err != nil {
+			return
+		}
+		keys = append(keys, b)
+	}
+	var amount util.Amount
+	if amount, err = util.NewAmount(s.CoreAmount); err != nil {
+		return
+	}
+	blacklist := []util.Address{}
+	for _, a := range s.Blacklist {
+		var addr util.Address
+		if addr, err = util.DecodeAddress(a, params); err != nil {
+			return
+		}
+		blacklist = append(blacklist, addr)
+	}
+	minBits := make([][]uint32, len(s.Forks))
+	for i, f := range s.Forks {
+		for _, a := range f.Algos {
+			var bits uint32
+			if bits, err = parseBits(a.MinBits); err != nil {
+				return
+			}
+			minBits[i] = append(minBits[i], bits)
+		}
+	}
+	compiled.restore()
+	for i, f := range s.Forks {
+		hf := &fork.List[i]
+		hf.Name, hf.TargetTimePerBlock, hf.AveragingInterval = f.Name, f.TargetTimePerBlock, f.AveragingInterval
+		if testnet(params) {
+			hf.TestnetStart = f.ActivationHeight
+		} else {
+			hf.ActivationHeight = f.ActivationHeight
+		}
+		for j, a := range f.Algos {
+			p := hf.Algos[a.Name]
+			p.MinBits = minBits[i][j]
+			hf.Algos[a.Name] = p
+			// the Plan 9 algorithms are also looked up by version
+			if _, ok := fork.P9AlgosNumeric[p.Version]; ok && i == 1 {
+				fork.P9AlgosNumeric[p.Version] = p
+			}
+		}
+	}
+	if testnet(params) {
+		TestnetPayees, TestnetCorePubkeyBytes, TestnetCoreAmount = payees, keys, amount
+	} else {
+		Payees, CorePubkeyBytes, CoreAmount = payees, keys, amount
+	}
+	Blacklist = blacklist
+	Info("fork schedule", s.Checksum, "in force for", params.Name)
+	return
+}
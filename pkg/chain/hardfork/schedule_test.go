@@ -0,0 +1,107 @@
+package hardfork
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
+)
+
+// TestSchedule checks the compiled in testnet schedule survives a round trip through a file, that damaged and invalid
+// schedules are refused, and that a changed schedule is put in force on networks other than mainnet.
+func TestSchedule(t *testing.T) {
+	tn := &netparams.TestNet3Params
+	s := Active(tn)
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSchedule(bytes.NewReader(b), tn)
+	if err != nil {
+		t.Fatalf("compiled in schedule does not load: %v", err)
+	}
+	if loaded.Checksum != s.Checksum {
+		t.Errorf("checksum changed from %s to %s", s.Checksum, loaded.Checksum)
+	}
+	if _, err = LoadSchedule(bytes.NewReader(b), &netparams.SimNetParams); err == nil {
+		t.Errorf("expected a testnet schedule to be refused on simnet")
+	}
+	// an edit without updating the checksum is caught
+	loaded.Forks[1].ActivationHeight = 100
+	if err = loaded.Validate(tn); err == nil {
+		t.Errorf("expected a checksum error")
+	}
+	for name, change := range map[string]func(s *Schedule){
+		"order":   func(s *Schedule) { s.Forks[1].ActivationHeight = -1 },
+		"algo":    func(s *Schedule) { s.Forks[1].Algos[0].Version = 99 },
+		"bits":    func(s *Schedule) { s.Forks[1].Algos[0].MinBits = "nope" },
+		"payee":   func(s *Schedule) { s.Payees[0].Address = "nope" },
+		"keys":    func(s *Schedule) { s.CorePubkeys = s.CorePubkeys[:3] },
+		"version": func(s *Schedule) { s.Version = ScheduleVersion + 1 },
+	} {
+		bad := Active(tn)
+		change(bad)
+		bad.Seal()
+		if err = bad.Validate(tn); err == nil {
+			t.Errorf("%s: expected an invalid schedule to be refused", name)
+		}
+	}
+	// a changed schedule is put in force, and shows as the active one
+	defer func() {
+		compiled.restore()
+		loadOnce, loadErr, ScheduleFile = sync.Once{}, nil, ""
+	}()
+	changed := Active(tn)
+	changed.Forks[1].ActivationHeight = 1000
+	changed.Payees = changed.Payees[:1]
+	changed.Seal()
+	dir, err := ioutil.TempDir("", "forkschedule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "forks.json")
+	if b, err = json.Marshal(changed); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filename, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = LoadScheduleFile(filename, &netparams.MainNetParams); err != ErrMainnetSchedule {
+		t.Errorf("expected the mainnet schedule to be fixed, got %v", err)
+	}
+	if err = LoadScheduleFile(filename, tn); err != nil {
+		t.Fatal(err)
+	}
+	if fork.List[1].TestnetStart != 1000 || len(TestnetPayees) != 1 || ScheduleFile != filename {
+		t.Errorf("schedule not in force: activation height %d with %d payees", fork.List[1].TestnetStart,
+			len(TestnetPayees))
+	}
+	if a := Active(tn); a.Checksum != changed.Checksum {
+		t.Errorf("active schedule checksum %s, want %s", a.Checksum, changed.Checksum)
+	}
+	// the schedule is only loaded once, and applying another is done on top of the compiled in tables
+	if err = LoadScheduleFile(filename+".other", tn); err == nil {
+		t.Errorf("expected a second schedule file to be refused")
+	}
+	other := Active(tn)
+	other.Forks[1].ActivationHeight = 2000
+	other.Payees = s.Payees
+	other.Seal()
+	if err = other.Apply(tn); err != nil {
+		t.Fatal(err)
+	}
+	if a := Active(tn); a.Checksum != other.Checksum || len(TestnetPayees) != len(s.Payees) {
+		t.Errorf("schedule applied over the one in force, checksum %s, want %s", a.Checksum, other.Checksum)
+	}
+	compiled.restore()
+	if a := Active(tn); a.Checksum != s.Checksum {
+		t.Errorf("compiled in schedule not restored, checksum %s, want %s", a.Checksum, s.Checksum)
+	}
+}
@@ -20,8 +20,8 @@ const (
 	cfIndexName = "committed filter index"
 )
 
-// Committed filters come in one flavor currently: basic. They are generated and dropped in pairs, and both are indexed
-// by a block's hash. Besides holding different content, they also live in different buckets.
+// Committed filters come in two flavors: basic and extended. They are generated and dropped together, and both are
+// indexed by a block's hash. Besides holding different content, they also live in different buckets.
 var (
 	// cfIndexParentBucketKey is the name of the parent bucket used to house the index. The rest of the buckets live
 	// below this bucket.
@@ -29,14 +29,17 @@ var (
 	// cfIndexKeys is an array of db bucket names used to house indexes of block hashes to cfilters.
 	cfIndexKeys = [][]byte{
 		[]byte("cf0byhashidx"),
+		[]byte("cf1byhashidx"),
 	}
 	// cfHeaderKeys is an array of db bucket names used to house indexes of block hashes to cf headers.
 	cfHeaderKeys = [][]byte{
 		[]byte("cf0headerbyhashidx"),
+		[]byte("cf1headerbyhashidx"),
 	}
 	// cfHashKeys is an array of db bucket names used to house indexes of block hashes to cf hashes.
 	cfHashKeys = [][]byte{
 		[]byte("cf0hashbyhashidx"),
+		[]byte("cf1hashbyhashidx"),
 	}
 	maxFilterType = uint8(len(cfHeaderKeys) - 1)
 	// zeroHash is the chainhash.Hash value of all zero bytes, defined here for convenience.
@@ -80,9 +83,31 @@ func (idx *CFIndex) NeedsInputs() bool {
 	return true
 }
 
-// Init initializes the hash-based cf index. This is part of the Indexer interface.
+// Init initializes the hash-based cf index. An index created before the extended filters were added is missing their
+// buckets, so they are created and the index tip is reset, which makes the index manager rebuild both filter types from
+// the genesis block, as the filter headers of each type form a chain. This is part of the Indexer interface.
 func (idx *CFIndex) Init() error {
-	return nil // Nothing to do.
+	return idx.db.Update(func(dbTx database.Tx) error {
+		cfIndexParentBucket := dbTx.Metadata().Bucket(cfIndexParentBucketKey)
+		missing := false
+		for _, keys := range [][][]byte{cfIndexKeys, cfHeaderKeys, cfHashKeys} {
+			for _, bucketName := range keys {
+				if cfIndexParentBucket.Bucket(bucketName) != nil {
+					continue
+				}
+				missing = true
+				if _, err := cfIndexParentBucket.CreateBucket(bucketName); err != nil {
+					Error(err)
+					return err
+				}
+			}
+		}
+		if !missing {
+			return nil
+		}
+		Info("adding extended filters to the", cfIndexName+", it will be rebuilt from the genesis block")
+		return dbPutIndexerTip(dbTx, cfIndexParentBucketKey, &chainhash.Hash{}, -1)
+	})
 }
 
 // Key returns the database key to use for the index as a byte slice. This is part of the Indexer interface.
@@ -96,7 +121,7 @@ func (idx *CFIndex) Name() string {
 }
 
 // Create is invoked when the indexer manager determines the index needs to be created for the first time. It creates
-// buckets for the two hash-based cf indexes (regular only currently).
+// buckets for the two hash-based cf indexes (regular and extended).
 func (idx *CFIndex) Create(dbTx database.Tx) error {
 	meta := dbTx.Metadata()
 	cfIndexParentBucket, err := meta.CreateBucket(cfIndexParentBucketKey)
@@ -200,7 +225,17 @@ func (idx *CFIndex) ConnectBlock(dbTx database.Tx, block *util.Block,
 		Error(err)
 		return err
 	}
-	return storeFilter(dbTx, block, f, wire.GCSFilterRegular)
+	err = storeFilter(dbTx, block, f, wire.GCSFilterRegular)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	f, err = builder.BuildExtFilter(block.MsgBlock())
+	if err != nil {
+		Error(err)
+		return err
+	}
+	return storeFilter(dbTx, block, f, wire.GCSFilterExtended)
 }
 
 // DisconnectBlock is invoked by the index manager when a block has been disconnected from the main chain. This indexer
@@ -271,40 +306,40 @@ func (idx *CFIndex) entriesByBlockHashes(filterTypeKeys [][]byte,
 	return entries, err
 }
 
-// FilterByBlockHash returns the serialized contents of a block's basic or committed filter.
+// FilterByBlockHash returns the serialized contents of a block's basic or extended committed filter.
 func (idx *CFIndex) FilterByBlockHash(h *chainhash.Hash,
 	filterType wire.FilterType) ([]byte, error) {
 	return idx.entryByBlockHash(cfIndexKeys, filterType, h)
 }
 
-// FiltersByBlockHashes returns the serialized contents of a block's basic or committed filter for a set of blocks by
-// hash.
+// FiltersByBlockHashes returns the serialized contents of a block's basic or extended committed filter for a set of
+// blocks by hash.
 func (idx *CFIndex) FiltersByBlockHashes(blockHashes []*chainhash.Hash,
 	filterType wire.FilterType) ([][]byte, error) {
 	return idx.entriesByBlockHashes(cfIndexKeys, filterType, blockHashes)
 }
 
-// FilterHeaderByBlockHash returns the serialized contents of a block's basic committed filter header.
+// FilterHeaderByBlockHash returns the serialized contents of a block's basic or extended committed filter header.
 func (idx *CFIndex) FilterHeaderByBlockHash(h *chainhash.Hash,
 	filterType wire.FilterType) ([]byte, error) {
 	return idx.entryByBlockHash(cfHeaderKeys, filterType, h)
 }
 
-// FilterHeadersByBlockHashes returns the serialized contents of a block's basic committed filter header for a set of
-// blocks by hash.
+// FilterHeadersByBlockHashes returns the serialized contents of a block's basic or extended committed filter header
+// for a set of blocks by hash.
 func (idx *CFIndex) FilterHeadersByBlockHashes(blockHashes []*chainhash.Hash,
 	filterType wire.FilterType) ([][]byte, error) {
 	return idx.entriesByBlockHashes(cfHeaderKeys, filterType, blockHashes)
 }
 
-// FilterHashByBlockHash returns the serialized contents of a block's basic committed filter hash.
+// FilterHashByBlockHash returns the serialized contents of a block's basic or extended committed filter hash.
 func (idx *CFIndex) FilterHashByBlockHash(h *chainhash.Hash,
 	filterType wire.FilterType) ([]byte, error) {
 	return idx.entryByBlockHash(cfHashKeys, filterType, h)
 }
 
-// FilterHashesByBlockHashes returns the serialized contents of a block's basic committed filter hash for a set of
-// blocks by hash.
+// FilterHashesByBlockHashes returns the serialized contents of a block's basic or extended committed filter hash for a
+// set of blocks by hash.
 func (idx *CFIndex) FilterHashesByBlockHashes(blockHashes []*chainhash.Hash,
 	filterType wire.FilterType) ([][]byte, error) {
 	return idx.entriesByBlockHashes(cfHashKeys, filterType, blockHashes)
@@ -2,6 +2,7 @@ package indexers
 
 import (
 	"fmt"
+	"sync"
 	
 	blockchain "github.com/p9c/pod/pkg/chain"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
@@ -111,6 +112,19 @@ func dbIndexDisconnectBlock(dbTx database.Tx, indexer Indexer, block *util.Block
 type Manager struct {
 	db             database.DB
 	enabledIndexes []Indexer
+	// mtx protects the tip heights and sync state of the indexes, which are updated both by the chain and by the
+	// catch-up of indexes that are behind it. It is only ever acquired while holding the database write lock.
+	mtx     sync.RWMutex
+	heights []int32
+	synced  []bool
+}
+
+// IndexStatus is how far an index has got through the main chain.
+type IndexStatus struct {
+	Name   string
+	Height int32
+	// Synced is false while the index is catching up with the chain in the background, and it can't be used until then
+	Synced bool
 }
 
 // Ensure the Manager type implements the blockchain.IndexManager interface.
@@ -193,10 +207,11 @@ func (m *Manager) maybeCreateIndexes(dbTx database.Tx) error {
 	return nil
 }
 
-// Init initializes the enabled indexes. This is called during chain initialization and primarily consists of catching
-// up all indexes to the current best chain tip. This is necessary since each index can be disabled and re-enabled at
-// any time and attempting to catch-up indexes at the same time new blocks are being downloaded would lead to an overall
-// longer time to catch up due to the I/O contention. This is part of the blockchain.IndexManager interface.
+// Init initializes the enabled indexes. This is called during chain initialization and primarily consists of rolling
+// back indexes with tips on orphaned forks and starting to catch up indexes that are behind the current best chain tip,
+// which is necessary since each index can be disabled and re-enabled at any time. Catching up is done in the background
+// as it can take hours for a large index, and until an index has caught up it is marked as not synced and its entries
+// are incomplete. This is part of the blockchain.IndexManager interface.
 func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{}) error {
 	// Nothing to do when no indexes are enabled.
 	if len(m.enabledIndexes) == 0 {
@@ -345,65 +360,144 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 		Error(err)
 		return err
 	}
+	m.mtx.Lock()
+	m.heights = indexerHeights
+	m.synced = make([]bool, len(m.enabledIndexes))
+	for i := range m.enabledIndexes {
+		m.synced[i] = indexerHeights[i] == bestHeight
+	}
+	m.mtx.Unlock()
 	// Nothing to index if all of the indexes are caught up.
 	if lowestHeight == bestHeight {
 		return nil
 	}
-	// Create a progress logger for the indexing process below.
-	progressLogger := newBlockProgressLogger("Indexed",
-		log.L)
-	// At this point, one or more indexes are behind the current best chain tip and need to be caught up, so log the
-	// details and loop through each block that needs to be indexed.
+	// At this point, one or more indexes are behind the current best chain tip and need to be caught up, which carries
+	// on while the chain is in use.
 	Infof(
-		"catching up indexes from height %d to %d",
+		"catching up indexes from height %d to %d in the background",
 		lowestHeight,
 		bestHeight,
 	)
-	for height := lowestHeight + 1; height <= bestHeight; height++ {
-		// Load the block for the height since it is required to index it.
-		block, err := chain.BlockByHeight(height)
-		if err != nil {
-			Error(err)
-			return err
-		}
+	go m.catchUp(chain, interrupt)
+	return nil
+}
+
+// catchUp connects the blocks of the main chain to the indexes that are behind it until they reach the tip, when the
+// chain takes over keeping them up to date. Each block is loaded along with its spend journal and connected in a single
+// database transaction, which holds off the chain connecting or disconnecting blocks, so a reorganization can't remove
+// the block or its spend journal entry before it is indexed. As the chain disconnects blocks from an index that is
+// catching up once it has reached them, the tips of the indexes are always in the main chain.
+func (m *Manager) catchUp(chain *blockchain.BlockChain, interrupt <-chan struct{}) {
+	// Create a progress logger for the indexing process below.
+	progressLogger := newBlockProgressLogger("Indexed",
+		log.L)
+	for {
 		if interruptRequested(interrupt) {
-			return errInterruptRequested
+			Info("index catch up interrupted, it will resume on the next start")
+			return
 		}
-		// Connect the block for all indexes that need it.
-		var spentTxos []blockchain.SpentTxOut
-		for i, indexer := range m.enabledIndexes {
-			// Skip indexes that don't need to be updated with this block.
-			if indexerHeights[i] >= height {
-				continue
-			}
-			// When the index requires all of the referenced txouts and they haven't been loaded yet, they need to be
-			// retrieved from the spend journal.
-			if spentTxos == nil && indexNeedsInputs(indexer) {
-				spentTxos, err = chain.FetchSpendJournal(block)
-				if err != nil {
-					Error(err)
-					return err
+		var block *util.Block
+		var done bool
+		err := m.db.Update(func(dbTx database.Tx) error {
+			m.mtx.Lock()
+			defer m.mtx.Unlock()
+			// Find the lowest tip of the indexes that are still catching up.
+			height := int32(-1)
+			for i := range m.enabledIndexes {
+				if !m.synced[i] && (height == -1 || m.heights[i]+1 < height) {
+					height = m.heights[i] + 1
 				}
 			}
-			err := m.db.Update(func(dbTx database.Tx) error {
-				return dbIndexConnectBlock(
-					dbTx, indexer, block, spentTxos,
-				)
-			})
+			if height == -1 {
+				done = true
+				return nil
+			}
+			bestHash, bestHeight, err := blockchain.DBFetchBestState(dbTx)
 			if err != nil {
 				Error(err)
 				return err
 			}
-			indexerHeights[i] = height
+			// A reorganization to a shorter chain can leave the indexes at its tip, which makes them caught up.
+			if height > bestHeight {
+				for i, indexer := range m.enabledIndexes {
+					if !m.synced[i] && m.heights[i] == bestHeight {
+						m.synced[i] = true
+						Infof("%s caught up to height %d", indexer.Name(), bestHeight)
+					}
+				}
+				return nil
+			}
+			// Load the block for the height since it is required to index it.
+			if block, err = blockchain.DBFetchBlockByHeight(dbTx, height); err != nil {
+				return err
+			}
+			// When an index requires all of the referenced txouts they need to be retrieved from the spend journal.
+			var spentTxos []blockchain.SpentTxOut
+			for _, indexer := range m.enabledIndexes {
+				if indexNeedsInputs(indexer) {
+					if spentTxos, err = blockchain.DBFetchSpendJournalEntry(dbTx, block); err != nil {
+						return err
+					}
+					break
+				}
+			}
+			// Connect the block for all indexes that need it.
+			for i, indexer := range m.enabledIndexes {
+				if m.synced[i] || m.heights[i] != height-1 {
+					continue
+				}
+				if err := dbIndexConnectBlock(dbTx, indexer, block, spentTxos); err != nil {
+					return err
+				}
+				m.heights[i] = height
+				// The index is caught up once it reaches the tip, and from then on the chain connects blocks to it.
+				if block.Hash().IsEqual(bestHash) {
+					m.synced[i] = true
+					Infof("%s caught up to height %d", indexer.Name(), height)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if !interruptRequested(interrupt) {
+				Error("index catch up stopped:", err)
+			}
+			return
+		}
+		if done {
+			Info("indexes caught up to height", chain.BestSnapshot().Height)
+			return
 		}
 		// Log indexing progress.
-		progressLogger.LogBlockHeight(block)
-		if interruptRequested(interrupt) {
-			return errInterruptRequested
+		if block != nil {
+			progressLogger.LogBlockHeight(block)
 		}
 	}
-	Info("indexes caught up to height", bestHeight)
-	return nil
+}
+
+// Status returns how far each of the enabled indexes has got through the main chain.
+//
+// This function is safe for concurrent access.
+func (m *Manager) Status() []IndexStatus {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	status := make([]IndexStatus, 0, len(m.enabledIndexes))
+	for i, indexer := range m.enabledIndexes {
+		status = append(status, IndexStatus{Name: indexer.Name(), Height: m.heights[i], Synced: m.synced[i]})
+	}
+	return status
+}
+
+// IndexStatus returns how far the provided index has got through the main chain, and false if it is not enabled.
+//
+// This function is safe for concurrent access.
+func (m *Manager) IndexStatus(indexer Indexer) (IndexStatus, bool) {
+	for _, status := range m.Status() {
+		if status.Name == indexer.Name() {
+			return status, true
+		}
+	}
+	return IndexStatus{}, false
 }
 
 // indexNeedsInputs returns whether or not the index needs access to the txouts referenced by the transaction inputs
@@ -449,13 +543,30 @@ func indexNeedsInputs(index Indexer) bool {
 // interface.
 func (m *Manager) ConnectBlock(dbTx database.Tx, block *util.Block,
 	stxos []blockchain.SpentTxOut) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
 	// Call each of the currently active optional indexes with the block being connected so they can update accordingly.
-	for _, index := range m.enabledIndexes {
+	for i, index := range m.enabledIndexes {
+		// An index that is catching up is left to the catch up unless it has reached the previous block, in which case
+		// it is caught up and the chain takes over from here.
+		if !m.synced[i] {
+			tipHash, _, err := dbFetchIndexerTip(dbTx, index.Key())
+			if err != nil {
+				Error(err)
+				return err
+			}
+			if !tipHash.IsEqual(&block.MsgBlock().Header.PrevBlock) {
+				continue
+			}
+			m.synced[i] = true
+			Infof("%s caught up to height %d", index.Name(), block.Height())
+		}
 		err := dbIndexConnectBlock(dbTx, index, block, stxos)
 		if err != nil {
 			Error(err)
 			return err
 		}
+		m.heights[i] = block.Height()
 	}
 	return nil
 }
@@ -465,14 +576,29 @@ func (m *Manager) ConnectBlock(dbTx database.Tx, block *util.Block,
 // entries associated with the block. This is part of the blockchain.IndexManager interface.
 func (m *Manager) DisconnectBlock(dbTx database.Tx, block *util.Block,
 	stxo []blockchain.SpentTxOut) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
 	// Call each of the currently active optional indexes with the block being disconnected so they can update
 	// accordingly.
-	for _, index := range m.enabledIndexes {
+	for i, index := range m.enabledIndexes {
+		// An index that is catching up is only affected if it has reached the block, which keeps its tip in the main
+		// chain.
+		if !m.synced[i] {
+			tipHash, _, err := dbFetchIndexerTip(dbTx, index.Key())
+			if err != nil {
+				Error(err)
+				return err
+			}
+			if !tipHash.IsEqual(block.Hash()) {
+				continue
+			}
+		}
 		err := dbIndexDisconnectBlock(dbTx, index, block, stxo)
 		if err != nil {
 			Error(err)
 			return err
 		}
+		m.heights[i] = block.Height() - 1
 	}
 	return nil
 }
@@ -0,0 +1,204 @@
+package indexers
+
+import (
+	"fmt"
+
+	qu "github.com/p9c/pod/pkg/util/quit"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// spendIndexName is the human-readable name for the index.
+	spendIndexName = "spent output index"
+	// spendKeySize is the size of the serialized outpoint used as the key of an entry.
+	spendKeySize = chainhash.HashSize + 4
+	// spendEntrySize is the size of the serialized spender of an outpoint.
+	spendEntrySize = chainhash.HashSize + 4 + 4
+)
+
+var (
+	// spendIndexKey is the key of the spent output index and the db bucket used to house it.
+	spendIndexKey = []byte("spendbyoutpointidx")
+)
+
+// The spent output index consists of an entry for every output that has been spent by a transaction in the main chain,
+// recording the transaction that spent it, the input of that transaction and the height of the block it is in. This
+// makes it possible to go forward from an output to the transaction spending it, which is the opposite direction to
+// the one the previous outpoints of the inputs provide.
+//
+// The serialized format for the keys and values in the spend index bucket is:
+//
+//   <prev hash><prev index> = <spender txhash><input index><height>
+//   Field           Type              Size
+//   prev hash       chainhash.Hash    32 bytes
+//   prev index      uint32            4 bytes
+//   spender txhash  chainhash.Hash    32 bytes
+//   input index     uint32            4 bytes
+//   height          uint32            4 bytes
+//   -----
+//   Total: 76 bytes
+
+// SpendEntry is the transaction input that spent an output, and the height of the block it was mined in.
+type SpendEntry struct {
+	TxHash chainhash.Hash
+	Index  uint32
+	Height int32
+}
+
+// spendIndexKeyFor returns the key of the entry for the provided outpoint.
+func spendIndexKeyFor(op *wire.OutPoint) []byte {
+	key := make([]byte, spendKeySize)
+	copy(key, op.Hash[:])
+	byteOrder.PutUint32(key[chainhash.HashSize:], op.Index)
+	return key
+}
+
+// serializeSpendEntry serializes the provided entry according to the format described above.
+func serializeSpendEntry(entry *SpendEntry) []byte {
+	serialized := make([]byte, spendEntrySize)
+	copy(serialized, entry.TxHash[:])
+	byteOrder.PutUint32(serialized[chainhash.HashSize:], entry.Index)
+	byteOrder.PutUint32(serialized[chainhash.HashSize+4:], uint32(entry.Height))
+	return serialized
+}
+
+// deserializeSpendEntry decodes an entry serialized by serializeSpendEntry.
+func deserializeSpendEntry(serialized []byte) (*SpendEntry, error) {
+	if len(serialized) != spendEntrySize {
+		return nil, fmt.Errorf("spend index entry is %d bytes, expected %d", len(serialized), spendEntrySize)
+	}
+	entry := &SpendEntry{}
+	copy(entry.TxHash[:], serialized)
+	entry.Index = byteOrder.Uint32(serialized[chainhash.HashSize:])
+	entry.Height = int32(byteOrder.Uint32(serialized[chainhash.HashSize+4:]))
+	return entry, nil
+}
+
+// dbAddSpendIndexEntries adds an entry for every output spent by the transactions in the passed block.
+func dbAddSpendIndexEntries(bucket internalBucket, block *util.Block) error {
+	height := block.Height()
+	for _, tx := range block.Transactions() {
+		if blockchain.IsCoinBase(tx) {
+			continue
+		}
+		for i, txIn := range tx.MsgTx().TxIn {
+			entry := &SpendEntry{TxHash: *tx.Hash(), Index: uint32(i), Height: height}
+			err := bucket.Put(spendIndexKeyFor(&txIn.PreviousOutPoint), serializeSpendEntry(entry))
+			if err != nil {
+				Error(err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dbRemoveSpendIndexEntries removes the entries for every output spent by the transactions in the passed block, leaving
+// them unspent as they are in the chain once the block has been disconnected.
+func dbRemoveSpendIndexEntries(bucket internalBucket, block *util.Block) error {
+	for _, tx := range block.Transactions() {
+		if blockchain.IsCoinBase(tx) {
+			continue
+		}
+		for _, txIn := range tx.MsgTx().TxIn {
+			if err := bucket.Delete(spendIndexKeyFor(&txIn.PreviousOutPoint)); err != nil {
+				Error(err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dbFetchSpendIndexEntry returns the entry for the provided outpoint, or nil when it has not been spent.
+func dbFetchSpendIndexEntry(bucket internalBucket, op *wire.OutPoint) (*SpendEntry, error) {
+	serialized := bucket.Get(spendIndexKeyFor(op))
+	if len(serialized) == 0 {
+		return nil, nil
+	}
+	entry, err := deserializeSpendEntry(serialized)
+	if err != nil {
+		return nil, database.DBError{
+			ErrorCode:   database.ErrCorruption,
+			Description: fmt.Sprintf("corrupt spend index entry for %v: %v", op, err),
+		}
+	}
+	return entry, nil
+}
+
+// SpendIndex implements a spent output index. That is to say, it supports querying the transaction that spent an output
+// in the main chain.
+type SpendIndex struct {
+	db database.DB
+}
+
+// Ensure the SpendIndex type implements the Indexer interface.
+var _ Indexer = (*SpendIndex)(nil)
+
+// Init is only provided to satisfy the Indexer interface as there is nothing to initialize for this index.
+func (idx *SpendIndex) Init() error {
+	return nil
+}
+
+// Key returns the database key to use for the index as a byte slice. This is part of the Indexer interface.
+func (idx *SpendIndex) Key() []byte {
+	return spendIndexKey
+}
+
+// Name returns the human-readable name of the index. This is part of the Indexer interface.
+func (idx *SpendIndex) Name() string {
+	return spendIndexName
+}
+
+// Create is invoked when the indexer manager determines the index needs to be created for the first time. It creates
+// the bucket for the spent output index. This is part of the Indexer interface.
+func (idx *SpendIndex) Create(dbTx database.Tx) error {
+	_, err := dbTx.Metadata().CreateBucket(spendIndexKey)
+	return err
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been connected to the main chain. This indexer adds
+// an entry for every output spent by the transactions in the block. This is part of the Indexer interface.
+func (idx *SpendIndex) ConnectBlock(dbTx database.Tx, block *util.Block, stxos []blockchain.SpentTxOut) error {
+	return dbAddSpendIndexEntries(dbTx.Metadata().Bucket(spendIndexKey), block)
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been disconnected from the main chain. This indexer
+// removes the entries for the outputs spent by the transactions in the block. This is part of the Indexer interface.
+func (idx *SpendIndex) DisconnectBlock(dbTx database.Tx, block *util.Block, stxos []blockchain.SpentTxOut) error {
+	return dbRemoveSpendIndexEntries(dbTx.Metadata().Bucket(spendIndexKey), block)
+}
+
+// Spender returns the transaction input in the main chain that spent the provided outpoint. When the output has not
+// been spent, nil will be returned for both the entry and the error.
+//
+// This function is safe for concurrent access.
+func (idx *SpendIndex) Spender(op *wire.OutPoint) (*SpendEntry, error) {
+	var entry *SpendEntry
+	err := idx.db.View(func(dbTx database.Tx) error {
+		var err error
+		entry, err = dbFetchSpendIndexEntry(dbTx.Metadata().Bucket(spendIndexKey), op)
+		return err
+	})
+	return entry, err
+}
+
+// NewSpendIndex returns a new instance of an indexer that is used to create a mapping of every spent output in the
+// blockchain to the transaction input that spent it.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in turn is used by the blockchain package.
+//
+// This allows the index to be seamlessly maintained along with the chain.
+func NewSpendIndex(db database.DB) *SpendIndex {
+	return &SpendIndex{db: db}
+}
+
+// DropSpendIndex drops the spent output index from the provided database if it exists.
+func DropSpendIndex(db database.DB, interrupt qu.C) error {
+	return dropIndex(db, spendIndexKey, spendIndexName, interrupt)
+}
@@ -0,0 +1,101 @@
+package indexers
+
+import (
+	"testing"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// spendIndexBucket provides a mock spend index database bucket by implementing the internalBucket interface.
+type spendIndexBucket struct {
+	entries map[string][]byte
+}
+
+// Get returns the value associated with the key from the mock spend index bucket.
+//
+// This is part of the internalBucket interface.
+func (b *spendIndexBucket) Get(key []byte) []byte {
+	return b.entries[string(key)]
+}
+
+// Put stores the provided key/value pair to the mock spend index bucket.
+//
+// This is part of the internalBucket interface.
+func (b *spendIndexBucket) Put(key []byte, value []byte) error {
+	b.entries[string(key)] = value
+	return nil
+}
+
+// Delete removes the provided key from the mock spend index bucket.
+//
+// This is part of the internalBucket interface.
+func (b *spendIndexBucket) Delete(key []byte) error {
+	delete(b.entries, string(key))
+	return nil
+}
+
+// TestSpendIndexConnectDisconnect ensures the spend index records the spender of every output spent in a block and
+// removes the entries again when the block is disconnected.
+func TestSpendIndexConnectDisconnect(t *testing.T) {
+	prev := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 3}
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), nil, nil))
+	coinbase.AddTxOut(wire.NewTxOut(50, nil))
+	spend := wire.NewMsgTx(1)
+	spend.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{2}, Index: 0}, nil, nil))
+	spend.AddTxIn(wire.NewTxIn(&prev, nil, nil))
+	spend.AddTxOut(wire.NewTxOut(10, nil))
+	// spends an output created in the same block
+	chained := wire.NewMsgTx(1)
+	chained.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, 0), nil, nil))
+	chained.AddTxOut(wire.NewTxOut(9, nil))
+	chained.TxIn[0].PreviousOutPoint.Hash = spend.TxHash()
+	msgBlock := wire.NewMsgBlock(&wire.BlockHeader{})
+	for _, tx := range []*wire.MsgTx{coinbase, spend, chained} {
+		if err := msgBlock.AddTransaction(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	block := util.NewBlock(msgBlock)
+	block.SetHeight(1234)
+	bucket := &spendIndexBucket{entries: make(map[string][]byte)}
+	if err := dbAddSpendIndexEntries(bucket, block); err != nil {
+		t.Fatal(err)
+	}
+	if len(bucket.entries) != 3 {
+		t.Fatalf("expected 3 entries after connecting the block, got %d", len(bucket.entries))
+	}
+	tests := []struct {
+		op    wire.OutPoint
+		entry SpendEntry
+	}{
+		{op: prev, entry: SpendEntry{TxHash: spend.TxHash(), Index: 1, Height: 1234}},
+		{op: wire.OutPoint{Hash: spend.TxHash(), Index: 0}, entry: SpendEntry{TxHash: chained.TxHash(), Index: 0, Height: 1234}},
+	}
+	for i, test := range tests {
+		entry, err := dbFetchSpendIndexEntry(bucket, &test.op)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if entry == nil || *entry != test.entry {
+			t.Fatalf("test %d: spender of %v is %v, expected %v", i, test.op, entry, test.entry)
+		}
+	}
+	// the coinbase input is not an output being spent
+	if entry, _ := dbFetchSpendIndexEntry(bucket, &coinbase.TxIn[0].PreviousOutPoint); entry != nil {
+		t.Fatal("the coinbase input was added to the index")
+	}
+	if err := dbRemoveSpendIndexEntries(bucket, block); err != nil {
+		t.Fatal(err)
+	}
+	if len(bucket.entries) != 0 {
+		t.Fatalf("expected no entries after disconnecting the block, got %d", len(bucket.entries))
+	}
+	// a corrupt entry is reported
+	bucket.entries[string(spendIndexKeyFor(&prev))] = []byte{1, 2, 3}
+	if _, err := dbFetchSpendIndexEntry(bucket, &prev); err == nil {
+		t.Fatal("fetched a corrupt entry without an error")
+	}
+}
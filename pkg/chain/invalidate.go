@@ -0,0 +1,211 @@
+package blockchain
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// InvalidateBlock marks the block with the given hash and all of its descendants as invalid. If the block is part of
+// the best chain, the chain is rewound to the block's parent and then reorganized onto the valid branch with the most
+// proof of work. This is intended to allow an operator to manually reject a block, for example to get off the wrong
+// side of a chain split around a hard fork activation. This function is safe for concurrent access.
+func (b *BlockChain) InvalidateBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %s is not known", hash)
+	}
+	if node.parent == nil {
+		return fmt.Errorf("the genesis block cannot be invalidated")
+	}
+	b.Index.SetStatusFlags(node, statusValidateFailed)
+	for _, n := range b.Index.descendants(node) {
+		b.Index.SetStatusFlags(n, statusInvalidAncestor)
+	}
+	var err error
+	if b.BestChain.Contains(node) {
+		Infof("INVALIDATE: rewinding best chain to %v (height %d)", node.parent.hash, node.parent.height)
+		err = b.activateBestChain(node.parent)
+	}
+	// As with connectBestChain, flush the index regardless of whether there was an error so that the invalid flags
+	// survive a restart.
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		Error("error flushing block index changes to disk:", writeErr)
+		if err == nil {
+			err = writeErr
+		}
+	}
+	return err
+}
+
+// ReconsiderBlock removes the invalid status from the block with the given hash, its ancestors and its descendants,
+// undoing the effect of InvalidateBlock. The chain is then reorganized onto the valid branch with the most proof of
+// work, which will revalidate any blocks that have not been fully validated before. This function is safe for
+// concurrent access.
+func (b *BlockChain) ReconsiderBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %s is not known", hash)
+	}
+	const invalidFlags = statusValidateFailed | statusInvalidAncestor
+	for n := node; n != nil; n = n.parent {
+		if b.Index.NodeStatus(n).KnownInvalid() {
+			b.Index.UnsetStatusFlags(n, invalidFlags)
+		}
+	}
+	for _, n := range b.Index.descendants(node) {
+		if b.Index.NodeStatus(n).KnownInvalid() {
+			b.Index.UnsetStatusFlags(n, invalidFlags)
+		}
+	}
+	err := b.activateBestChain(b.BestChain.Tip())
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		Error("error flushing block index changes to disk:", writeErr)
+		if err == nil {
+			err = writeErr
+		}
+	}
+	return err
+}
+
+// PreciousBlock treats the block with the given hash as if it had been received before any competing block with the
+// same amount of work. If the block is on a side chain with at least as much work as the best chain, the chain is
+// reorganized so that the block becomes part of the best chain. A block on a branch with less work is left alone. This
+// function is safe for concurrent access.
+func (b *BlockChain) PreciousBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %s is not known", hash)
+	}
+	if b.BestChain.Contains(node) {
+		return nil
+	}
+	if b.Index.NodeStatus(node).KnownInvalid() {
+		return fmt.Errorf("block %s is known to be invalid", hash)
+	}
+	tip := b.BestChain.Tip()
+	forkNode := b.BestChain.FindFork(node)
+	for n := node; n != nil && n != forkNode; n = n.parent {
+		if !b.Index.NodeStatus(n).HaveData() {
+			return fmt.Errorf("block %s has ancestors with missing block data", hash)
+		}
+	}
+	if relativeWork(node, tip).Sign() < 0 {
+		Debugf("PRECIOUS: block %v has less work than the best chain, ignoring", node.hash)
+		return nil
+	}
+	detachNodes, attachNodes := b.getReorganizeNodes(node)
+	Infof("PRECIOUS: block %v is causing a reorganize", node.hash)
+	err := b.reorganizeChain(detachNodes, attachNodes)
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		Error("error flushing block index changes to disk:", writeErr)
+	}
+	return err
+}
+
+// activateBestChain reorganizes the chain onto the valid block with the most proof of work, measured relative to the
+// given base block, which must be part of the best chain and is used when no other block has more work. Blocks that fail
+// validation during the reorganization are marked invalid and the next best candidate is tried. This function MUST be
+// called with the chain state lock held (for writes).
+func (b *BlockChain) activateBestChain(base *BlockNode) error {
+	for {
+		tip := b.BestChain.Tip()
+		target := b.bestCandidate(base)
+		if target == tip {
+			return nil
+		}
+		var detachNodes, attachNodes *list.List
+		if b.BestChain.Contains(target) {
+			// The target is an ancestor of the current tip, so the chain only needs to be rewound.
+			detachNodes, attachNodes = list.New(), list.New()
+			for n := tip; n != nil && n != target; n = n.parent {
+				detachNodes.PushBack(n)
+			}
+		} else {
+			detachNodes, attachNodes = b.getReorganizeNodes(target)
+		}
+		err := b.reorganizeChain(detachNodes, attachNodes)
+		if err == nil {
+			return nil
+		}
+		// reorganizeChain marks the offending block and its descendants as invalid when the failure is due to a
+		// rule violation, so the next iteration will pick a different candidate.
+		if _, ok := err.(RuleError); !ok || !b.Index.NodeStatus(target).KnownInvalid() {
+			return err
+		}
+		Warn("candidate chain tip", target.hash, "failed validation, trying next best:", err)
+	}
+}
+
+// bestCandidate returns the block with the most proof of work that can become the tip of the best chain, considering
+// only blocks whose data is stored and that are not known to be invalid. The base block is returned if no block has
+// more work than it. This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) bestCandidate(base *BlockNode) *BlockNode {
+	best := base
+	bestWork := new(big.Int)
+	for _, tip := range b.Index.tips() {
+		forkNode := findCommonAncestor(tip, base)
+		// Walk back from the tip to the fork point to find the highest block which has all of its ancestors on the
+		// branch stored and not known to be invalid.
+		var candidate *BlockNode
+		for n := tip; n != nil && n != forkNode; n = n.parent {
+			status := b.Index.NodeStatus(n)
+			if status.KnownInvalid() || !status.HaveData() {
+				candidate = nil
+				continue
+			}
+			if candidate == nil {
+				candidate = n
+			}
+		}
+		if candidate == nil {
+			continue
+		}
+		if work := relativeWork(candidate, base); work.Cmp(bestWork) > 0 {
+			best, bestWork = candidate, work
+		}
+	}
+	return best
+}
+
+// relativeWork returns the amount of proof of work on the branch ending in node minus the amount of proof of work on
+// the branch ending in base, both counted from the point where the two branches fork.
+func relativeWork(node, base *BlockNode) *big.Int {
+	forkNode := findCommonAncestor(node, base)
+	work := new(big.Int)
+	for n := node; n != nil && n != forkNode; n = n.parent {
+		work.Add(work, CalcWork(n.bits, n.height, n.version))
+	}
+	for n := base; n != nil && n != forkNode; n = n.parent {
+		work.Sub(work, CalcWork(n.bits, n.height, n.version))
+	}
+	return work
+}
+
+// findCommonAncestor returns the most recent block that both of the given blocks descend from, or are. It returns nil
+// if the blocks do not share any ancestor.
+func findCommonAncestor(a, b *BlockNode) *BlockNode {
+	if a == nil || b == nil {
+		return nil
+	}
+	if a.height > b.height {
+		a = a.Ancestor(b.height)
+	} else if b.height > a.height {
+		b = b.Ancestor(a.height)
+	}
+	for a != nil && b != nil && a != b {
+		a, b = a.parent, b.parent
+	}
+	if a != b {
+		return nil
+	}
+	return a
+}
@@ -0,0 +1,265 @@
+package netsync
+
+import (
+	"sync/atomic"
+
+	qu "github.com/p9c/pod/pkg/util/quit"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	peerpkg "github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// maxCmpctAnnouncers is the number of peers asked to announce new blocks as compact blocks without an inv or
+	// headers message first (high bandwidth mode).
+	maxCmpctAnnouncers = 3
+	// maxPartialBlocks is the number of compact blocks per peer that may be waiting for their missing transactions
+	// before further compact blocks from the peer are fetched in full instead.
+	maxPartialBlocks = 3
+)
+
+type (
+	// cmpctBlockMsg packages a bitcoin cmpctblock message and the peer it came from together so the block handler has
+	// access to that information.
+	cmpctBlockMsg struct {
+		msg   *wire.MsgCmpctBlock
+		peer  *peerpkg.Peer
+		reply qu.C
+	}
+	// blockTxnMsg packages a bitcoin blocktxn message and the peer it came from together so the block handler has
+	// access to that information.
+	blockTxnMsg struct {
+		msg   *wire.MsgBlockTxn
+		peer  *peerpkg.Peer
+		reply qu.C
+	}
+	// partialBlock is a block relayed as a compact block that is waiting for the transactions that were not in the
+	// mempool to be sent in a blocktxn message.
+	partialBlock struct {
+		header  wire.BlockHeader
+		txs     []*wire.MsgTx
+		missing []uint32
+	}
+)
+
+// QueueCmpctBlock adds the passed compact block message and peer to the block handling queue. Responds to the done
+// channel argument after the compact block is processed.
+func (sm *SyncManager) QueueCmpctBlock(msg *wire.MsgCmpctBlock, peer *peerpkg.Peer, done qu.C) {
+	// Don't accept more blocks if we're shutting down.
+	if atomic.LoadInt32(&sm.shutdown) != 0 {
+		done <- struct{}{}
+		return
+	}
+	sm.msgChan <- &cmpctBlockMsg{msg: msg, peer: peer, reply: done}
+}
+
+// QueueBlockTxn adds the passed blocktxn message and peer to the block handling queue. Responds to the done channel
+// argument after the transactions are processed.
+func (sm *SyncManager) QueueBlockTxn(msg *wire.MsgBlockTxn, peer *peerpkg.Peer, done qu.C) {
+	// Don't accept more blocks if we're shutting down.
+	if atomic.LoadInt32(&sm.shutdown) != 0 {
+		done <- struct{}{}
+		return
+	}
+	sm.msgChan <- &blockTxnMsg{msg: msg, peer: peer, reply: done}
+}
+
+// handleCmpctBlockMsg handles compact blocks from all peers. The block is rebuilt from the prefilled transactions and
+// those in the mempool, and the transactions that are missing are requested from the peer. Compact blocks that can't be
+// rebuilt are fetched in full instead.
+func (sm *SyncManager) handleCmpctBlockMsg(cmsg *cmpctBlockMsg) {
+	pp := cmsg.peer
+	state, exists := sm.peerStates[pp]
+	if !exists {
+		Trace("received compact block message from unknown peer", pp)
+		return
+	}
+	msg := cmsg.msg
+	blockHash := msg.Header.BlockHash()
+	pp.AddKnownInventory(wire.NewInvVect(wire.InvTypeBlock, &blockHash))
+	// Compact blocks are only of use at the tip of the chain, so they are ignored while syncing and when the block is
+	// already known.
+	if sm.headersFirstMode || !sm.current() {
+		Trace("ignoring compact block", blockHash, "from", pp, "while syncing")
+		return
+	}
+	if msg.TxCount() == 0 {
+		Debug("got compact block", blockHash, "without transactions from", pp)
+		return
+	}
+	if have, err := sm.chain.HaveBlock(&blockHash); err != nil || have {
+		return
+	}
+	if _, exists = state.partialBlocks[blockHash]; exists {
+		return
+	}
+	// Mark the block as requested from this peer so it is not fetched from elsewhere while it is rebuilt, and so that
+	// it is accepted when it is processed.
+	sm.requestedBlocks[blockHash] = struct{}{}
+	sm.limitMap(sm.requestedBlocks, maxRequestedBlocks)
+	state.requestedBlocks[blockHash] = struct{}{}
+	// A block whose parent is not known can't be checked yet, so it is fetched in full to go through the orphan
+	// handling of full blocks.
+	if have, err := sm.chain.HaveBlock(&msg.Header.PrevBlock); err != nil || !have ||
+		len(state.partialBlocks) >= maxPartialBlocks {
+		sm.requestFullBlock(pp, &blockHash)
+		return
+	}
+	pb, ok := sm.reconstructBlock(msg)
+	if !ok {
+		Debug("short transaction IDs of compact block", blockHash, "from", pp, "collide, fetching it in full")
+		sm.requestFullBlock(pp, &blockHash)
+		return
+	}
+	if len(pb.missing) == 0 {
+		sm.processPartialBlock(pp, &blockHash, pb)
+		return
+	}
+	Debugf(
+		"requesting %d of %d transactions of compact block %v from %s",
+		len(pb.missing), len(pb.txs), blockHash, pp,
+	)
+	state.partialBlocks[blockHash] = pb
+	pp.QueueMessage(wire.NewMsgGetBlockTxn(&blockHash, pb.missing), nil)
+}
+
+// handleBlockTxnMsg handles the transactions a peer sent for a compact block it relayed that could not be rebuilt from
+// the mempool, completing and processing the block.
+func (sm *SyncManager) handleBlockTxnMsg(bmsg *blockTxnMsg) {
+	pp := bmsg.peer
+	state, exists := sm.peerStates[pp]
+	if !exists {
+		Trace("received blocktxn message from unknown peer", pp)
+		return
+	}
+	msg := bmsg.msg
+	pb, exists := state.partialBlocks[msg.BlockHash]
+	if !exists {
+		Debug("got unrequested transactions of block", msg.BlockHash, "from", pp)
+		return
+	}
+	delete(state.partialBlocks, msg.BlockHash)
+	if len(msg.Transactions) != len(pb.missing) {
+		Debugf(
+			"got %d transactions of block %v from %s, wanted %d, fetching it in full",
+			len(msg.Transactions), msg.BlockHash, pp, len(pb.missing),
+		)
+		sm.requestFullBlock(pp, &msg.BlockHash)
+		return
+	}
+	for i, index := range pb.missing {
+		pb.txs[index] = msg.Transactions[i]
+	}
+	pb.missing = nil
+	sm.processPartialBlock(pp, &msg.BlockHash, pb)
+}
+
+// reconstructBlock fills in the transactions of a compact block from its prefilled transactions and the mempool,
+// returning the indexes of those that could not be found. It fails if the short IDs of the block collide with each
+// other or with more than one transaction in the mempool.
+func (sm *SyncManager) reconstructBlock(msg *wire.MsgCmpctBlock) (pb *partialBlock, ok bool) {
+	pb = &partialBlock{header: msg.Header, txs: make([]*wire.MsgTx, msg.TxCount())}
+	for _, ptx := range msg.PrefilledTxns {
+		pb.txs[ptx.Index] = ptx.Tx
+	}
+	// The short IDs are for the transactions that were not prefilled, in the order they are in the block.
+	slots := make(map[uint64]int, len(msg.ShortIDs))
+	index := 0
+	for _, id := range msg.ShortIDs {
+		for pb.txs[index] != nil {
+			index++
+		}
+		if _, exists := slots[id]; exists {
+			return nil, false
+		}
+		slots[id] = index
+		index++
+	}
+	key := msg.ShortIDKey()
+	filled := make(map[int]bool, len(slots))
+	for _, txD := range sm.txMemPool.TxDescs() {
+		index, exists := slots[wire.ShortTxID(&key, txD.Tx.Hash())]
+		if !exists {
+			continue
+		}
+		if filled[index] {
+			return nil, false
+		}
+		filled[index] = true
+		pb.txs[index] = txD.Tx.MsgTx()
+	}
+	for i, tx := range pb.txs {
+		if tx == nil {
+			pb.missing = append(pb.missing, uint32(i))
+		}
+	}
+	return pb, true
+}
+
+// processPartialBlock checks that the transactions of a rebuilt compact block make up the block and processes it as a
+// block sent in full. If a transaction from the mempool was mistaken for one in the block the block is fetched in full.
+func (sm *SyncManager) processPartialBlock(pp *peerpkg.Peer, blockHash *chainhash.Hash, pb *partialBlock) {
+	msgBlock := wire.NewMsgBlock(&pb.header)
+	msgBlock.Transactions = pb.txs
+	block := util.NewBlock(msgBlock)
+	merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
+	if !merkles[len(merkles)-1].IsEqual(&pb.header.MerkleRoot) {
+		Debug("rebuilt compact block", blockHash, "from", pp, "does not match its merkle root, fetching it in full")
+		sm.requestFullBlock(pp, blockHash)
+		return
+	}
+	sm.handleBlockMsg(0, &blockMsg{block: block, peer: pp})
+}
+
+// requestFullBlock asks a peer for a block in full, when a compact block it relayed could not be rebuilt.
+func (sm *SyncManager) requestFullBlock(pp *peerpkg.Peer, blockHash *chainhash.Hash) {
+	state, exists := sm.peerStates[pp]
+	if !exists {
+		return
+	}
+	sm.requestedBlocks[*blockHash] = struct{}{}
+	sm.limitMap(sm.requestedBlocks, maxRequestedBlocks)
+	state.requestedBlocks[*blockHash] = struct{}{}
+	gdmsg := wire.NewMsgGetData()
+	if err := gdmsg.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, blockHash)); Check(err) {
+		return
+	}
+	pp.QueueMessage(gdmsg, nil)
+}
+
+// updateCmpctAnnouncers makes a peer that delivered a new block one of the peers asked to announce new blocks as
+// compact blocks, asking the one that was made one longest ago to stop if there are more than maxCmpctAnnouncers.
+func (sm *SyncManager) updateCmpctAnnouncers(pp *peerpkg.Peer) {
+	if !pp.SupportsCmpctBlocks() {
+		return
+	}
+	for i, p := range sm.cmpctAnnouncers {
+		if p == pp {
+			// Move the peer to the back of the list, as it is the last to have delivered a block.
+			sm.cmpctAnnouncers = append(append(sm.cmpctAnnouncers[:i:i], sm.cmpctAnnouncers[i+1:]...), pp)
+			return
+		}
+	}
+	Debug("asking", pp, "to announce new blocks as compact blocks")
+	pp.QueueMessage(wire.NewMsgSendCmpct(true, wire.CmpctBlockVersion), nil)
+	sm.cmpctAnnouncers = append(sm.cmpctAnnouncers, pp)
+	if len(sm.cmpctAnnouncers) > maxCmpctAnnouncers {
+		oldest := sm.cmpctAnnouncers[0]
+		sm.cmpctAnnouncers = sm.cmpctAnnouncers[1:]
+		Debug("asking", oldest, "to stop announcing new blocks as compact blocks")
+		oldest.QueueMessage(wire.NewMsgSendCmpct(false, wire.CmpctBlockVersion), nil)
+	}
+}
+
+// removeCmpctAnnouncer drops a peer that disconnected from the peers asked to announce new blocks as compact blocks.
+func (sm *SyncManager) removeCmpctAnnouncer(pp *peerpkg.Peer) {
+	for i, p := range sm.cmpctAnnouncers {
+		if p == pp {
+			sm.cmpctAnnouncers = append(sm.cmpctAnnouncers[:i:i], sm.cmpctAnnouncers[i+1:]...)
+			return
+		}
+	}
+}
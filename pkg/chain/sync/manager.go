@@ -43,6 +43,9 @@ type (
 		requestedBlocks map[chainhash.Hash]struct{}
 		syncPeer        *peerpkg.Peer
 		peerStates      map[*peerpkg.Peer]*peerSyncState
+		// cmpctAnnouncers are the peers asked to announce new blocks as compact blocks, in the order they were last
+		// the first to deliver a new block.
+		cmpctAnnouncers []*peerpkg.Peer
 		// The following fields are used for headers-first mode.
 		headersFirstMode bool
 		headerList       *list.List
@@ -103,6 +106,7 @@ type (
 		requestQueue    []*wire.InvVect
 		requestedTxns   map[chainhash.Hash]struct{}
 		requestedBlocks map[chainhash.Hash]struct{}
+		partialBlocks   map[chainhash.Hash]*partialBlock
 	}
 	// processBlockMsg is a message type to be sent across the message channel for requested a block is processed. Note
 	// this call differs from blockMsg above in that blockMsg is intended for blocks that came from peers and have extra
@@ -277,6 +281,12 @@ out:
 			case *blockMsg:
 				sm.handleBlockMsg(0, msg)
 				msg.reply <- struct{}{}
+			case *cmpctBlockMsg:
+				sm.handleCmpctBlockMsg(msg)
+				msg.reply <- struct{}{}
+			case *blockTxnMsg:
+				sm.handleBlockTxnMsg(msg)
+				msg.reply <- struct{}{}
 			case *invMsg:
 				sm.handleInvMsg(msg)
 			case *headersMsg:
@@ -566,6 +576,10 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 		blkHashUpdate = &best.Hash
 		// Clear the rejected transactions.
 		sm.rejectedTxns = make(map[chainhash.Hash]struct{})
+		// Peers that deliver new blocks first are asked to relay the next ones as compact blocks straight away.
+		if sm.current() {
+			sm.updateCmpctAnnouncers(pp)
+		}
 	}
 	// Update the block height for this peer. But only send a message to the server for updating peer heights if this is
 	// an orphan or our chain is "current". This avoids sending a spammy amount of messages if we're syncing the chain
@@ -717,6 +731,7 @@ func (sm *SyncManager) handleDonePeerMsg(peer *peerpkg.Peer) {
 	}
 	// Remove the peer from the list of candidate peers.
 	delete(sm.peerStates, peer)
+	sm.removeCmpctAnnouncer(peer)
 	Trace("lost peer ", peer)
 	// Remove requested transactions from the global map so that they will be fetched from elsewhere next time we get an
 	// inv.
@@ -973,7 +988,12 @@ func (sm *SyncManager) handleInvMsg(imsg *invMsg) {
 				sm.requestedBlocks[iv.Hash] = struct{}{}
 				sm.limitMap(sm.requestedBlocks, maxRequestedBlocks)
 				state.requestedBlocks[iv.Hash] = struct{}{}
-				if peer.IsWitnessEnabled() {
+				// New blocks are requested as compact blocks from peers that can send them, as most of their
+				// transactions will already be in the mempool.
+				switch {
+				case sm.current() && peer.SupportsCmpctBlocks():
+					iv.Type = wire.InvTypeCmpctBlock
+				case peer.IsWitnessEnabled():
 					iv.Type = wire.InvTypeWitnessBlock
 				}
 				err := gdmsg.AddInvVect(iv)
@@ -1026,6 +1046,12 @@ func (sm *SyncManager) handleNewPeerMsg(peer *peerpkg.Peer) {
 		syncCandidate:   isSyncCandidate,
 		requestedTxns:   make(map[chainhash.Hash]struct{}),
 		requestedBlocks: make(map[chainhash.Hash]struct{}),
+		partialBlocks:   make(map[chainhash.Hash]*partialBlock),
+	}
+	// Tell peers that can relay compact blocks that we can receive them. They are asked to announce new blocks this way
+	// once they have been the first to deliver one.
+	if peer.ProtocolVersion() >= wire.BIP0152Version {
+		peer.QueueMessage(wire.NewMsgSendCmpct(false, wire.CmpctBlockVersion), nil)
 	}
 	// Start syncing by choosing the best candidate if needed.
 	if isSyncCandidate && sm.syncPeer == nil {
@@ -0,0 +1,22 @@
+package blockchain
+
+import (
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// NewSyntheticChain returns a chain with only the network parameters set, which is all the difficulty adjustment
+// functions use, for running them on block indexes that are built in memory and not backed by a database.
+func NewSyntheticChain(params *netparams.Params) *BlockChain {
+	return &BlockChain{params: params}
+}
+
+// NewRootBlockNode returns a block node without a parent at the given height, for starting a synthetic block index part
+// of the way through the chain, such as at the activation height of a hard fork. This function is NOT safe for
+// concurrent access.
+func NewRootBlockNode(blockHeader *wire.BlockHeader, height int32) *BlockNode {
+	node := NewBlockNode(blockHeader, nil)
+	node.height = height
+	node.workSum = CalcWork(node.bits, height, node.version)
+	return node
+}
@@ -222,6 +222,18 @@ func (b *BlockChain) warnUnknownRuleActivations(node *BlockNode) error {
 	return nil
 }
 
+// Warnings returns the warnings about the state of the chain that should be shown to the operator, such as the
+// activation of consensus rules this software does not know about. This function is safe for concurrent access.
+func (b *BlockChain) Warnings() []string {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	var warnings []string
+	if b.unknownRulesWarned {
+		warnings = append(warnings, "Unknown new rules activated, this software may need to be upgraded")
+	}
+	return warnings
+}
+
 // warnUnknownVersions logs a warning if a high enough percentage of the last
 // blocks have unexpected versions.
 // This function MUST be called with the chain state lock held (for writes)
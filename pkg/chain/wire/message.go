@@ -51,6 +51,12 @@ const (
 	CmdCFilter      = "cfilter"
 	CmdCFHeaders    = "cfheaders"
 	CmdCFCheckpt    = "cfcheckpt"
+	CmdSendCmpct    = "sendcmpct"
+	CmdCmpctBlock   = "cmpctblock"
+	CmdGetBlockTxn  = "getblocktxn"
+	CmdBlockTxn     = "blocktxn"
+	CmdSendAddrV2   = "sendaddrv2"
+	CmdAddrV2       = "addrv2"
 )
 
 // MessageEncoding represents the wire message encoding format to be used.
@@ -139,6 +145,18 @@ func makeEmptyMessage(command string) (Message, error) {
 		msg = &MsgCFHeaders{}
 	case CmdCFCheckpt:
 		msg = &MsgCFCheckpt{}
+	case CmdSendCmpct:
+		msg = &MsgSendCmpct{}
+	case CmdCmpctBlock:
+		msg = &MsgCmpctBlock{}
+	case CmdGetBlockTxn:
+		msg = &MsgGetBlockTxn{}
+	case CmdBlockTxn:
+		msg = &MsgBlockTxn{}
+	case CmdSendAddrV2:
+		msg = &MsgSendAddrV2{}
+	case CmdAddrV2:
+		msg = &MsgAddrV2{}
 	default:
 		return nil, fmt.Errorf("unhandled command [%s]", command)
 	}
@@ -0,0 +1,120 @@
+package wire
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestAddrV2Wire tests the MsgAddrV2 wire encode and decode of addresses of each network, and that it is refused before
+// AddrV2Version.
+func TestAddrV2Wire(t *testing.T) {
+	ts := time.Unix(0x495fab29, 0) // 2009-01-03 12:15:05 -0600 CST
+	torV3 := bytes.Repeat([]byte{0xab}, 32)
+	na4 := NewNetAddressTimestamp(ts, SFNodeNetwork, net.ParseIP("127.0.0.1"), 8333)
+	naTorV2 := NewNetAddressTimestamp(ts, 0, net.ParseIP("fd87:d87e:eb43:102:304:506:708:90a"), 8333)
+	naTorV3, err := NewNetAddressNetID(ts, SFNodeNetwork, NetTorV3, torV3, 11047)
+	if err != nil {
+		t.Fatalf("NewNetAddressNetID error %v", err)
+	}
+	msg := NewMsgAddrV2()
+	if err = msg.AddAddresses(na4, naTorV2, naTorV3); err != nil {
+		t.Fatalf("AddAddresses error %v", err)
+	}
+	if cmd := msg.Command(); cmd != "addrv2" {
+		t.Errorf("NewMsgAddrV2: wrong command - got %v want %v",
+			cmd, "addrv2")
+	}
+	wantBuf := []byte{
+		0x03,                   // Varint for number of addresses
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01,                   // Varint for services
+		0x01,                   // Network ID IPv4
+		0x04,                   // Varint for address length
+		0x7f, 0x00, 0x00, 0x01, // IP 127.0.0.1
+		0x20, 0x8d, // Port 8333 in big-endian
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x00,                                                       // Varint for services
+		0x03,                                                       // Network ID Tor v2
+		0x0a,                                                       // Varint for address length
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, // Onion
+		0x20, 0x8d, // Port 8333 in big-endian
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01, // Varint for services
+		0x04, // Network ID Tor v3
+		0x20, // Varint for address length
+	}
+	wantBuf = append(wantBuf, torV3...)
+	wantBuf = append(wantBuf, 0x2b, 0x27) // Port 11047 in big-endian
+	var buf bytes.Buffer
+	if err = msg.BtcEncode(&buf, AddrV2Version, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode error %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), wantBuf) {
+		t.Errorf("BtcEncode\n got: %s want: %s",
+			spew.Sdump(buf.Bytes()), spew.Sdump(wantBuf))
+	}
+	var readmsg MsgAddrV2
+	if err = readmsg.BtcDecode(bytes.NewReader(wantBuf), AddrV2Version, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode error %v", err)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(readmsg),
+			spew.Sdump(msg))
+	}
+	if readmsg.AddrList[1].NetID() != NetTorV2 || readmsg.AddrList[2].NetID() != NetTorV3 ||
+		!readmsg.AddrList[2].NeedsAddrV2() || readmsg.AddrList[0].NeedsAddrV2() {
+		t.Errorf("BtcDecode: wrong networks of addresses %s", spew.Sdump(readmsg.AddrList))
+	}
+	// Older protocol versions should fail since the message didn't exist yet.
+	oldPver := AddrV2Version - 1
+	if err = msg.BtcEncode(&buf, oldPver, BaseEncoding); err == nil {
+		t.Errorf("encode of MsgAddrV2 succeeded when it shouldn't "+
+			"have %v", msg)
+	}
+	if err = readmsg.BtcDecode(bytes.NewReader(wantBuf), oldPver, BaseEncoding); err == nil {
+		t.Errorf("decode of MsgAddrV2 succeeded when it shouldn't "+
+			"have %v", spew.Sdump(wantBuf))
+	}
+}
+
+// TestAddrV2WireNetworks tests that addresses of unknown networks are skipped when decoding a MsgAddrV2, and that
+// addresses of known networks of the wrong length are refused.
+func TestAddrV2WireNetworks(t *testing.T) {
+	entry := func(netID byte, addr []byte) []byte {
+		b := []byte{0x29, 0xab, 0x5f, 0x49, 0x00, netID, byte(len(addr))}
+		return append(append(b, addr...), 0x20, 0x8d)
+	}
+	tests := []struct {
+		buf   []byte
+		count int
+		fail  bool
+	}{
+		// Unknown network.
+		{append([]byte{0x01}, entry(0x42, make([]byte, 20))...), 0, false},
+		// CJDNS can't be connected to.
+		{append([]byte{0x01}, entry(0x06, make([]byte, 16))...), 0, false},
+		// I2P among an unknown network.
+		{append(append([]byte{0x02}, entry(0x42, nil)...), entry(0x05, make([]byte, 32))...), 1, false},
+		// Tor v3 of the wrong length.
+		{append([]byte{0x01}, entry(0x04, make([]byte, 16))...), 0, true},
+		// IPv4 of the wrong length.
+		{append([]byte{0x01}, entry(0x01, make([]byte, 16))...), 0, true},
+	}
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		var msg MsgAddrV2
+		err := msg.BtcDecode(bytes.NewReader(test.buf), AddrV2Version, BaseEncoding)
+		if (err != nil) != test.fail {
+			t.Errorf("BtcDecode #%d wrong error %v", i, err)
+			continue
+		}
+		if err == nil && len(msg.AddrList) != test.count {
+			t.Errorf("BtcDecode #%d got %d addresses, want %d", i, len(msg.AddrList), test.count)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// MsgBlockTxn implements the Message interface and represents a bitcoin blocktxn message. It is used to reply to a
+// getblocktxn message with the requested transactions of a block, in the order they were requested. This message was
+// not added until protocol versions starting with BIP0152Version.
+type MsgBlockTxn struct {
+	BlockHash    chainhash.Hash
+	Transactions []*MsgTx
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface
+// implementation.
+func (msg *MsgBlockTxn) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	if pver < BIP0152Version {
+		str := fmt.Sprintf("blocktxn message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgBlockTxn.BtcDecode", str)
+	}
+	err := readElement(r, &msg.BlockHash)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	txCount, err := ReadVarInt(r, pver)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	// Prevent more transactions than could possibly fit into a block.
+	if txCount > maxTxPerBlock {
+		str := fmt.Sprintf("too many transactions to fit into a block "+
+			"[count %d, max %d]", txCount, maxTxPerBlock)
+		return messageError("MsgBlockTxn.BtcDecode", str)
+	}
+	msg.Transactions = make([]*MsgTx, 0, txCount)
+	for i := uint64(0); i < txCount; i++ {
+		tx := MsgTx{}
+		err := tx.BtcDecode(r, pver, enc)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		msg.Transactions = append(msg.Transactions, &tx)
+	}
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface
+// implementation.
+func (msg *MsgBlockTxn) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if pver < BIP0152Version {
+		str := fmt.Sprintf("blocktxn message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgBlockTxn.BtcEncode", str)
+	}
+	err := writeElement(w, &msg.BlockHash)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	err = WriteVarInt(w, pver, uint64(len(msg.Transactions)))
+	if err != nil {
+		Error(err)
+		return err
+	}
+	for _, tx := range msg.Transactions {
+		err = tx.BtcEncode(w, pver, enc)
+		if err != nil {
+			Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part of the Message interface implementation.
+func (msg *MsgBlockTxn) Command() string {
+	return CmdBlockTxn
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver. This is part of the Message
+// interface implementation.
+func (msg *MsgBlockTxn) MaxPayloadLength(pver uint32) uint32 {
+	// The transactions of a block are never bigger than the block.
+	return MaxBlockPayload
+}
+
+// NewMsgBlockTxn returns a new bitcoin blocktxn message carrying transactions of the block with the given hash. See
+// MsgBlockTxn for details.
+func NewMsgBlockTxn(blockHash *chainhash.Hash, txs []*MsgTx) *MsgBlockTxn {
+	return &MsgBlockTxn{
+		BlockHash:    *blockHash,
+		Transactions: txs,
+	}
+}
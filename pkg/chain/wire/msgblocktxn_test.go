@@ -0,0 +1,43 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// TestBlockTxnWire tests the MsgBlockTxn wire encode and decode.
+func TestBlockTxnWire(t *testing.T) {
+	hash := chainhash.Hash{0x01, 0x02}
+	msg := NewMsgBlockTxn(&hash, []*MsgTx{multiTx})
+	if cmd := msg.Command(); cmd != "blocktxn" {
+		t.Errorf("NewMsgBlockTxn: wrong command - got %v want %v",
+			cmd, "blocktxn")
+	}
+	wantBuf := append(append(hash[:], 0x01), multiTxEncoded...)
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode error %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), wantBuf) {
+		t.Errorf("BtcEncode\n got: %s want: %s",
+			spew.Sdump(buf.Bytes()), spew.Sdump(wantBuf))
+	}
+	var readmsg MsgBlockTxn
+	if err := readmsg.BtcDecode(bytes.NewReader(wantBuf), ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode error %v", err)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(readmsg),
+			spew.Sdump(msg))
+	}
+	// Older protocol versions should fail since the message didn't exist yet.
+	if err := msg.BtcEncode(&buf, BIP0152Version-1, BaseEncoding); err == nil {
+		t.Errorf("encode of MsgBlockTxn succeeded when it shouldn't "+
+			"have %v", msg)
+	}
+}
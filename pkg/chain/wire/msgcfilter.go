@@ -13,6 +13,8 @@ type FilterType uint8
 const (
 	// GCSFilterRegular is the regular filter type.
 	GCSFilterRegular FilterType = iota
+	// GCSFilterExtended is the extended filter type, which commits to the outpoints spent and all data pushes.
+	GCSFilterExtended
 )
 const (
 	// MaxCFilterDataSize is the maximum byte size of a committed filter. The maximum size is currently defined as
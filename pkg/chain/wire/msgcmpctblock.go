@@ -0,0 +1,222 @@
+package wire
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/aead/siphash"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// ShortTxIDLen is the number of bytes a short transaction ID takes up in a cmpctblock message.
+const ShortTxIDLen = 6
+
+// PrefilledTx is a transaction sent in full in a cmpctblock message, along with its index in the block.
+type PrefilledTx struct {
+	Index uint32
+	Tx    *MsgTx
+}
+
+// MsgCmpctBlock implements the Message interface and represents a bitcoin cmpctblock message. It is used to relay a
+// block as its header and a short ID for each of its transactions, so a peer that already has most of them in its
+// mempool can rebuild the block without them being sent again. The transactions the sender expects the receiver not to
+// have, such as the coinbase, are sent in full. This message was not added until protocol versions starting with
+// BIP0152Version.
+type MsgCmpctBlock struct {
+	Header        BlockHeader
+	Nonce         uint64
+	ShortIDs      []uint64
+	PrefilledTxns []PrefilledTx
+}
+
+// TxCount returns the number of transactions in the block the message relays.
+func (msg *MsgCmpctBlock) TxCount() int {
+	return len(msg.ShortIDs) + len(msg.PrefilledTxns)
+}
+
+// ShortIDKey returns the key of the short transaction IDs of the message, which is the first 16 bytes of the single
+// SHA256 hash of the block header followed by the nonce.
+func (msg *MsgCmpctBlock) ShortIDKey() (key [16]byte) {
+	buf := bytes.NewBuffer(make([]byte, 0, blockHeaderLen+8))
+	_ = writeBlockHeader(buf, 0, &msg.Header)
+	_ = binarySerializer.PutUint64(buf, littleEndian, msg.Nonce)
+	h := sha256.Sum256(buf.Bytes())
+	copy(key[:], h[:16])
+	return
+}
+
+// ShortTxID computes the short ID of a transaction with the key of a cmpctblock message, which is the SipHash-2-4 of the
+// transaction hash with its top two bytes dropped.
+func ShortTxID(key *[16]byte, txHash *chainhash.Hash) uint64 {
+	return siphash.Sum64(txHash[:], key) & 0xffffffffffff
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface
+// implementation.
+func (msg *MsgCmpctBlock) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	if pver < BIP0152Version {
+		str := fmt.Sprintf("cmpctblock message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgCmpctBlock.BtcDecode", str)
+	}
+	err := readBlockHeader(r, pver, &msg.Header)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	err = readElement(r, &msg.Nonce)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	// Prevent more short IDs than could possibly fit into a block.
+	if count > maxTxPerBlock {
+		str := fmt.Sprintf("too many short transaction IDs to fit into "+
+			"a block [count %d, max %d]", count, maxTxPerBlock)
+		return messageError("MsgCmpctBlock.BtcDecode", str)
+	}
+	msg.ShortIDs = make([]uint64, 0, count)
+	var b [8]byte
+	for i := uint64(0); i < count; i++ {
+		if _, err = io.ReadFull(r, b[:ShortTxIDLen]); err != nil {
+			Error(err)
+			return err
+		}
+		msg.ShortIDs = append(msg.ShortIDs, binary.LittleEndian.Uint64(b[:]))
+	}
+	count, err = ReadVarInt(r, pver)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	if count+uint64(len(msg.ShortIDs)) > maxTxPerBlock {
+		str := fmt.Sprintf("too many transactions to fit into a block "+
+			"[count %d, max %d]", count+uint64(len(msg.ShortIDs)), maxTxPerBlock)
+		return messageError("MsgCmpctBlock.BtcDecode", str)
+	}
+	msg.PrefilledTxns = make([]PrefilledTx, 0, count)
+	// The indexes are sent as the difference from the one before, less one, so the absolute index is rebuilt as they
+	// are read and must stay within the block.
+	var index uint64
+	for i := uint64(0); i < count; i++ {
+		diff, err := ReadVarInt(r, pver)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		index += diff
+		if index >= count+uint64(len(msg.ShortIDs)) {
+			str := fmt.Sprintf("prefilled transaction index %d is "+
+				"outside the block", index)
+			return messageError("MsgCmpctBlock.BtcDecode", str)
+		}
+		tx := MsgTx{}
+		err = tx.BtcDecode(r, pver, enc)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		msg.PrefilledTxns = append(msg.PrefilledTxns, PrefilledTx{Index: uint32(index), Tx: &tx})
+		index++
+	}
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface
+// implementation.
+func (msg *MsgCmpctBlock) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if pver < BIP0152Version {
+		str := fmt.Sprintf("cmpctblock message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgCmpctBlock.BtcEncode", str)
+	}
+	err := writeBlockHeader(w, pver, &msg.Header)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	err = writeElement(w, msg.Nonce)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	err = WriteVarInt(w, pver, uint64(len(msg.ShortIDs)))
+	if err != nil {
+		Error(err)
+		return err
+	}
+	var b [8]byte
+	for _, id := range msg.ShortIDs {
+		binary.LittleEndian.PutUint64(b[:], id)
+		if _, err = w.Write(b[:ShortTxIDLen]); err != nil {
+			Error(err)
+			return err
+		}
+	}
+	err = WriteVarInt(w, pver, uint64(len(msg.PrefilledTxns)))
+	if err != nil {
+		Error(err)
+		return err
+	}
+	var next uint32
+	for _, ptx := range msg.PrefilledTxns {
+		if ptx.Index < next {
+			str := fmt.Sprintf("prefilled transaction index %d is out "+
+				"of order", ptx.Index)
+			return messageError("MsgCmpctBlock.BtcEncode", str)
+		}
+		err = WriteVarInt(w, pver, uint64(ptx.Index-next))
+		if err != nil {
+			Error(err)
+			return err
+		}
+		err = ptx.Tx.BtcEncode(w, pver, enc)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		next = ptx.Index + 1
+	}
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part of the Message interface implementation.
+func (msg *MsgCmpctBlock) Command() string {
+	return CmdCmpctBlock
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver. This is part of the Message
+// interface implementation.
+func (msg *MsgCmpctBlock) MaxPayloadLength(pver uint32) uint32 {
+	// A compact block is never bigger than the block it relays.
+	return MaxBlockPayload
+}
+
+// NewMsgCmpctBlock returns a new bitcoin cmpctblock message relaying a block, with the coinbase sent in full and the
+// other transactions as short IDs keyed by the nonce. See MsgCmpctBlock for details.
+func NewMsgCmpctBlock(block *MsgBlock, nonce uint64) *MsgCmpctBlock {
+	msg := &MsgCmpctBlock{
+		Header:   block.Header,
+		Nonce:    nonce,
+		ShortIDs: make([]uint64, 0, len(block.Transactions)),
+	}
+	key := msg.ShortIDKey()
+	for i, tx := range block.Transactions {
+		if i == 0 {
+			msg.PrefilledTxns = append(msg.PrefilledTxns, PrefilledTx{Index: 0, Tx: tx})
+			continue
+		}
+		txHash := tx.TxHash()
+		msg.ShortIDs = append(msg.ShortIDs, ShortTxID(&key, &txHash))
+	}
+	return msg
+}
@@ -0,0 +1,75 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestCmpctBlockWire tests that a compact block made from a block survives wire encode and decode, and that its short
+// IDs identify the transactions of the block.
+func TestCmpctBlockWire(t *testing.T) {
+	block := blockOne
+	block.Transactions = []*MsgTx{blockOne.Transactions[0], multiTx, multiTx.Copy()}
+	block.Transactions[2].LockTime++
+	msg := NewMsgCmpctBlock(&block, 0x0123456789abcdef)
+	if cmd := msg.Command(); cmd != "cmpctblock" {
+		t.Errorf("NewMsgCmpctBlock: wrong command - got %v want %v",
+			cmd, "cmpctblock")
+	}
+	if msg.TxCount() != len(block.Transactions) {
+		t.Errorf("TxCount: got %d, want %d", msg.TxCount(),
+			len(block.Transactions))
+	}
+	if len(msg.PrefilledTxns) != 1 || msg.PrefilledTxns[0].Index != 0 {
+		t.Errorf("NewMsgCmpctBlock: the coinbase was not prefilled - "+
+			"got %s", spew.Sdump(msg.PrefilledTxns))
+	}
+	key := msg.ShortIDKey()
+	for i, tx := range block.Transactions[1:] {
+		txHash := tx.TxHash()
+		if id := ShortTxID(&key, &txHash); id != msg.ShortIDs[i] {
+			t.Errorf("short ID #%d: got %x, want %x", i, msg.ShortIDs[i], id)
+		}
+		if msg.ShortIDs[i]>>48 != 0 {
+			t.Errorf("short ID #%d is longer than 6 bytes: %x", i,
+				msg.ShortIDs[i])
+		}
+	}
+	if msg.ShortIDs[0] == msg.ShortIDs[1] {
+		t.Errorf("different transactions have the same short ID %x",
+			msg.ShortIDs[0])
+	}
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode error %v", err)
+	}
+	// Header, nonce, 2 short IDs and the coinbase with its index.
+	wantLen := blockHeaderLen + 8 + 1 + 2*ShortTxIDLen + 1 + 1 +
+		block.Transactions[0].SerializeSize()
+	if buf.Len() != wantLen {
+		t.Errorf("BtcEncode: got %d bytes, want %d", buf.Len(), wantLen)
+	}
+	var readmsg MsgCmpctBlock
+	if err := readmsg.BtcDecode(bytes.NewReader(buf.Bytes()), ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode error %v", err)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(readmsg),
+			spew.Sdump(msg))
+	}
+	// A prefilled transaction whose index is past the end of the block is refused.
+	bad := buf.Bytes()
+	bad[blockHeaderLen+8+1+2*ShortTxIDLen+1] = 3
+	if err := readmsg.BtcDecode(bytes.NewReader(bad), ProtocolVersion, BaseEncoding); err == nil {
+		t.Errorf("decode of MsgCmpctBlock with a prefilled transaction " +
+			"outside the block succeeded")
+	}
+	// Older protocol versions should fail since the message didn't exist yet.
+	if err := msg.BtcEncode(&buf, BIP0152Version-1, BaseEncoding); err == nil {
+		t.Errorf("encode of MsgCmpctBlock succeeded when it shouldn't "+
+			"have %v", msg)
+	}
+}
@@ -0,0 +1,118 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// MsgGetBlockTxn implements the Message interface and represents a bitcoin getblocktxn message. It is used to request
+// the transactions of a block relayed in a cmpctblock message that the receiver of the compact block could not find in
+// its mempool, by their indexes in the block. This message was not added until protocol versions starting with
+// BIP0152Version.
+type MsgGetBlockTxn struct {
+	BlockHash chainhash.Hash
+	Indexes   []uint32
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface
+// implementation.
+func (msg *MsgGetBlockTxn) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+	if pver < BIP0152Version {
+		str := fmt.Sprintf("getblocktxn message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgGetBlockTxn.BtcDecode", str)
+	}
+	err := readElement(r, &msg.BlockHash)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	count, err := ReadVarInt(r, pver)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	// Prevent more indexes than there could possibly be transactions in a block.
+	if count > maxTxPerBlock {
+		str := fmt.Sprintf("too many transaction indexes for a block "+
+			"[count %d, max %d]", count, maxTxPerBlock)
+		return messageError("MsgGetBlockTxn.BtcDecode", str)
+	}
+	msg.Indexes = make([]uint32, 0, count)
+	// The indexes are sent as the difference from the one before, less one.
+	var index uint64
+	for i := uint64(0); i < count; i++ {
+		diff, err := ReadVarInt(r, pver)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		index += diff
+		if index >= maxTxPerBlock {
+			str := fmt.Sprintf("transaction index %d is outside any "+
+				"block", index)
+			return messageError("MsgGetBlockTxn.BtcDecode", str)
+		}
+		msg.Indexes = append(msg.Indexes, uint32(index))
+		index++
+	}
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface
+// implementation.
+func (msg *MsgGetBlockTxn) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+	if pver < BIP0152Version {
+		str := fmt.Sprintf("getblocktxn message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgGetBlockTxn.BtcEncode", str)
+	}
+	err := writeElement(w, &msg.BlockHash)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	err = WriteVarInt(w, pver, uint64(len(msg.Indexes)))
+	if err != nil {
+		Error(err)
+		return err
+	}
+	var next uint32
+	for _, index := range msg.Indexes {
+		if index < next {
+			str := fmt.Sprintf("transaction index %d is out of order",
+				index)
+			return messageError("MsgGetBlockTxn.BtcEncode", str)
+		}
+		err = WriteVarInt(w, pver, uint64(index-next))
+		if err != nil {
+			Error(err)
+			return err
+		}
+		next = index + 1
+	}
+	return nil
+}
+
+// Command returns the protocol command string for the message. This is part of the Message interface implementation.
+func (msg *MsgGetBlockTxn) Command() string {
+	return CmdGetBlockTxn
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver. This is part of the Message
+// interface implementation.
+func (msg *MsgGetBlockTxn) MaxPayloadLength(pver uint32) uint32 {
+	// Block hash + index count + an index for every transaction that could fit into a block.
+	return chainhash.HashSize + MaxVarIntPayload + maxTxPerBlock*MaxVarIntPayload
+}
+
+// NewMsgGetBlockTxn returns a new bitcoin getblocktxn message requesting the transactions at the given indexes, which
+// must be in ascending order, of the block with the given hash. See MsgGetBlockTxn for details.
+func NewMsgGetBlockTxn(blockHash *chainhash.Hash, indexes []uint32) *MsgGetBlockTxn {
+	return &MsgGetBlockTxn{
+		BlockHash: *blockHash,
+		Indexes:   indexes,
+	}
+}
@@ -0,0 +1,49 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// TestGetBlockTxnWire tests the MsgGetBlockTxn wire encode and decode, with its indexes sent as differences.
+func TestGetBlockTxnWire(t *testing.T) {
+	hash := chainhash.Hash{0x01, 0x02}
+	msg := NewMsgGetBlockTxn(&hash, []uint32{0, 1, 5, 300})
+	if cmd := msg.Command(); cmd != "getblocktxn" {
+		t.Errorf("NewMsgGetBlockTxn: wrong command - got %v want %v",
+			cmd, "getblocktxn")
+	}
+	wantBuf := append(hash[:], 0x04, 0x00, 0x00, 0x03, 0xfd, 0x26, 0x01)
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode error %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), wantBuf) {
+		t.Errorf("BtcEncode\n got: %s want: %s",
+			spew.Sdump(buf.Bytes()), spew.Sdump(wantBuf))
+	}
+	var readmsg MsgGetBlockTxn
+	if err := readmsg.BtcDecode(bytes.NewReader(wantBuf), ProtocolVersion, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode error %v", err)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(readmsg),
+			spew.Sdump(msg))
+	}
+	// Indexes that are not in ascending order can't be encoded as differences.
+	msg.Indexes = []uint32{5, 1}
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err == nil {
+		t.Errorf("encode of MsgGetBlockTxn with indexes out of order " +
+			"succeeded")
+	}
+	// Older protocol versions should fail since the message didn't exist yet.
+	if err := readmsg.BtcDecode(bytes.NewReader(wantBuf), BIP0152Version-1, BaseEncoding); err == nil {
+		t.Errorf("decode of MsgGetBlockTxn succeeded when it shouldn't "+
+			"have %v", spew.Sdump(wantBuf))
+	}
+}
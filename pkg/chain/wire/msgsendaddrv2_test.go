@@ -0,0 +1,47 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestSendAddrV2Wire tests the MsgSendAddrV2 wire encode and decode, and that it is refused before AddrV2Version.
+func TestSendAddrV2Wire(t *testing.T) {
+	msg := NewMsgSendAddrV2()
+	if cmd := msg.Command(); cmd != "sendaddrv2" {
+		t.Errorf("NewMsgSendAddrV2: wrong command - got %v want %v",
+			cmd, "sendaddrv2")
+	}
+	if maxPayload := msg.MaxPayloadLength(ProtocolVersion); maxPayload != 0 {
+		t.Errorf("MaxPayloadLength: wrong max payload length - got %v, "+
+			"want %v", maxPayload, 0)
+	}
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, AddrV2Version, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode error %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("BtcEncode\n got: %s want no payload", spew.Sdump(buf.Bytes()))
+	}
+	var readmsg MsgSendAddrV2
+	if err := readmsg.BtcDecode(bytes.NewReader(nil), AddrV2Version, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode error %v", err)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(readmsg),
+			spew.Sdump(msg))
+	}
+	// Older protocol versions should fail since the message didn't exist yet.
+	oldPver := AddrV2Version - 1
+	if err := msg.BtcEncode(&buf, oldPver, BaseEncoding); err == nil {
+		t.Errorf("encode of MsgSendAddrV2 succeeded when it shouldn't "+
+			"have %v", msg)
+	}
+	if err := readmsg.BtcDecode(bytes.NewReader(nil), oldPver, BaseEncoding); err == nil {
+		t.Errorf("decode of MsgSendAddrV2 succeeded when it shouldn't " +
+			"have")
+	}
+}
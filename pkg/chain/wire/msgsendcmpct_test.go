@@ -0,0 +1,49 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestSendCmpctWire tests the MsgSendCmpct wire encode and decode, and that it is refused before BIP0152Version.
+func TestSendCmpctWire(t *testing.T) {
+	msg := NewMsgSendCmpct(true, CmpctBlockVersion)
+	if cmd := msg.Command(); cmd != "sendcmpct" {
+		t.Errorf("NewMsgSendCmpct: wrong command - got %v want %v",
+			cmd, "sendcmpct")
+	}
+	if maxPayload := msg.MaxPayloadLength(ProtocolVersion); maxPayload != 9 {
+		t.Errorf("MaxPayloadLength: wrong max payload length - got %v, "+
+			"want %v", maxPayload, 9)
+	}
+	wantBuf := []byte{0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, BIP0152Version, BaseEncoding); err != nil {
+		t.Fatalf("BtcEncode error %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), wantBuf) {
+		t.Errorf("BtcEncode\n got: %s want: %s",
+			spew.Sdump(buf.Bytes()), spew.Sdump(wantBuf))
+	}
+	var readmsg MsgSendCmpct
+	if err := readmsg.BtcDecode(bytes.NewReader(wantBuf), BIP0152Version, BaseEncoding); err != nil {
+		t.Fatalf("BtcDecode error %v", err)
+	}
+	if !reflect.DeepEqual(&readmsg, msg) {
+		t.Errorf("BtcDecode\n got: %s want: %s", spew.Sdump(readmsg),
+			spew.Sdump(msg))
+	}
+	// Older protocol versions should fail since the message didn't exist yet.
+	oldPver := BIP0152Version - 1
+	if err := msg.BtcEncode(&buf, oldPver, BaseEncoding); err == nil {
+		t.Errorf("encode of MsgSendCmpct succeeded when it shouldn't "+
+			"have %v", msg)
+	}
+	if err := readmsg.BtcDecode(bytes.NewReader(wantBuf), oldPver, BaseEncoding); err == nil {
+		t.Errorf("decode of MsgSendCmpct succeeded when it shouldn't "+
+			"have %v", spew.Sdump(wantBuf))
+	}
+}
@@ -1,12 +1,65 @@
 package wire
 
 import (
+	"bytes"
 	"encoding/binary"
+	"fmt"
 	"io"
 	"net"
 	"time"
 )
 
+// NetworkID identifies the network an address belongs to in an addrv2 message, as defined in BIP 155.
+type NetworkID uint8
+
+const (
+	// NetIPv4 is the network ID of IPv4 addresses.
+	NetIPv4 NetworkID = 1
+	// NetIPv6 is the network ID of IPv6 addresses.
+	NetIPv6 NetworkID = 2
+	// NetTorV2 is the network ID of Tor v2 onion services, which are relayed in addr messages as onioncat IPv6
+	// addresses.
+	NetTorV2 NetworkID = 3
+	// NetTorV3 is the network ID of Tor v3 onion services, whose address is their 32 byte ed25519 public key.
+	NetTorV3 NetworkID = 4
+	// NetI2P is the network ID of I2P destinations, whose address is the 32 byte SHA256 hash of the destination.
+	NetI2P NetworkID = 5
+	// NetCJDNS is the network ID of CJDNS addresses.
+	NetCJDNS NetworkID = 6
+)
+
+// Map of network IDs back to their constant names for pretty printing.
+var netIDStrings = map[NetworkID]string{
+	NetIPv4:  "IPV4",
+	NetIPv6:  "IPV6",
+	NetTorV2: "TORV2",
+	NetTorV3: "TORV3",
+	NetI2P:   "I2P",
+	NetCJDNS: "CJDNS",
+}
+
+// String returns the NetworkID in human-readable form.
+func (n NetworkID) String() string {
+	if s, ok := netIDStrings[n]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown NetworkID (%d)", uint8(n))
+}
+
+// netIDAddrLen is the length of the address of each network in an addrv2 message.
+var netIDAddrLen = map[NetworkID]int{
+	NetIPv4:  net.IPv4len,
+	NetIPv6:  net.IPv6len,
+	NetTorV2: 10,
+	NetTorV3: 32,
+	NetI2P:   32,
+	NetCJDNS: net.IPv6len,
+}
+
+// onionCatPrefix is the IPv6 prefix Tor v2 onion services are mapped into by onioncat so they can be relayed as IPv6
+// addresses.
+var onionCatPrefix = []byte{0xfd, 0x87, 0xd8, 0x7e, 0xeb, 0x43}
+
 // maxNetAddressPayload returns the max payload size for a bitcoin NetAddress based on the protocol version.
 func maxNetAddressPayload(pver uint32) uint32 {
 	// Services 8 bytes + ip 16 bytes + port 2 bytes.
@@ -32,6 +85,11 @@ type NetAddress struct {
 	IP net.IP
 	// Port the peer is using.  This is encoded in big endian on the wire which differs from most everything else.
 	Port uint16
+	// Network of an address that can't be represented as an IP address, such as a Tor v3 onion service or an I2P
+	// destination. It is zero for addresses that can, and such addresses are only relayed in addrv2 messages.
+	Net NetworkID
+	// Address on the network given by Net, when that is set. IP is nil for such addresses.
+	Addr []byte
 }
 
 // HasService returns whether the specified service is supported by the address.
@@ -44,6 +102,26 @@ func (na *NetAddress) AddService(service ServiceFlag) {
 	na.Services |= service
 }
 
+// NetID returns the network the address belongs to.
+func (na *NetAddress) NetID() NetworkID {
+	switch {
+	case na.Net != 0:
+		return na.Net
+	case na.IP.To4() != nil:
+		return NetIPv4
+	case bytes.HasPrefix(na.IP, onionCatPrefix):
+		return NetTorV2
+	default:
+		return NetIPv6
+	}
+}
+
+// NeedsAddrV2 returns whether the address can only be relayed in an addrv2 message, because it can't be represented as
+// an IPv6 address.
+func (na *NetAddress) NeedsAddrV2() bool {
+	return na.Net != 0
+}
+
 // NewNetAddressIPPort returns a new NetAddress using the provided IP, port, and supported services with defaults for
 // the remaining fields.
 func NewNetAddressIPPort(ip net.IP, port uint16, services ServiceFlag) *NetAddress {
@@ -63,6 +141,35 @@ func NewNetAddressTimestamp(timestamp time.Time, services ServiceFlag, ip net.IP
 	return &na
 }
 
+// NewNetAddressNetID returns a new NetAddress using the provided timestamp, network, address, port, and supported
+// services. Addresses of networks that fit in an IPv6 address, which are IPv4, IPv6 and Tor v2, are stored as an IP
+// address as if they had been read from an addr message. An error is returned if the address is not of the length
+// used by the network, or the network is not one that can be connected to.
+func NewNetAddressNetID(timestamp time.Time, services ServiceFlag, netID NetworkID, addr []byte,
+	port uint16) (*NetAddress, error) {
+	addrLen, ok := netIDAddrLen[netID]
+	if !ok || netID == NetCJDNS {
+		str := fmt.Sprintf("unsupported address network %v", netID)
+		return nil, messageError("NewNetAddressNetID", str)
+	}
+	if len(addr) != addrLen {
+		str := fmt.Sprintf("%v address has length %d, must be %d", netID, len(addr), addrLen)
+		return nil, messageError("NewNetAddressNetID", str)
+	}
+	switch netID {
+	case NetIPv4, NetIPv6:
+		return NewNetAddressTimestamp(timestamp, services, net.IP(addr).To16(), port), nil
+	case NetTorV2:
+		ip := make(net.IP, 0, net.IPv6len)
+		ip = append(append(ip, onionCatPrefix...), addr...)
+		return NewNetAddressTimestamp(timestamp, services, ip, port), nil
+	}
+	na := NewNetAddressTimestamp(timestamp, services, nil, port)
+	na.Net = netID
+	na.Addr = append([]byte(nil), addr...)
+	return na, nil
+}
+
 // NewNetAddress returns a new NetAddress using the provided TCP address and supported services with defaults for the
 // remaining fields.
 func NewNetAddress(addr *net.TCPAddr, services ServiceFlag) *NetAddress {
@@ -127,3 +234,96 @@ func writeNetAddress(w io.Writer, pver uint32, na *NetAddress, ts bool) error {
 	// Sigh.  Bitcoin protocol mixes little and big endian.
 	return binary.Write(w, bigEndian, na.Port)
 }
+
+// maxNetAddressV2Payload is the max payload size of an address in an addrv2 message, with the longest address allowed
+// by BIP 155.
+const maxNetAddressV2Payload = 4 + MaxVarIntPayload + 1 + MaxVarIntPayload + maxAddrV2Len + 2
+
+// maxAddrV2Len is the longest address of any network allowed in an addrv2 message.
+const maxAddrV2Len = 512
+
+// readNetAddressV2 reads an address in the encoding of addrv2 messages from r. Addresses of networks that are unknown
+// or can't be connected to are read but not stored in na, and false is returned for them.
+func readNetAddressV2(r io.Reader, pver uint32, na *NetAddress) (bool, error) {
+	var timestamp uint32Time
+	err := readElement(r, &timestamp)
+	if err != nil {
+		Error(err)
+		return false, err
+	}
+	services, err := ReadVarInt(r, pver)
+	if err != nil {
+		Error(err)
+		return false, err
+	}
+	id, err := binarySerializer.Uint8(r)
+	if err != nil {
+		Error(err)
+		return false, err
+	}
+	netID := NetworkID(id)
+	addr, err := ReadVarBytes(r, pver, maxAddrV2Len, "addrv2 address")
+	if err != nil {
+		Error(err)
+		return false, err
+	}
+	port, err := binarySerializer.Uint16(r, bigEndian)
+	if err != nil {
+		Error(err)
+		return false, err
+	}
+	// Addresses of networks nodes don't know are to be skipped, but known networks must have the right length.
+	addrLen, ok := netIDAddrLen[netID]
+	if !ok || netID == NetCJDNS {
+		return false, nil
+	}
+	if len(addr) != addrLen {
+		str := fmt.Sprintf("%v address has length %d, must be %d", netID, len(addr), addrLen)
+		return false, messageError("readNetAddressV2", str)
+	}
+	n, err := NewNetAddressNetID(time.Time(timestamp), ServiceFlag(services), netID, addr, port)
+	if err != nil {
+		Error(err)
+		return false, err
+	}
+	*na = *n
+	return true, nil
+}
+
+// writeNetAddressV2 serializes a NetAddress to w in the encoding of addrv2 messages.
+func writeNetAddressV2(w io.Writer, pver uint32, na *NetAddress) error {
+	netID := na.NetID()
+	var addr []byte
+	switch netID {
+	case NetIPv4:
+		addr = na.IP.To4()
+	case NetIPv6:
+		addr = na.IP.To16()
+	case NetTorV2:
+		addr = na.IP[len(onionCatPrefix):]
+	default:
+		addr = na.Addr
+	}
+	if addrLen, ok := netIDAddrLen[netID]; !ok || len(addr) != addrLen {
+		str := fmt.Sprintf("%v address has length %d, must be %d", netID, len(addr), addrLen)
+		return messageError("writeNetAddressV2", str)
+	}
+	err := writeElement(w, uint32(na.Timestamp.Unix()))
+	if err != nil {
+		Error(err)
+		return err
+	}
+	if err = WriteVarInt(w, pver, uint64(na.Services)); err != nil {
+		Error(err)
+		return err
+	}
+	if err = binarySerializer.PutUint8(w, uint8(netID)); err != nil {
+		Error(err)
+		return err
+	}
+	if err = WriteVarBytes(w, pver, addr); err != nil {
+		Error(err)
+		return err
+	}
+	return binary.Write(w, bigEndian, na.Port)
+}
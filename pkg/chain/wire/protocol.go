@@ -9,7 +9,7 @@ import (
 // XXX pedro: we will probably need to bump this.
 const (
 	// ProtocolVersion is the latest protocol version this package supports.
-	ProtocolVersion uint32 = 70013
+	ProtocolVersion uint32 = 70016
 	// MultipleAddressVersion is the protocol version which added multiple addresses per message (pver >=
 	// MultipleAddressVersion).
 	MultipleAddressVersion uint32 = 209
@@ -31,6 +31,12 @@ const (
 	SendHeadersVersion uint32 = 70012
 	// FeeFilterVersion is the protocol version which added a new feefilter message.
 	FeeFilterVersion uint32 = 70013
+	// BIP0152Version is the protocol version which added the sendcmpct, cmpctblock, getblocktxn and blocktxn messages
+	// of compact block relay.
+	BIP0152Version uint32 = 70014
+	// AddrV2Version is the protocol version which added the sendaddrv2 and addrv2 messages of BIP 155, which relay
+	// addresses of networks that don't fit in an IPv6 address, such as Tor v3 onion services and I2P.
+	AddrV2Version uint32 = 70016
 )
 
 // ServiceFlag identifies services supported by a bitcoin peer.
@@ -54,6 +60,8 @@ const (
 	SFNodeCF
 	// SFNode2X is a flag used to indicate a peer is running the Segwit2X software.
 	SFNode2X
+	// SFNodeP2PV2 is a flag used to indicate a peer accepts connections over the encrypted v2 transport (BIP0324).
+	SFNodeP2PV2 ServiceFlag = 1 << 11
 )
 
 // Map of service flags back to their constant names for pretty printing.
@@ -66,6 +74,7 @@ var sfStrings = map[ServiceFlag]string{
 	SFNodeBit5:    "SFNodeBit5",
 	SFNodeCF:      "SFNodeCF",
 	SFNode2X:      "SFNode2X",
+	SFNodeP2PV2:   "SFNodeP2PV2",
 }
 
 // orderedSFStrings is an ordered list of service flags from highest to lowest.
@@ -78,6 +87,7 @@ var orderedSFStrings = []ServiceFlag{
 	SFNodeBit5,
 	SFNodeCF,
 	SFNode2X,
+	SFNodeP2PV2,
 }
 
 // String returns the ServiceFlag in human-readable form.
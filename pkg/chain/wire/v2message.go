@@ -0,0 +1,145 @@
+package wire
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
+
+// v2ShortIDs maps the commands of messages sent with a one byte short message ID in the v2 transport to their ID. The
+// IDs are those of BIP 324, and the commands of other messages are sent in full.
+var v2ShortIDs = map[string]byte{
+	CmdAddr:         1,
+	CmdBlock:        2,
+	CmdBlockTxn:     3,
+	CmdCmpctBlock:   4,
+	CmdFeeFilter:    5,
+	CmdFilterAdd:    6,
+	CmdFilterClear:  7,
+	CmdFilterLoad:   8,
+	CmdGetBlocks:    9,
+	CmdGetBlockTxn:  10,
+	CmdGetData:      11,
+	CmdGetHeaders:   12,
+	CmdHeaders:      13,
+	CmdInv:          14,
+	CmdMemPool:      15,
+	CmdMerkleBlock:  16,
+	CmdNotFound:     17,
+	CmdPing:         18,
+	CmdPong:         19,
+	CmdSendCmpct:    20,
+	CmdTx:           21,
+	CmdGetCFilters:  22,
+	CmdCFilter:      23,
+	CmdGetCFHeaders: 24,
+	CmdCFHeaders:    25,
+	CmdGetCFCheckpt: 26,
+	CmdCFCheckpt:    27,
+	CmdAddrV2:       28,
+}
+
+// v2ShortIDCommands maps the short message IDs of the v2 transport back to their commands.
+var v2ShortIDCommands = func() map[byte]string {
+	m := make(map[byte]string, len(v2ShortIDs))
+	for cmd, id := range v2ShortIDs {
+		m[id] = cmd
+	}
+	return m
+}()
+
+// EncodeV2MessageContents returns the contents of a v2 transport packet carrying the passed message. The contents start
+// with the short ID of the message command, or a zero byte followed by the command padded to CommandSize bytes if the
+// command has no short ID, and the message payload follows. Unlike the v1 framing there is no network magic, length or
+// checksum, as the packet length and authentication are part of the encrypted transport.
+func EncodeV2MessageContents(msg Message, pver uint32, enc MessageEncoding) ([]byte, error) {
+	cmd := msg.Command()
+	if len(cmd) > CommandSize {
+		str := fmt.Sprintf("command [%s] is too long [max %v]",
+			cmd, CommandSize)
+		return nil, messageError("EncodeV2MessageContents", str)
+	}
+	var bw bytes.Buffer
+	if id, ok := v2ShortIDs[cmd]; ok {
+		bw.WriteByte(id)
+	} else {
+		var command [CommandSize + 1]byte
+		copy(command[1:], cmd)
+		bw.Write(command[:])
+	}
+	headerLen := bw.Len()
+	err := msg.BtcEncode(&bw, pver, enc)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	lenp := bw.Len() - headerLen
+	// Enforce maximum overall message payload.
+	if lenp > MaxMessagePayload {
+		str := fmt.Sprintf("message payload is too large - encoded "+
+			"%d bytes, but maximum message payload is %d bytes",
+			lenp, MaxMessagePayload)
+		return nil, messageError("EncodeV2MessageContents", str)
+	}
+	// Enforce maximum message payload based on the message type.
+	mpl := msg.MaxPayloadLength(pver)
+	if uint32(lenp) > mpl {
+		str := fmt.Sprintf("message payload is too large - encoded "+
+			"%d bytes, but maximum message payload size for "+
+			"messages of type [%s] is %d.", lenp, cmd, mpl)
+		return nil, messageError("EncodeV2MessageContents", str)
+	}
+	return bw.Bytes(), nil
+}
+
+// DecodeV2MessageContents parses the message carried by the contents of a v2 transport packet, returning it along with
+// its raw payload. See EncodeV2MessageContents for the format of the contents.
+func DecodeV2MessageContents(contents []byte, pver uint32, enc MessageEncoding) (Message, []byte, error) {
+	if len(contents) == 0 {
+		return nil, nil, messageError("DecodeV2MessageContents",
+			"message has no command")
+	}
+	var command string
+	var payload []byte
+	if contents[0] != 0 {
+		var ok bool
+		if command, ok = v2ShortIDCommands[contents[0]]; !ok {
+			str := fmt.Sprintf("unknown short message ID %d", contents[0])
+			return nil, nil, messageError("DecodeV2MessageContents", str)
+		}
+		payload = contents[1:]
+	} else {
+		if len(contents) < CommandSize+1 {
+			return nil, nil, messageError("DecodeV2MessageContents",
+				"message command is truncated")
+		}
+		command = string(bytes.TrimRight(contents[1:CommandSize+1], string(rune(0))))
+		payload = contents[CommandSize+1:]
+	}
+	// Check for malformed commands.
+	if !utf8.ValidString(command) {
+		str := fmt.Sprintf("invalid command %v", []byte(command))
+		return nil, nil, messageError("DecodeV2MessageContents", str)
+	}
+	// Create struct of appropriate message type based on the command.
+	msg, err := makeEmptyMessage(command)
+	if err != nil {
+		Error(err)
+		return nil, nil, messageError("DecodeV2MessageContents",
+			err.Error())
+	}
+	if mpl := msg.MaxPayloadLength(pver); uint32(len(payload)) > mpl {
+		str := fmt.Sprintf("payload exceeds max length - packet "+
+			"has %v bytes, but max payload size for "+
+			"messages of type [%v] is %v.", len(payload), command, mpl)
+		return nil, nil, messageError("DecodeV2MessageContents", str)
+	}
+	// Unmarshal message. NOTE: This must be a *bytes.Buffer since the MsgVersion BtcDecode function requires it.
+	pr := bytes.NewBuffer(payload)
+	err = msg.BtcDecode(pr, pver, enc)
+	if err != nil {
+		Error(err)
+		return nil, nil, err
+	}
+	return msg, payload, nil
+}
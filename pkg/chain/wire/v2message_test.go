@@ -0,0 +1,76 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestV2MessageContents tests the encoding and decoding of messages for the v2 transport with and without short IDs.
+func TestV2MessageContents(t *testing.T) {
+	ping := NewMsgPing(123123)
+	verack := NewMsgVerAck()
+	var verackCmd [CommandSize + 1]byte
+	copy(verackCmd[1:], CmdVerAck)
+	tests := []struct {
+		in  Message
+		out []byte
+	}{
+		{ping, []byte{18, 0xf3, 0xe0, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{verack, verackCmd[:]},
+	}
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		contents, err := EncodeV2MessageContents(test.in, ProtocolVersion, BaseEncoding)
+		if err != nil {
+			t.Errorf("EncodeV2MessageContents #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(contents, test.out) {
+			t.Errorf("EncodeV2MessageContents #%d\n got: %s want: %s", i,
+				spew.Sdump(contents), spew.Sdump(test.out))
+			continue
+		}
+		msg, _, err := DecodeV2MessageContents(contents, ProtocolVersion, BaseEncoding)
+		if err != nil {
+			t.Errorf("DecodeV2MessageContents #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(msg, test.in) {
+			t.Errorf("DecodeV2MessageContents #%d\n got: %s want: %s", i,
+				spew.Sdump(msg), spew.Sdump(test.in))
+		}
+	}
+	// Every short ID must map back to its own command.
+	for cmd, id := range v2ShortIDs {
+		if v2ShortIDCommands[id] != cmd {
+			t.Errorf("short ID %d maps to %q, want %q", id,
+				v2ShortIDCommands[id], cmd)
+		}
+	}
+}
+
+// TestV2MessageContentsErrors performs negative tests against decoding v2 message contents to confirm error paths work
+// correctly.
+func TestV2MessageContentsErrors(t *testing.T) {
+	var bogusCmd [CommandSize + 1]byte
+	copy(bogusCmd[1:], "bogus")
+	tests := []struct {
+		name string
+		in   []byte
+	}{
+		{"empty", []byte{}},
+		{"unknown short id", []byte{200}},
+		{"truncated command", []byte{0, 'p', 'i', 'n', 'g'}},
+		{"unknown command", bogusCmd[:]},
+		{"truncated payload", []byte{18, 0x01, 0x02}},
+		{"oversized payload", append([]byte{18}, make([]byte, 9)...)},
+	}
+	for _, test := range tests {
+		if _, _, err := DecodeV2MessageContents(test.in, ProtocolVersion, BaseEncoding); err == nil {
+			t.Errorf("DecodeV2MessageContents %s: expected error", test.name)
+		}
+	}
+}
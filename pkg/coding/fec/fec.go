@@ -1,106 +1,304 @@
-// Package fec implements Reed Solomon 9/3 forward error correction,
-//  intended to be sent as 9 pieces where 3 uncorrupted parts allows assembly of the message
+// Package fec implements Reed Solomon forward error correction with a configurable number of shards, where any of the
+// required number of uncorrupted shards out of the total allows assembly of the message.
+//
+// Messages can be split into segments that each encode to shards no larger than a given size, so a message bigger than
+// one datagram can be sent as several sets of shards. Every shard carries the format version and the parameters it was
+// encoded with, so a receiver can reassemble messages from senders with different settings, and refuses shards in a
+// format it does not know.
 package fec
 
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
+	"math"
+	"sync"
 
 	"github.com/vivint/infectious"
 )
 
+const (
+	// Version is the format of the shards, the first byte of their header
+	Version = 1
+	// HeaderLen is the size of the header at the front of each shard: the format version, the shard number, the
+	// required and total shard counts, and the segment number and count
+	HeaderLen = 6
+	// MaxShards is the largest total number of shards a segment can be encoded to
+	MaxShards = 255
+	// MaxSegments is the largest number of segments a message can be split into
+	MaxSegments = 255
+	// prefixLen is the size of the length prefix in front of the data of each segment
+	prefixLen = 4
+)
+
 var (
-	rsTotal    = 9
-	rsRequired = 3
-	rsFEC      = func() *infectious.FEC {
-		fec, err := infectious.NewFEC(rsRequired, rsTotal)
-		if err != nil {
-			Error(err)
-		}
-		return fec
-	}()
+	// Default is the 9/3 encoding that was previously the only one available
+	Default = Params{Required: 3, Total: 9}
+	// ErrTooLarge is returned when a message needs more than MaxSegments segments
+	ErrTooLarge = errors.New("message is too large to be segmented")
+	// ErrUnknownVersion is returned for a shard in a format other than Version
+	ErrUnknownVersion = errors.New("shard format version is not known")
+	codecs            sync.Map
 )
 
-// padData appends a 2 byte length prefix, and pads to a multiple of rsTotal. Max message size is limited to 1<<32 but
-// in our use will never get near this size through higher level protocols breaking packets into sessions
-func padData(data []byte) (out []byte) {
-	dataLen := len(data)
-	prefixBytes := make([]byte, 4)
-	binary.LittleEndian.PutUint32(prefixBytes, uint32(dataLen))
-	data = append(prefixBytes, data...)
-	dataLen = len(data)
-	chunkLen := (dataLen) / rsTotal
-	chunkMod := (dataLen) % rsTotal
-	if chunkMod != 0 {
-		chunkLen++
-	}
-	padLen := rsTotal*chunkLen - dataLen
-	out = append(data, make([]byte, padLen)...)
+// Params are the number of shards a segment is encoded to and how many of them are needed to decode it
+type Params struct {
+	Required int
+	Total    int
+}
+
+// Validate returns an error if the parameters cannot be used for encoding
+func (p Params) Validate() error {
+	if p.Required < 1 || p.Total < p.Required || p.Total > MaxShards {
+		return fmt.Errorf("invalid fec parameters %d/%d", p.Total, p.Required)
+	}
+	return nil
+}
+
+// Redundancy is the ratio of the amount of encoded data to the amount of the original data
+func (p Params) Redundancy() float64 {
+	return float64(p.Total) / float64(p.Required)
+}
+
+// FailureRate is the probability that fewer than the required number of shards of a segment arrive when each shard is
+// lost independently with the given probability
+func (p Params) FailureRate(loss float64) (rate float64) {
+	if loss <= 0 {
+		return 0
+	}
+	if loss >= 1 {
+		return 1
+	}
+	// sum the binomial probabilities of receiving 0 to Required-1 shards
+	lgTotal, _ := math.Lgamma(float64(p.Total + 1))
+	for received := 0; received < p.Required; received++ {
+		lgReceived, _ := math.Lgamma(float64(received + 1))
+		lgLost, _ := math.Lgamma(float64(p.Total - received + 1))
+		rate += math.Exp(
+			lgTotal - lgReceived - lgLost +
+				float64(received)*math.Log(1-loss) + float64(p.Total-received)*math.Log(loss),
+		)
+	}
+	return math.Min(rate, 1)
+}
+
+// ForLoss returns the parameters with the given number of required shards and the smallest total for which the
+// failure rate at the given loss rate is no more than maxFailure. If no total up to MaxShards achieves it, MaxShards
+// is used.
+func ForLoss(required int, loss, maxFailure float64) (p Params) {
+	p.Required = required
+	for p.Total = required; p.Total < MaxShards; p.Total++ {
+		if p.FailureRate(loss) <= maxFailure {
+			break
+		}
+	}
 	return
 }
 
-// Encode turns a byte slice into a set of shards with first byte containing the shard number. Previously this code
-// included a CRC32 but this is unnecessary since the shards will be sent wrapped in HMAC protected encryption
-func Encode(data []byte) (chunks [][]byte, err error) {
-	// First we must pad the data
-	data = padData(data)
-	shares := make([]infectious.Share, rsTotal)
-	output := func(s infectious.Share) {
-		shares[s.Number] = s.DeepCopy()
-	}
-	err = rsFEC.Encode(data, output)
-	if err != nil {
-		Error(err)
+// getFEC returns the codec for a set of parameters, which are created once and shared
+func getFEC(p Params) (f *infectious.FEC, err error) {
+	if c, ok := codecs.Load(p); ok {
+		return c.(*infectious.FEC), nil
+	}
+	if err = p.Validate(); err != nil {
 		return
 	}
-	for i := range shares {
-		// Append the chunk number to the front of the chunk
-		chunk := append([]byte{byte(shares[i].Number)}, shares[i].Data...)
-		// Checksum includes chunk number byte so we know if its checksum is incorrect so could the chunk number be
-		//
-		// checksum := crc32.Checksum(chunk, crc32.MakeTable(crc32.Castagnoli))
-		// checkBytes := make([]byte, 4)
-		// binary.LittleEndian.PutUint32(checkBytes, checksum)
-		// chunk = append(chunk, checkBytes...)
-		chunks = append(chunks, chunk)
-	}
-	// L.Spew(chunks)
+	if f, err = infectious.NewFEC(p.Required, p.Total); err != nil {
+		return
+	}
+	codecs.Store(p, f)
+	return
+}
+
+// padData prepends a 4 byte length prefix, and pads to a multiple of the required number of shards. Max segment size
+// is limited to 1<<32 but in our use will never get near this size as messages are broken into segments
+func padData(data []byte, required int) (out []byte) {
+	out = make([]byte, prefixLen+len(data))
+	binary.LittleEndian.PutUint32(out, uint32(len(data)))
+	copy(out[prefixLen:], data)
+	if mod := len(out) % required; mod != 0 {
+		out = append(out, make([]byte, required-mod)...)
+	}
+	return
+}
+
+// SegmentSize returns the largest amount of data that fits in one segment with shards no larger than maxShardSize
+func (p Params) SegmentSize(maxShardSize int) int {
+	return (maxShardSize-HeaderLen)*p.Required - prefixLen
+}
+
+// Encode turns a byte slice into shards with the header in front. If maxShardSize is greater than zero the data is
+// split into segments that encode to shards of at most that size, otherwise it is encoded as one segment. The shards
+// are in segment order. There is no checksum as the shards will be sent wrapped in HMAC protected encryption
+func (p Params) Encode(data []byte, maxShardSize int) (shards [][]byte, err error) {
+	var f *infectious.FEC
+	if f, err = getFEC(p); err != nil {
+		return
+	}
+	segments := [][]byte{data}
+	if maxShardSize > 0 {
+		size := p.SegmentSize(maxShardSize)
+		if size < 1 {
+			return nil, fmt.Errorf("shard size %d is too small to hold any data", maxShardSize)
+		}
+		segments = nil
+		for len(data) > size {
+			segments = append(segments, data[:size])
+			data = data[size:]
+		}
+		segments = append(segments, data)
+	}
+	if len(segments) > MaxSegments {
+		return nil, ErrTooLarge
+	}
+	for seg := range segments {
+		header := []byte{Version, 0, byte(p.Required), byte(p.Total), byte(seg), byte(len(segments))}
+		if err = f.Encode(
+			padData(segments[seg], p.Required),
+			func(s infectious.Share) {
+				shard := make([]byte, HeaderLen+len(s.Data))
+				copy(shard, header)
+				shard[1] = byte(s.Number)
+				copy(shard[HeaderLen:], s.Data)
+				shards = append(shards, shard)
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
+// Encode turns a byte slice into one segment of shards with the default parameters
+func Encode(data []byte) (chunks [][]byte, err error) {
+	return Default.Encode(data, 0)
+}
+
+// Decode reassembles a message from a set of shards
 func Decode(chunks [][]byte) (data []byte, err error) {
-	var shares []infectious.Share
 	if len(chunks) < 1 {
 		Debug("nil chunks")
 		return nil, errors.New("asked to decode nothing")
 	}
-	totalLen := 0
+	p := NewPartial()
 	for i := range chunks {
-		// bodyLen := len(chunks[i])
-		// log.SPEW(chunks[i])
-		body := chunks[i] // [:bodyLen]
-		share := infectious.Share{
-			Number: int(body[0]),
-			Data:   body[1:],
+		if err = p.Add(chunks[i]); err != nil {
+			return
 		}
-		shares = append(shares, share)
-		totalLen += len(share.Data)
 	}
-	if shares == nil {
-		panic("shares are nil")
+	return p.Data()
+}
+
+// segment is the state of the reassembly of one segment of a message
+type segment struct {
+	params Params
+	shares []infectious.Share
+	seen   []bool
+	data   []byte
+}
+
+// Partial collects the shards of a message as they arrive and decodes each segment once enough of its shards are in
+type Partial struct {
+	segments []*segment
+	decoded  int
+	received int
+	expected int
+}
+
+// NewPartial returns an empty Partial
+func NewPartial() *Partial {
+	return &Partial{}
+}
+
+// Add adds a shard to the message. Shards that were already received, and shards of segments that have already been
+// decoded, are ignored apart from being counted. Shards in an unknown format are refused with ErrUnknownVersion.
+func (p *Partial) Add(shard []byte) (err error) {
+	if len(shard) < HeaderLen {
+		return errors.New("shard is shorter than its header")
+	}
+	if shard[0] != Version {
+		return ErrUnknownVersion
+	}
+	number, seg, segs := int(shard[1]), int(shard[4]), int(shard[5])
+	params := Params{Required: int(shard[2]), Total: int(shard[3])}
+	if err = params.Validate(); err != nil {
+		return
+	}
+	if number >= params.Total || seg >= segs {
+		return errors.New("shard header is out of range")
+	}
+	if p.segments == nil {
+		p.segments = make([]*segment, segs)
+	} else if len(p.segments) != segs {
+		return errors.New("shard segment count does not match the message")
+	}
+	s := p.segments[seg]
+	if s == nil {
+		s = &segment{params: params, seen: make([]bool, params.Total)}
+		p.segments[seg] = s
+		p.expected += params.Total
+	} else if s.params != params {
+		return errors.New("shard parameters do not match the segment")
+	}
+	if s.seen[number] {
+		return
+	}
+	s.seen[number] = true
+	p.received++
+	if s.data != nil {
+		return
+	}
+	s.shares = append(s.shares, infectious.Share{Number: number, Data: shard[HeaderLen:]})
+	if len(s.shares) < params.Required {
+		return
+	}
+	var f *infectious.FEC
+	if f, err = getFEC(params); err != nil {
+		return
+	}
+	dataLen := len(s.shares[0].Data)
+	data := make([]byte, dataLen*params.Required)
+	if err = f.Rebuild(
+		s.shares, func(sh infectious.Share) {
+			copy(data[sh.Number*dataLen:], sh.Data)
+		},
+	); err != nil {
+		return
 	}
-	data = make([]byte, totalLen)
-	dataLen := len(shares[0].Data)
-	if err = rsFEC.Rebuild(shares, func(s infectious.Share) {
-		copy(data[s.Number*dataLen:], s.Data)
-	}); Check(err) {
+	if len(data) < prefixLen {
+		return errors.New("decoded segment is shorter than its length prefix")
 	}
-	// data, err = rsFEC.Decode(nil, shares)
-	if len(data) > 4 {
-		prefix := data[:4]
-		data = data[4:]
-		dataLen := int(binary.LittleEndian.Uint32(prefix))
-		data = data[:dataLen]
+	length := int(binary.LittleEndian.Uint32(data))
+	if length > len(data)-prefixLen {
+		return errors.New("decoded segment is shorter than its length prefix says")
 	}
+	s.data, s.shares = data[prefixLen:prefixLen+length], nil
+	p.decoded++
 	return
 }
+
+// Complete returns true when every segment of the message has been decoded
+func (p *Partial) Complete() bool {
+	return p.segments != nil && p.decoded == len(p.segments)
+}
+
+// Data returns the decoded message
+func (p *Partial) Data() (data []byte, err error) {
+	if !p.Complete() {
+		return nil, errors.New("message is incomplete")
+	}
+	for _, s := range p.segments {
+		data = append(data, s.data...)
+	}
+	return
+}
+
+// Received is the number of distinct shards that have been added
+func (p *Partial) Received() int {
+	return p.received
+}
+
+// Expected is the number of shards that were sent for the segments that have been seen so far
+func (p *Partial) Expected() int {
+	return p.expected
+}
@@ -1,7 +1,152 @@
 package fec_test
 
-import "testing"
+import (
+	"bytes"
+	"math/rand"
+	"testing"
 
+	"github.com/p9c/pod/pkg/coding/fec"
+)
+
+// testData returns n bytes of random data
+func testData(rng *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	rng.Read(b)
+	return b
+}
+
+// simulate sends messages through a channel that loses each shard with the given probability, and returns the
+// fraction of the messages that could be decoded. Shards arrive in a random order, as they may on a real network.
+func simulate(t *testing.T, rng *rand.Rand, p fec.Params, loss float64, messages, size, shardSize int) float64 {
+	var delivered int
+	for i := 0; i < messages; i++ {
+		data := testData(rng, size)
+		shards, err := p.Encode(data, shardSize)
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		rng.Shuffle(len(shards), func(i, j int) { shards[i], shards[j] = shards[j], shards[i] })
+		partial := fec.NewPartial()
+		for _, shard := range shards {
+			if rng.Float64() < loss {
+				continue
+			}
+			if err = partial.Add(shard); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		if !partial.Complete() {
+			continue
+		}
+		decoded, err := partial.Data()
+		if err != nil {
+			t.Fatalf("Data: %v", err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Fatalf("%d/%d: decoded message does not match", p.Total, p.Required)
+		}
+		delivered++
+	}
+	return float64(delivered) / float64(messages)
+}
+
+// TestCodec ensures messages of various sizes survive encoding and decoding with the minimum number of shards.
 func TestCodec(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, p := range []fec.Params{fec.Default, {Required: 1, Total: 1}, {Required: 4, Total: 5}, {Required: 16, Total: 48}} {
+		for _, size := range []int{0, 1, 100, 1000, 10000} {
+			for _, shardSize := range []int{0, 64, 1400} {
+				data := testData(rng, size)
+				shards, err := p.Encode(data, shardSize)
+				if err != nil {
+					t.Fatalf("%d/%d %d bytes: Encode: %v", p.Total, p.Required, size, err)
+				}
+				partial := fec.NewPartial()
+				// keep only the last required shards of each segment
+				for i, shard := range shards {
+					if shardSize > 0 && len(shard) > shardSize {
+						t.Fatalf("%d/%d: shard is %d bytes, more than %d", p.Total, p.Required, len(shard), shardSize)
+					}
+					if i%p.Total < p.Total-p.Required {
+						continue
+					}
+					if err = partial.Add(shard); err != nil {
+						t.Fatalf("Add: %v", err)
+					}
+				}
+				decoded, err := partial.Data()
+				if err != nil {
+					t.Fatalf("%d/%d %d bytes in %d byte shards: Data: %v", p.Total, p.Required, size, shardSize, err)
+				}
+				if !bytes.Equal(decoded, data) {
+					t.Errorf("%d/%d %d bytes in %d byte shards: decoded message does not match", p.Total, p.Required,
+						size, shardSize)
+				}
+			}
+		}
+	}
+	// the package level functions use the default parameters
+	shards, err := fec.Encode([]byte("hello"))
+	if err != nil || len(shards) != fec.Default.Total {
+		t.Fatalf("Encode: got %d shards and error %v", len(shards), err)
+	}
+	if decoded, err := fec.Decode(shards[:fec.Default.Required]); err != nil || string(decoded) != "hello" {
+		t.Errorf("Decode: got %q and error %v", decoded, err)
+	}
+	if _, err = fec.Decode(shards[:fec.Default.Required-1]); err == nil {
+		t.Errorf("Decode: expected an error with too few shards")
+	}
+	if _, err = fec.Default.Encode(make([]byte, 100000), 64); err != fec.ErrTooLarge {
+		t.Errorf("Encode: got %v for a message with too many segments, want %v", err, fec.ErrTooLarge)
+	}
+	// shards in another format are refused
+	shards[0][0] = fec.Version + 1
+	if err = fec.NewPartial().Add(shards[0]); err != fec.ErrUnknownVersion {
+		t.Errorf("Add: got %v for a shard of an unknown version, want %v", err, fec.ErrUnknownVersion)
+	}
+}
+
+// TestLoss simulates lossy networks and checks that the parameters chosen for each loss rate deliver close to the
+// target rate of messages, while the old fixed 9/3 encoding falls short at high loss.
+func TestLoss(t *testing.T) {
+	const (
+		messages   = 2000
+		maxFailure = 0.001
+	)
+	rng := rand.New(rand.NewSource(1))
+	for _, loss := range []float64{0.01, 0.05, 0.2, 0.4} {
+		p := fec.ForLoss(3, loss, maxFailure)
+		if p.FailureRate(loss) > maxFailure {
+			t.Errorf("loss %v: %d/%d fails %v of the time", loss, p.Total, p.Required, p.FailureRate(loss))
+		}
+		// the simulation is allowed some slack over the computed rate, so the test is not flaky
+		if delivered := simulate(t, rng, p, loss, messages, 100, 0); delivered < 1-maxFailure*10 {
+			t.Errorf("loss %v: %d/%d delivered %v of the messages", loss, p.Total, p.Required, delivered)
+		}
+		// messages split into several segments need every segment to arrive
+		p = fec.ForLoss(3, loss, maxFailure/10)
+		if delivered := simulate(t, rng, p, loss, messages/10, 5000, 512); delivered < 1-maxFailure*10 {
+			t.Errorf("loss %v: %d/%d delivered %v of segmented messages", loss, p.Total, p.Required, delivered)
+		}
+	}
+	if p := fec.ForLoss(3, 0.01, maxFailure); p.Redundancy() >= fec.Default.Redundancy() {
+		t.Errorf("expected less redundancy than %d/%d on a clean network, got %d/%d", fec.Default.Total,
+			fec.Default.Required, p.Total, p.Required)
+	}
+	if delivered := simulate(t, rng, fec.Default, 0.6, messages, 100, 0); delivered > 0.9 {
+		t.Errorf("expected %d/%d to lose messages at high loss, delivered %v", fec.Default.Total,
+			fec.Default.Required, delivered)
+	}
+}
 
+// TestFailureRate checks the computed failure rate against a simple case worked out by hand.
+func TestFailureRate(t *testing.T) {
+	// with 2 shards of which 1 is required, the message is lost when both are
+	p := fec.Params{Required: 1, Total: 2}
+	if rate := p.FailureRate(0.1); rate < 0.0099 || rate > 0.0101 {
+		t.Errorf("FailureRate: got %v, want 0.01", rate)
+	}
+	if p.FailureRate(0) != 0 || p.FailureRate(1) != 1 {
+		t.Errorf("FailureRate: unexpected result at the limits")
+	}
 }
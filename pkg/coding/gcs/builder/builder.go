@@ -2,6 +2,7 @@ package builder
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"fmt"
 	"math"
 
@@ -158,6 +159,24 @@ func (b *GCSBuilder) AddHash(hash *chainhash.Hash) *GCSBuilder {
 	return b.AddEntry(hash.CloneBytes())
 }
 
+// AddOutPoint adds a wire.OutPoint to the list of entries to be included in the GCS filter when it's built.
+func (b *GCSBuilder) AddOutPoint(outpoint *wire.OutPoint) *GCSBuilder {
+	// Do nothing if the builder's already errored out.
+	if b.err != nil {
+		return b
+	}
+	return b.AddEntry(OutPointEntry(outpoint))
+}
+
+// OutPointEntry returns the filter entry for an outpoint, which is the hash of the transaction followed by the index of
+// the output as a little endian uint32, for matching spends of it against an extended filter.
+func OutPointEntry(outpoint *wire.OutPoint) []byte {
+	entry := make([]byte, chainhash.HashSize+4)
+	copy(entry, outpoint.Hash[:])
+	binary.LittleEndian.PutUint32(entry[chainhash.HashSize:], outpoint.Index)
+	return entry
+}
+
 // AddWitness adds each item of the passed filter stack to the filter, and then adds each item as a script.
 func (b *GCSBuilder) AddWitness(witness wire.TxWitness) *GCSBuilder {
 	// Do nothing if the builder's already errored out.
@@ -287,6 +306,54 @@ func BuildBasicFilter(block *wire.MsgBlock, prevOutScripts [][]byte) (*gcs.Filte
 	return b.Build()
 }
 
+// BuildExtFilter builds an extended GCS filter from a block. An extended filter contains the outpoints spent by all
+// the inputs within a block, every data push in their signature scripts and witnesses, and every data push within the
+// outputs created within a block, so spends of known outpoints and scripts containing known keys or hashes can be
+// matched without fetching the block.
+func BuildExtFilter(block *wire.MsgBlock) (*gcs.Filter, error) {
+	blockHash := block.BlockHash()
+	b := WithKeyHash(&blockHash)
+	// If the filter had an issue with the specified key, then we force it to bubble up here by calling the Key()
+	// function.
+	_, err := b.Key()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	for i, tx := range block.Transactions {
+		// The coinbase doesn't spend an outpoint, and its signature script is arbitrary data, so it is skipped.
+		if i > 0 {
+			for _, txIn := range tx.TxIn {
+				b.AddOutPoint(&txIn.PreviousOutPoint)
+				addPushes(b, txIn.SignatureScript)
+				for _, item := range txIn.Witness {
+					if len(item) > 0 {
+						b.AddEntry(item)
+					}
+				}
+			}
+		}
+		for _, txOut := range tx.TxOut {
+			addPushes(b, txOut.PkScript)
+		}
+	}
+	return b.Build()
+}
+
+// addPushes adds each non-empty data push in a script to the filter. A script that fails to parse adds nothing, as it
+// can't be spent or redeemed anyway.
+func addPushes(b *GCSBuilder, script []byte) {
+	pushes, err := txscript.PushedData(script)
+	if err != nil {
+		return
+	}
+	for _, push := range pushes {
+		if len(push) > 0 {
+			b.AddEntry(push)
+		}
+	}
+}
+
 // GetFilterHash returns the double-SHA256 of the filter.
 func GetFilterHash(filter *gcs.Filter) (chainhash.Hash, error) {
 	filterData, err := filter.NBytes()
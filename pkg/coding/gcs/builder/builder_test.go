@@ -248,3 +248,73 @@ func BuilderTest(b *builder.GCSBuilder, hash *chainhash.Hash, p uint8,
 		t.Fatal("Filter size increased with duplicate items")
 	}
 }
+
+// TestBuildExtFilter tests that an extended filter contains the outpoints spent, the data pushes of the inputs and the
+// outputs of a block, and not the coinbase input.
+func TestBuildExtFilter(t *testing.T) {
+	hash, err := chainhash.NewHashFromStr(testHash)
+	if err != nil {
+		t.Fatalf("Hash from string failed: %s", err.Error())
+	}
+	addr, err := util.DecodeAddress(testAddr, &netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("Address decode failed: %s", err.Error())
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("Address script build failed: %s", err.Error())
+	}
+	sigPush := []byte("a signature in a signature script")
+	sigScript, err := txscript.NewScriptBuilder().AddData(sigPush).Script()
+	if err != nil {
+		t.Fatalf("Signature script build failed: %s", err.Error())
+	}
+	coinbasePush := []byte("coinbase data that is not indexed")
+	coinbaseScript, err := txscript.NewScriptBuilder().AddData(coinbasePush).Script()
+	if err != nil {
+		t.Fatalf("Coinbase script build failed: %s", err.Error())
+	}
+	outPoint := wire.OutPoint{Hash: *hash, Index: 4321}
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), coinbaseScript, nil))
+	coinbase.AddTxOut(wire.NewTxOut(1, pkScript))
+	spend := wire.NewMsgTx(1)
+	spend.AddTxIn(wire.NewTxIn(&outPoint, sigScript, wire.TxWitness{witness[0]}))
+	spend.AddTxOut(wire.NewTxOut(1, []byte{txscript.OP_TRUE}))
+	block := &wire.MsgBlock{Transactions: []*wire.MsgTx{coinbase, spend}}
+	f, err := builder.BuildExtFilter(block)
+	if err != nil {
+		t.Fatalf("Filter build failed: %s", err.Error())
+	}
+	blockHash := block.BlockHash()
+	key := builder.DeriveKey(&blockHash)
+	// the address script contains a push of the hash of the public key
+	pushes, err := txscript.PushedData(pkScript)
+	if err != nil || len(pushes) != 1 {
+		t.Fatalf("expected the address script to push one item: %v", err)
+	}
+	for _, c := range []struct {
+		name  string
+		entry []byte
+	}{
+		{"outpoint", builder.OutPointEntry(&outPoint)},
+		{"signature script push", sigPush},
+		{"witness item", witness[0]},
+		{"output push", pushes[0]},
+	} {
+		match, err := f.Match(key, c.entry)
+		if err != nil {
+			t.Fatalf("Filter match failed: %s", err)
+		}
+		if !match {
+			t.Fatalf("Extended filter didn't match the %s", c.name)
+		}
+	}
+	match, err := f.Match(key, coinbasePush)
+	if err != nil {
+		t.Fatalf("Filter match failed: %s", err)
+	}
+	if match {
+		t.Logf("False positive match of the coinbase data, should be 1 in 2**%d!", builder.DefaultP)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -19,6 +20,7 @@ import (
 	"time"
 	
 	qu "github.com/p9c/pod/pkg/util/quit"
+	"golang.org/x/crypto/sha3"
 	
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
@@ -50,6 +52,9 @@ type serializedKnownAddress struct {
 	TimeStamp   int64
 	LastAttempt int64
 	LastSuccess int64
+	// Network and Services were added in version 2.
+	Network  wire.NetworkID
+	Services wire.ServiceFlag
 	// no refcount or tried, that is available from context.
 }
 type serializedAddrManager struct {
@@ -114,8 +119,11 @@ const (
 	getAddrMax = 2500
 	// getAddrPercent is the percentage of total addresses known that we will share with a call to AddressCache.
 	getAddrPercent = 23
-	// serialisationVersion is the current version of the on-disk format.
-	serialisationVersion = 1
+	// serialisationVersion is the current version of the on-disk format. Version 2 added the network and services of
+	// addresses, and version 1 files are still read.
+	serialisationVersion = 2
+	// torV3Version is the version byte at the end of a Tor v3 onion service address.
+	torV3Version = 0x03
 )
 
 // updateAddress is a helper function to either update an address already known to the address manager, or to add the
@@ -310,6 +318,8 @@ func (a *AddrManager) savePeers() {
 		ska.Attempts = v.attempts
 		ska.LastAttempt = v.lastattempt.Unix()
 		ska.LastSuccess = v.lastsuccess.Unix()
+		ska.Network = v.na.NetID()
+		ska.Services = v.na.Services
 		// Tried and refs are implicit in the rest of the structure and will be worked out from context on
 		// deserialisation.
 		sam.Addresses[i] = ska
@@ -394,7 +404,7 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 		Error(err)
 		return fmt.Errorf("error reading %s: %v", filePath, err)
 	}
-	if sam.Version != serialisationVersion {
+	if sam.Version != 1 && sam.Version != serialisationVersion {
 		return fmt.Errorf(
 			"unknown version %v in serialized addrmanager",
 			sam.Version,
@@ -415,6 +425,14 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 			return fmt.Errorf("failed to deserialize netaddress "+
 				"%s: %v", v.Src, err)
 		}
+		if sam.Version >= 2 {
+			if ka.na.NetID() != v.Network {
+				return fmt.Errorf("netaddress %s is not on network %v",
+					v.Addr, v.Network)
+			}
+			ka.na.Timestamp = time.Unix(v.TimeStamp, 0)
+			ka.na.Services = v.Services
+		}
 		ka.attempts = v.Attempts
 		ka.lastattempt = time.Unix(v.LastAttempt, 0)
 		ka.lastsuccess = time.Unix(v.LastSuccess, 0)
@@ -614,10 +632,29 @@ func (a *AddrManager) reset() {
 
 // HostToNetAddress returns a netaddress given a host address.
 //
-// If the address is a Tor .onion address this will be taken care of.
+// If the address is a Tor .onion address, either v2 or v3, or an I2P .b32.i2p address this will be taken care of.
 //
 // Else if the host is not an IP address it will be resolved ( via Tor if required).
 func (a *AddrManager) HostToNetAddress(host string, port uint16, services wire.ServiceFlag) (*wire.NetAddress, error) {
+	// Tor v3 address is 56 char base32 + ".onion"
+	if len(host) == 62 && host[56:] == ".onion" {
+		pubKey, err := decodeTorV3(host[:56])
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		return wire.NewNetAddressNetID(time.Now(), services, wire.NetTorV3, pubKey, port)
+	}
+	// I2P address is 52 char base32 + ".b32.i2p"
+	if len(host) == 60 && host[52:] == ".b32.i2p" {
+		data, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(
+			strings.ToUpper(host[:52]))
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		return wire.NewNetAddressNetID(time.Now(), services, wire.NetI2P, data, port)
+	}
 	// Tor address is 16 char base32 + ".onion"
 	var ip net.IP
 	if len(host) == 22 && host[16:] == ".onion" {
@@ -645,9 +682,47 @@ func (a *AddrManager) HostToNetAddress(host string, port uint16, services wire.S
 	return wire.NewNetAddressIPPort(ip, port, services), nil
 }
 
+// torV3Checksum returns the checksum of a Tor v3 onion service address, which is the first two bytes of the SHA3-256
+// hash of ".onion checksum", the public key and the version.
+func torV3Checksum(pubKey []byte) []byte {
+	h := sha3.New256()
+	_, _ = h.Write([]byte(".onion checksum"))
+	_, _ = h.Write(pubKey)
+	_, _ = h.Write([]byte{torV3Version})
+	return h.Sum(nil)[:2]
+}
+
+// decodeTorV3 returns the public key of a Tor v3 onion service from the 56 character base32 part of its address, which
+// encodes the public key followed by its checksum and the version.
+func decodeTorV3(s string) ([]byte, error) {
+	data, err := base32.StdEncoding.DecodeString(strings.ToUpper(s))
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if len(data) != 35 || data[34] != torV3Version {
+		return nil, fmt.Errorf("%s.onion is not a version 3 onion address", s)
+	}
+	pubKey := data[:32]
+	if checksum := torV3Checksum(pubKey); data[32] != checksum[0] || data[33] != checksum[1] {
+		return nil, fmt.Errorf("%s.onion has an invalid checksum", s)
+	}
+	return pubKey, nil
+}
+
 // ipString returns a string for the ip from the provided NetAddress. If the ip is in the range used for Tor addresses
-// then it will be transformed into the relevant .onion address.
+// then it will be transformed into the relevant .onion address, and Tor v3 and I2P addresses are given as their .onion
+// and .b32.i2p addresses.
 func ipString(na *wire.NetAddress) string {
+	if IsTorV3(na) {
+		data := append(append(append([]byte{}, na.Addr...), torV3Checksum(na.Addr)...), torV3Version)
+		s := base32.StdEncoding.EncodeToString(data)
+		return strings.ToLower(s) + ".onion"
+	}
+	if IsI2P(na) {
+		s := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(na.Addr)
+		return strings.ToLower(s) + ".b32.i2p"
+	}
 	if IsOnionCatTor(na) {
 		// We know now that na.IP is long enough.
 		s := base32.StdEncoding.EncodeToString(na.IP[6:])
@@ -853,10 +928,21 @@ func (a *AddrManager) SetServices(addr *wire.NetAddress, services wire.ServiceFl
 	}
 }
 
+// Services returns the services last known to be supported by the given address, or 0 if the address is unknown.
+func (a *AddrManager) Services(addr *wire.NetAddress) wire.ServiceFlag {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	ka := a.find(addr)
+	if ka == nil {
+		return 0
+	}
+	return ka.na.Services
+}
+
 // AddLocalAddress adds na to the list of known local addresses to advertise with the given priority.
 func (a *AddrManager) AddLocalAddress(na *wire.NetAddress, priority AddressPriority) error {
 	if !IsRoutable(na) {
-		return fmt.Errorf("address %s is not routable", na.IP)
+		return fmt.Errorf("address %s is not routable", ipString(na))
 	}
 	a.lamtx.Lock()
 	defer a.lamtx.Unlock()
@@ -875,6 +961,29 @@ func (a *AddrManager) AddLocalAddress(na *wire.NetAddress, priority AddressPrior
 	return nil
 }
 
+// LocalAddress is a local address that is advertised to peers along with the priority it was added with.
+type LocalAddress struct {
+	NetAddress *wire.NetAddress
+	Score      AddressPriority
+}
+
+// LocalAddresses returns the known local addresses that are advertised to peers, ordered by descending score.
+func (a *AddrManager) LocalAddresses() []LocalAddress {
+	a.lamtx.Lock()
+	defer a.lamtx.Unlock()
+	addrs := make([]LocalAddress, 0, len(a.localAddresses))
+	for _, la := range a.localAddresses {
+		addrs = append(addrs, LocalAddress{NetAddress: la.na, Score: la.score})
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		if addrs[i].Score != addrs[j].Score {
+			return addrs[i].Score > addrs[j].Score
+		}
+		return NetAddressKey(addrs[i].NetAddress) < NetAddressKey(addrs[j].NetAddress)
+	})
+	return addrs
+}
+
 // getReachabilityFrom returns the relative reachability of the provided local address to the provided remote address.
 func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 	const (
@@ -889,8 +998,8 @@ func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 	if !IsRoutable(remoteAddr) {
 		return Unreachable
 	}
-	if IsOnionCatTor(remoteAddr) {
-		if IsOnionCatTor(localAddr) {
+	if IsOnionCatTor(remoteAddr) || IsTorV3(remoteAddr) {
+		if IsOnionCatTor(localAddr) || IsTorV3(localAddr) {
 			return Private
 		}
 		if IsRoutable(localAddr) && IsIPv4(localAddr) {
@@ -898,6 +1007,12 @@ func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 		}
 		return Default
 	}
+	if IsI2P(remoteAddr) {
+		if IsI2P(localAddr) {
+			return Private
+		}
+		return Default
+	}
 	if IsRFC4380(remoteAddr) {
 		if !IsRoutable(localAddr) {
 			return Default
@@ -917,6 +1032,10 @@ func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 		return Unreachable
 	}
 	/* ipv6 */
+	// Onion and I2P addresses can only be reached through a proxy.
+	if localAddr.NeedsAddrV2() {
+		return Default
+	}
 	var tunnelled bool
 	// Is our v6 is tunnelled?
 	if IsRFC3964(localAddr) || IsRFC6052(localAddr) || IsRFC6145(localAddr) {
@@ -955,14 +1074,13 @@ func (a *AddrManager) GetBestLocalAddress(remoteAddr *wire.NetAddress) *wire.Net
 		}
 	}
 	if bestAddress != nil {
-		Tracef("suggesting address %s:%d for %s:%d", bestAddress.IP,
-			bestAddress.Port, remoteAddr.IP, remoteAddr.Port)
+		Tracef("suggesting address %s for %s", NetAddressKey(bestAddress),
+			NetAddressKey(remoteAddr))
 	} else {
-		Tracef("no worthy address for %s:%d", remoteAddr.IP,
-			remoteAddr.Port)
+		Tracef("no worthy address for %s", NetAddressKey(remoteAddr))
 		// Send something unroutable if nothing suitable.
 		var ip net.IP
-		if !IsIPv4(remoteAddr) && !IsOnionCatTor(remoteAddr) {
+		if !IsIPv4(remoteAddr) && !IsOnionCatTor(remoteAddr) && !IsTorV3(remoteAddr) {
 			ip = net.IPv6zero
 		} else {
 			ip = net.IPv4zero
@@ -3,8 +3,11 @@ package addrmgr_test
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -182,6 +185,22 @@ func TestAddLocalAddress(t *testing.T) {
 			continue
 		}
 	}
+	// Local addresses are listed with the highest score first.
+	amgr = addrmgr.New("testlocaladdresses", nil)
+	if err := amgr.AddLocalAddress(&wire.NetAddress{IP: net.ParseIP("2620:100::1")}, addrmgr.InterfacePrio); err != nil {
+		t.Fatalf("AddLocalAddress: unexpected error: %v", err)
+	}
+	if err := amgr.AddLocalAddress(&wire.NetAddress{IP: net.ParseIP("204.124.1.1")}, addrmgr.ManualPrio); err != nil {
+		t.Fatalf("AddLocalAddress: unexpected error: %v", err)
+	}
+	localAddrs := amgr.LocalAddresses()
+	if len(localAddrs) != 2 {
+		t.Fatalf("LocalAddresses: got %d addresses, want 2", len(localAddrs))
+	}
+	if !localAddrs[0].NetAddress.IP.Equal(net.ParseIP("204.124.1.1")) || localAddrs[0].Score != addrmgr.ManualPrio {
+		t.Errorf("LocalAddresses: got %s with score %d first, want 204.124.1.1", localAddrs[0].NetAddress.IP,
+			localAddrs[0].Score)
+	}
 }
 func TestAttempt(t *testing.T) {
 	n := addrmgr.New("testattempt", lookupFunc)
@@ -421,3 +440,83 @@ func TestNetAddressKey(t *testing.T) {
 		}
 	}
 }
+func TestTorV3AndI2PAddresses(t *testing.T) {
+	var tests = []struct {
+		host  string
+		netID wire.NetworkID
+		group string
+		err   bool
+	}{
+		{"2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion", wire.NetTorV3, "torv3:1", false},
+		{"2GZYXA5IHM7NSGGFXNU52RCK2VV4RVMDLKIU3ZZUI5DU4XYCLEN53WID.onion", wire.NetTorV3, "torv3:1", false},
+		// Wrong checksum.
+		{"2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen52wid.onion", 0, "", true},
+		// Wrong version.
+		{"2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wia.onion", 0, "", true},
+		{"ijoit3k3w6fhmi724yh5rjxwjbeic2dub7ucz5vngtfkjud2vfza.b32.i2p", wire.NetI2P, "i2p:2", false},
+		{"ijoit3k3w6fhmi724yh5rjxwjbeic2dub7ucz5vngtfkjud2vf!a.b32.i2p", 0, "", true},
+		{"aaaaaaaaaaaaaaaa.onion", wire.NetTorV2, "tor:0", false},
+	}
+	amgr := addrmgr.New("testtorv3andi2paddresses", lookupFunc)
+	for i, test := range tests {
+		na, err := amgr.HostToNetAddress(test.host, 11047, wire.SFNodeNetwork)
+		if test.err {
+			if err == nil {
+				t.Errorf("HostToNetAddress #%d (%s) expected an error and got none", i, test.host)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("HostToNetAddress #%d (%s) unexpected error %v", i, test.host, err)
+			continue
+		}
+		if na.NetID() != test.netID {
+			t.Errorf("HostToNetAddress #%d (%s) wrong network - got %v, want %v", i, test.host, na.NetID(), test.netID)
+		}
+		if !addrmgr.IsRoutable(na) {
+			t.Errorf("IsRoutable #%d (%s) got false, want true", i, test.host)
+		}
+		if key := addrmgr.GroupKey(na); key != test.group {
+			t.Errorf("GroupKey #%d (%s) got %s, want %s", i, test.host, key, test.group)
+		}
+		want := fmt.Sprintf("%s:11047", test.host)
+		if key := addrmgr.NetAddressKey(na); key != strings.ToLower(want) {
+			t.Errorf("NetAddressKey #%d got %s, want %s", i, key, strings.ToLower(want))
+		}
+	}
+}
+func TestSavePeersTorV3(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testsavepeerstorv3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	n := addrmgr.New(dir, lookupFunc)
+	n.Start()
+	onion, err := n.HostToNetAddress("2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion", 11047,
+		wire.SFNodeNetwork|wire.SFNodeBloom)
+	if err != nil {
+		t.Fatalf("HostToNetAddress failed: %v", err)
+	}
+	onion.Timestamp = time.Now().Add(-time.Hour).Truncate(time.Second)
+	srcAddr := wire.NewNetAddressIPPort(net.IPv4(173, 144, 173, 111), 11047, 0)
+	n.AddAddress(onion, srcAddr)
+	if err = n.Stop(); err != nil {
+		t.Fatalf("Address Manager failed to stop: %v", err)
+	}
+	n = addrmgr.New(dir, lookupFunc)
+	n.Start()
+	defer n.Stop()
+	ka := n.GetAddress()
+	if ka == nil {
+		t.Fatalf("Did not get the saved address back")
+	}
+	if ka.NetID() != wire.NetTorV3 {
+		t.Errorf("Wrong network: got %v, want %v", ka.NetID(), wire.NetTorV3)
+	}
+	got := ka.NetAddress()
+	if addrmgr.NetAddressKey(got) != addrmgr.NetAddressKey(onion) || got.Services != onion.Services ||
+		!got.Timestamp.Equal(onion.Timestamp) {
+		t.Errorf("Wrong address: got %v, want %v", got, onion)
+	}
+}
@@ -22,6 +22,11 @@ func (ka *KnownAddress) NetAddress() *wire.NetAddress {
 	return ka.na
 }
 
+// NetID returns the network of the known address, which tells whether it is reached over the internet, Tor or I2P.
+func (ka *KnownAddress) NetID() wire.NetworkID {
+	return ka.na.NetID()
+}
+
 // LastAttempt returns the last time the known address was attempted.
 func (ka *KnownAddress) LastAttempt() time.Time {
 	return ka.lastattempt
@@ -0,0 +1,227 @@
+package connmgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// banListVersion is the version of the format of the ban list file.
+const banListVersion = 1
+
+// Ban is a subnet that may not connect to the node until the ban expires, with the reason it was banned.
+type Ban struct {
+	Subnet  *net.IPNet
+	Created time.Time
+	Until   time.Time
+	Reason  string
+}
+
+// serializedBan is the form a Ban is kept in the ban list file.
+type serializedBan struct {
+	Subnet  string `json:"subnet"`
+	Created int64  `json:"created"`
+	Until   int64  `json:"until"`
+	Reason  string `json:"reason"`
+}
+
+// serializedBanList is the form the ban list is kept in its file.
+type serializedBanList struct {
+	Version int             `json:"version"`
+	Bans    []serializedBan `json:"bans"`
+}
+
+// BanList is a list of banned subnets that is kept in a file so the bans outlast the process. It is safe for
+// concurrent access.
+type BanList struct {
+	mtx  sync.Mutex
+	path string
+	bans map[string]*Ban
+}
+
+// NewBanList returns the ban list kept in the file at path, reading the bans that have not expired from it. If the
+// file is missing or malformed the list starts out empty.
+func NewBanList(path string) *BanList {
+	b := &BanList{path: path, bans: make(map[string]*Ban)}
+	if err := b.load(); err != nil {
+		Errorf("failed to read the ban list %s: %v", path, err)
+		b.bans = make(map[string]*Ban)
+	}
+	return b
+}
+
+// ParseSubnet parses a subnet in CIDR notation, or a single IP address as the subnet holding only that address.
+func ParseSubnet(s string) (subnet *net.IPNet, err error) {
+	if strings.Contains(s, "/") {
+		if _, subnet, err = net.ParseCIDR(s); err != nil {
+			return nil, fmt.Errorf("invalid subnet %q", s)
+		}
+		return
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// Add bans a subnet until the given time, replacing any ban of the same subnet.
+func (b *BanList) Add(subnet *net.IPNet, until time.Time, reason string) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.bans[subnet.String()] = &Ban{Subnet: subnet, Created: time.Now(), Until: until, Reason: reason}
+	return b.save()
+}
+
+// Remove lifts the ban of a subnet, returning false if it was not banned.
+func (b *BanList) Remove(subnet *net.IPNet) (removed bool, err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if _, removed = b.bans[subnet.String()]; !removed {
+		return
+	}
+	delete(b.bans, subnet.String())
+	return true, b.save()
+}
+
+// Clear lifts all bans.
+func (b *BanList) Clear() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.bans = make(map[string]*Ban)
+	return b.save()
+}
+
+// Banned returns the ban of a subnet holding an IP address, if there is one.
+func (b *BanList) Banned(ip net.IP) (ban *Ban, ok bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.expire()
+	for _, ban = range b.bans {
+		if ban.Subnet.Contains(ip) {
+			return ban, true
+		}
+	}
+	return nil, false
+}
+
+// IsBanned reports whether the host of a network address is in a banned subnet. Addresses that are not IP addresses,
+// such as those of onion services, are never banned.
+func (b *BanList) IsBanned(addr net.Addr) bool {
+	var ip net.IP
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ip = a.IP
+	default:
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return false
+		}
+		if ip = net.ParseIP(host); ip == nil {
+			return false
+		}
+	}
+	_, banned := b.Banned(ip)
+	return banned
+}
+
+// List returns the bans that have not expired, ordered by subnet.
+func (b *BanList) List() (bans []Ban) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.expire()
+	for _, ban := range b.bans {
+		bans = append(bans, *ban)
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].Subnet.String() < bans[j].Subnet.String() })
+	return
+}
+
+// expire removes the bans that have expired, saving the list if any were. The mutex must be held.
+func (b *BanList) expire() {
+	now := time.Now()
+	var expired bool
+	for key, ban := range b.bans {
+		if now.Before(ban.Until) {
+			continue
+		}
+		Infof("%s is no longer banned", key)
+		delete(b.bans, key)
+		expired = true
+	}
+	if expired {
+		if err := b.save(); Check(err) {
+		}
+	}
+}
+
+// save writes the bans to the ban list file, replacing it only once it has been written in full. The mutex must be
+// held.
+func (b *BanList) save() (err error) {
+	sbl := serializedBanList{Version: banListVersion, Bans: make([]serializedBan, 0, len(b.bans))}
+	for key, ban := range b.bans {
+		sbl.Bans = append(
+			sbl.Bans, serializedBan{
+				Subnet:  key,
+				Created: ban.Created.Unix(),
+				Until:   ban.Until.Unix(),
+				Reason:  ban.Reason,
+			},
+		)
+	}
+	var data []byte
+	if data, err = json.MarshalIndent(&sbl, "", "  "); Check(err) {
+		return
+	}
+	tmp := b.path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); Check(err) {
+		return
+	}
+	return os.Rename(tmp, b.path)
+}
+
+// load reads the bans that have not expired from the ban list file. A missing file is an empty list.
+func (b *BanList) load() (err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(b.path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return
+	}
+	var sbl serializedBanList
+	if err = json.Unmarshal(data, &sbl); err != nil {
+		return
+	}
+	if sbl.Version != banListVersion {
+		return fmt.Errorf("unknown version %d", sbl.Version)
+	}
+	now := time.Now()
+	for _, sb := range sbl.Bans {
+		var subnet *net.IPNet
+		if _, subnet, err = net.ParseCIDR(sb.Subnet); err != nil {
+			return
+		}
+		until := time.Unix(sb.Until, 0)
+		if !now.Before(until) {
+			continue
+		}
+		b.bans[subnet.String()] = &Ban{
+			Subnet:  subnet,
+			Created: time.Unix(sb.Created, 0),
+			Until:   until,
+			Reason:  sb.Reason,
+		}
+	}
+	Debugf("loaded %d bans from %s", len(b.bans), b.path)
+	return
+}
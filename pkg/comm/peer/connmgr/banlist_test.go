@@ -0,0 +1,77 @@
+package connmgr
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestBanList ensures bans of subnets cover the addresses in them, outlast the ban list they were made with, and are
+// lifted when they expire or are removed.
+func TestBanList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "banlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "banlist.json")
+	bl := NewBanList(path)
+	for _, s := range []string{"10.0.0.0/8", "192.168.1.5", "2001:db8::/32"} {
+		subnet, err := ParseSubnet(s)
+		if err != nil {
+			t.Fatalf("ParseSubnet(%q): %v", s, err)
+		}
+		if err = bl.Add(subnet, time.Now().Add(time.Hour), "test"); err != nil {
+			t.Fatalf("Add(%v): %v", subnet, err)
+		}
+	}
+	if _, err = ParseSubnet("not an address"); err == nil {
+		t.Errorf("ParseSubnet: parsed an invalid address")
+	}
+	expired, _ := ParseSubnet("172.16.0.1")
+	if err = bl.Add(expired, time.Now().Add(-time.Second), "expired"); err != nil {
+		t.Fatalf("Add(%v): %v", expired, err)
+	}
+	// a new list reading the same file stands in for a restart
+	bl = NewBanList(path)
+	tests := []struct {
+		addr   net.Addr
+		banned bool
+	}{
+		{&net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 11047}, true},
+		{&net.TCPAddr{IP: net.ParseIP("192.168.1.5"), Port: 11047}, true},
+		{&net.TCPAddr{IP: net.ParseIP("192.168.1.6"), Port: 11047}, false},
+		{&net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 11047}, true},
+		{&net.TCPAddr{IP: net.ParseIP("172.16.0.1"), Port: 11047}, false},
+		{mockAddr{"tcp", "10.9.9.9:11047"}, true},
+		{mockAddr{"tcp", "abcdefghijklmnop.onion:11047"}, false},
+	}
+	for _, test := range tests {
+		if banned := bl.IsBanned(test.addr); banned != test.banned {
+			t.Errorf("IsBanned(%v): got %v, want %v", test.addr, banned, test.banned)
+		}
+	}
+	bans := bl.List()
+	if len(bans) != 3 {
+		t.Fatalf("List: got %d bans, want 3", len(bans))
+	}
+	if bans[0].Subnet.String() != "10.0.0.0/8" || bans[0].Reason != "test" {
+		t.Errorf("List: got first ban %v %q", bans[0].Subnet, bans[0].Reason)
+	}
+	subnet, _ := ParseSubnet("192.168.1.5")
+	if removed, err := bl.Remove(subnet); !removed || err != nil {
+		t.Errorf("Remove(%v): got %v, %v", subnet, removed, err)
+	}
+	if removed, err := bl.Remove(subnet); removed || err != nil {
+		t.Errorf("Remove(%v) of a subnet that is not banned: got %v, %v", subnet, removed, err)
+	}
+	if err = bl.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if bans = NewBanList(path).List(); len(bans) != 0 {
+		t.Errorf("Clear: got %d bans after clearing", len(bans))
+	}
+}
@@ -107,6 +107,9 @@ type Config struct {
 	// This field will not have any effect if the Listeners field is not also specified since there couldn't possibly be
 	// any accepted connections in that case.
 	OnAccept func(net.Conn)
+	// Bans is the list of banned subnets. Connections accepted from a banned address are closed without being passed to
+	// OnAccept. It may be nil if no addresses are banned.
+	Bans *BanList
 	// TargetOutbound is the number of outbound network connections to maintain. Defaults to 8.
 	TargetOutbound uint32
 	// RetryDuration is the duration to wait before retrying connection requests. Defaults to 5s.
@@ -137,8 +140,9 @@ type handleConnected struct {
 
 // handleDisconnected is used to remove a connection.
 type handleDisconnected struct {
-	id    uint64
-	retry bool
+	id     uint64
+	retry  bool
+	redial bool
 }
 
 // handleFailed is used to remove a pending connection.
@@ -267,6 +271,13 @@ out:
 					connReq.updateState(ConnDisconnected)
 					continue
 				}
+				// A redial connects to the same address again straight away.
+				if msg.redial {
+					connReq.updateState(ConnPending)
+					pending[msg.id] = connReq
+					go cm.Connect(connReq)
+					continue
+				}
 				// Otherwise, we will attempt a reconnection if we do not have enough peers, or if this is a persistent
 				// peer. The connection request is re added to the pending map, so that subsequent processing of
 				// connections and failures do not ignore the request.
@@ -390,7 +401,19 @@ func (cm *ConnManager) Disconnect(id uint64) {
 		return
 	}
 	select {
-	case cm.requests <- handleDisconnected{id, true}:
+	case cm.requests <- handleDisconnected{id, true, false}:
+	case <-cm.quit.Wait():
+	}
+}
+
+// Redial disconnects the connection corresponding to the given connection id and connects to the same address again
+// straight away, instead of after the retry duration if permanent or to a new address otherwise.
+func (cm *ConnManager) Redial(id uint64) {
+	if atomic.LoadInt32(&cm.stop) != 0 {
+		return
+	}
+	select {
+	case cm.requests <- handleDisconnected{id, true, true}:
 	case <-cm.quit.Wait():
 	}
 }
@@ -403,7 +426,7 @@ func (cm *ConnManager) Remove(id uint64) {
 		return
 	}
 	select {
-	case cm.requests <- handleDisconnected{id, false}:
+	case cm.requests <- handleDisconnected{id, false, false}:
 	case <-cm.quit.Wait():
 	}
 }
@@ -425,6 +448,12 @@ func (cm *ConnManager) listenHandler(listener net.Listener) {
 			}
 			continue
 		}
+		if cm.Cfg.Bans != nil && cm.Cfg.Bans.IsBanned(conn.RemoteAddr()) {
+			Debug("refusing connection from banned address", conn.RemoteAddr())
+			if err := conn.Close(); Check(err) {
+			}
+			continue
+		}
 		go cm.Cfg.OnAccept(conn)
 	}
 	cm.wg.Done()
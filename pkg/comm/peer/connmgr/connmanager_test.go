@@ -281,6 +281,53 @@ func TestRetryPermanent(t *testing.T) {
 	cmgr.Stop()
 }
 
+// TestRedial tests that a redialled permanent connection request connects again straight away, rather than after the
+// retry duration.
+func TestRedial(t *testing.T) {
+	connected := make(chan *ConnReq)
+	disconnected := make(chan *ConnReq)
+	cmgr, err := New(&Config{
+		RetryDuration:  time.Hour,
+		TargetOutbound: 1,
+		Dial:           mockDialer,
+		OnConnection: func(c *ConnReq, conn net.Conn) {
+			connected <- c
+		},
+		OnDisconnection: func(c *ConnReq) {
+			disconnected <- c
+		},
+	})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	cr := &ConnReq{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("127.0.0.1"),
+			Port: 18555,
+		},
+		Permanent: true,
+	}
+	go cmgr.Connect(cr)
+	cmgr.Start()
+	<-connected
+	cmgr.Redial(cr.ID())
+	<-disconnected
+	select {
+	case gotConnReq := <-connected:
+		if gotConnReq.ID() != cr.ID() {
+			t.Fatalf("redial: %v - want ID %v, got ID %v", cr.Addr, cr.ID(), gotConnReq.ID())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("redial: %v - not connected again straight away", cr.Addr)
+	}
+	if gotState := cr.State(); gotState != ConnEstablished {
+		t.Fatalf("redial: %v - want state %v, got state %v", cr.Addr, ConnEstablished, gotState)
+	}
+	cmgr.Remove(cr.ID())
+	<-disconnected
+	cmgr.Stop()
+}
+
 // TestMaxRetryDuration tests the maximum retry duration. We have a timed dialer which initially returns err but after
 // RetryDuration hits maxRetryDuration returns a mock conn.
 func TestMaxRetryDuration(t *testing.T) {
@@ -45,6 +45,8 @@ func invSummary(invList []*wire.InvVect) string {
 			return fmt.Sprintf("witness block %s", iv.Hash)
 		case wire.InvTypeBlock:
 			return fmt.Sprintf("block %s", iv.Hash)
+		case wire.InvTypeCmpctBlock:
+			return fmt.Sprintf("compact block %s", iv.Hash)
 		case wire.InvTypeWitnessTx:
 			return fmt.Sprintf("witness tx %s", iv.Hash)
 		case wire.InvTypeTx:
@@ -108,6 +110,9 @@ func messageSummary(msg wire.Message) string {
 	case *wire.MsgAddr:
 		return fmt.Sprintf("%d addr", len(msg.AddrList))
 
+	case *wire.MsgAddrV2:
+		return fmt.Sprintf("%d addr", len(msg.AddrList))
+
 	case *wire.MsgPing:
 		// No summary - perhaps add Nonce.
 
@@ -130,6 +135,19 @@ func messageSummary(msg wire.Message) string {
 		return fmt.Sprintf("hash %s, ver %d, %d tx, %s", msg.BlockHash(),
 			header.Version, len(msg.Transactions), header.Timestamp)
 
+	case *wire.MsgCmpctBlock:
+		return fmt.Sprintf("hash %s, %d tx, %d prefilled", msg.Header.BlockHash(),
+			msg.TxCount(), len(msg.PrefilledTxns))
+
+	case *wire.MsgGetBlockTxn:
+		return fmt.Sprintf("hash %s, %d tx", msg.BlockHash, len(msg.Indexes))
+
+	case *wire.MsgBlockTxn:
+		return fmt.Sprintf("hash %s, %d tx", msg.BlockHash, len(msg.Transactions))
+
+	case *wire.MsgSendCmpct:
+		return fmt.Sprintf("announce %v, ver %d", msg.Announce, msg.Version)
+
 	case *wire.MsgInv:
 		return invSummary(msg.InvList)
 
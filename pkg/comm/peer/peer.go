@@ -28,7 +28,7 @@ import (
 
 const (
 	// MaxProtocolVersion is the max protocol version the peer supports.
-	MaxProtocolVersion = wire.FeeFilterVersion
+	MaxProtocolVersion = wire.AddrV2Version
 	// DefaultTrickleInterval is the min time between attempts to send an inv message to a peer.
 	DefaultTrickleInterval = time.Second
 	// MinAcceptableProtocolVersion is the lowest protocol version that a connected peer may support.
@@ -81,6 +81,8 @@ type MessageListeners struct {
 	OnGetAddr func(p *Peer, msg *wire.MsgGetAddr)
 	// OnAddr is invoked when a peer receives an addr bitcoin message.
 	OnAddr func(p *Peer, msg *wire.MsgAddr)
+	// OnAddrV2 is invoked when a peer receives an addrv2 bitcoin message.
+	OnAddrV2 func(p *Peer, msg *wire.MsgAddrV2)
 	// OnPing is invoked when a peer receives a ping bitcoin message.
 	OnPing func(p *Peer, msg *wire.MsgPing)
 	// OnPong is invoked when a peer receives a pong bitcoin message.
@@ -145,6 +147,18 @@ type MessageListeners struct {
 	// OnSendHeaders is invoked when a peer receives a sendheaders bitcoin
 	// message.
 	OnSendHeaders func(p *Peer, msg *wire.MsgSendHeaders)
+	// OnSendCmpct is invoked when a peer receives a sendcmpct bitcoin
+	// message.
+	OnSendCmpct func(p *Peer, msg *wire.MsgSendCmpct)
+	// OnCmpctBlock is invoked when a peer receives a cmpctblock bitcoin
+	// message.
+	OnCmpctBlock func(p *Peer, msg *wire.MsgCmpctBlock)
+	// OnGetBlockTxn is invoked when a peer receives a getblocktxn bitcoin
+	// message.
+	OnGetBlockTxn func(p *Peer, msg *wire.MsgGetBlockTxn)
+	// OnBlockTxn is invoked when a peer receives a blocktxn bitcoin
+	// message.
+	OnBlockTxn func(p *Peer, msg *wire.MsgBlockTxn)
 	// OnRead is invoked when a peer receives a bitcoin message.
 	//
 	// It consists of the number of bytes read, the message, and whether or not an error in the read occurred.
@@ -196,6 +210,10 @@ type Config struct {
 	Listeners MessageListeners
 	// TrickleInterval is the duration of the ticker which trickles down the inventory to a peer.
 	TrickleInterval time.Duration
+	// V2Transport enables the encrypted v2 transport. An outbound peer starts the connection with the v2 handshake and
+	// fails if the remote peer doesn't answer it, see V2HandshakeRefused, while an inbound peer accepts both v2 and
+	// plaintext v1 connections.
+	V2Transport bool
 }
 
 // minUint32 is a helper function to return the minimum of two uint32s. This avoids a math import and the need to cast
@@ -277,23 +295,25 @@ type stallControlMsg struct {
 
 // StatsSnap is a snapshot of peer stats at a point in time.
 type StatsSnap struct {
-	ID             int32
-	Addr           string
-	Services       wire.ServiceFlag
-	LastSend       time.Time
-	LastRecv       time.Time
-	BytesSent      uint64
-	BytesRecv      uint64
-	ConnTime       time.Time
-	TimeOffset     int64
-	Version        uint32
-	UserAgent      string
-	Inbound        bool
-	StartingHeight int32
-	LastBlock      int32
-	LastPingNonce  uint64
-	LastPingTime   time.Time
-	LastPingMicros int64
+	ID                int32
+	Addr              string
+	Services          wire.ServiceFlag
+	LastSend          time.Time
+	LastRecv          time.Time
+	BytesSent         uint64
+	BytesRecv         uint64
+	ConnTime          time.Time
+	TimeOffset        int64
+	Version           uint32
+	UserAgent         string
+	Inbound           bool
+	StartingHeight    int32
+	LastBlock         int32
+	LastPingNonce     uint64
+	LastPingTime      time.Time
+	LastPingMicros    int64
+	TransportProtocol string
+	SessionID         string
 }
 
 // HashFunc is a function which returns a block hash, height and error It is used as a callback to get newest block
@@ -342,6 +362,7 @@ type Peer struct {
 	connected     int32
 	disconnect    int32
 	conn          net.Conn
+	connReader    io.Reader // reads from conn, after any bytes read to tell a v1 from a v2 connection
 	// These fields are set at creation time and never modified, so they are safe to read from concurrently without a
 	// mutex.
 	Nonce                uint64
@@ -357,9 +378,14 @@ type Peer struct {
 	advertisedProtoVer   uint32 // protocol version advertised by remote
 	protocolVersion      uint32 // negotiated protocol version
 	sendHeadersPreferred bool   // peer sent a sendheaders message
+	cmpctBlocks          bool   // peer sent a sendcmpct message with a version we understand
+	cmpctAnnounce        bool   // peer wants new blocks announced as compact blocks
+	sendAddrV2           bool   // peer sent a sendaddrv2 message before its verack
 	verAckReceived       bool
 	witnessEnabled       bool
 	wireEncoding         wire.MessageEncoding
+	transport            *v2Transport // encrypted transport, nil for a plaintext v1 connection
+	v2Refused            bool         // peer closed the connection without answering our v2 handshake
 	knownInventory       *mruInventoryMap
 	prevGetBlocksMtx     sync.Mutex
 	prevGetBlocksBegin   *chainhash.Hash
@@ -440,25 +466,28 @@ func (p *Peer) StatsSnapshot() *StatsSnap {
 	services := p.services
 	protocolVersion := p.advertisedProtoVer
 	p.flagsMtx.Unlock()
+	transportProtocol, sessionID := p.TransportProtocol(), p.SessionID()
 	// Get a copy of all relevant flags and stats.
 	statsSnap := &StatsSnap{
-		ID:             id,
-		Addr:           addr,
-		UserAgent:      userAgent,
-		Services:       services,
-		LastSend:       p.LastSend(),
-		LastRecv:       p.LastRecv(),
-		BytesSent:      p.BytesSent(),
-		BytesRecv:      p.BytesReceived(),
-		ConnTime:       p.timeConnected,
-		TimeOffset:     p.timeOffset,
-		Version:        protocolVersion,
-		Inbound:        p.inbound,
-		StartingHeight: p.startingHeight,
-		LastBlock:      p.lastBlock,
-		LastPingNonce:  p.lastPingNonce,
-		LastPingMicros: p.lastPingMicros,
-		LastPingTime:   p.lastPingTime,
+		ID:                id,
+		Addr:              addr,
+		UserAgent:         userAgent,
+		Services:          services,
+		LastSend:          p.LastSend(),
+		LastRecv:          p.LastRecv(),
+		BytesSent:         p.BytesSent(),
+		BytesRecv:         p.BytesReceived(),
+		ConnTime:          p.timeConnected,
+		TimeOffset:        p.timeOffset,
+		Version:           protocolVersion,
+		Inbound:           p.inbound,
+		StartingHeight:    p.startingHeight,
+		LastBlock:         p.lastBlock,
+		LastPingNonce:     p.lastPingNonce,
+		LastPingMicros:    p.lastPingMicros,
+		LastPingTime:      p.lastPingTime,
+		TransportProtocol: transportProtocol,
+		SessionID:         sessionID,
 	}
 	p.statsMtx.RUnlock()
 	return statsSnap
@@ -675,6 +704,33 @@ func (p *Peer) WantsHeaders() bool {
 	return sendHeadersPreferred
 }
 
+// SupportsCmpctBlocks returns if the peer has said it understands the version of compact blocks we relay. This function
+// is safe for concurrent access.
+func (p *Peer) SupportsCmpctBlocks() bool {
+	p.flagsMtx.Lock()
+	cmpctBlocks := p.cmpctBlocks
+	p.flagsMtx.Unlock()
+	return cmpctBlocks
+}
+
+// WantsCmpctBlocks returns if the peer wants new blocks announced to it as compact blocks without first being sent an
+// inventory vector or header for them. This function is safe for concurrent access.
+func (p *Peer) WantsCmpctBlocks() bool {
+	p.flagsMtx.Lock()
+	cmpctAnnounce := p.cmpctBlocks && p.cmpctAnnounce
+	p.flagsMtx.Unlock()
+	return cmpctAnnounce
+}
+
+// WantsAddrV2 returns if the peer wants addresses relayed to it in addrv2 messages, which can carry addresses of
+// networks that don't fit in an IPv6 address. This function is safe for concurrent access.
+func (p *Peer) WantsAddrV2() bool {
+	p.flagsMtx.Lock()
+	sendAddrV2 := p.sendAddrV2
+	p.flagsMtx.Unlock()
+	return sendAddrV2
+}
+
 // IsWitnessEnabled returns true if the peer has signalled that it supports segregated witness. This function is safe
 // for concurrent access.
 func (p *Peer) IsWitnessEnabled() bool {
@@ -685,7 +741,8 @@ func (p *Peer) IsWitnessEnabled() bool {
 }
 
 // PushAddrMsg sends an addr message to the connected peer using the provided
-// addresses.
+// addresses, or an addrv2 message if the peer asked for them. Addresses that can only be relayed in an addrv2 message
+// are left out for peers that did not.
 //
 // This function is useful over manually sending the message via QueueMessage since it automatically limits the
 // addresses to the maximum number allowed by the message and randomizes the chosen addresses when there are too many.
@@ -693,26 +750,38 @@ func (p *Peer) IsWitnessEnabled() bool {
 // It returns the addresses that were actually sent and no message will be sent if there are no entries in the provided
 // addresses slice. This function is safe for concurrent access.
 func (p *Peer) PushAddrMsg(addresses []*wire.NetAddress) ([]*wire.NetAddress, error) {
-	addressCount := len(addresses)
+	addrV2 := p.WantsAddrV2()
+	addrList := make([]*wire.NetAddress, 0, len(addresses))
+	for _, na := range addresses {
+		if addrV2 || !na.NeedsAddrV2() {
+			addrList = append(addrList, na)
+		}
+	}
+	addressCount := len(addrList)
 	// Nothing to send.
 	if addressCount == 0 {
 		return nil, nil
 	}
-	msg := wire.NewMsgAddr()
-	msg.AddrList = make([]*wire.NetAddress, addressCount)
-	copy(msg.AddrList, addresses)
 	// Randomize the addresses sent if there are more than the maximum allowed.
 	if addressCount > wire.MaxAddrPerMsg {
 		// Shuffle the address list.
 		for i := 0; i < wire.MaxAddrPerMsg; i++ {
 			j := i + rand.Intn(addressCount-i)
-			msg.AddrList[i], msg.AddrList[j] = msg.AddrList[j], msg.AddrList[i]
+			addrList[i], addrList[j] = addrList[j], addrList[i]
 		}
 		// Truncate it to the maximum size.
-		msg.AddrList = msg.AddrList[:wire.MaxAddrPerMsg]
+		addrList = addrList[:wire.MaxAddrPerMsg]
 	}
-	p.QueueMessage(msg, nil)
-	return msg.AddrList, nil
+	if addrV2 {
+		msg := wire.NewMsgAddrV2()
+		msg.AddrList = addrList
+		p.QueueMessage(msg, nil)
+	} else {
+		msg := wire.NewMsgAddr()
+		msg.AddrList = addrList
+		p.QueueMessage(msg, nil)
+	}
+	return addrList, nil
 }
 
 // PushGetBlocksMsg sends a getblocks message for the provided block locator and stop hash. It will ignore back-to-back
@@ -867,8 +936,16 @@ func (p *Peer) handlePongMsg(msg *wire.MsgPong) {
 
 // readMessage reads the next bitcoin message from the peer with logging.
 func (p *Peer) readMessage(encoding wire.MessageEncoding) (wire.Message, []byte, error) {
-	n, msg, buf, err := wire.ReadMessageWithEncodingN(p.conn,
-		p.ProtocolVersion(), p.cfg.ChainParams.Net, encoding)
+	var n int
+	var msg wire.Message
+	var buf []byte
+	var err error
+	if t := p.v2(); t != nil {
+		n, msg, buf, err = t.readMessage(p.connReader, p.ProtocolVersion(), encoding)
+	} else {
+		n, msg, buf, err = wire.ReadMessageWithEncodingN(p.connReader,
+			p.ProtocolVersion(), p.cfg.ChainParams.Net, encoding)
+	}
 	atomic.AddUint64(&p.bytesReceived, uint64(n))
 	if p.cfg.Listeners.OnRead != nil {
 		p.cfg.Listeners.OnRead(p, n, msg, err)
@@ -939,8 +1016,14 @@ func (p *Peer) writeMessage(msg wire.Message, enc wire.MessageEncoding) error {
 		})
 	}
 	// Write the message to the peer.
-	n, err := wire.WriteMessageWithEncodingN(p.conn, msg,
-		p.ProtocolVersion(), p.cfg.ChainParams.Net, enc)
+	var n int
+	var err error
+	if t := p.v2(); t != nil {
+		n, err = t.writeMessage(p.conn, msg, p.ProtocolVersion(), enc)
+	} else {
+		n, err = wire.WriteMessageWithEncodingN(p.conn, msg,
+			p.ProtocolVersion(), p.cfg.ChainParams.Net, enc)
+	}
 	atomic.AddUint64(&p.bytesSent, uint64(n))
 	if p.cfg.Listeners.OnWrite != nil {
 		p.cfg.Listeners.OnWrite(p, n, msg, err)
@@ -1009,8 +1092,9 @@ func (p *Peer) maybeAddDeadline(pendingResponses map[string]time.Time, msgCmd st
 		// Expects an inv message.
 		pendingResponses[wire.CmdInv] = deadline
 	case wire.CmdGetData:
-		// Expects a block, merkleblock, tx, or notfound message.
+		// Expects a block, cmpctblock, merkleblock, tx, or notfound message.
 		pendingResponses[wire.CmdBlock] = deadline
+		pendingResponses[wire.CmdCmpctBlock] = deadline
 		pendingResponses[wire.CmdMerkleBlock] = deadline
 		pendingResponses[wire.CmdTx] = deadline
 		pendingResponses[wire.CmdNotFound] = deadline
@@ -1020,6 +1104,9 @@ func (p *Peer) maybeAddDeadline(pendingResponses map[string]time.Time, msgCmd st
 		// Use a longer deadline since it can take a while for the remote peer to load all of the headers.
 		deadline = time.Now().Add(stallResponseTimeout * 3)
 		pendingResponses[wire.CmdHeaders] = deadline
+	case wire.CmdGetBlockTxn:
+		// Expects a blocktxn message.
+		pendingResponses[wire.CmdBlockTxn] = deadline
 	}
 }
 
@@ -1064,12 +1151,15 @@ out:
 				switch msgCmd := msg.message.Command(); msgCmd {
 				case wire.CmdBlock:
 					fallthrough
+				case wire.CmdCmpctBlock:
+					fallthrough
 				case wire.CmdMerkleBlock:
 					fallthrough
 				case wire.CmdTx:
 					fallthrough
 				case wire.CmdNotFound:
 					delete(pendingResponses, wire.CmdBlock)
+					delete(pendingResponses, wire.CmdCmpctBlock)
 					delete(pendingResponses, wire.CmdMerkleBlock)
 					delete(pendingResponses, wire.CmdTx)
 					delete(pendingResponses, wire.CmdNotFound)
@@ -1232,6 +1322,17 @@ out:
 			if p.cfg.Listeners.OnAddr != nil {
 				p.cfg.Listeners.OnAddr(p, msg)
 			}
+		case *wire.MsgSendAddrV2:
+			// The peer only gets addrv2 messages if it asked for them before sending its verack.
+			if !p.verAckReceived {
+				p.flagsMtx.Lock()
+				p.sendAddrV2 = true
+				p.flagsMtx.Unlock()
+			}
+		case *wire.MsgAddrV2:
+			if p.cfg.Listeners.OnAddrV2 != nil {
+				p.cfg.Listeners.OnAddrV2(p, msg)
+			}
 		case *wire.MsgPing:
 			p.handlePingMsg(msg)
 			if p.cfg.Listeners.OnPing != nil {
@@ -1333,6 +1434,29 @@ out:
 			if p.cfg.Listeners.OnSendHeaders != nil {
 				p.cfg.Listeners.OnSendHeaders(p, msg)
 			}
+		case *wire.MsgSendCmpct:
+			// Compact blocks of versions we don't know are ignored, as the peer may offer several.
+			if msg.Version == wire.CmpctBlockVersion {
+				p.flagsMtx.Lock()
+				p.cmpctBlocks = true
+				p.cmpctAnnounce = msg.Announce
+				p.flagsMtx.Unlock()
+			}
+			if p.cfg.Listeners.OnSendCmpct != nil {
+				p.cfg.Listeners.OnSendCmpct(p, msg)
+			}
+		case *wire.MsgCmpctBlock:
+			if p.cfg.Listeners.OnCmpctBlock != nil {
+				p.cfg.Listeners.OnCmpctBlock(p, msg)
+			}
+		case *wire.MsgGetBlockTxn:
+			if p.cfg.Listeners.OnGetBlockTxn != nil {
+				p.cfg.Listeners.OnGetBlockTxn(p, msg)
+			}
+		case *wire.MsgBlockTxn:
+			if p.cfg.Listeners.OnBlockTxn != nil {
+				p.cfg.Listeners.OnBlockTxn(p, msg)
+			}
 		default:
 			Debugf(
 				"Received unhandled message of type %v from %v %s",
@@ -1787,20 +1911,32 @@ func (p *Peer) writeLocalVersionMsg() error {
 	return p.writeMessage(localVerMsg, wire.LatestEncoding)
 }
 
-// negotiateInboundProtocol waits to receive a version message from the peer then sends our version message.
+// negotiateInboundProtocol waits to receive a version message from the peer then sends our version message, after
+// the v2 handshake if v2 is enabled and the peer didn't start a v1 connection.
 //
 // If the events do not occur in that order then it returns an error.
 func (p *Peer) negotiateInboundProtocol() error {
+	if p.cfg.V2Transport {
+		if err := p.acceptV2Transport(); err != nil {
+			return err
+		}
+	}
 	if err := p.readRemoteVersionMsg(); err != nil {
 		return err
 	}
 	return p.writeLocalVersionMsg()
 }
 
-// negotiateOutboundProtocol sends our version message then waits to receive a version message from the peer.
+// negotiateOutboundProtocol sends our version message then waits to receive a version message from the peer, after
+// the v2 handshake if v2 is enabled.
 //
 // If the events do not occur in that order then it returns an error.
 func (p *Peer) negotiateOutboundProtocol() error {
+	if p.cfg.V2Transport {
+		if err := p.initiateV2Transport(); err != nil {
+			return err
+		}
+	}
 	if err := p.writeLocalVersionMsg(); err != nil {
 		return err
 	}
@@ -1822,7 +1958,7 @@ func (p *Peer) start() error {
 	select {
 	case err := <-negotiateErr:
 		if err != nil {
-			if err != io.EOF {
+			if err != io.EOF && !p.V2HandshakeRefused() {
 				Error(err)
 			}
 			p.Disconnect()
@@ -1839,6 +1975,10 @@ func (p *Peer) start() error {
 	go p.queueHandler()
 	go p.outHandler()
 	go p.pingHandler()
+	// Ask for addresses in addrv2 messages, which has to be done before the verack is sent.
+	if p.ProtocolVersion() >= wire.AddrV2Version {
+		p.QueueMessage(wire.NewMsgSendAddrV2(), nil)
+	}
 	// Send our verack message now that the IO processing machinery has started.
 	p.QueueMessage(wire.NewMsgVerAck(), nil)
 	return nil
@@ -1852,6 +1992,7 @@ func (p *Peer) AssociateConnection(conn net.Conn) {
 		return
 	}
 	p.conn = conn
+	p.connReader = conn
 	p.timeConnected = time.Now()
 	if p.inbound {
 		p.addr = p.conn.RemoteAddr().String()
@@ -1,8 +1,10 @@
 package peer_test
 
 import (
+	"bytes"
 	"errors"
 	"io"
+	"io/ioutil"
 	"net"
 	"strconv"
 	"testing"
@@ -330,6 +332,9 @@ func TestPeerListeners(t *testing.T) {
 			OnAddr: func(p *peer.Peer, msg *wire.MsgAddr) {
 				ok <- msg
 			},
+			OnAddrV2: func(p *peer.Peer, msg *wire.MsgAddrV2) {
+				ok <- msg
+			},
 			OnPing: func(p *peer.Peer, msg *wire.MsgPing) {
 				ok <- msg
 			},
@@ -409,6 +414,18 @@ func TestPeerListeners(t *testing.T) {
 			OnSendHeaders: func(p *peer.Peer, msg *wire.MsgSendHeaders) {
 				ok <- msg
 			},
+			OnSendCmpct: func(p *peer.Peer, msg *wire.MsgSendCmpct) {
+				ok <- msg
+			},
+			OnCmpctBlock: func(p *peer.Peer, msg *wire.MsgCmpctBlock) {
+				ok <- msg
+			},
+			OnGetBlockTxn: func(p *peer.Peer, msg *wire.MsgGetBlockTxn) {
+				ok <- msg
+			},
+			OnBlockTxn: func(p *peer.Peer, msg *wire.MsgBlockTxn) {
+				ok <- msg
+			},
 		},
 		UserAgentName:     "peer",
 		UserAgentVersion:  "1.0",
@@ -454,6 +471,10 @@ func TestPeerListeners(t *testing.T) {
 			"OnAddr",
 			wire.NewMsgAddr(),
 		},
+		{
+			"OnAddrV2",
+			wire.NewMsgAddrV2(),
+		},
 		{
 			"OnPing",
 			wire.NewMsgPing(42),
@@ -554,6 +575,23 @@ func TestPeerListeners(t *testing.T) {
 			"OnSendHeaders",
 			wire.NewMsgSendHeaders(),
 		},
+		{
+			"OnSendCmpct",
+			wire.NewMsgSendCmpct(true, wire.CmpctBlockVersion),
+		},
+		{
+			"OnCmpctBlock",
+			wire.NewMsgCmpctBlock(wire.NewMsgBlock(wire.NewBlockHeader(1,
+				&chainhash.Hash{}, &chainhash.Hash{}, 1, 1)), 1),
+		},
+		{
+			"OnGetBlockTxn",
+			wire.NewMsgGetBlockTxn(&chainhash.Hash{}, []uint32{1}),
+		},
+		{
+			"OnBlockTxn",
+			wire.NewMsgBlockTxn(&chainhash.Hash{}, nil),
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for _, test := range tests {
@@ -566,10 +604,80 @@ func TestPeerListeners(t *testing.T) {
 			return
 		}
 	}
+	if !inPeer.SupportsCmpctBlocks() || !inPeer.WantsCmpctBlocks() {
+		t.Errorf("TestPeerListeners: sendcmpct was not recorded")
+	}
+	if !inPeer.WantsAddrV2() || !outPeer.WantsAddrV2() {
+		t.Errorf("TestPeerListeners: sendaddrv2 was not recorded")
+	}
 	inPeer.Disconnect()
 	outPeer.Disconnect()
 }
 
+// TestPushTorV3Address ensures a Tor v3 address pushed once the verack has been received reaches a peer that asked for
+// addrv2 messages, while on the version message it is not yet known that the peer takes them.
+func TestPushTorV3Address(t *testing.T) {
+	torV3, err := wire.NewNetAddressNetID(time.Now(), wire.SFNodeNetwork, wire.NetTorV3,
+		bytes.Repeat([]byte{0xab}, 32), 11047)
+	if err != nil {
+		t.Fatalf("NewNetAddressNetID: unexpected err %v", err)
+	}
+	received := make(chan *wire.MsgAddrV2, 1)
+	inCfg := &peer.Config{
+		Listeners: peer.MessageListeners{
+			OnAddrV2: func(p *peer.Peer, msg *wire.MsgAddrV2) {
+				received <- msg
+			},
+		},
+		UserAgentName:    "peer",
+		UserAgentVersion: "1.0",
+		ChainParams:      &netparams.MainNetParams,
+	}
+	sentOnVersion := make(chan int, 1)
+	outCfg := *inCfg
+	outCfg.Listeners = peer.MessageListeners{
+		OnVersion: func(p *peer.Peer, msg *wire.MsgVersion) *wire.MsgReject {
+			sent, _ := p.PushAddrMsg([]*wire.NetAddress{torV3})
+			sentOnVersion <- len(sent)
+			return nil
+		},
+		OnVerAck: func(p *peer.Peer, msg *wire.MsgVerAck) {
+			if _, err := p.PushAddrMsg([]*wire.NetAddress{torV3}); err != nil {
+				t.Errorf("PushAddrMsg: unexpected err %v", err)
+			}
+		},
+	}
+	inConn, outConn := pipe(
+		&conn{laddr: "10.0.0.1:11047", raddr: "10.0.0.2:11047"},
+		&conn{laddr: "10.0.0.2:11047", raddr: "10.0.0.1:11047"},
+	)
+	inPeer := peer.NewInboundPeer(inCfg)
+	inPeer.AssociateConnection(inConn)
+	outPeer, err := peer.NewOutboundPeer(&outCfg, inConn.laddr)
+	if err != nil {
+		t.Fatalf("NewOutboundPeer: unexpected err %v", err)
+	}
+	outPeer.AssociateConnection(outConn)
+	defer func() {
+		inPeer.Disconnect()
+		outPeer.Disconnect()
+		inPeer.WaitForDisconnect()
+		outPeer.WaitForDisconnect()
+	}()
+	select {
+	case msg := <-received:
+		if len(msg.AddrList) != 1 || msg.AddrList[0].NetID() != wire.NetTorV3 ||
+			!bytes.Equal(msg.AddrList[0].Addr, torV3.Addr) || msg.AddrList[0].Port != torV3.Port {
+			t.Errorf("got addresses %v, want only %v", msg.AddrList, torV3)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Tor v3 address was not received")
+	}
+	if n := <-sentOnVersion; n != 0 {
+		t.Errorf("%d addresses were sent on the version message, before sendaddrv2 could be read", n)
+	}
+}
+
 // TestOutboundPeer tests that the outbound peer works as expected.
 func TestOutboundPeer(t *testing.T) {
 	peerCfg := &peer.Config{
@@ -843,6 +951,88 @@ func TestDuplicateVersionMsg(t *testing.T) {
 		t.Fatal("peer did not disconnect")
 	}
 }
+
+// TestPeerV2Transport tests that peers with the v2 transport enabled encrypt the connection between them, accept a v1
+// peer in plaintext, and report when an outbound v2 handshake is refused.
+func TestPeerV2Transport(t *testing.T) {
+	verack := qu.T()
+	v2Cfg := &peer.Config{
+		Listeners: peer.MessageListeners{
+			OnVerAck: func(p *peer.Peer, msg *wire.MsgVerAck) {
+				verack <- struct{}{}
+			},
+		},
+		UserAgentName:    "peer",
+		UserAgentVersion: "1.0",
+		ChainParams:      &netparams.MainNetParams,
+		V2Transport:      true,
+	}
+	v1Cfg := *v2Cfg
+	v1Cfg.V2Transport = false
+	tests := []struct {
+		name          string
+		outCfg        *peer.Config
+		wantTransport string
+	}{
+		{"v2 to v2", v2Cfg, "v2"},
+		{"v1 to v2", &v1Cfg, "v1"},
+	}
+	for _, test := range tests {
+		inConn, outConn := pipe(
+			&conn{laddr: "10.0.0.1:11047", raddr: "10.0.0.2:11047"},
+			&conn{laddr: "10.0.0.2:11047", raddr: "10.0.0.1:11047"},
+		)
+		inPeer := peer.NewInboundPeer(v2Cfg)
+		inPeer.AssociateConnection(inConn)
+		outPeer, err := peer.NewOutboundPeer(test.outCfg, inConn.laddr)
+		if err != nil {
+			t.Fatalf("%s: NewOutboundPeer: unexpected err: %v", test.name, err)
+		}
+		outPeer.AssociateConnection(outConn)
+		for i := 0; i < 2; i++ {
+			select {
+			case <-verack.Wait():
+			case <-time.After(time.Second):
+				t.Fatalf("%s: verack timeout", test.name)
+			}
+		}
+		for _, p := range []*peer.Peer{inPeer, outPeer} {
+			if got := p.TransportProtocol(); got != test.wantTransport {
+				t.Errorf("%s: TransportProtocol of %v: got %v, want %v", test.name, p, got,
+					test.wantTransport)
+			}
+			if got := p.StatsSnapshot().TransportProtocol; got != test.wantTransport {
+				t.Errorf("%s: StatsSnapshot of %v: got transport %v, want %v", test.name, p, got,
+					test.wantTransport)
+			}
+		}
+		if inPeer.SessionID() != outPeer.SessionID() {
+			t.Errorf("%s: session ids differ: %v != %v", test.name, inPeer.SessionID(),
+				outPeer.SessionID())
+		}
+		if (test.wantTransport == "v2") != (outPeer.SessionID() != "") {
+			t.Errorf("%s: unexpected session id %q", test.name, outPeer.SessionID())
+		}
+		inPeer.Disconnect()
+		outPeer.Disconnect()
+		inPeer.WaitForDisconnect()
+		outPeer.WaitForDisconnect()
+	}
+	// A v1 peer closes the connection on reading the handshake key, which must be reported as a refusal.
+	outPeer, err := peer.NewOutboundPeer(v2Cfg, "10.0.0.1:11047")
+	if err != nil {
+		t.Fatalf("NewOutboundPeer: unexpected err: %v", err)
+	}
+	outPeer.AssociateConnection(&conn{
+		Reader: bytes.NewReader(nil),
+		Writer: ioutil.Discard,
+		raddr:  "10.0.0.1:11047",
+	})
+	outPeer.WaitForDisconnect()
+	if !outPeer.V2HandshakeRefused() {
+		t.Error("V2HandshakeRefused: got false, want true")
+	}
+}
 func init() {
 	// Allow self connection when running the tests.
 	peer.TstAllowSelfConns()
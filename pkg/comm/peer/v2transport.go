@@ -0,0 +1,418 @@
+package peer
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+)
+
+// The v2 transport encrypts and authenticates everything sent over a connection after a key exchange, along the lines
+// of BIP 324. Each side sends a compressed ephemeral secp256k1 public key, the shared secret of the two keys is expanded
+// into a key pair for each direction and a session ID, and from then on every message is sent as a packet with an
+// encrypted three byte length followed by the message contents sealed with ChaCha20-Poly1305.
+//
+// Unlike BIP 324 the public keys are sent as they are rather than ElligatorSwift encoded, so the handshake is not
+// indistinguishable from random bytes to an observer, and there is no garbage before the first packet. The transport
+// protects the contents of the connection but does not hide that it is a v2 connection.
+const (
+	// v2PubKeyLen is the length of the ephemeral public keys sent in the handshake.
+	v2PubKeyLen = 33
+	// v2LengthLen is the length of the encrypted length field that starts every packet.
+	v2LengthLen = 3
+	// v2HeaderLen is the length of the header byte that is encrypted along with the contents of every packet.
+	v2HeaderLen = 1
+	// v2TagLen is the length of the Poly1305 tag that ends every packet.
+	v2TagLen = 16
+	// v2IgnoreBit marks a decoy packet in the header byte, which the receiver must drop.
+	v2IgnoreBit = 0x80
+	// v2MaxContentsLen is the most contents a packet can carry, as the length field is 24 bits.
+	v2MaxContentsLen = 1<<24 - 1
+	// v2MaxRecvContentsLen is the most contents accepted in a received packet, a long command and the largest message
+	// payload, checked before the buffer for the packet is allocated so the limit holds whatever the size of the
+	// length field.
+	v2MaxRecvContentsLen = 1 + wire.CommandSize + wire.MaxMessagePayload
+	// v2RekeyInterval is the number of packets after which the keys of each direction are replaced.
+	v2RekeyInterval = 224
+	// v1PrefixLen is the length of the network magic and command of a v1 version message, which is how an inbound
+	// connection to a node accepting v2 is recognised as v1.
+	v1PrefixLen = 4 + wire.CommandSize
+)
+
+// fsChaCha20 is the forward secure stream cipher which encrypts the packet lengths. The keystream runs on across
+// packets, and after every v2RekeyInterval packets the key is replaced with the next 32 bytes of it.
+type fsChaCha20 struct {
+	key     [chacha20.KeySize]byte
+	c       *chacha20.Cipher
+	packets uint32
+	rekeys  uint64
+}
+
+// newFSChaCha20 returns a new length cipher using the given key.
+func newFSChaCha20(key []byte) *fsChaCha20 {
+	f := &fsChaCha20{}
+	copy(f.key[:], key)
+	f.reset()
+	return f
+}
+
+// reset starts the keystream for the current key.
+func (f *fsChaCha20) reset() {
+	var nonce [chacha20.NonceSize]byte
+	binary.LittleEndian.PutUint64(nonce[4:], f.rekeys)
+	// This only fails for wrong key or nonce sizes.
+	f.c, _ = chacha20.NewUnauthenticatedCipher(f.key[:], nonce[:])
+}
+
+// crypt encrypts or decrypts the length field of a packet in place.
+func (f *fsChaCha20) crypt(b []byte) {
+	f.c.XORKeyStream(b, b)
+	if f.packets++; f.packets == v2RekeyInterval {
+		var key [chacha20.KeySize]byte
+		f.c.XORKeyStream(key[:], key[:])
+		f.key = key
+		f.packets = 0
+		f.rekeys++
+		f.reset()
+	}
+}
+
+// fsChaCha20Poly1305 is the forward secure AEAD which seals the packet contents. The nonce is made of the packet count
+// since the last rekey and the number of rekeys, and after every v2RekeyInterval packets the key is replaced with the
+// start of the encryption of zeroes under a nonce no packet uses.
+type fsChaCha20Poly1305 struct {
+	aead    cipher.AEAD
+	packets uint32
+	rekeys  uint64
+}
+
+// newFSChaCha20Poly1305 returns a new contents cipher using the given key.
+func newFSChaCha20Poly1305(key []byte) *fsChaCha20Poly1305 {
+	// This only fails for a wrong key size.
+	aead, _ := chacha20poly1305.New(key)
+	return &fsChaCha20Poly1305{aead: aead}
+}
+
+// nonce returns the nonce for the given packet count and the current number of rekeys.
+func (f *fsChaCha20Poly1305) nonce(packet uint32) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	binary.LittleEndian.PutUint32(nonce, packet)
+	binary.LittleEndian.PutUint64(nonce[4:], f.rekeys)
+	return nonce
+}
+
+// next moves on to the next packet, replacing the key if it is due.
+func (f *fsChaCha20Poly1305) next() {
+	if f.packets++; f.packets == v2RekeyInterval {
+		key := f.aead.Seal(nil, f.nonce(0xffffffff), make([]byte, chacha20poly1305.KeySize), nil)
+		f.aead, _ = chacha20poly1305.New(key[:chacha20poly1305.KeySize])
+		f.packets = 0
+		f.rekeys++
+	}
+}
+
+// seal appends the sealed plaintext to dst.
+func (f *fsChaCha20Poly1305) seal(dst, plaintext []byte) []byte {
+	out := f.aead.Seal(dst, f.nonce(f.packets), plaintext, nil)
+	f.next()
+	return out
+}
+
+// open authenticates and decrypts the ciphertext in place.
+func (f *fsChaCha20Poly1305) open(ciphertext []byte) ([]byte, error) {
+	out, err := f.aead.Open(ciphertext[:0], f.nonce(f.packets), ciphertext, nil)
+	f.next()
+	return out, err
+}
+
+// v2Transport holds the ciphers of an established v2 connection. The send and receive sides are only used by the
+// goroutines writing and reading the connection respectively, so it needs no locking.
+type v2Transport struct {
+	sendL     *fsChaCha20
+	sendP     *fsChaCha20Poly1305
+	recvL     *fsChaCha20
+	recvP     *fsChaCha20Poly1305
+	sessionID [32]byte
+}
+
+// newV2Transport derives the keys of a v2 connection from the shared secret of our private key and the remote public
+// key, and the public keys of the initiator and responder of the connection.
+func newV2Transport(priv *ec.PrivateKey, theirs, initiatorPub, responderPub []byte, btcnet wire.BitcoinNet,
+	initiator bool) (*v2Transport, error) {
+	pub, err := ec.ParsePubKey(theirs, ec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid v2 handshake public key: %v", err)
+	}
+	ikm := ec.GenerateSharedSecret(priv, pub)
+	ikm = append(ikm, initiatorPub...)
+	ikm = append(ikm, responderPub...)
+	salt := []byte("pod_v2_shared_secret")
+	salt = append(salt, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(salt[len(salt)-4:], uint32(btcnet))
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	expand := func(info string) []byte {
+		key := make([]byte, 32)
+		// The output is far below the limit of the expansion so this can't fail.
+		_, _ = io.ReadFull(hkdf.Expand(sha256.New, prk, []byte(info)), key)
+		return key
+	}
+	t := &v2Transport{}
+	initiatorL, initiatorP := expand("initiator_L"), expand("initiator_P")
+	responderL, responderP := expand("responder_L"), expand("responder_P")
+	copy(t.sessionID[:], expand("session_id"))
+	if initiator {
+		t.sendL, t.sendP = newFSChaCha20(initiatorL), newFSChaCha20Poly1305(initiatorP)
+		t.recvL, t.recvP = newFSChaCha20(responderL), newFSChaCha20Poly1305(responderP)
+	} else {
+		t.sendL, t.sendP = newFSChaCha20(responderL), newFSChaCha20Poly1305(responderP)
+		t.recvL, t.recvP = newFSChaCha20(initiatorL), newFSChaCha20Poly1305(initiatorP)
+	}
+	return t, nil
+}
+
+// writePacket encrypts and writes a packet with the given header byte and contents, returning the number of bytes
+// written.
+func (t *v2Transport) writePacket(w io.Writer, header byte, contents []byte) (int, error) {
+	if len(contents) > v2MaxContentsLen {
+		return 0, fmt.Errorf("v2 packet contents of %d bytes exceed the maximum of %d", len(contents),
+			v2MaxContentsLen)
+	}
+	buf := make([]byte, v2LengthLen, v2LengthLen+v2HeaderLen+len(contents)+v2TagLen)
+	buf[0], buf[1], buf[2] = byte(len(contents)), byte(len(contents)>>8), byte(len(contents)>>16)
+	t.sendL.crypt(buf)
+	plaintext := make([]byte, v2HeaderLen+len(contents))
+	plaintext[0] = header
+	copy(plaintext[v2HeaderLen:], contents)
+	return w.Write(t.sendP.seal(buf, plaintext))
+}
+
+// readPacket reads and decrypts a packet, returning its header byte, its contents and the number of bytes read.
+func (t *v2Transport) readPacket(r io.Reader) (byte, []byte, int, error) {
+	var length [v2LengthLen]byte
+	n, err := io.ReadFull(r, length[:])
+	if err != nil {
+		return 0, nil, n, err
+	}
+	t.recvL.crypt(length[:])
+	contentsLen := int(length[0]) | int(length[1])<<8 | int(length[2])<<16
+	if contentsLen > v2MaxRecvContentsLen {
+		return 0, nil, n, fmt.Errorf("v2 packet contents of %d bytes are more than the maximum of %d", contentsLen,
+			v2MaxRecvContentsLen)
+	}
+	ciphertext := make([]byte, v2HeaderLen+contentsLen+v2TagLen)
+	nn, err := io.ReadFull(r, ciphertext)
+	n += nn
+	if err != nil {
+		return 0, nil, n, err
+	}
+	plaintext, err := t.recvP.open(ciphertext)
+	if err != nil {
+		return 0, nil, n, errors.New("v2 packet failed authentication")
+	}
+	return plaintext[0], plaintext[v2HeaderLen:], n, nil
+}
+
+// writeMessage writes a message as a v2 packet, returning the number of bytes written.
+func (t *v2Transport) writeMessage(w io.Writer, msg wire.Message, pver uint32, enc wire.MessageEncoding) (int,
+	error) {
+	contents, err := wire.EncodeV2MessageContents(msg, pver, enc)
+	if err != nil {
+		return 0, err
+	}
+	return t.writePacket(w, 0, contents)
+}
+
+// readMessage reads the next message from v2 packets, skipping decoy packets, and returns the number of bytes read
+// along with the message and its raw payload.
+func (t *v2Transport) readMessage(r io.Reader, pver uint32, enc wire.MessageEncoding) (int, wire.Message, []byte,
+	error) {
+	var n int
+	for {
+		header, contents, nn, err := t.readPacket(r)
+		n += nn
+		if err != nil {
+			return n, nil, nil, err
+		}
+		if header&v2IgnoreBit != 0 {
+			continue
+		}
+		msg, buf, err := wire.DecodeV2MessageContents(contents, pver, enc)
+		return n, msg, buf, err
+	}
+}
+
+// v1Prefix returns the first bytes a v1 peer sends, which are the network magic and command of its version message.
+func v1Prefix(btcnet wire.BitcoinNet) []byte {
+	prefix := make([]byte, v1PrefixLen)
+	binary.LittleEndian.PutUint32(prefix, uint32(btcnet))
+	copy(prefix[4:], wire.CmdVersion)
+	return prefix
+}
+
+// isHandshakeRefusal returns whether the error from reading the response to our handshake key means the remote peer
+// closed the connection, which is how a peer that only speaks v1 reacts to it.
+func isHandshakeRefusal(err error) bool {
+	return err == io.EOF || errors.Is(err, syscall.ECONNRESET)
+}
+
+// initiateV2Transport performs the handshake of an outbound v2 connection.
+func (p *Peer) initiateV2Transport() error {
+	priv, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		Error(err)
+		return err
+	}
+	ours := priv.PubKey().SerializeCompressed()
+	n, err := p.conn.Write(ours)
+	atomic.AddUint64(&p.bytesSent, uint64(n))
+	if err != nil {
+		return err
+	}
+	theirs := make([]byte, v2PubKeyLen)
+	n, err = io.ReadFull(p.conn, theirs)
+	atomic.AddUint64(&p.bytesReceived, uint64(n))
+	if err != nil {
+		if n == 0 && isHandshakeRefusal(err) {
+			p.flagsMtx.Lock()
+			p.v2Refused = true
+			p.flagsMtx.Unlock()
+		}
+		return err
+	}
+	t, err := newV2Transport(priv, theirs, ours, theirs, p.cfg.ChainParams.Net, true)
+	if err != nil {
+		return err
+	}
+	// The initiator sends its version packet first.
+	if err = p.writeV2VersionPacket(t); err != nil {
+		return err
+	}
+	if err = p.readV2VersionPacket(t); err != nil {
+		return err
+	}
+	p.setV2Transport(t)
+	return nil
+}
+
+// acceptV2Transport performs the handshake of an inbound v2 connection, unless the peer turns out to have started a v1
+// connection, in which case the bytes read to tell them apart are put back in front of the connection.
+func (p *Peer) acceptV2Transport() error {
+	theirs := make([]byte, v2PubKeyLen)
+	n, err := io.ReadFull(p.conn, theirs[:v1PrefixLen])
+	if err != nil {
+		atomic.AddUint64(&p.bytesReceived, uint64(n))
+		return err
+	}
+	if bytes.Equal(theirs[:v1PrefixLen], v1Prefix(p.cfg.ChainParams.Net)) {
+		p.connReader = io.MultiReader(bytes.NewReader(theirs[:v1PrefixLen]), p.conn)
+		return nil
+	}
+	nn, err := io.ReadFull(p.conn, theirs[v1PrefixLen:])
+	atomic.AddUint64(&p.bytesReceived, uint64(n+nn))
+	if err != nil {
+		return err
+	}
+	priv, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		Error(err)
+		return err
+	}
+	ours := priv.PubKey().SerializeCompressed()
+	t, err := newV2Transport(priv, theirs, theirs, ours, p.cfg.ChainParams.Net, false)
+	if err != nil {
+		return err
+	}
+	n, err = p.conn.Write(ours)
+	atomic.AddUint64(&p.bytesSent, uint64(n))
+	if err != nil {
+		return err
+	}
+	if err = p.readV2VersionPacket(t); err != nil {
+		return err
+	}
+	if err = p.writeV2VersionPacket(t); err != nil {
+		return err
+	}
+	p.setV2Transport(t)
+	return nil
+}
+
+// writeV2VersionPacket sends the transport version packet, which has no contents as there is only one version so far.
+func (p *Peer) writeV2VersionPacket(t *v2Transport) error {
+	n, err := t.writePacket(p.conn, 0, nil)
+	atomic.AddUint64(&p.bytesSent, uint64(n))
+	return err
+}
+
+// readV2VersionPacket reads the transport version packet of the remote peer. It is the first packet to be
+// authenticated, so it fails if the two sides didn't arrive at the same keys.
+func (p *Peer) readV2VersionPacket(t *v2Transport) error {
+	for {
+		header, _, n, err := t.readPacket(p.conn)
+		atomic.AddUint64(&p.bytesReceived, uint64(n))
+		if err != nil {
+			return err
+		}
+		// Contents are ignored so that later versions can add to the packet.
+		if header&v2IgnoreBit == 0 {
+			return nil
+		}
+	}
+}
+
+// setV2Transport switches the connection over to the established v2 transport.
+func (p *Peer) setV2Transport(t *v2Transport) {
+	p.flagsMtx.Lock()
+	p.transport = t
+	p.flagsMtx.Unlock()
+	Debugf("v2 transport established with %s, session id %x", p, t.sessionID)
+}
+
+// v2 returns the v2 transport of the connection, or nil if it is a plaintext v1 connection.
+func (p *Peer) v2() *v2Transport {
+	p.flagsMtx.Lock()
+	t := p.transport
+	p.flagsMtx.Unlock()
+	return t
+}
+
+// TransportProtocol returns "v2" if the connection to the peer is encrypted with the v2 transport and "v1" if it is
+// plaintext. This function is safe for concurrent access.
+func (p *Peer) TransportProtocol() string {
+	if p.v2() != nil {
+		return "v2"
+	}
+	return "v1"
+}
+
+// SessionID returns the hex encoded session ID of a v2 connection, which is the same on both sides unless there is a
+// man in the middle, or an empty string for a v1 connection. This function is safe for concurrent access.
+func (p *Peer) SessionID() string {
+	if t := p.v2(); t != nil {
+		return hex.EncodeToString(t.sessionID[:])
+	}
+	return ""
+}
+
+// V2HandshakeRefused returns whether the remote peer of an outbound v2 connection closed it without answering the
+// handshake, which is what a peer that only speaks v1 does. The caller can then connect again without v2. This function
+// is safe for concurrent access.
+func (p *Peer) V2HandshakeRefused() bool {
+	p.flagsMtx.Lock()
+	refused := p.v2Refused
+	p.flagsMtx.Unlock()
+	return refused
+}
@@ -0,0 +1,93 @@
+package peer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+)
+
+// newV2TransportPair returns the initiator and responder sides of a v2 connection set up with fresh keys.
+func newV2TransportPair(t *testing.T) (*v2Transport, *v2Transport) {
+	initiatorKey, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	responderKey, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	initiatorPub := initiatorKey.PubKey().SerializeCompressed()
+	responderPub := responderKey.PubKey().SerializeCompressed()
+	btcnet := netparams.MainNetParams.Net
+	initiator, err := newV2Transport(initiatorKey, responderPub, initiatorPub, responderPub, btcnet, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	responder, err := newV2Transport(responderKey, initiatorPub, initiatorPub, responderPub, btcnet, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return initiator, responder
+}
+
+// TestV2Transport ensures messages sent over the v2 transport arrive intact in both directions, including across
+// rekeys and with decoy packets in between, and that the two sides agree on the session ID.
+func TestV2Transport(t *testing.T) {
+	initiator, responder := newV2TransportPair(t)
+	if initiator.sessionID != responder.sessionID {
+		t.Fatalf("session ids differ: %x != %x", initiator.sessionID, responder.sessionID)
+	}
+	var buf bytes.Buffer
+	// Send enough messages to go through several rekeys of both ciphers.
+	for i := 0; i < 3*v2RekeyInterval; i++ {
+		for _, dir := range []struct {
+			from, to *v2Transport
+		}{{initiator, responder}, {responder, initiator}} {
+			if i%7 == 0 {
+				if _, err := dir.from.writePacket(&buf, v2IgnoreBit, []byte("decoy")); err != nil {
+					t.Fatalf("writePacket #%d: %v", i, err)
+				}
+			}
+			ping := wire.NewMsgPing(uint64(i))
+			n, err := dir.from.writeMessage(&buf, ping, wire.ProtocolVersion, wire.BaseEncoding)
+			if err != nil {
+				t.Fatalf("writeMessage #%d: %v", i, err)
+			}
+			if want := v2LengthLen + v2HeaderLen + 9 + v2TagLen; n != want {
+				t.Fatalf("writeMessage #%d: wrote %d bytes, want %d", i, n, want)
+			}
+			_, msg, _, err := dir.to.readMessage(&buf, wire.ProtocolVersion, wire.BaseEncoding)
+			if err != nil {
+				t.Fatalf("readMessage #%d: %v", i, err)
+			}
+			if got, ok := msg.(*wire.MsgPing); !ok || got.Nonce != uint64(i) {
+				t.Fatalf("readMessage #%d: got %v, want ping %d", i, msg, i)
+			}
+		}
+	}
+	// A packet that was tampered with must fail authentication.
+	if _, err := initiator.writeMessage(&buf, wire.NewMsgPing(1), wire.ProtocolVersion,
+		wire.BaseEncoding); err != nil {
+		t.Fatal(err)
+	}
+	buf.Bytes()[v2LengthLen] ^= 1
+	if _, _, _, err := responder.readMessage(&buf, wire.ProtocolVersion, wire.BaseEncoding); err == nil {
+		t.Fatal("readMessage of a tampered packet succeeded")
+	}
+}
+
+// TestV2TransportWrongKey ensures a side that derived different keys fails to read the packets it is sent.
+func TestV2TransportWrongKey(t *testing.T) {
+	initiator, _ := newV2TransportPair(t)
+	_, responder := newV2TransportPair(t)
+	var buf bytes.Buffer
+	if _, err := initiator.writePacket(&buf, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := responder.readPacket(&buf); err == nil {
+		t.Fatal("readPacket with the wrong keys succeeded")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 	
 	qu "github.com/p9c/pod/pkg/util/quit"
@@ -25,12 +26,26 @@ const (
 	DefaultPort = 11049
 )
 
+const (
+	// LossWindow is how long the shards of a message are collected for before the message is discarded and the shards
+	// that never arrived are counted as lost
+	LossWindow = time.Second
+	// MaxFailureRate is the rate of undecodable messages that adaptive redundancy aims to stay under
+	MaxFailureRate = 0.001
+	// MinLoss is the lowest loss rate adaptive redundancy assumes, so there is always some redundancy
+	MinLoss = 0.01
+	// lossWeight is the weight of each message in the moving average of the loss rate
+	lossWeight = 0.1
+	// minLossSamples is the number of messages that must be measured before redundancy is adapted
+	minLossSamples = 8
+)
+
 var DefaultIP = net.IPv4(224, 0, 0, 1)
 var MulticastAddress = &net.UDPAddr{IP: DefaultIP, Port: DefaultPort}
 
 type (
 	MsgBuffer struct {
-		Buffers [][]byte
+		Partial *fec.Partial
 		First   time.Time
 		Decoded bool
 		Source  net.Addr
@@ -50,9 +65,120 @@ type (
 		Receiver        *net.UDPConn
 		sendCiph        cipher.AEAD
 		Sender          *net.UDPConn
+		mx              sync.Mutex
+		params          fec.Params
+		adaptive        bool
+		loss            float64
+		lossSamples     int
 	}
 )
 
+// SetFEC sets the shard counts used for sending messages. If adaptive is set the total number of shards follows the
+// loss measured on received messages, keeping the required number of shards and starting from the given total until
+// enough messages have been measured.
+func (c *Channel) SetFEC(params fec.Params, adaptive bool) (err error) {
+	if err = params.Validate(); Check(err) {
+		return
+	}
+	c.mx.Lock()
+	c.params, c.adaptive = params, adaptive
+	c.mx.Unlock()
+	return
+}
+
+// FEC returns the shard counts currently used for sending messages
+func (c *Channel) FEC() (params fec.Params) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.params.Total == 0 {
+		return fec.Default
+	}
+	if !c.adaptive || c.lossSamples < minLossSamples {
+		return c.params
+	}
+	loss := c.loss
+	if loss < MinLoss {
+		loss = MinLoss
+	}
+	return fec.ForLoss(c.params.Required, loss, MaxFailureRate)
+}
+
+// Loss returns the moving average of the fraction of shards of received messages that did not arrive
+func (c *Channel) Loss() float64 {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.loss
+}
+
+// measure adds the loss of a message to the moving average. Messages sent by this channel are skipped, as the loopback
+// copies of multicast messages do not cross the network.
+func (c *Channel) measure(b *MsgBuffer) {
+	if b.Partial.Expected() == 0 {
+		return
+	}
+	if c.Sender != nil && b.Source != nil && b.Source.String() == c.Sender.LocalAddr().String() {
+		return
+	}
+	loss := 1 - float64(b.Partial.Received())/float64(b.Partial.Expected())
+	c.mx.Lock()
+	if c.lossSamples == 0 {
+		c.loss = loss
+	} else {
+		c.loss += (loss - c.loss) * lossWeight
+	}
+	c.lossSamples++
+	c.mx.Unlock()
+}
+
+// expire measures and discards the messages that started arriving more than LossWindow before now
+func (c *Channel) expire(now time.Time) {
+	for i, b := range c.buffers {
+		if now.Sub(b.First) > LossWindow {
+			c.measure(b)
+			delete(c.buffers, i)
+		}
+	}
+}
+
+// receive adds a decrypted shard to the message with the given nonce, and returns the message when it is complete.
+// Messages are kept until they expire, so the shards that arrive after a message is decoded are counted in the
+// measured loss and do not cause it to be handled again.
+func (c *Channel) receive(nonce string, shard []byte, src net.Addr) (data []byte, ok bool, err error) {
+	now := time.Now()
+	bn, found := c.buffers[nonce]
+	if !found {
+		c.expire(now)
+		bn = &MsgBuffer{Partial: fec.NewPartial(), First: now, Source: src}
+		c.buffers[nonce] = bn
+	}
+	if err = bn.Partial.Add(shard); err != nil || bn.Decoded || !bn.Partial.Complete() {
+		return
+	}
+	bn.Decoded = true
+	if data, err = bn.Partial.Data(); err == nil {
+		ok = true
+	}
+	return
+}
+
+// ShardSize is the largest shard that fits in a datagram along with the magic, nonce and authentication tag
+func (c *Channel) ShardSize() (size int) {
+	size = c.MaxDatagramSize - 4
+	if c.sendCiph != nil {
+		size -= c.sendCiph.NonceSize() + c.sendCiph.Overhead()
+	}
+	return
+}
+
+// GetShards returns the fec encoded shards to feed to Channel.SendMany for the provided buffer, using the shard counts
+// of the channel and splitting the buffer into segments if it does not fit in one datagram
+func (c *Channel) GetShards(data []byte) (shards [][]byte) {
+	var err error
+	if shards, err = c.FEC().Encode(data, c.ShardSize()); Check(err) {
+	}
+	return
+}
+
 // SetDestination changes the address the outbound connection of a multicast directs to
 func (c *Channel) SetDestination(dst string) (err error) {
 	Debug("sending to", dst)
@@ -101,7 +227,8 @@ func (c *Channel) Close() (err error) {
 }
 
 // GetShards returns a buffer iterator to feed to Channel.SendMany containing fec encoded shards built from the provided
-// buffer
+// buffer with the default shard counts, as one segment. Channel.GetShards should be used where there is a channel, to
+// use its settings and keep the shards within its datagram size
 func GetShards(data []byte) (shards [][]byte) {
 	var err error
 	if shards, err = fec.Encode(data); Check(err) {
@@ -117,6 +244,8 @@ func NewUnicastChannel(creator string, ctx interface{}, key, sender, receiver st
 		MaxDatagramSize: maxDatagramSize,
 		buffers:         make(map[string]*MsgBuffer),
 		context:         ctx,
+		params:          fec.Default,
+		adaptive:        true,
 	}
 	var magics []string
 
@@ -176,7 +305,7 @@ func Listen(address string, channel *Channel, maxDatagramSize int, handlers Hand
 func NewBroadcastChannel(creator string, ctx interface{}, key string, port int, maxDatagramSize int, handlers Handlers,
 	quit qu.C) (channel *Channel, err error) {
 	channel = &Channel{Creator: creator, MaxDatagramSize: maxDatagramSize,
-		buffers: make(map[string]*MsgBuffer), context: ctx, Ready: qu.T()}
+		buffers: make(map[string]*MsgBuffer), context: ctx, Ready: qu.T(), params: fec.Default, adaptive: true}
 	if channel.sendCiph, err = gcm.GetCipher(key); Check(err) {
 	}
 	if channel.sendCiph == nil {
@@ -241,7 +370,8 @@ func handleNetworkError(address string, err error) (result int) {
 }
 
 // Handle listens for messages, decodes them, aggregates them, recovers the data from the reed solomon fec shards
-// received and invokes the handler provided matching the magic on the complete received messages
+// received and invokes the handler provided matching the magic on the complete received messages. The shards of each
+// message are counted to measure the loss rate that adaptive redundancy follows
 func Handle(address string, channel *Channel,
 	handlers Handlers, maxDatagramSize int, quit qu.C) {
 	buffer := make([]byte, maxDatagramSize)
@@ -290,48 +420,12 @@ out:
 			if shard, err = channel.receiveCiph.Open(nil, nonceBytes, msg[4+len(nonceBytes):], nil); err != nil {
 				continue
 			}
-			// DEBUG("read", numBytes, "from", src, err, hex.EncodeToString(msg))
-			if bn, ok := channel.buffers[nonce]; ok {
-				if !bn.Decoded {
-					bn.Buffers = append(bn.Buffers, shard)
-					if len(bn.Buffers) >= 3 {
-						// DEBUG(len(bn.Buffers))
-						// try to decode it
-						var cipherText []byte
-						cipherText, err = fec.Decode(bn.Buffers)
-						if err != nil {
-							Error(err)
-							continue
-						}
-						bn.Decoded = true
-						// DEBUG(numBytes, src, err)
-						Debugf("received packet with magic %s from %s", magic, src.String())
-						if err = handler(channel.context, src, address, cipherText); Check(err) {
-							continue
-						}
-						// src = nil
-						// buffer = buffer[:0]
-					}
-				} else {
-					// if nonce == seenNonce {
-					// 	continue
-					// }
-					// seenNonce = nonce
-					for i := range channel.buffers {
-						if i != nonce || (channel.buffers[i].Decoded &&
-							len(channel.buffers[i].Buffers) > 8) {
-							// superseded messages can be deleted from the buffers, we don't add more data for the
-							// already decoded.
-							// todo: this will be changed to track stats for the puncture rate and redundancy scaling
-							delete(channel.buffers, i)
-						}
-					}
-				}
-			} else {
-				channel.buffers[nonce] = &MsgBuffer{[][]byte{},
-					time.Now(), false, src}
-				channel.buffers[nonce].Buffers = append(channel.buffers[nonce].
-					Buffers, shard)
+			var data []byte
+			if data, ok, err = channel.receive(nonce, shard, src); Check(err) || !ok {
+				continue
+			}
+			Debugf("received packet with magic %s from %s", magic, src.String())
+			if err = handler(channel.context, src, address, data); Check(err) {
 			}
 		}
 		// for i := range buffer {
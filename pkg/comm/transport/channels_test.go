@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"bytes"
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/coding/fec"
+)
+
+// lossyChannel returns a channel without sockets or encryption, for feeding shards directly to its receiver
+func lossyChannel() *Channel {
+	return &Channel{
+		Creator:         "test",
+		MaxDatagramSize: 512,
+		buffers:         make(map[string]*MsgBuffer),
+		params:          fec.Default,
+		adaptive:        true,
+	}
+}
+
+// deliver sends a message through the channel, losing each shard with the given probability, and returns whether it
+// was received intact
+func deliver(t *testing.T, rng *rand.Rand, c *Channel, nonce string, data []byte, loss float64) (delivered bool) {
+	src := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: DefaultPort}
+	for _, shard := range c.GetShards(data) {
+		if len(shard) > c.ShardSize() {
+			t.Fatalf("shard of %d bytes does not fit in a datagram", len(shard))
+		}
+		if rng.Float64() < loss {
+			continue
+		}
+		received, ok, err := c.receive(nonce, shard, src)
+		if err != nil {
+			t.Fatalf("receive: %v", err)
+		}
+		if ok {
+			if delivered {
+				t.Fatalf("message was received twice")
+			}
+			if !bytes.Equal(received, data) {
+				t.Fatalf("received message does not match")
+			}
+			delivered = true
+		}
+	}
+	return
+}
+
+// TestAdaptiveRedundancy simulates lossy networks and checks the channel measures the loss and adapts its redundancy
+// to it, and that messages bigger than a datagram are segmented and reassembled.
+func TestAdaptiveRedundancy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, loss := range []float64{0, 0.1, 0.3} {
+		c := lossyChannel()
+		if p := c.FEC(); p != fec.Default {
+			t.Fatalf("expected %v before measuring loss, got %v", fec.Default, p)
+		}
+		for i := 0; i < 200; i++ {
+			// every 10th message is too big for one datagram
+			size := 100
+			if i%10 == 0 {
+				size = 3000
+			}
+			deliver(t, rng, c, string(rune(i)), make([]byte, size), loss)
+			// the shards of the message are counted when it expires
+			c.expire(time.Now().Add(2 * LossWindow))
+		}
+		if measured := c.Loss(); measured < loss-0.1 || measured > loss+0.1 {
+			t.Errorf("loss %v: measured %v", loss, measured)
+		}
+		p := c.FEC()
+		if p.Required != fec.Default.Required {
+			t.Errorf("loss %v: required shards changed to %d", loss, p.Required)
+		}
+		if loss == 0 && p.Total >= fec.Default.Total {
+			t.Errorf("loss %v: expected less redundancy than %v, got %v", loss, fec.Default, p)
+		}
+		if loss == 0.3 && p.Total <= fec.Default.Total {
+			t.Errorf("loss %v: expected more redundancy than %v, got %v", loss, fec.Default, p)
+		}
+		// with the adapted redundancy nearly every message gets through
+		var delivered int
+		for i := 0; i < 500; i++ {
+			if deliver(t, rng, c, string(rune(1000+i)), make([]byte, 100), loss) {
+				delivered++
+			}
+		}
+		if delivered < 495 {
+			t.Errorf("loss %v: %v delivered %d of 500 messages", loss, p, delivered)
+		}
+	}
+	// fixed settings are not adapted
+	c := lossyChannel()
+	fixed := fec.Params{Required: 2, Total: 3}
+	if err := c.SetFEC(fixed, false); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		deliver(t, rng, c, string(rune(i)), make([]byte, 100), 0)
+		c.expire(time.Now().Add(2 * LossWindow))
+	}
+	if p := c.FEC(); p != fixed {
+		t.Errorf("expected fixed settings %v, got %v", fixed, p)
+	}
+	if err := c.SetFEC(fec.Params{Required: 3, Total: 2}, true); err == nil {
+		t.Errorf("expected an error for invalid settings")
+	}
+}
@@ -43,7 +43,7 @@ func main() {
 	loop.To(10, func(i int) {
 		text := []byte(fmt.Sprintf("this is a test %d", i))
 		Infof("%s -> %s [%d] '%s'", c.Sender.LocalAddr(), c.Sender.RemoteAddr(), n-4, text)
-		if err = c.SendMany(TestMagicB, transport.GetShards(text)); Check(err) {
+		if err = c.SendMany(TestMagicB, c.GetShards(text)); Check(err) {
 		} else {
 		}
 	})
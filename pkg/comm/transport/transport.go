@@ -16,11 +16,11 @@ import (
 type HandleFunc map[string]func(ctx interface{}) func(b []byte) (err error)
 
 // Connection is the state and working memory references for a simple reliable UDP lan transport, encrypted by a GCM AES
-// cipher, with the simple protocol of sending out 9 packets containing encrypted FEC shards containing a slice of
-// bytes.
+// cipher, with the simple protocol of sending out packets containing encrypted FEC shards containing a slice of bytes,
+// 9 for each segment of the message that fits in a datagram.
 //
 // This protocol probably won't work well outside of a multicast lan in adverse conditions but it is designed for local
-// network control systems todo: it is if the updated fec segmenting code is put in
+// network control systems. Channel adapts its redundancy to the measured loss and should be preferred
 type Connection struct {
 	maxDatagramSize int
 	buffers         map[string]*MsgBuffer
@@ -103,7 +103,10 @@ func (c *Connection) CreateShards(b, magic []byte) (shards [][]byte,
 		return
 	}
 	// generate the shards
-	shards, err = fec.Encode(b)
+	if shards, err = fec.Default.Encode(b, c.maxDatagramSize-magicLen-nonceLen-c.ciph.Overhead()); err != nil {
+		Error(err)
+		return
+	}
 	for i := range shards {
 		encryptedShard := c.ciph.Seal(nil, nonce, shards[i], nil)
 		shardLen := len(encryptedShard)
@@ -213,44 +216,34 @@ func (c *Connection) Listen(handlers HandleFunc, ifc interface{},
 					// corrupted or irrelevant message
 					continue
 				}
-				if bn, ok := c.buffers[nonce]; ok {
-					if !bn.Decoded {
-						bn.Buffers = append(bn.Buffers, shard)
-						if len(bn.Buffers) >= 3 {
-							// try to decode it
-							var cipherText []byte
-							cipherText, err = fec.Decode(bn.Buffers)
-							if err != nil {
-								Error(err)
-								continue
-							}
-							bn.Decoded = true
-							err = handlers[magic](ifc)(cipherText)
-							if err != nil {
-								Error(err)
-								continue
-							}
-						}
-					} else {
-						for i := range c.buffers {
-							if i != nonce {
-								// superseded messages can be deleted from the
-								// buffers,
-								// we don't add more data for the already
-								// decoded.
-								// Trace("deleting superseded buffer",
-								// 	hex.EncodeToString([]byte(i)))
-								delete(c.buffers, i)
-							}
+				bn, ok := c.buffers[nonce]
+				if !ok {
+					for i := range c.buffers {
+						if time.Since(c.buffers[i].First) > LossWindow {
+							// superseded messages can be deleted from the buffers
+							delete(c.buffers, i)
 						}
 					}
-				} else {
-					// Trace("new message arriving",
-					// 	hex.EncodeToString([]byte(nonce)))
-					c.buffers[nonce] = &MsgBuffer{[][]byte{},
-						time.Now(), false, src}
-					c.buffers[nonce].Buffers = append(c.buffers[nonce].
-						Buffers, shard)
+					bn = &MsgBuffer{Partial: fec.NewPartial(), First: time.Now(), Source: src}
+					c.buffers[nonce] = bn
+				}
+				if err = bn.Partial.Add(shard); err != nil {
+					Error(err)
+					continue
+				}
+				if bn.Decoded || !bn.Partial.Complete() {
+					continue
+				}
+				bn.Decoded = true
+				var cipherText []byte
+				if cipherText, err = bn.Partial.Data(); err != nil {
+					Error(err)
+					continue
+				}
+				err = handlers[magic](ifc)(cipherText)
+				if err != nil {
+					Error(err)
+					continue
 				}
 			}
 			select {
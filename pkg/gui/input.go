@@ -132,6 +132,12 @@ func (w *Window) Input(txt, hint, borderColorFocused, borderColorUnfocused,
 	return p
 }
 
+// SetHint changes the text shown while the input is empty
+func (in *Input) SetHint(hint string) *Input {
+	in.input.Hint(hint)
+	return in
+}
+
 // Fn renders the input widget
 func (in *Input) Fn(gtx l.Context) l.Dimensions {
 	// gtx.Constraints.Max.X = int(in.TextSize.Scale(float32(in.size)).V)
@@ -184,6 +184,12 @@ func (p *Password) Fn(gtx l.Context) l.Dimensions {
 	}(gtx)
 }
 
+// SetHint changes the text shown while the password is empty
+func (p *Password) SetHint(hint string) *Password {
+	p.passInput.Hint(hint)
+	return p
+}
+
 func (p *Password) GetPassword() string {
 	return p.passInput.editor.Text()
 }
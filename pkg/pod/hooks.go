@@ -0,0 +1,219 @@
+package pod
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Restart is the hook of the settings that only take effect when pod is started again.
+const Restart = "restart"
+
+// Change is a setting that differs between two versions of the configuration, with the name of its field in Config,
+// its hook tag and its values in each version.
+type Change struct {
+	Field string
+	Hook  string
+	Old   interface{}
+	New   interface{}
+}
+
+// Diff returns the settings that differ between two configurations, in the order of the fields of Config.
+func Diff(old, new *Config) (changes []Change) {
+	ov, nv := reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	t := ov.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type.Kind() != reflect.Ptr {
+			continue
+		}
+		o, n := setting(ov.Field(i)), setting(nv.Field(i))
+		if equal(o, n) {
+			continue
+		}
+		changes = append(
+			changes, Change{Field: f.Name, Hook: f.Tag.Get("hook"), Old: o.Interface(), New: n.Interface()},
+		)
+	}
+	return
+}
+
+// setting returns the value a field of Config points to, or the zero value of the setting if the field is nil.
+func setting(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+	return v.Elem()
+}
+
+// equal reports whether two values of a setting are the same, counting empty and nil lists as the same.
+func equal(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Slice && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// ReadConfig reads a configuration file.
+func ReadConfig(path string) (c *Config, err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	c, _ = EmptyConfig()
+	if err = json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// clone returns a copy of a configuration that shares nothing with it.
+func clone(c *Config) *Config {
+	c.Lock()
+	defer c.Unlock()
+	out := &Config{}
+	cv, ov := reflect.ValueOf(c).Elem(), reflect.ValueOf(out).Elem()
+	for i := 0; i < cv.NumField(); i++ {
+		f := cv.Field(i)
+		if f.Kind() != reflect.Ptr || f.IsNil() {
+			continue
+		}
+		v := f.Elem()
+		if v.Kind() == reflect.Slice {
+			s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			reflect.Copy(s, v)
+			v = s
+		}
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		ov.Field(i).Set(p)
+	}
+	return out
+}
+
+// set changes the value of a setting, keeping the pointer of its field so everything holding it sees the new value.
+func (c *Config) set(field string, value interface{}) {
+	c.Lock()
+	defer c.Unlock()
+	f := reflect.ValueOf(c).Elem().FieldByName(field)
+	if f.IsNil() {
+		f.Set(reflect.New(f.Type().Elem()))
+	}
+	f.Elem().Set(reflect.ValueOf(value))
+}
+
+// Hooks applies the settings changed in the configuration file to the running configuration, and calls the functions
+// registered for the hook tags of the changed settings so the parts of pod using them can take them up.
+type Hooks struct {
+	mx       sync.Mutex
+	handlers map[string][]func(changes []Change)
+	// reload is held while the configuration is reloaded, so the changes are applied in the order they were made
+	reload  sync.Mutex
+	cfg     *Config
+	started *Config
+	loaded  *Config
+}
+
+// NewHooks returns Hooks without any functions registered. Load must be called before the configuration can be
+// reloaded.
+func NewHooks() *Hooks {
+	return &Hooks{handlers: make(map[string][]func(changes []Change))}
+}
+
+// Register adds a function to call with the changed settings that have a hook when the configuration is reloaded. It is
+// called once for each reload that changes any of them, after the settings without the Restart hook have been
+// applied.
+func (h *Hooks) Register(hook string, fn func(changes []Change)) {
+	h.mx.Lock()
+	h.handlers[hook] = append(h.handlers[hook], fn)
+	h.mx.Unlock()
+}
+
+// Load sets the running configuration the reloaded settings are applied to, and reads the configuration file it was
+// loaded from to find the settings changed in it later.
+func (h *Hooks) Load(cfg *Config) (err error) {
+	var loaded *Config
+	if loaded, err = ReadConfig(*cfg.ConfigFile); err != nil {
+		return
+	}
+	h.reload.Lock()
+	h.cfg, h.started, h.loaded = cfg, clone(cfg), loaded
+	h.reload.Unlock()
+	return
+}
+
+// Reload reads the configuration file and applies the settings changed in it since it was last read to the running
+// configuration, then calls the functions registered for their hooks. Settings with the Restart hook are left as they
+// are, as they only take effect when pod is started again, and are only passed on when they differ from those pod was
+// started with, as other processes using the same file write back the settings they were given on the command line.
+func (h *Hooks) Reload() (changes []Change, err error) {
+	h.reload.Lock()
+	defer h.reload.Unlock()
+	if h.cfg == nil {
+		return nil, errors.New("the configuration has not been loaded")
+	}
+	var next *Config
+	if next, err = ReadConfig(*h.cfg.ConfigFile); err != nil {
+		return
+	}
+	started := reflect.ValueOf(h.started).Elem()
+	for _, c := range Diff(h.loaded, next) {
+		switch {
+		case c.Field == "WalletPass":
+			// the file holds the hash of the password, which is never the password itself
+			continue
+		case c.Hook == Restart:
+			if equal(setting(started.FieldByName(c.Field)), reflect.ValueOf(c.New)) {
+				continue
+			}
+		default:
+			h.cfg.set(c.Field, c.New)
+		}
+		changes = append(changes, c)
+	}
+	h.loaded = next
+	h.dispatch(changes)
+	return
+}
+
+// dispatch calls the functions registered for each hook with the changes of its settings, leaving the Restart hook to
+// last.
+func (h *Hooks) dispatch(changes []Change) {
+	var hooks []string
+	byHook := make(map[string][]Change)
+	for _, c := range changes {
+		if _, ok := byHook[c.Hook]; !ok {
+			hooks = append(hooks, c.Hook)
+		}
+		byHook[c.Hook] = append(byHook[c.Hook], c)
+	}
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[j] == Restart && hooks[i] != Restart })
+	h.mx.Lock()
+	handlers := make(map[string][]func(changes []Change), len(h.handlers))
+	for hook, fns := range h.handlers {
+		handlers[hook] = fns
+	}
+	h.mx.Unlock()
+	for _, hook := range hooks {
+		fns := handlers[hook]
+		if len(fns) == 0 {
+			Debugf("no handler for the %q hook, changed %v", hook, fields(byHook[hook]))
+			continue
+		}
+		Debugf("calling the handlers of the %q hook for %v", hook, fields(byHook[hook]))
+		for _, fn := range fns {
+			fn(byHook[hook])
+		}
+	}
+}
+
+// fields returns the names of the fields of changes.
+func fields(changes []Change) (names []string) {
+	for _, c := range changes {
+		names = append(names, c.Field)
+	}
+	return
+}
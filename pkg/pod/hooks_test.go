@@ -0,0 +1,79 @@
+package pod
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli"
+)
+
+// TestReload ensures settings changed in the configuration file are applied and passed to the functions registered for
+// their hooks, and that settings needing a restart are left as they are.
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg, _ := EmptyConfig()
+	*cfg.ConfigFile = filepath.Join(dir, "pod.json")
+	*cfg.LogLevel = "info"
+	*cfg.AddPeers = cli.StringSlice{"127.0.0.1:11047"}
+	write := func(c *Config) {
+		b, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(*c.ConfigFile, b, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(cfg)
+	hooks := NewHooks()
+	if _, err = hooks.Reload(); err == nil {
+		t.Errorf("Reload: reloaded a configuration that was not loaded")
+	}
+	if err = hooks.Load(cfg); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	called := make(map[string][]Change)
+	for _, hook := range []string{"loglevel", "addpeer", Restart} {
+		hook := hook
+		hooks.Register(hook, func(changes []Change) { called[hook] = changes })
+	}
+	next, _ := EmptyConfig()
+	*next.ConfigFile = *cfg.ConfigFile
+	*next.LogLevel = "debug"
+	*next.AddPeers = cli.StringSlice{"127.0.0.1:11047", "127.0.0.2:11047"}
+	*next.UPNP = true
+	*next.WalletPass = "0123456789abcdef"
+	write(next)
+	changes, err := hooks.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := fields(changes); len(got) != 3 {
+		t.Errorf("Reload: got changes %v, want AddPeers, LogLevel and UPNP", got)
+	}
+	if *cfg.LogLevel != "debug" || len(*cfg.AddPeers) != 2 {
+		t.Errorf("Reload: did not apply the changed settings, got %q and %v", *cfg.LogLevel, *cfg.AddPeers)
+	}
+	if *cfg.UPNP || *cfg.WalletPass != "" {
+		t.Errorf("Reload: applied a setting that needs a restart or the hash of the wallet password")
+	}
+	for _, hook := range []string{"loglevel", "addpeer", Restart} {
+		if len(called[hook]) != 1 {
+			t.Errorf("hook %q: got changes %v, want one", hook, fields(called[hook]))
+		}
+	}
+	if old := called["addpeer"][0].Old.(cli.StringSlice); len(old) != 1 {
+		t.Errorf("addpeer hook: got old value %v", old)
+	}
+	called = make(map[string][]Change)
+	if changes, err = hooks.Reload(); err != nil || len(changes) != 0 || len(called) != 0 {
+		t.Errorf("Reload of an unchanged file: got changes %v, error %v", fields(changes), err)
+	}
+}
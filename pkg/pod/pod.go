@@ -77,7 +77,7 @@ func GetConfigSchema(cfg *Config) Schema {
 			Description: field.Tag.Get("description"),
 			Featured:    field.Tag.Get("featured"),
 			Group:       field.Tag.Get("group"),
-			Hooks:       field.Tag.Get("hooks"),
+			Hooks:       field.Tag.Get("hook"),
 			Label:       field.Tag.Get("label"),
 			Model:       field.Tag.Get("json"),
 			Options:     options,
@@ -132,6 +132,7 @@ type Config struct {
 	ConfigFile             *string          `group:"" label:"Configuration File" description:"location of configuration file, cannot actually be changed" type:"path" widget:"string" json:"ConfigFile" hook:"restart"`
 	ConnectPeers           *cli.StringSlice `group:"node" label:"Connect Peers" description:"connect ONLY to these addresses (disables inbound connections)" type:"address" widget:"multi" json:"ConnectPeers" hook:"restart"`
 	Controller             *string          `group:"node" label:"Controller Listener" description:"address to bind miner controller to" type:"address" widget:"string" json:"Controller" hook:"controller"`
+	ControllerPriority     *int             `group:"mining" label:"Controller Priority" description:"priority of the miner controller in the election between controllers on the LAN, workers prefer the highest" type:"" widget:"integer" json:"ControllerPriority" hook:"controller"`
 	CPUProfile             *string          `group:"debug" label:"CPU Profile" description:"write cpu profile to this file" type:"path" widget:"string" json:"CPUProfile" hook:"restart"`
 	DataDir                *string          `group:"" label:"Data Directory" description:"root folder where application data is stored" type:"path" widget:"string" json:"DataDir" hook:"restart"`
 	DbType                 *string          `group:"" label:"Database Type" description:"type of database storage engine to use (only one right now)" type:"" widget:"string" json:"DbType" hook:"restart"`
@@ -141,6 +142,8 @@ type Config struct {
 	DisableListen          *bool            `group:"node" label:"Disable Listen" description:"disables inbound connections for the peer to peer network" type:"" widget:"toggle" json:"DisableListen" hook:"restart"`
 	DisableRPC             *bool            `group:"rpc" label:"Disable RPC" description:"disable rpc servers, as well as kopach controller" type:"" widget:"toggle" json:"DisableRPC" hook:"restart"`
 	ExternalIPs            *cli.StringSlice `group:"node" label:"External IP Addresses" description:"extra addresses to tell peers they can connect to" type:"address" widget:"multi" json:"ExternalIPs" hook:"restart"`
+	ExternalOnion          *string          `group:"proxy" label:"External Onion" description:"onion service address of this node (v2 or v3 .onion, with optional port) to tell peers they can connect to" type:"address" widget:"string" json:"ExternalOnion" hook:"restart"`
+	ForkSchedule           *string          `group:"debug" label:"Fork Schedule" description:"file with the hard fork schedule, disbursement and blacklist to use instead of the compiled in ones, not allowed on mainnet" type:"path" widget:"string" json:"ForkSchedule" hook:"restart"`
 	FreeTxRelayLimit       *float64         `group:"policy" label:"Free Tx Relay Limit" description:"limit relay of transactions with no transaction fee to the given amount in thousands of bytes per minute" type:"" widget:"float" json:"FreeTxRelayLimit" hook:"restart"`
 	Generate               *bool            `group:"mining" label:"Generate Blocks" description:"turn on Kopach CPU miner" type:"" widget:"toggle" json:"Generate" hook:"generate"`
 	GenThreads             *int             `group:"mining" label:"Gen Threads" description:"number of threads to mine with" type:"" widget:"integer" json:"GenThreads" hook:"genthreads"`
@@ -173,6 +176,7 @@ type Config struct {
 	ProxyPass              *string          `group:"proxy" label:"Proxy Pass" description:"proxy password, if required" type:"" widget:"password" json:"ProxyPass" hook:"restart"`
 	ProxyUser              *string          `group:"proxy" label:"ProxyUser" description:"proxy username, if required" type:"" widget:"string" json:"ProxyUser" hook:"restart"`
 	RejectNonStd           *bool            `group:"node" label:"Reject Non Std" description:"reject non-standard transactions regardless of the default settings for the active network" type:"" widget:"toggle" json:"RejectNonStd" hook:"restart"`
+	RejectReplacement      *bool            `group:"policy" label:"Reject Replacement" description:"reject transactions that replace transactions in the mempool, even those signalling replacement by fee" type:"" widget:"toggle" json:"RejectReplacement" hook:"restart"`
 	RelayNonStd            *bool            `group:"node" label:"Relay Non Std" description:"relay non-standard transactions regardless of the default settings for the active network" type:"" widget:"toggle" json:"RelayNonStd" hook:"restart"`
 	RPCCert                *string          `group:"rpc" label:"RPC Cert" description:"location of RPC TLS certificate" type:"path" widget:"string" json:"RPCCert" hook:"restart"`
 	RPCConnect             *string          `group:"wallet" label:"RPC Connect" description:"full node RPC for wallet" type:"address" widget:"string" json:"RPCConnect" hook:"restart"`
@@ -187,6 +191,9 @@ type Config struct {
 	ServerUser             *string          `group:"rpc" label:"Server User" description:"username for chain server connections" type:"" widget:"string" json:"ServerUser" hook:"restart"`
 	SigCacheMaxSize        *int             `group:"node" label:"Sig Cache Max Size" description:"the maximum number of entries in the signature verification cache" type:"" widget:"integer" json:"SigCacheMaxSize" hook:"restart"`
 	Solo                   *bool            `group:"mining" label:"Solo Generate" description:"mine even if not connected to a network" type:"" widget:"toggle" json:"Solo" hook:"restart"`
+	SpendIndex             *bool            `group:"node" label:"Spend Index" description:"maintain an index of the transaction spending each output which makes the gettxspendingprevout RPC available" type:"" widget:"toggle" json:"SpendIndex" hook:"dropspendindex"`
+	StratumListener        *string          `group:"mining" label:"Stratum Listener" description:"address to listen on for stratum connections from external mining software, disabled when empty" type:"address" widget:"string" json:"StratumListener" hook:"restart"`
+	StratumUsers           *cli.StringSlice `group:"mining" label:"Stratum Users" description:"user:password pairs that miners authorize with on the stratum listener, which must differ from the RPC credentials" type:"" widget:"multi" json:"StratumUsers" hook:"restart"`
 	TLS                    *bool            `group:"tls" label:"TLS" description:"enable TLS for RPC connections" type:"" widget:"toggle" json:"TLS" hook:"restart"`
 	TLSSkipVerify          *bool            `group:"tls" label:"TLS Skip Verify" description:"skip TLS certificate verification (ignore CA errors)" type:"" widget:"toggle" json:"TLSSkipVerify" hook:"restart"`
 	TorIsolation           *bool            `group:"proxy" label:"Tor Isolation" description:"makes a separate proxy connection for each connection" type:"" widget:"toggle" json:"TorIsolation" hook:"restart"`
@@ -195,6 +202,7 @@ type Config struct {
 	UPNP                   *bool            `group:"node" label:"UPNP" description:"enable UPNP for NAT traversal" type:"" widget:"toggle" json:"UPNP" hook:"restart"`
 	UserAgentComments      *cli.StringSlice `group:"" label:"User Agent Comments" description:"comment to add to the user agent -- See BIP 14 for more information" type:"" widget:"multi" json:"UserAgentComments" hook:"restart"`
 	Username               *string          `group:"rpc" label:"Username" description:"password for client RPC connections" type:"" widget:"string" json:"Username" hook:"restart"`
+	V2Transport            *bool            `group:"node" label:"V2 Transport" description:"encrypt peer connections with the v2 transport (BIP 324) where the peer supports it, and accept it from inbound peers" type:"" widget:"toggle" json:"V2Transport" hook:"restart"`
 	Wallet                 *bool            `group:"debug" label:"Connect to Wallet" description:"set ctl to connect to wallet instead of chain server" type:"" widget:"toggle" json:"Wallet"`
 	WalletFile             *string          `group:"config" label:"Wallet File" description:"wallet database file" type:"path" widget:"string" featured:"true" json:"WalletFile" hook:"restart"`
 	WalletOff              *bool            `group:"debug" label:"Wallet Off" description:"turn off the wallet backend" type:"" widget:"toggle" json:"WalletOff" hook:"wallet"`
@@ -204,8 +212,9 @@ type Config struct {
 	WalletRPCMaxWebsockets *int             `group:"wallet" label:"Legacy RPC Max Websockets" description:"maximum number of websocket clients allowed for wallet RPC" type:"" widget:"integer" json:"WalletRPCMaxWebsockets" hook:"restart"`
 	WalletServer           *string          `group:"wallet" label:"Wallet Server" description:"node address to connect wallet server to" type:"address" widget:"string" json:"WalletServer" hook:"restart"`
 	Whitelists             *cli.StringSlice `group:"debug" label:"Whitelists" description:"peers that you don't want to ever ban" type:"address" widget:"multi" json:"Whitelists" hook:"restart"`
+	WorkerName             *string          `group:"mining" label:"Worker Name" description:"name the shares of this miner are recorded under by the mining controller, a random name kept in the data directory is used when empty" type:"" widget:"string" json:"WorkerName" hook:"restart"`
 	LAN                    *bool            `group:"debug" label:"LAN" description:"run without any connection to nodes on the internet (does not apply on mainnet)" type:"" widget:"toggle" json:"LAN" hook:"restart"`
-	DarkTheme              *bool            `group:"config" label:"Dark Theme" description:"sets dark theme for GUI" type:"" widget:"toggle" json:"DarkTheme" hook:"restart"`
+	DarkTheme              *bool            `group:"config" label:"Dark Theme" description:"sets dark theme for GUI" type:"" widget:"toggle" json:"DarkTheme" hook:"theme"`
 	RunAsService           *bool            `group:"" label:"Run As Service" description:"shuts down on lock timeout" type:"" widget:"toggle" json:"" hook:"restart"`
 	CAPI                   *bool            `group:"" label:"Enable cAPI" description:"disable cAPI rpc" type:"" widget:"toggle" json:"CAPI" hook:"restart"`
 }
@@ -230,6 +239,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		ConfigFile:             newstring(),
 		ConnectPeers:           newStringSlice(),
 		Controller:             newstring(),
+		ControllerPriority:     newint(),
 		CPUProfile:             newstring(),
 		DarkTheme:              newbool(),
 		DataDir:                &datadir,
@@ -240,6 +250,8 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		DisableListen:          newbool(),
 		DisableRPC:             newbool(),
 		ExternalIPs:            newStringSlice(),
+		ExternalOnion:          newstring(),
+		ForkSchedule:           newstring(),
 		FreeTxRelayLimit:       newfloat64(),
 		Generate:               newbool(),
 		GenThreads:             newint(),
@@ -273,6 +285,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		ProxyPass:              newstring(),
 		ProxyUser:              newstring(),
 		RejectNonStd:           newbool(),
+		RejectReplacement:      newbool(),
 		RelayNonStd:            newbool(),
 		RPCCert:                newstring(),
 		RPCConnect:             newstring(),
@@ -288,6 +301,9 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		ServerUser:             newstring(),
 		SigCacheMaxSize:        newint(),
 		Solo:                   newbool(),
+		SpendIndex:             newbool(),
+		StratumListener:        newstring(),
+		StratumUsers:           newStringSlice(),
 		TLS:                    newbool(),
 		TLSSkipVerify:          newbool(),
 		TorIsolation:           newbool(),
@@ -296,6 +312,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		UPNP:                   newbool(),
 		UserAgentComments:      newStringSlice(),
 		Username:               newstring(),
+		V2Transport:            newbool(),
 		Wallet:                 newbool(),
 		WalletFile:             newstring(),
 		WalletOff:              newbool(),
@@ -305,6 +322,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		WalletRPCMaxWebsockets: newint(),
 		WalletServer:           newstring(),
 		Whitelists:             newStringSlice(),
+		WorkerName:             newstring(),
 	}
 	conf = map[string]interface{}{
 		"AddCheckpoints":         c.AddCheckpoints,
@@ -324,6 +342,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"ConfigFile":             c.ConfigFile,
 		"ConnectPeers":           c.ConnectPeers,
 		"Controller":             c.Controller,
+		"ControllerPriority":     c.ControllerPriority,
 		"CPUProfile":             c.CPUProfile,
 		"DarkTheme":              c.DarkTheme,
 		"DataDir":                c.DataDir,
@@ -334,6 +353,8 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"DisableListen":          c.DisableListen,
 		"DisableRPC":             c.DisableRPC,
 		"ExternalIPs":            c.ExternalIPs,
+		"ExternalOnion":          c.ExternalOnion,
+		"ForkSchedule":           c.ForkSchedule,
 		"FreeTxRelayLimit":       c.FreeTxRelayLimit,
 		"Generate":               c.Generate,
 		"GenThreads":             c.GenThreads,
@@ -367,6 +388,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"ProxyPass":              c.ProxyPass,
 		"ProxyUser":              c.ProxyUser,
 		"RejectNonStd":           c.RejectNonStd,
+		"RejectReplacement":      c.RejectReplacement,
 		"RelayNonStd":            c.RelayNonStd,
 		"RPCCert":                c.RPCCert,
 		"RPCConnect":             c.RPCConnect,
@@ -382,6 +404,9 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"ServerUser":             c.ServerUser,
 		"SigCacheMaxSize":        c.SigCacheMaxSize,
 		"Solo":                   c.Solo,
+		"SpendIndex":             c.SpendIndex,
+		"StratumListener":        c.StratumListener,
+		"StratumUsers":           c.StratumUsers,
 		"TLS":                    c.TLS,
 		"TLSSkipVerify":          c.TLSSkipVerify,
 		"TorIsolation":           c.TorIsolation,
@@ -390,6 +415,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"UPNP":                   c.UPNP,
 		"UserAgentComments":      c.UserAgentComments,
 		"Username":               c.Username,
+		"V2Transport":            c.V2Transport,
 		"Wallet":                 c.Wallet,
 		"WalletFile":             c.WalletFile,
 		"WalletOff":              c.WalletOff,
@@ -399,6 +425,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"WalletRPCMaxWebsockets": c.WalletRPCMaxWebsockets,
 		"WalletServer":           c.WalletServer,
 		"Whitelists":             c.Whitelists,
+		"WorkerName":             c.WorkerName,
 	}
 	return
 }
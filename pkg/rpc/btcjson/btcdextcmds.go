@@ -71,6 +71,15 @@ func NewGetCurrentNetCmd() *GetCurrentNetCmd {
 	return &GetCurrentNetCmd{}
 }
 
+// GetForkInfoCmd defines the getforkinfo JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type GetForkInfoCmd struct{}
+
+// NewGetForkInfoCmd returns a new instance which can be used to issue a getforkinfo JSON-RPC command.
+func NewGetForkInfoCmd() *GetForkInfoCmd {
+	return &GetForkInfoCmd{}
+}
+
 // GetHeadersCmd defines the getheaders JSON-RPC command. NOTE: This is a btcsuite extension ported from github.com/decred/dcrd/dcrjson.
 type GetHeadersCmd struct {
 	BlockLocators []string `json:"blocklocators"`
@@ -86,6 +95,23 @@ func NewGetHeadersCmd(blockLocators []string, hashStop string) *GetHeadersCmd {
 	}
 }
 
+// GetWorkerSharesCmd defines the getworkershares JSON-RPC command. This command is not a standard Bitcoin command. It
+// is an extension for pod.
+type GetWorkerSharesCmd struct {
+	Window *int     `jsonrpcdefault:"0"`
+	Reward *float64 `jsonrpcdefault:"0"`
+}
+
+// NewGetWorkerSharesCmd returns a new instance which can be used to issue a getworkershares JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewGetWorkerSharesCmd(window *int, reward *float64) *GetWorkerSharesCmd {
+	return &GetWorkerSharesCmd{
+		Window: window,
+		Reward: reward,
+	}
+}
+
 // VersionCmd defines the version JSON-RPC command. NOTE: This is a btcsuite extension ported from github.com/decred/dcrd/dcrjson.
 type VersionCmd struct{}
 
@@ -102,6 +128,8 @@ func init() {
 	MustRegisterCmd("generate", (*GenerateCmd)(nil), flags)
 	MustRegisterCmd("getbestblock", (*GetBestBlockCmd)(nil), flags)
 	MustRegisterCmd("getcurrentnet", (*GetCurrentNetCmd)(nil), flags)
+	MustRegisterCmd("getforkinfo", (*GetForkInfoCmd)(nil), flags)
 	MustRegisterCmd("getheaders", (*GetHeadersCmd)(nil), flags)
+	MustRegisterCmd("getworkershares", (*GetWorkerSharesCmd)(nil), flags)
 	MustRegisterCmd("version", (*VersionCmd)(nil), flags)
 }
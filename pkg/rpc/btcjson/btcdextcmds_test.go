@@ -173,6 +173,45 @@ func TestPodExtCmds(t *testing.T) {
 				HashStop: "000000000000000000ba33b33e1fad70b69e234fc24414dd47113bff38f523f7",
 			},
 		},
+		{
+			name: "getforkinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getforkinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetForkInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getforkinfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetForkInfoCmd{},
+		},
+		{
+			name: "getworkershares",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getworkershares")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetWorkerSharesCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getworkershares","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetWorkerSharesCmd{
+				Window: btcjson.Int(0),
+				Reward: btcjson.Float64(0),
+			},
+		},
+		{
+			name: "getworkershares optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getworkershares", 100, 2.5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetWorkerSharesCmd(btcjson.Int(100), btcjson.Float64(2.5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getworkershares","netparams":[100,2.5],"id":1}`,
+			unmarshalled: &btcjson.GetWorkerSharesCmd{
+				Window: btcjson.Int(100),
+				Reward: btcjson.Float64(2.5),
+			},
+		},
 		{
 			name: "version",
 			newCmd: func() (interface{}, error) {
@@ -9,3 +9,71 @@ type VersionResult struct {
 	Prerelease    string `json:"prerelease"`
 	BuildMetadata string `json:"buildmetadata"`
 }
+
+// GetWorkerSharesResult models the data returned from the getworkershares command. NOTE: This is a pod extension.
+type GetWorkerSharesResult struct {
+	Shares  int64                `json:"shares"`
+	Workers []WorkerSharesResult `json:"workers"`
+	Payouts []SharePayoutResult  `json:"payouts,omitempty"`
+}
+
+// WorkerSharesResult models the totals of the shares of one worker in the getworkershares result.
+type WorkerSharesResult struct {
+	Worker     string           `json:"worker"`
+	Shares     int64            `json:"shares"`
+	Difficulty float64          `json:"difficulty"`
+	Weight     float64          `json:"weight"`
+	Algos      map[string]int64 `json:"algos"`
+	Blocks     []string         `json:"blocks"`
+	LastShare  int64            `json:"lastshare"`
+}
+
+// SharePayoutResult models the part of a reward a worker gets in the PPLNS split of the getworkershares result.
+type SharePayoutResult struct {
+	Worker   string  `json:"worker"`
+	Shares   int64   `json:"shares"`
+	Weight   float64 `json:"weight"`
+	Fraction float64 `json:"fraction"`
+	Amount   float64 `json:"amount"`
+}
+
+// GetForkInfoResult models the data returned from the getforkinfo command. NOTE: This is a pod extension.
+type GetForkInfoResult struct {
+	Network     string            `json:"network"`
+	Version     int               `json:"version"`
+	Checksum    string            `json:"checksum"`
+	File        string            `json:"file,omitempty"`
+	Height      int32             `json:"height"`
+	CurrentFork uint32            `json:"currentfork"`
+	Forks       []ForkInfoResult  `json:"forks"`
+	Payees      []ForkPayeeResult `json:"payees"`
+	CorePubkeys []string          `json:"corepubkeys"`
+	CoreAmount  float64           `json:"coreamount"`
+	Blacklist   []string          `json:"blacklist"`
+}
+
+// ForkInfoResult models a hard fork in the getforkinfo result.
+type ForkInfoResult struct {
+	Number             uint32           `json:"number"`
+	Name               string           `json:"name"`
+	ActivationHeight   int32            `json:"activationheight"`
+	TargetTimePerBlock int32            `json:"targettimeperblock"`
+	AveragingInterval  int32            `json:"averaginginterval"`
+	Active             bool             `json:"active"`
+	Algos              []ForkAlgoResult `json:"algos"`
+}
+
+// ForkAlgoResult models an algorithm of a hard fork in the getforkinfo result.
+type ForkAlgoResult struct {
+	Name            string `json:"name"`
+	Version         int32  `json:"version"`
+	MinBits         string `json:"minbits"`
+	AlgoID          uint32 `json:"algoid"`
+	VersionInterval int    `json:"versioninterval"`
+}
+
+// ForkPayeeResult models a payee of the hard fork disbursement in the getforkinfo result.
+type ForkPayeeResult struct {
+	Address string  `json:"address"`
+	Amount  float64 `json:"amount"`
+}
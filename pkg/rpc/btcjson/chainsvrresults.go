@@ -152,9 +152,19 @@ type GetBlockVerboseResult struct {
 	NextHash      string        `json:"nextblockhash,omitempty"`
 }
 
+// GetChainTipsResult models a single chain tip returned from the getchaintips command.
+type GetChainTipsResult struct {
+	Height    int32  `json:"height"`
+	Hash      string `json:"hash"`
+	BranchLen int32  `json:"branchlen"`
+	PowAlgo   string `json:"pow_algo"`
+	Status    string `json:"status"`
+}
+
 // GetMempoolEntryResult models the data returned from the getmempoolentry command.
 type GetMempoolEntryResult struct {
 	Size             int32    `json:"size"`
+	VSize            int32    `json:"vsize"`
 	Fee              float64  `json:"fee"`
 	ModifiedFee      float64  `json:"modifiedfee"`
 	Time             int64    `json:"time"`
@@ -168,6 +178,13 @@ type GetMempoolEntryResult struct {
 	AncestorSize     int64    `json:"ancestorsize"`
 	AncestorFees     float64  `json:"ancestorfees"`
 	Depends          []string `json:"depends"`
+	SpentBy          []string `json:"spentby"`
+}
+
+// GetIndexInfoResult models the objects included in the getindexinfo response, keyed by the name of the index.
+type GetIndexInfoResult struct {
+	Synced          bool  `json:"synced"`
+	BestBlockHeight int32 `json:"best_block_height"`
 }
 
 // GetMempoolInfoResult models the data returned from the getmempoolinfo command.
@@ -239,6 +256,8 @@ type GetNetworkInfoResult struct {
 	LocalRelay      bool                   `json:"localrelay"`
 	TimeOffset      int64                  `json:"timeoffset"`
 	Connections     int32                  `json:"connections"`
+	ConnectionsIn   int32                  `json:"connections_in"`
+	ConnectionsOut  int32                  `json:"connections_out"`
 	NetworkActive   bool                   `json:"networkactive"`
 	Networks        []NetworksResult       `json:"networks"`
 	RelayFee        float64                `json:"relayfee"`
@@ -270,6 +289,8 @@ type GetPeerInfoResult struct {
 	BanScore       int32   `json:"banscore"`
 	FeeFilter      int64   `json:"feefilter"`
 	SyncNode       bool    `json:"syncnode"`
+	Transport      string  `json:"transport_protocol_type"`
+	SessionID      string  `json:"session_id"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool command when the verbose flag is set. When
@@ -294,6 +315,16 @@ type GetTxOutResult struct {
 	Coinbase      bool               `json:"coinbase"`
 }
 
+// GetTxSpendingPrevOutResult models the data from the gettxspendingprevout command. The spending fields are omitted
+// when the output has not been spent.
+type GetTxSpendingPrevOutResult struct {
+	Txid         string  `json:"txid"`
+	Vout         uint32  `json:"vout"`
+	SpendingTxid string  `json:"spendingtxid,omitempty"`
+	SpendingVin  *uint32 `json:"spendingvin,omitempty"`
+	BlockHeight  int32   `json:"blockheight,omitempty"`
+}
+
 // GetWorkResult models the data from the getwork command.
 type GetWorkResult struct {
 	Data     string `json:"data"`
@@ -301,6 +332,20 @@ type GetWorkResult struct {
 	Midstate string `json:"midstate"`
 	Target   string `json:"target"`
 }
+
+// ListBannedResult models the data from the listbanned command.
+type ListBannedResult struct {
+	Address     string `json:"address"`
+	BanCreated  int64  `json:"bancreated"`
+	BannedUntil int64  `json:"banneduntil"`
+	BanReason   string `json:"banreason"`
+}
+
+// ReloadConfigResult models the data from the reloadconfig command.
+type ReloadConfigResult struct {
+	Changed []string `json:"changed"`
+	Restart bool     `json:"restart"`
+}
 type (
 	// InfoChainResult models the data returned by the chain server getinfo command.
 	InfoChainResult struct {
@@ -30,6 +30,50 @@ func NewAddWitnessAddressCmd(address string) *AddWitnessAddressCmd {
 	}
 }
 
+// BackupWalletCmd defines the backupwallet JSON-RPC command.
+type BackupWalletCmd struct {
+	Destination string
+}
+
+// NewBackupWalletCmd returns a new instance which can be used to issue a backupwallet JSON-RPC command.
+func NewBackupWalletCmd(destination string) *BackupWalletCmd {
+	return &BackupWalletCmd{
+		Destination: destination,
+	}
+}
+
+// BumpFeeOpts represents the options of the bumpfee command.
+type BumpFeeOpts struct {
+	FeeRate *float64 `json:"feeRate,omitempty"` // In DUO/kB
+}
+
+// BumpFeeCmd defines the bumpfee JSON-RPC command.
+type BumpFeeCmd struct {
+	TxID    string
+	Options *BumpFeeOpts
+}
+
+// NewBumpFeeCmd returns a new instance which can be used to issue a bumpfee JSON-RPC command. The parameters which are
+// pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewBumpFeeCmd(txID string, options *BumpFeeOpts) *BumpFeeCmd {
+	return &BumpFeeCmd{
+		TxID:    txID,
+		Options: options,
+	}
+}
+
+// CombinePsbtCmd defines the combinepsbt JSON-RPC command.
+type CombinePsbtCmd struct {
+	Psbts []string
+}
+
+// NewCombinePsbtCmd returns a new instance which can be used to issue a combinepsbt JSON-RPC command.
+func NewCombinePsbtCmd(psbts []string) *CombinePsbtCmd {
+	return &CombinePsbtCmd{
+		Psbts: psbts,
+	}
+}
+
 // CreateMultisigCmd defines the createmultisig JSON-RPC command.
 type CreateMultisigCmd struct {
 	NRequired int
@@ -44,6 +88,18 @@ func NewCreateMultisigCmd(nRequired int, keys []string) *CreateMultisigCmd {
 	}
 }
 
+// DecodePsbtCmd defines the decodepsbt JSON-RPC command.
+type DecodePsbtCmd struct {
+	Psbt string
+}
+
+// NewDecodePsbtCmd returns a new instance which can be used to issue a decodepsbt JSON-RPC command.
+func NewDecodePsbtCmd(psbt string) *DecodePsbtCmd {
+	return &DecodePsbtCmd{
+		Psbt: psbt,
+	}
+}
+
 // DropWalletHistoryCmd defines the restart JSON-RPC command.
 type DropWalletHistoryCmd struct{}
 
@@ -100,6 +156,21 @@ func NewEstimatePriorityCmd(numBlocks int64) *EstimatePriorityCmd {
 	}
 }
 
+// FinalizePsbtCmd defines the finalizepsbt JSON-RPC command.
+type FinalizePsbtCmd struct {
+	Psbt    string
+	Extract *bool `jsonrpcdefault:"true"`
+}
+
+// NewFinalizePsbtCmd returns a new instance which can be used to issue a finalizepsbt JSON-RPC command. The parameters
+// which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewFinalizePsbtCmd(psbt string, extract *bool) *FinalizePsbtCmd {
+	return &FinalizePsbtCmd{
+		Psbt:    psbt,
+		Extract: extract,
+	}
+}
+
 // GetAccountCmd defines the getaccount JSON-RPC command.
 type GetAccountCmd struct {
 	Address string
@@ -447,22 +518,32 @@ func NewSendFromCmd(fromAccount, toAddress string, amount float64, minConf *int,
 	}
 }
 
+// CoinControlOpts represents the options of the sendmany and sendtoaddress commands that choose the outputs of the
+// wallet the transaction spends.
+type CoinControlOpts struct {
+	Inputs   []TransactionInput `json:"inputs,omitempty"`
+	Strategy *string            `json:"strategy,omitempty"`
+}
+
 // SendManyCmd defines the sendmany JSON-RPC command.
 type SendManyCmd struct {
 	FromAccount string
 	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
 	MinConf     *int               `jsonrpcdefault:"1"`
 	Comment     *string
+	Options     *CoinControlOpts
 }
 
 // NewSendManyCmd returns a new instance which can be used to issue a sendmany JSON-RPC command. The parameters which
 // are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
-func NewSendManyCmd(fromAccount string, amounts map[string]float64, minConf *int, comment *string) *SendManyCmd {
+func NewSendManyCmd(fromAccount string, amounts map[string]float64, minConf *int, comment *string,
+	options *CoinControlOpts) *SendManyCmd {
 	return &SendManyCmd{
 		FromAccount: fromAccount,
 		Amounts:     amounts,
 		MinConf:     minConf,
 		Comment:     comment,
+		Options:     options,
 	}
 }
 
@@ -472,17 +553,20 @@ type SendToAddressCmd struct {
 	Amount    float64
 	Comment   *string
 	CommentTo *string
+	Options   *CoinControlOpts
 }
 
 // NewSendToAddressCmd returns a new instance which can be used to issue a sendtoaddress JSON-RPC command. The
 // parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
 // value.
-func NewSendToAddressCmd(address string, amount float64, comment, commentTo *string) *SendToAddressCmd {
+func NewSendToAddressCmd(address string, amount float64, comment, commentTo *string,
+	options *CoinControlOpts) *SendToAddressCmd {
 	return &SendToAddressCmd{
 		Address:   address,
 		Amount:    amount,
 		Comment:   comment,
 		CommentTo: commentTo,
+		Options:   options,
 	}
 }
 
@@ -500,6 +584,20 @@ func NewSetAccountCmd(address, account string) *SetAccountCmd {
 	}
 }
 
+// SetLabelCmd defines the setlabel JSON-RPC command.
+type SetLabelCmd struct {
+	Address string
+	Label   string
+}
+
+// NewSetLabelCmd returns a new instance which can be used to issue a setlabel JSON-RPC command.
+func NewSetLabelCmd(address, label string) *SetLabelCmd {
+	return &SetLabelCmd{
+		Address: address,
+		Label:   label,
+	}
+}
+
 // SetTxFeeCmd defines the settxfee JSON-RPC command.
 type SetTxFeeCmd struct {
 	Amount float64 // In DUO
@@ -512,6 +610,20 @@ func NewSetTxFeeCmd(amount float64) *SetTxFeeCmd {
 	}
 }
 
+// SetTxLabelCmd defines the settxlabel JSON-RPC command.
+type SetTxLabelCmd struct {
+	TxID  string
+	Label string
+}
+
+// NewSetTxLabelCmd returns a new instance which can be used to issue a settxlabel JSON-RPC command.
+func NewSetTxLabelCmd(txID, label string) *SetTxLabelCmd {
+	return &SetTxLabelCmd{
+		TxID:  txID,
+		Label: label,
+	}
+}
+
 // SignMessageCmd defines the signmessage JSON-RPC command.
 type SignMessageCmd struct {
 	Address string
@@ -554,6 +666,36 @@ func NewSignRawTransactionCmd(hexEncodedTx string, inputs *[]RawTxInput, privKey
 	}
 }
 
+// WalletCreateFundedPsbtOpts represents the options of the walletcreatefundedpsbt command.
+type WalletCreateFundedPsbtOpts struct {
+	Account       *string  `json:"account,omitempty"`
+	ChangeAddress *string  `json:"changeAddress,omitempty"`
+	FeeRate       *float64 `json:"feeRate,omitempty"` // In DUO/kB
+	LockUnspents  *bool    `json:"lockUnspents,omitempty"`
+	MinConf       *int     `json:"minconf,omitempty"`
+}
+
+// WalletCreateFundedPsbtCmd defines the walletcreatefundedpsbt JSON-RPC command.
+type WalletCreateFundedPsbtCmd struct {
+	Inputs   []TransactionInput
+	Outputs  map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	LockTime *uint32
+	Options  *WalletCreateFundedPsbtOpts
+}
+
+// NewWalletCreateFundedPsbtCmd returns a new instance which can be used to issue a walletcreatefundedpsbt JSON-RPC
+// command. The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use
+// the default value.
+func NewWalletCreateFundedPsbtCmd(inputs []TransactionInput, outputs map[string]float64, lockTime *uint32,
+	options *WalletCreateFundedPsbtOpts) *WalletCreateFundedPsbtCmd {
+	return &WalletCreateFundedPsbtCmd{
+		Inputs:   inputs,
+		Outputs:  outputs,
+		LockTime: lockTime,
+		Options:  options,
+	}
+}
+
 // WalletLockCmd defines the walletlock JSON-RPC command.
 type WalletLockCmd struct{}
 
@@ -590,17 +732,40 @@ func NewWalletPassphraseChangeCmd(oldPassphrase, newPassphrase string) *WalletPa
 		NewPassphrase: newPassphrase,
 	}
 }
+
+// WalletProcessPsbtCmd defines the walletprocesspsbt JSON-RPC command.
+type WalletProcessPsbtCmd struct {
+	Psbt        string
+	Sign        *bool   `jsonrpcdefault:"true"`
+	SighashType *string `jsonrpcdefault:"\"ALL\""`
+}
+
+// NewWalletProcessPsbtCmd returns a new instance which can be used to issue a walletprocesspsbt JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewWalletProcessPsbtCmd(psbt string, sign *bool, sighashType *string) *WalletProcessPsbtCmd {
+	return &WalletProcessPsbtCmd{
+		Psbt:        psbt,
+		Sign:        sign,
+		SighashType: sighashType,
+	}
+}
 func init() {
 	// The commands in this file are only usable with a wallet server.
 	flags := UFWalletOnly
 	MustRegisterCmd("addmultisigaddress", (*AddMultisigAddressCmd)(nil), flags)
 	MustRegisterCmd("addwitnessaddress", (*AddWitnessAddressCmd)(nil), flags)
+	MustRegisterCmd("backupwallet", (*BackupWalletCmd)(nil), flags)
+	MustRegisterCmd("bumpfee", (*BumpFeeCmd)(nil), flags)
+	MustRegisterCmd("combinepsbt", (*CombinePsbtCmd)(nil), flags)
 	MustRegisterCmd("createmultisig", (*CreateMultisigCmd)(nil), flags)
+	MustRegisterCmd("decodepsbt", (*DecodePsbtCmd)(nil), flags)
 	MustRegisterCmd("dropwallethistory", (*DropWalletHistoryCmd)(nil), flags)
 	MustRegisterCmd("dumpprivkey", (*DumpPrivKeyCmd)(nil), flags)
 	MustRegisterCmd("encryptwallet", (*EncryptWalletCmd)(nil), flags)
 	MustRegisterCmd("estimatefee", (*EstimateFeeCmd)(nil), flags)
 	MustRegisterCmd("estimatepriority", (*EstimatePriorityCmd)(nil), flags)
+	MustRegisterCmd("finalizepsbt", (*FinalizePsbtCmd)(nil), flags)
 	MustRegisterCmd("getaccount", (*GetAccountCmd)(nil), flags)
 	MustRegisterCmd("getaccountaddress", (*GetAccountAddressCmd)(nil), flags)
 	MustRegisterCmd("getaddressesbyaccount", (*GetAddressesByAccountCmd)(nil), flags)
@@ -627,10 +792,14 @@ func init() {
 	MustRegisterCmd("sendmany", (*SendManyCmd)(nil), flags)
 	MustRegisterCmd("sendtoaddress", (*SendToAddressCmd)(nil), flags)
 	MustRegisterCmd("setaccount", (*SetAccountCmd)(nil), flags)
+	MustRegisterCmd("setlabel", (*SetLabelCmd)(nil), flags)
 	MustRegisterCmd("settxfee", (*SetTxFeeCmd)(nil), flags)
+	MustRegisterCmd("settxlabel", (*SetTxLabelCmd)(nil), flags)
 	MustRegisterCmd("signmessage", (*SignMessageCmd)(nil), flags)
 	MustRegisterCmd("signrawtransaction", (*SignRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("walletcreatefundedpsbt", (*WalletCreateFundedPsbtCmd)(nil), flags)
 	MustRegisterCmd("walletlock", (*WalletLockCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrase", (*WalletPassphraseCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrasechange", (*WalletPassphraseChangeCmd)(nil), flags)
+	MustRegisterCmd("walletprocesspsbt", (*WalletProcessPsbtCmd)(nil), flags)
 }
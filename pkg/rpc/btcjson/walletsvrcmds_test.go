@@ -68,6 +68,59 @@ func TestWalletSvrCmds(t *testing.T) {
 				Address: "1address",
 			},
 		},
+		{
+			name: "backupwallet",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("backupwallet", "/backups/wallet.db")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBackupWalletCmd("/backups/wallet.db")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"backupwallet","netparams":["/backups/wallet.db"],"id":1}`,
+			unmarshalled: &btcjson.BackupWalletCmd{
+				Destination: "/backups/wallet.db",
+			},
+		},
+		{
+			name: "bumpfee",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bumpfee", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBumpFeeCmd("123", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","netparams":["123"],"id":1}`,
+			unmarshalled: &btcjson.BumpFeeCmd{
+				TxID: "123",
+			},
+		},
+		{
+			name: "bumpfee optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bumpfee", "123", `{"feeRate":0.0002}`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBumpFeeCmd("123", &btcjson.BumpFeeOpts{FeeRate: btcjson.Float64(0.0002)})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","netparams":["123",{"feeRate":0.0002}],"id":1}`,
+			unmarshalled: &btcjson.BumpFeeCmd{
+				TxID:    "123",
+				Options: &btcjson.BumpFeeOpts{FeeRate: btcjson.Float64(0.0002)},
+			},
+		},
+		{
+			name: "combinepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("combinepsbt", []string{"cHNidP8A", "cHNidP8B"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCombinePsbtCmd([]string{"cHNidP8A", "cHNidP8B"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"combinepsbt","netparams":[["cHNidP8A","cHNidP8B"]],"id":1}`,
+			unmarshalled: &btcjson.CombinePsbtCmd{
+				Psbts: []string{"cHNidP8A", "cHNidP8B"},
+			},
+		},
 		{
 			name: "createmultisig",
 			newCmd: func() (interface{}, error) {
@@ -83,6 +136,19 @@ func TestWalletSvrCmds(t *testing.T) {
 				Keys:      []string{"031234", "035678"},
 			},
 		},
+		{
+			name: "decodepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("decodepsbt", "cHNidP8A")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDecodePsbtCmd("cHNidP8A")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"decodepsbt","netparams":["cHNidP8A"],"id":1}`,
+			unmarshalled: &btcjson.DecodePsbtCmd{
+				Psbt: "cHNidP8A",
+			},
+		},
 		{
 			name: "dumpprivkey",
 			newCmd: func() (interface{}, error) {
@@ -135,6 +201,34 @@ func TestWalletSvrCmds(t *testing.T) {
 				NumBlocks: 6,
 			},
 		},
+		{
+			name: "finalizepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("finalizepsbt", "cHNidP8A")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewFinalizePsbtCmd("cHNidP8A", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","netparams":["cHNidP8A"],"id":1}`,
+			unmarshalled: &btcjson.FinalizePsbtCmd{
+				Psbt:    "cHNidP8A",
+				Extract: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "finalizepsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("finalizepsbt", "cHNidP8A", false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewFinalizePsbtCmd("cHNidP8A", btcjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","netparams":["cHNidP8A",false],"id":1}`,
+			unmarshalled: &btcjson.FinalizePsbtCmd{
+				Psbt:    "cHNidP8A",
+				Extract: btcjson.Bool(false),
+			},
+		},
 		{
 			name: "getaccount",
 			newCmd: func() (interface{}, error) {
@@ -956,7 +1050,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, nil, nil)
+				return btcjson.NewSendManyCmd("from", amounts, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5}],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -973,7 +1067,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), nil)
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -990,7 +1084,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"))
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"comment"],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -1000,13 +1094,39 @@ func TestWalletSvrCmds(t *testing.T) {
 				Comment:     btcjson.String("comment"),
 			},
 		},
+		{
+			name: "sendmany optional3",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendmany", "from", `{"1Address":0.5}`, 6, "comment",
+					`{"inputs":[{"txid":"123","vout":1}],"strategy":"privacy"}`)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"),
+					&btcjson.CoinControlOpts{
+						Inputs:   []btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+						Strategy: btcjson.String("privacy"),
+					})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"comment",{"inputs":[{"txid":"123","vout":1}],"strategy":"privacy"}],"id":1}`,
+			unmarshalled: &btcjson.SendManyCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     btcjson.Int(6),
+				Comment:     btcjson.String("comment"),
+				Options: &btcjson.CoinControlOpts{
+					Inputs:   []btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+					Strategy: btcjson.String("privacy"),
+				},
+			},
+		},
 		{
 			name: "sendtoaddress",
 			newCmd: func() (interface{}, error) {
 				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5)
 			},
 			staticCmd: func() interface{} {
-				return btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil)
+				return btcjson.NewSendToAddressCmd("1Address", 0.5, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5],"id":1}`,
 			unmarshalled: &btcjson.SendToAddressCmd{
@@ -1023,7 +1143,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				return btcjson.NewSendToAddressCmd("1Address", 0.5, btcjson.String("comment"),
-					btcjson.String("commentto"))
+					btcjson.String("commentto"), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"comment","commentto"],"id":1}`,
 			unmarshalled: &btcjson.SendToAddressCmd{
@@ -1033,6 +1153,24 @@ func TestWalletSvrCmds(t *testing.T) {
 				CommentTo: btcjson.String("commentto"),
 			},
 		},
+		{
+			name: "sendtoaddress optional2",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5, "", "", `{"strategy":"branch-and-bound"}`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSendToAddressCmd("1Address", 0.5, btcjson.String(""), btcjson.String(""),
+					&btcjson.CoinControlOpts{Strategy: btcjson.String("branch-and-bound")})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"","",{"strategy":"branch-and-bound"}],"id":1}`,
+			unmarshalled: &btcjson.SendToAddressCmd{
+				Address:   "1Address",
+				Amount:    0.5,
+				Comment:   btcjson.String(""),
+				CommentTo: btcjson.String(""),
+				Options:   &btcjson.CoinControlOpts{Strategy: btcjson.String("branch-and-bound")},
+			},
+		},
 		{
 			name: "setaccount",
 			newCmd: func() (interface{}, error) {
@@ -1047,6 +1185,20 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "setlabel",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setlabel", "1Address", "label")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetLabelCmd("1Address", "label")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setlabel","netparams":["1Address","label"],"id":1}`,
+			unmarshalled: &btcjson.SetLabelCmd{
+				Address: "1Address",
+				Label:   "label",
+			},
+		},
 		{
 			name: "settxfee",
 			newCmd: func() (interface{}, error) {
@@ -1060,6 +1212,20 @@ func TestWalletSvrCmds(t *testing.T) {
 				Amount: 0.0001,
 			},
 		},
+		{
+			name: "settxlabel",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("settxlabel", "123", "label")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetTxLabelCmd("123", "label")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"settxlabel","netparams":["123","label"],"id":1}`,
+			unmarshalled: &btcjson.SetTxLabelCmd{
+				TxID:  "123",
+				Label: "label",
+			},
+		},
 		{
 			name: "signmessage",
 			newCmd: func() (interface{}, error) {
@@ -1158,6 +1324,45 @@ func TestWalletSvrCmds(t *testing.T) {
 				Flags:    btcjson.String("ALL"),
 			},
 		},
+		{
+			name: "walletcreatefundedpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletcreatefundedpsbt", `[{"txid":"123","vout":1}]`, `{"1Address":0.5}`)
+			},
+			staticCmd: func() interface{} {
+				txInputs := []btcjson.TransactionInput{{Txid: "123", Vout: 1}}
+				return btcjson.NewWalletCreateFundedPsbtCmd(txInputs, map[string]float64{"1Address": 0.5}, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","netparams":[[{"txid":"123","vout":1}],{"1Address":0.5}],"id":1}`,
+			unmarshalled: &btcjson.WalletCreateFundedPsbtCmd{
+				Inputs:  []btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+				Outputs: map[string]float64{"1Address": 0.5},
+			},
+		},
+		{
+			name: "walletcreatefundedpsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletcreatefundedpsbt", `[]`, `{"1Address":0.5}`, 100,
+					`{"account":"imported","lockUnspents":true}`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletCreateFundedPsbtCmd([]btcjson.TransactionInput{},
+					map[string]float64{"1Address": 0.5}, btcjson.Uint32(100), &btcjson.WalletCreateFundedPsbtOpts{
+						Account:      btcjson.String("imported"),
+						LockUnspents: btcjson.Bool(true),
+					})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","netparams":[[],{"1Address":0.5},100,{"account":"imported","lockUnspents":true}],"id":1}`,
+			unmarshalled: &btcjson.WalletCreateFundedPsbtCmd{
+				Inputs:   []btcjson.TransactionInput{},
+				Outputs:  map[string]float64{"1Address": 0.5},
+				LockTime: btcjson.Uint32(100),
+				Options: &btcjson.WalletCreateFundedPsbtOpts{
+					Account:      btcjson.String("imported"),
+					LockUnspents: btcjson.Bool(true),
+				},
+			},
+		},
 		{
 			name: "walletlock",
 			newCmd: func() (interface{}, error) {
@@ -1197,6 +1402,37 @@ func TestWalletSvrCmds(t *testing.T) {
 				NewPassphrase: "new",
 			},
 		},
+		{
+			name: "walletprocesspsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletprocesspsbt", "cHNidP8A")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletProcessPsbtCmd("cHNidP8A", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","netparams":["cHNidP8A"],"id":1}`,
+			unmarshalled: &btcjson.WalletProcessPsbtCmd{
+				Psbt:        "cHNidP8A",
+				Sign:        btcjson.Bool(true),
+				SighashType: btcjson.String("ALL"),
+			},
+		},
+		{
+			name: "walletprocesspsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletprocesspsbt", "cHNidP8A", false, "SINGLE|ANYONECANPAY")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletProcessPsbtCmd("cHNidP8A", btcjson.Bool(false),
+					btcjson.String("SINGLE|ANYONECANPAY"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","netparams":["cHNidP8A",false,"SINGLE|ANYONECANPAY"],"id":1}`,
+			unmarshalled: &btcjson.WalletProcessPsbtCmd{
+				Psbt:        "cHNidP8A",
+				Sign:        btcjson.Bool(false),
+				SighashType: btcjson.String("SINGLE|ANYONECANPAY"),
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
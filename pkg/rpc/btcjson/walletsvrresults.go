@@ -10,6 +10,7 @@ type (
 		InvolvesWatchOnly bool     `json:"involveswatchonly,omitempty"`
 		Fee               *float64 `json:"fee,omitempty"`
 		Vout              uint32   `json:"vout"`
+		Label             string   `json:"label,omitempty"`
 	}
 	// GetTransactionResult models the data from the gettransaction command.
 	GetTransactionResult struct {
@@ -25,6 +26,8 @@ type (
 		TimeReceived    int64                         `json:"timereceived"`
 		Details         []GetTransactionDetailsResult `json:"details"`
 		Hex             string                        `json:"hex"`
+		Comment         string                        `json:"comment,omitempty"`
+		To              string                        `json:"to,omitempty"`
 	}
 	// InfoWalletResult models the data returned by the wallet server getinfo command.
 	InfoWalletResult struct {
@@ -67,6 +70,8 @@ type (
 		Vout              uint32   `json:"vout"`
 		WalletConflicts   []string `json:"walletconflicts"`
 		Comment           string   `json:"comment,omitempty"`
+		To                string   `json:"to,omitempty"`
+		Label             string   `json:"label,omitempty"`
 		OtherAccount      string   `json:"otheraccount,omitempty"`
 	}
 	// ListReceivedByAccountResult models the data from the listreceivedbyaccount command.
@@ -136,4 +141,91 @@ type (
 		Hash   string `json:"hash"`
 		Height int32  `json:"height"`
 	}
+	// WalletCreateFundedPsbtResult models the data from the walletcreatefundedpsbt command.
+	WalletCreateFundedPsbtResult struct {
+		Psbt      string  `json:"psbt"`
+		Fee       float64 `json:"fee"`
+		ChangePos int64   `json:"changepos"`
+	}
+	// WalletProcessPsbtResult models the data from the walletprocesspsbt command.
+	WalletProcessPsbtResult struct {
+		Psbt     string `json:"psbt"`
+		Complete bool   `json:"complete"`
+	}
+	// FinalizePsbtResult models the data from the finalizepsbt command. Hex is set instead of Psbt when the transaction
+	// is complete and has been extracted.
+	FinalizePsbtResult struct {
+		Psbt     string `json:"psbt,omitempty"`
+		Hex      string `json:"hex,omitempty"`
+		Complete bool   `json:"complete"`
+	}
+	// DecodePsbtBip32Deriv models the derivation of a public key in the data from the decodepsbt command.
+	DecodePsbtBip32Deriv struct {
+		PubKey            string `json:"pubkey"`
+		MasterFingerprint string `json:"master_fingerprint"`
+		Path              string `json:"path"`
+	}
+	// DecodePsbtUtxo models the output spent by a witness input in the data from the decodepsbt command.
+	DecodePsbtUtxo struct {
+		Amount       float64            `json:"amount"`
+		ScriptPubKey ScriptPubKeyResult `json:"scriptPubKey"`
+	}
+	// DecodePsbtInput models an input in the data from the decodepsbt command.
+	DecodePsbtInput struct {
+		NonWitnessUtxo     *TxRawDecodeResult     `json:"non_witness_utxo,omitempty"`
+		WitnessUtxo        *DecodePsbtUtxo        `json:"witness_utxo,omitempty"`
+		PartialSignatures  map[string]string      `json:"partial_signatures,omitempty"`
+		Sighash            string                 `json:"sighash,omitempty"`
+		RedeemScript       *ScriptPubKeyResult    `json:"redeem_script,omitempty"`
+		WitnessScript      *ScriptPubKeyResult    `json:"witness_script,omitempty"`
+		Bip32Derivs        []DecodePsbtBip32Deriv `json:"bip32_derivs,omitempty"`
+		FinalScriptSig     *ScriptSig             `json:"final_scriptSig,omitempty"`
+		FinalScriptWitness []string               `json:"final_scriptwitness,omitempty"`
+		Unknown            map[string]string      `json:"unknown,omitempty"`
+	}
+	// DecodePsbtOutput models an output in the data from the decodepsbt command.
+	DecodePsbtOutput struct {
+		RedeemScript  *ScriptPubKeyResult    `json:"redeem_script,omitempty"`
+		WitnessScript *ScriptPubKeyResult    `json:"witness_script,omitempty"`
+		Bip32Derivs   []DecodePsbtBip32Deriv `json:"bip32_derivs,omitempty"`
+		Unknown       map[string]string      `json:"unknown,omitempty"`
+	}
+	// DecodePsbtResult models the data from the decodepsbt command. The fee is only known when the outputs spent by all
+	// of the inputs are.
+	DecodePsbtResult struct {
+		Tx      TxRawDecodeResult  `json:"tx"`
+		Unknown map[string]string  `json:"unknown"`
+		Inputs  []DecodePsbtInput  `json:"inputs"`
+		Outputs []DecodePsbtOutput `json:"outputs"`
+		Fee     *float64           `json:"fee,omitempty"`
+	}
+	// GetWalletInfoResult models the data from the getwalletinfo command.
+	GetWalletInfoResult struct {
+		WalletVersion      int32   `json:"walletversion"`
+		Balance            float64 `json:"balance"`
+		UnconfirmedBalance float64 `json:"unconfirmed_balance"`
+		ImmatureBalance    float64 `json:"immature_balance"`
+		TxCount            int64   `json:"txcount"`
+		PaytxFee           float64 `json:"paytxfee"`
+		PrivateKeysEnabled bool    `json:"private_keys_enabled"`
+		Unlocked           bool    `json:"unlocked"`
+		Birthday           int64   `json:"birthday"`
+		SyncedTo           int32   `json:"syncedto"`
+	}
+	// ListAddressGroupingsResult models an address in one of the groups from the listaddressgroupings command.
+	ListAddressGroupingsResult struct {
+		Address string  `json:"address"`
+		Amount  float64 `json:"amount"`
+		Label   string  `json:"label,omitempty"`
+	}
+	// DumpWalletResult models the data from the dumpwallet command.
+	DumpWalletResult struct {
+		Filename string `json:"filename"`
+	}
+	// BumpFeeResult models the data from the bumpfee command.
+	BumpFeeResult struct {
+		TxID    string  `json:"txid"`
+		OrigFee float64 `json:"origfee"`
+		Fee     float64 `json:"fee"`
+	}
 )
@@ -40,6 +40,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.AddNodeCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "clearbanned",
+		Handler: "ClearBanned",
+		Cmd:     "*None",
+		ResType: "None",
+	},
 	{
 		Method:  "createrawtransaction",
 		Handler: "CreateRawTransaction",
@@ -136,6 +142,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetCFilterHeaderCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getchaintips",
+		Handler: "GetChainTips",
+		Cmd:     "*None",
+		ResType: "[]btcjson.GetChainTipsResult",
+	},
 	{
 		Method:  "getconnectioncount",
 		Handler: "GetConnectionCount",
@@ -154,6 +166,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetDifficultyCmd",
 		ResType: "float64",
 	},
+	{
+		Method:  "getforkinfo",
+		Handler: "GetForkInfo",
+		Cmd:     "*btcjson.GetForkInfoCmd",
+		ResType: "btcjson.GetForkInfoResult",
+	},
 	{
 		Method:  "getgenerate",
 		Handler: "GetGenerate",
@@ -172,12 +190,36 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetHeadersCmd",
 		ResType: "[]string",
 	},
+	{
+		Method:  "getindexinfo",
+		Handler: "GetIndexInfo",
+		Cmd:     "*btcjson.GetIndexInfoCmd",
+		ResType: "map[string]btcjson.GetIndexInfoResult",
+	},
 	{
 		Method:  "getinfo",
 		Handler: "GetInfo",
 		Cmd:     "*None",
 		ResType: "btcjson.InfoChainResult0",
 	},
+	{
+		Method:  "getmempoolancestors",
+		Handler: "GetMempoolAncestors",
+		Cmd:     "*btcjson.GetMempoolAncestorsCmd",
+		ResType: "[]string",
+	},
+	{
+		Method:  "getmempooldescendants",
+		Handler: "GetMempoolDescendants",
+		Cmd:     "*btcjson.GetMempoolDescendantsCmd",
+		ResType: "[]string",
+	},
+	{
+		Method:  "getmempoolentry",
+		Handler: "GetMempoolEntry",
+		Cmd:     "*btcjson.GetMempoolEntryCmd",
+		ResType: "btcjson.GetMempoolEntryResult",
+	},
 	{
 		Method:  "getmempoolinfo",
 		Handler: "GetMempoolInfo",
@@ -202,6 +244,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetNetworkHashPSCmd",
 		ResType: "[]btcjson.GetPeerInfoResult",
 	},
+	{
+		Method:  "getnetworkinfo",
+		Handler: "GetNetworkInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetNetworkInfoResult",
+	},
 	{
 		Method:  "getpeerinfo",
 		Handler: "GetPeerInfo",
@@ -226,12 +274,36 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetTxOutCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "gettxspendingprevout",
+		Handler: "GetTxSpendingPrevOut",
+		Cmd:     "*btcjson.GetTxSpendingPrevOutCmd",
+		ResType: "[]btcjson.GetTxSpendingPrevOutResult",
+	},
+	{
+		Method:  "getworkershares",
+		Handler: "GetWorkerShares",
+		Cmd:     "*btcjson.GetWorkerSharesCmd",
+		ResType: "btcjson.GetWorkerSharesResult",
+	},
 	{
 		Method:  "help",
 		Handler: "Help",
 		Cmd:     "*btcjson.HelpCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "invalidateblock",
+		Handler: "InvalidateBlock",
+		Cmd:     "*btcjson.InvalidateBlockCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "listbanned",
+		Handler: "ListBanned",
+		Cmd:     "*None",
+		ResType: "[]btcjson.ListBannedResult",
+	},
 	{
 		Method:  "node",
 		Handler: "Node",
@@ -244,6 +316,18 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "None",
 	},
+	{
+		Method:  "preciousblock",
+		Handler: "PreciousBlock",
+		Cmd:     "*btcjson.PreciousBlockCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "reconsiderblock",
+		Handler: "ReconsiderBlock",
+		Cmd:     "*btcjson.ReconsiderBlockCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "searchrawtransactions",
 		Handler: "SearchRawTransactions",
@@ -256,6 +340,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SendRawTransactionCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "setban",
+		Handler: "SetBan",
+		Cmd:     "*btcjson.SetBanCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "setgenerate",
 		Handler: "SetGenerate",
@@ -274,6 +364,12 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "None",
 	},
+	{
+		Method:  "reloadconfig",
+		Handler: "ReloadConfig",
+		Cmd:     "*None",
+		ResType: "btcjson.ReloadConfigResult",
+	},
 	{
 		Method:  "resetchain",
 		Handler: "ResetChain",
@@ -16,16 +16,20 @@ import (
 	
 	"github.com/p9c/pod/pkg/util/logi"
 	
+	"github.com/p9c/pod/cmd/kopach/control/ledger"
 	"github.com/p9c/pod/cmd/node/mempool"
 	"github.com/p9c/pod/cmd/node/version"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
 	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/hardfork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/interrupt"
@@ -83,6 +87,15 @@ func HandleAskWallet(
 	return nil, ErrRPCNoWallet
 }
 
+// HandleClearBanned implements the clearbanned command.
+func HandleClearBanned(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	if err := s.Cfg.ConnMgr.ClearBanned(); err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to clear the ban list")
+	}
+	return nil, nil
+}
+
 // HandleCreateRawTransaction handles createrawtransaction commands.
 func HandleCreateRawTransaction(
 	s *Server,
@@ -1152,6 +1165,9 @@ func HandleGetCFilter(s *Server, cmd interface{}, closeChan qu.C) (interface{},
 			Message: "The CF index must be enabled for this command",
 		}
 	}
+	if rErr := IndexSyncingError(s, s.Cfg.CfIndex); rErr != nil {
+		return nil, rErr
+	}
 	var msg string
 	var err error
 	c, ok := cmd.(*btcjson.GetCFilterCmd)
@@ -1194,6 +1210,9 @@ func HandleGetCFilterHeader(s *Server, cmd interface{}, closeChan qu.C) (interfa
 			Message: "The CF index must be enabled for this command",
 		}
 	}
+	if rErr := IndexSyncingError(s, s.Cfg.CfIndex); rErr != nil {
+		return nil, rErr
+	}
 	var msg string
 	var err error
 	c, ok := cmd.(*btcjson.GetCFilterHeaderCmd)
@@ -1241,6 +1260,22 @@ func HandleGetCFilterHeader(s *Server, cmd interface{}, closeChan qu.C) (interfa
 	return hash.String(), nil
 }
 
+// HandleGetChainTips implements the getchaintips command.
+func HandleGetChainTips(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	tips := s.Cfg.Chain.ChainTips()
+	results := make([]btcjson.GetChainTipsResult, len(tips))
+	for i := range tips {
+		results[i] = btcjson.GetChainTipsResult{
+			Height:    tips[i].Height,
+			Hash:      tips[i].Hash.String(),
+			BranchLen: tips[i].BranchLen,
+			PowAlgo:   fork.GetAlgoName(tips[i].Algo, tips[i].Height),
+			Status:    string(tips[i].Status),
+		}
+	}
+	return results, nil
+}
+
 // HandleGetConnectionCount implements the getconnectioncount command.
 func HandleGetConnectionCount(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	return s.Cfg.ConnMgr.ConnectedCount(), nil
@@ -1319,6 +1354,61 @@ func HandleGetDifficulty(s *Server, cmd interface{}, closeChan qu.C) (interface{
 	return GetDifficultyRatio(bestbits, s.Cfg.ChainParams, algo), nil
 }
 
+// HandleGetForkInfo implements the getforkinfo command.
+func HandleGetForkInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	if _, ok := cmd.(*btcjson.GetForkInfoCmd); !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("getforkinfo")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	schedule := hardfork.Active(s.Cfg.ChainParams)
+	height := s.Cfg.Chain.BestSnapshot().Height
+	result := &btcjson.GetForkInfoResult{
+		Network:     schedule.Network,
+		Version:     schedule.Version,
+		Checksum:    schedule.Checksum,
+		File:        hardfork.ScheduleFile,
+		Height:      height,
+		CurrentFork: uint32(fork.GetCurrent(height)),
+		Forks:       []btcjson.ForkInfoResult{},
+		Payees:      []btcjson.ForkPayeeResult{},
+		CorePubkeys: schedule.CorePubkeys,
+		CoreAmount:  schedule.CoreAmount,
+		Blacklist:   schedule.Blacklist,
+	}
+	for _, f := range schedule.Forks {
+		fi := btcjson.ForkInfoResult{
+			Number:             f.Number,
+			Name:               f.Name,
+			ActivationHeight:   f.ActivationHeight,
+			TargetTimePerBlock: f.TargetTimePerBlock,
+			AveragingInterval:  f.AveragingInterval,
+			Active:             f.Number == result.CurrentFork,
+		}
+		for _, a := range f.Algos {
+			fi.Algos = append(fi.Algos, btcjson.ForkAlgoResult{
+				Name:            a.Name,
+				Version:         a.Version,
+				MinBits:         a.MinBits,
+				AlgoID:          a.AlgoID,
+				VersionInterval: a.VersionInterval,
+			})
+		}
+		result.Forks = append(result.Forks, fi)
+	}
+	for _, p := range schedule.Payees {
+		result.Payees = append(result.Payees, btcjson.ForkPayeeResult{Address: p.Address, Amount: p.Amount})
+	}
+	return result, nil
+}
+
 // HandleGetGenerate implements the getgenerate command.
 func HandleGetGenerate(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) { // cpuminer
 	// generating := s.StateCfg.Miner != nil
@@ -1401,6 +1491,25 @@ func HandleGetHeaders(s *Server, cmd interface{}, closeChan qu.C) (interface{},
 	return hexBlockHeaders, nil
 }
 
+// HandleGetIndexInfo implements the getindexinfo command.
+func HandleGetIndexInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	c := cmd.(*btcjson.GetIndexInfoCmd)
+	result := make(map[string]btcjson.GetIndexInfoResult)
+	if s.Cfg.IndexManager == nil {
+		return result, nil
+	}
+	for _, status := range s.Cfg.IndexManager.Status() {
+		if c.IndexName != nil && *c.IndexName != status.Name {
+			continue
+		}
+		result[status.Name] = btcjson.GetIndexInfoResult{
+			Synced:          status.Synced,
+			BestBlockHeight: status.Height,
+		}
+	}
+	return result, nil
+}
+
 // HandleGetInfo implements the getinfo command. We only return the fields that are not related to wallet functionality.
 // TODO: simplify this, break it up
 func HandleGetInfo(
@@ -1535,6 +1644,93 @@ func HandleGetInfo(
 	return ret, nil
 }
 
+// HandleGetMempoolAncestors implements the getmempoolancestors command.
+func HandleGetMempoolAncestors(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GetMempoolAncestorsCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("getmempoolancestors")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	txHash, err := chainhash.NewHashFromStr(c.TxID)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.TxID)
+	}
+	descs, err := s.Cfg.TxMemPool.Ancestors(txHash)
+	if err != nil {
+		return nil, TxNotInMempoolError()
+	}
+	return MempoolPackageResult(s, descs, c.Verbose != nil && *c.Verbose), nil
+}
+
+// HandleGetMempoolDescendants implements the getmempooldescendants command.
+func HandleGetMempoolDescendants(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GetMempoolDescendantsCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("getmempooldescendants")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	txHash, err := chainhash.NewHashFromStr(c.TxID)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.TxID)
+	}
+	descs, err := s.Cfg.TxMemPool.Descendants(txHash)
+	if err != nil {
+		return nil, TxNotInMempoolError()
+	}
+	return MempoolPackageResult(s, descs, c.Verbose != nil && *c.Verbose), nil
+}
+
+// HandleGetMempoolEntry implements the getmempoolentry command.
+func HandleGetMempoolEntry(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GetMempoolEntryCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("getmempoolentry")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	txHash, err := chainhash.NewHashFromStr(c.TxID)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.TxID)
+	}
+	entry, err := s.Cfg.TxMemPool.MempoolEntry(txHash)
+	if err != nil {
+		return nil, TxNotInMempoolError()
+	}
+	return entry, nil
+}
+
 // HandleGetMempoolInfo implements the getmempoolinfo command.
 func HandleGetMempoolInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	mempoolTxns := s.Cfg.TxMemPool.TxDescs()
@@ -1804,6 +2000,76 @@ func HandleGetNetworkHashPS(s *Server, cmd interface{}, closeChan qu.C) (interfa
 	return hashesPerSec.Int64(), nil
 }
 
+// HandleGetNetworkInfo implements the getnetworkinfo command.
+func HandleGetNetworkInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var inbound, outbound int32
+	for _, sp := range s.Cfg.ConnMgr.ConnectedPeers() {
+		if sp.ToPeer().Inbound() {
+			inbound++
+		} else {
+			outbound++
+		}
+	}
+	// Ordinary connections are made directly or through the configured proxy. Onion addresses can only be reached when
+	// tor is enabled, through the onion proxy if there is one, and otherwise through the general proxy.
+	onionProxy := *s.Config.OnionProxy
+	if onionProxy == "" {
+		onionProxy = *s.Config.Proxy
+	}
+	networks := []btcjson.NetworksResult{
+		{
+			Name:                      "ipv4",
+			Reachable:                 true,
+			Proxy:                     *s.Config.Proxy,
+			ProxyRandomizeCredentials: *s.Config.TorIsolation,
+		},
+		{
+			Name:                      "ipv6",
+			Reachable:                 true,
+			Proxy:                     *s.Config.Proxy,
+			ProxyRandomizeCredentials: *s.Config.TorIsolation,
+		},
+		{
+			Name:                      "onion",
+			Limited:                   !*s.Config.Onion,
+			Reachable:                 *s.Config.Onion && onionProxy != "",
+			Proxy:                     onionProxy,
+			ProxyRandomizeCredentials: *s.Config.TorIsolation,
+		},
+	}
+	localAddrs := s.Cfg.ConnMgr.LocalAddresses()
+	localAddresses := make([]btcjson.LocalAddressesResult, len(localAddrs))
+	for i, la := range localAddrs {
+		localAddresses[i] = btcjson.LocalAddressesResult{
+			Address: la.NetAddress.IP.String(),
+			Port:    la.NetAddress.Port,
+			Score:   int32(la.Score),
+		}
+	}
+	ret := &btcjson.GetNetworkInfoResult{
+		Version: int32(
+			1000000*version.AppMajor +
+				10000*version.AppMinor +
+				100*version.AppPatch,
+		),
+		SubVersion:      fmt.Sprintf("%s%s:%s/", wire.DefaultUserAgent, UserAgentName, UserAgentVersion),
+		ProtocolVersion: int32(MaxProtocolVersion),
+		LocalServices:   fmt.Sprintf("%016x", uint64(s.Cfg.ConnMgr.Services())),
+		LocalRelay:      !*s.Config.BlocksOnly,
+		TimeOffset:      int64(s.Cfg.TimeSource.Offset().Seconds()),
+		Connections:     inbound + outbound,
+		ConnectionsIn:   inbound,
+		ConnectionsOut:  outbound,
+		NetworkActive:   true,
+		Networks:        networks,
+		RelayFee:        s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		IncrementalFee:  s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		LocalAddresses:  localAddresses,
+		Warnings:        strings.Join(s.Cfg.Chain.Warnings(), "; "),
+	}
+	return ret, nil
+}
+
 // HandleGetPeerInfo implements the getpeerinfo command.
 func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	peers := s.Cfg.ConnMgr.ConnectedPeers()
@@ -1832,6 +2098,8 @@ func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan qu.C) (interface{},
 			BanScore:       int32(p.GetBanScore()),
 			FeeFilter:      p.GetFeeFilter(),
 			SyncNode:       statsSnap.ID == syncPeerID,
+			Transport:      statsSnap.TransportProtocol,
+			SessionID:      statsSnap.SessionID,
 		}
 		if p.ToPeer().LastPingNonce() != 0 {
 			wait := float64(time.Since(statsSnap.LastPingTime).Nanoseconds())
@@ -1902,6 +2170,9 @@ func HandleGetRawTransaction(s *Server, cmd interface{}, closeChan qu.C) (interf
 					"(specify --txindex)",
 			}
 		}
+		if rErr := IndexSyncingError(s, s.Cfg.TxIndex); rErr != nil {
+			return nil, rErr
+		}
 		// Look up the location of the transaction.
 		blockRegion, err := s.Cfg.TxIndex.TxBlockRegion(txHash)
 		if err != nil {
@@ -2099,6 +2370,146 @@ func HandleGetTxOut(s *Server, cmd interface{}, closeChan qu.C) (interface{}, er
 	return txOutReply, nil
 }
 
+// HandleGetTxSpendingPrevOut implements the gettxspendingprevout command.
+func HandleGetTxSpendingPrevOut(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	// Respond with an error if the spend index is not enabled.
+	spendIndex := s.Cfg.SpendIndex
+	if spendIndex == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Spend index must be enabled (--spendindex)",
+		}
+	}
+	if rErr := IndexSyncingError(s, spendIndex); rErr != nil {
+		return nil, rErr
+	}
+	c := cmd.(*btcjson.GetTxSpendingPrevOutCmd)
+	if len(c.Outputs) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Invalid parameter, outputs are missing",
+		}
+	}
+	results := make([]btcjson.GetTxSpendingPrevOutResult, 0, len(c.Outputs))
+	for _, output := range c.Outputs {
+		txHash, err := chainhash.NewHashFromStr(output.Txid)
+		if err != nil {
+			Error(err)
+			return nil, DecodeHexError(output.Txid)
+		}
+		result := btcjson.GetTxSpendingPrevOutResult{Txid: output.Txid, Vout: output.Vout}
+		op := wire.OutPoint{Hash: *txHash, Index: output.Vout}
+		// A transaction in the mempool spending the output has no block height yet, otherwise look for the spender in the
+		// main chain.
+		if tx := s.Cfg.TxMemPool.CheckSpend(op); tx != nil {
+			for i, txIn := range tx.MsgTx().TxIn {
+				if txIn.PreviousOutPoint == op {
+					vin := uint32(i)
+					result.SpendingTxid = tx.Hash().String()
+					result.SpendingVin = &vin
+					break
+				}
+			}
+		} else {
+			entry, err := spendIndex.Spender(&op)
+			if err != nil {
+				Error(err)
+				return nil, &btcjson.RPCError{
+					Code:    btcjson.ErrRPCDatabase,
+					Message: "Failed to load spend index entry: " + err.Error(),
+				}
+			}
+			if entry != nil {
+				result.SpendingTxid = entry.TxHash.String()
+				result.SpendingVin = &entry.Index
+				result.BlockHeight = entry.Height
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// HandleGetWorkerShares implements the getworkershares command.
+func HandleGetWorkerShares(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.GetWorkerSharesCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("getworkershares")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	shareLedger, _ := s.Cfg.ShareLedger.Load().(*ledger.Ledger)
+	if shareLedger == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Share ledger is not available, the miner controller is not running",
+		}
+	}
+	workers, err := shareLedger.Workers()
+	if err != nil {
+		return nil, InternalRPCError(err.Error(), "Failed to read share ledger")
+	}
+	result := &btcjson.GetWorkerSharesResult{
+		Shares:  int64(shareLedger.Count()),
+		Workers: make([]btcjson.WorkerSharesResult, len(workers)),
+	}
+	for i, w := range workers {
+		blocks := make([]string, len(w.Blocks))
+		for j := range w.Blocks {
+			blocks[j] = w.Blocks[j].String()
+		}
+		result.Workers[i] = btcjson.WorkerSharesResult{
+			Worker:     w.Worker,
+			Shares:     w.Shares,
+			Difficulty: w.Difficulty,
+			Weight:     w.Weight,
+			Algos:      w.Algos,
+			Blocks:     blocks,
+			LastShare:  w.LastShare.Unix(),
+		}
+	}
+	if c.Window == nil || *c.Window <= 0 {
+		return result, nil
+	}
+	if *c.Window > ledger.MaxShares {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("Window is longer than the %d most recent shares the ledger keeps", ledger.MaxShares),
+		}
+	}
+	var reward util.Amount
+	if c.Reward != nil && *c.Reward > 0 {
+		if reward, err = util.NewAmount(*c.Reward); err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "Invalid reward amount",
+			}
+		}
+	}
+	payouts, err := shareLedger.PPLNS(*c.Window, int64(reward))
+	if err != nil {
+		return nil, InternalRPCError(err.Error(), "Failed to read share ledger")
+	}
+	result.Payouts = make([]btcjson.SharePayoutResult, len(payouts))
+	for i, p := range payouts {
+		result.Payouts[i] = btcjson.SharePayoutResult{
+			Worker:   p.Worker,
+			Shares:   p.Shares,
+			Weight:   p.Weight,
+			Fraction: p.Fraction,
+			Amount:   util.Amount(p.Amount).ToDUO(),
+		}
+	}
+	return result, nil
+}
+
 // HandleHelp implements the help command.
 func HandleHelp(s *Server, cmd interface{}, closeChan qu.C) (
 	interface{}, error,
@@ -2152,6 +2563,58 @@ func HandleHelp(s *Server, cmd interface{}, closeChan qu.C) (
 	return help, nil
 }
 
+// HandleInvalidateBlock implements the invalidateblock command.
+func HandleInvalidateBlock(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.InvalidateBlockCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("invalidateblock")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.BlockHash)
+	}
+	if _, err = s.Cfg.Chain.HeaderByHash(hash); err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	if err = s.Cfg.Chain.InvalidateBlock(hash); err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to invalidate block")
+	}
+	return nil, nil
+}
+
+// HandleListBanned implements the listbanned command.
+func HandleListBanned(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	bans := s.Cfg.ConnMgr.Banned()
+	result := make([]btcjson.ListBannedResult, 0, len(bans))
+	for _, ban := range bans {
+		result = append(
+			result, btcjson.ListBannedResult{
+				Address:     ban.Subnet.String(),
+				BanCreated:  ban.Created.Unix(),
+				BannedUntil: ban.Until.Unix(),
+				BanReason:   ban.Reason,
+			},
+		)
+	}
+	return result, nil
+}
+
 // HandleNode handles node commands.
 func HandleNode(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	var msg string
@@ -2269,6 +2732,76 @@ func HandlePing(s *Server, cmd interface{}, closeChan qu.C) (
 	return nil, nil
 }
 
+// HandlePreciousBlock implements the preciousblock command.
+func HandlePreciousBlock(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.PreciousBlockCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("preciousblock")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.BlockHash)
+	}
+	if _, err = s.Cfg.Chain.HeaderByHash(hash); err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	if err = s.Cfg.Chain.PreciousBlock(hash); err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to prefer block")
+	}
+	return nil, nil
+}
+
+// HandleReconsiderBlock implements the reconsiderblock command.
+func HandleReconsiderBlock(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.ReconsiderBlockCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("reconsiderblock")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.BlockHash)
+	}
+	if _, err = s.Cfg.Chain.HeaderByHash(hash); err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	if err = s.Cfg.Chain.ReconsiderBlock(hash); err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to reconsider block")
+	}
+	return nil, nil
+}
+
 // HandleSearchRawTransactions implements the searchrawtransactions command.
 // TODO: simplify this, break it up
 func HandleSearchRawTransactions(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
@@ -2280,6 +2813,9 @@ func HandleSearchRawTransactions(s *Server, cmd interface{}, closeChan qu.C) (in
 			Message: "Address index must be enabled (--addrindex)",
 		}
 	}
+	if rErr := IndexSyncingError(s, addrIndex); rErr != nil {
+		return nil, rErr
+	}
 	// Override the flag for including extra previous output information in each input if needed.
 	c := cmd.(*btcjson.SearchRawTransactionsCmd)
 	vinExtra := false
@@ -2295,6 +2831,11 @@ func HandleSearchRawTransactions(s *Server, cmd interface{}, closeChan qu.C) (in
 			Message: "Transaction index must be enabled (--txindex)",
 		}
 	}
+	if vinExtra {
+		if rErr := IndexSyncingError(s, s.Cfg.TxIndex); rErr != nil {
+			return nil, rErr
+		}
+	}
 	// Attempt to decode the supplied address.
 	params := s.Cfg.ChainParams
 	addr, err := util.DecodeAddress(c.Address, params)
@@ -2596,6 +3137,69 @@ func HandleSendRawTransaction(
 	return tx.Hash().String(), nil
 }
 
+// HandleSetBan implements the setban command.
+func HandleSetBan(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.SetBanCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("setban")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	var subnet *net.IPNet
+	if subnet, err = connmgr.ParseSubnet(c.Subnet); err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: err.Error(),
+		}
+	}
+	switch c.SubCmd {
+	case btcjson.SBAdd:
+		// the ban lasts for the configured ban duration unless a time is given, in seconds from now or as a unix time
+		until := time.Now().Add(*s.Config.BanDuration)
+		if c.BanTime != nil && *c.BanTime > 0 {
+			if c.Absolute != nil && *c.Absolute {
+				until = time.Unix(*c.BanTime, 0)
+			} else {
+				until = time.Now().Add(time.Duration(*c.BanTime) * time.Second)
+			}
+		}
+		if !until.After(time.Now()) {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "the ban would already have expired",
+			}
+		}
+		reason := "manually added"
+		if c.Reason != nil && *c.Reason != "" {
+			reason = *c.Reason
+		}
+		err = s.Cfg.ConnMgr.Ban(subnet, until, reason)
+	case btcjson.SBRemove:
+		err = s.Cfg.ConnMgr.Unban(subnet)
+	default:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "invalid subcommand for setban",
+		}
+	}
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: err.Error(),
+		}
+	}
+	return nil, nil
+}
+
 // HandleSetGenerate implements the setgenerate command.
 // TODO: this and lots of RPC needs to be revised before release
 func HandleSetGenerate(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) { // cpuminer
@@ -2704,6 +3308,31 @@ func HandleRestart(s *Server, cmd interface{}, closeChan qu.C) (
 	return nil, nil
 }
 
+// HandleReloadConfig implements the reloadconfig command.
+func HandleReloadConfig(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
+	if s.Cfg.Hooks == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "reloading the configuration is not available",
+		}
+	}
+	changes, err := s.Cfg.Hooks.Reload()
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "failed to reload the configuration: " + err.Error(),
+		}
+	}
+	result := btcjson.ReloadConfigResult{Changed: []string{}}
+	for _, c := range changes {
+		result.Changed = append(result.Changed, c.Field)
+		if c.Hook == pod.Restart {
+			result.Restart = true
+		}
+	}
+	return result, nil
+}
+
 // HandleSubmitBlock implements the submitblock command.
 func HandleSubmitBlock(s *Server, cmd interface{}, closeChan qu.C) (interface{}, error) {
 	var msg string
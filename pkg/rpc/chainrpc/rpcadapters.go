@@ -1,7 +1,10 @@
 package chainrpc
 
 import (
+	"errors"
+	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/p9c/pod/cmd/node/mempool"
 	blockchain "github.com/p9c/pod/pkg/chain"
@@ -9,6 +12,8 @@ import (
 	netsync "github.com/p9c/pod/pkg/chain/sync"
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/addrmgr"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	"github.com/p9c/pod/pkg/util"
 )
 
@@ -134,6 +139,55 @@ func (cm *ConnManager) DisconnectByAddr(addr string) error {
 	return <-replyChan
 }
 
+// Ban bans a subnet until the given time and disconnects the connected peers in it.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) Ban(subnet *net.IPNet, until time.Time, reason string) error {
+	if err := cm.server.Bans.Add(subnet, until, reason); err != nil {
+		return err
+	}
+	for _, sp := range cm.ConnectedPeers() {
+		host, _, err := net.SplitHostPort(sp.ToPeer().Addr())
+		if err != nil || !subnet.Contains(net.ParseIP(host)) {
+			continue
+		}
+		Infof("disconnecting banned peer %s", sp.ToPeer())
+		// the peer may have disconnected in the meantime
+		_ = cm.DisconnectByID(sp.ToPeer().ID())
+	}
+	return nil
+}
+
+// Unban lifts the ban of a subnet.
+//
+// Attempting to unban a subnet that is not banned will return an error.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) Unban(subnet *net.IPNet) error {
+	removed, err := cm.server.Bans.Remove(subnet)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New("subnet is not banned")
+	}
+	return nil
+}
+
+// Banned returns the bans that have not expired.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) Banned() []connmgr.Ban {
+	return cm.server.Bans.List()
+}
+
+// ClearBanned lifts all bans.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) ClearBanned() error {
+	return cm.server.Bans.Clear()
+}
+
 // ConnectedCount returns the number of currently connected peers.
 //
 // This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
@@ -148,6 +202,20 @@ func (cm *ConnManager) NetTotals() (uint64, uint64) {
 	return cm.server.NetTotals()
 }
 
+// LocalAddresses returns the local addresses that are advertised to peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) LocalAddresses() []addrmgr.LocalAddress {
+	return cm.server.AddrManager.LocalAddresses()
+}
+
+// Services returns the services offered by this node to its peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) Services() wire.ServiceFlag {
+	return cm.server.Services
+}
+
 // ConnectedPeers returns an array consisting of all connected peers.
 //
 // This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
@@ -55,6 +55,8 @@ type (
 	None struct{} 
 	// AddNodeRes is the result from a call to AddNode
 	AddNodeRes struct { Res *None; Err error }
+	// ClearBannedRes is the result from a call to ClearBanned
+	ClearBannedRes struct { Res *None; Err error }
 	// CreateRawTransactionRes is the result from a call to CreateRawTransaction
 	CreateRawTransactionRes struct { Res *string; Err error }
 	// DecodeRawTransactionRes is the result from a call to DecodeRawTransaction
@@ -87,20 +89,32 @@ type (
 	GetCFilterRes struct { Res *string; Err error }
 	// GetCFilterHeaderRes is the result from a call to GetCFilterHeader
 	GetCFilterHeaderRes struct { Res *string; Err error }
+	// GetChainTipsRes is the result from a call to GetChainTips
+	GetChainTipsRes struct { Res *[]btcjson.GetChainTipsResult; Err error }
 	// GetConnectionCountRes is the result from a call to GetConnectionCount
 	GetConnectionCountRes struct { Res *int32; Err error }
 	// GetCurrentNetRes is the result from a call to GetCurrentNet
 	GetCurrentNetRes struct { Res *string; Err error }
 	// GetDifficultyRes is the result from a call to GetDifficulty
 	GetDifficultyRes struct { Res *float64; Err error }
+	// GetForkInfoRes is the result from a call to GetForkInfo
+	GetForkInfoRes struct { Res *btcjson.GetForkInfoResult; Err error }
 	// GetGenerateRes is the result from a call to GetGenerate
 	GetGenerateRes struct { Res *bool; Err error }
 	// GetHashesPerSecRes is the result from a call to GetHashesPerSec
 	GetHashesPerSecRes struct { Res *float64; Err error }
 	// GetHeadersRes is the result from a call to GetHeaders
 	GetHeadersRes struct { Res *[]string; Err error }
+	// GetIndexInfoRes is the result from a call to GetIndexInfo
+	GetIndexInfoRes struct { Res *map[string]btcjson.GetIndexInfoResult; Err error }
 	// GetInfoRes is the result from a call to GetInfo
 	GetInfoRes struct { Res *btcjson.InfoChainResult0; Err error }
+	// GetMempoolAncestorsRes is the result from a call to GetMempoolAncestors
+	GetMempoolAncestorsRes struct { Res *[]string; Err error }
+	// GetMempoolDescendantsRes is the result from a call to GetMempoolDescendants
+	GetMempoolDescendantsRes struct { Res *[]string; Err error }
+	// GetMempoolEntryRes is the result from a call to GetMempoolEntry
+	GetMempoolEntryRes struct { Res *btcjson.GetMempoolEntryResult; Err error }
 	// GetMempoolInfoRes is the result from a call to GetMempoolInfo
 	GetMempoolInfoRes struct { Res *btcjson.GetMempoolInfoResult; Err error }
 	// GetMiningInfoRes is the result from a call to GetMiningInfo
@@ -109,6 +123,8 @@ type (
 	GetNetTotalsRes struct { Res *btcjson.GetNetTotalsResult; Err error }
 	// GetNetworkHashPSRes is the result from a call to GetNetworkHashPS
 	GetNetworkHashPSRes struct { Res *[]btcjson.GetPeerInfoResult; Err error }
+	// GetNetworkInfoRes is the result from a call to GetNetworkInfo
+	GetNetworkInfoRes struct { Res *btcjson.GetNetworkInfoResult; Err error }
 	// GetPeerInfoRes is the result from a call to GetPeerInfo
 	GetPeerInfoRes struct { Res *[]btcjson.GetPeerInfoResult; Err error }
 	// GetRawMempoolRes is the result from a call to GetRawMempool
@@ -117,12 +133,26 @@ type (
 	GetRawTransactionRes struct { Res *string; Err error }
 	// GetTxOutRes is the result from a call to GetTxOut
 	GetTxOutRes struct { Res *string; Err error }
+	// GetTxSpendingPrevOutRes is the result from a call to GetTxSpendingPrevOut
+	GetTxSpendingPrevOutRes struct { Res *[]btcjson.GetTxSpendingPrevOutResult; Err error }
+	// GetWorkerSharesRes is the result from a call to GetWorkerShares
+	GetWorkerSharesRes struct { Res *btcjson.GetWorkerSharesResult; Err error }
 	// HelpRes is the result from a call to Help
 	HelpRes struct { Res *string; Err error }
+	// InvalidateBlockRes is the result from a call to InvalidateBlock
+	InvalidateBlockRes struct { Res *None; Err error }
+	// ListBannedRes is the result from a call to ListBanned
+	ListBannedRes struct { Res *[]btcjson.ListBannedResult; Err error }
 	// NodeRes is the result from a call to Node
 	NodeRes struct { Res *None; Err error }
 	// PingRes is the result from a call to Ping
 	PingRes struct { Res *None; Err error }
+	// PreciousBlockRes is the result from a call to PreciousBlock
+	PreciousBlockRes struct { Res *None; Err error }
+	// ReconsiderBlockRes is the result from a call to ReconsiderBlock
+	ReconsiderBlockRes struct { Res *None; Err error }
+	// ReloadConfigRes is the result from a call to ReloadConfig
+	ReloadConfigRes struct { Res *btcjson.ReloadConfigResult; Err error }
 	// ResetChainRes is the result from a call to ResetChain
 	ResetChainRes struct { Res *None; Err error }
 	// RestartRes is the result from a call to Restart
@@ -131,6 +161,8 @@ type (
 	SearchRawTransactionsRes struct { Res *[]btcjson.SearchRawTransactionsResult; Err error }
 	// SendRawTransactionRes is the result from a call to SendRawTransaction
 	SendRawTransactionRes struct { Res *None; Err error }
+	// SetBanRes is the result from a call to SetBan
+	SetBanRes struct { Res *None; Err error }
 	// SetGenerateRes is the result from a call to SetGenerate
 	SetGenerateRes struct { Res *None; Err error }
 	// StopRes is the result from a call to Stop
@@ -166,6 +198,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"addnode":{ 
 		Fn: HandleAddNode, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan AddNodeRes)} }}, 
+	"clearbanned":{ 
+		Fn: HandleClearBanned, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan ClearBannedRes)} }}, 
 	"createrawtransaction":{ 
 		Fn: HandleCreateRawTransaction, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan CreateRawTransactionRes)} }}, 
@@ -214,6 +249,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getcfilterheader":{ 
 		Fn: HandleGetCFilterHeader, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetCFilterHeaderRes)} }}, 
+	"getchaintips":{ 
+		Fn: HandleGetChainTips, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetChainTipsRes)} }}, 
 	"getconnectioncount":{ 
 		Fn: HandleGetConnectionCount, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetConnectionCountRes)} }}, 
@@ -223,6 +261,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getdifficulty":{ 
 		Fn: HandleGetDifficulty, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetDifficultyRes)} }}, 
+	"getforkinfo":{ 
+		Fn: HandleGetForkInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetForkInfoRes)} }}, 
 	"getgenerate":{ 
 		Fn: HandleGetGenerate, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetGenerateRes)} }}, 
@@ -232,9 +273,21 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getheaders":{ 
 		Fn: HandleGetHeaders, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetHeadersRes)} }}, 
+	"getindexinfo":{ 
+		Fn: HandleGetIndexInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetIndexInfoRes)} }}, 
 	"getinfo":{ 
 		Fn: HandleGetInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetInfoRes)} }}, 
+	"getmempoolancestors":{ 
+		Fn: HandleGetMempoolAncestors, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMempoolAncestorsRes)} }}, 
+	"getmempooldescendants":{ 
+		Fn: HandleGetMempoolDescendants, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMempoolDescendantsRes)} }}, 
+	"getmempoolentry":{ 
+		Fn: HandleGetMempoolEntry, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetMempoolEntryRes)} }}, 
 	"getmempoolinfo":{ 
 		Fn: HandleGetMempoolInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetMempoolInfoRes)} }}, 
@@ -247,6 +300,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getnetworkhashps":{ 
 		Fn: HandleGetNetworkHashPS, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetNetworkHashPSRes)} }}, 
+	"getnetworkinfo":{ 
+		Fn: HandleGetNetworkInfo, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetNetworkInfoRes)} }}, 
 	"getpeerinfo":{ 
 		Fn: HandleGetPeerInfo, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetPeerInfoRes)} }}, 
@@ -259,15 +315,36 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"gettxout":{ 
 		Fn: HandleGetTxOut, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan GetTxOutRes)} }}, 
+	"gettxspendingprevout":{ 
+		Fn: HandleGetTxSpendingPrevOut, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetTxSpendingPrevOutRes)} }}, 
+	"getworkershares":{ 
+		Fn: HandleGetWorkerShares, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan GetWorkerSharesRes)} }}, 
 	"help":{ 
 		Fn: HandleHelp, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan HelpRes)} }}, 
+	"invalidateblock":{ 
+		Fn: HandleInvalidateBlock, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan InvalidateBlockRes)} }}, 
+	"listbanned":{ 
+		Fn: HandleListBanned, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan ListBannedRes)} }}, 
 	"node":{ 
 		Fn: HandleNode, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan NodeRes)} }}, 
 	"ping":{ 
 		Fn: HandlePing, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan PingRes)} }}, 
+	"preciousblock":{ 
+		Fn: HandlePreciousBlock, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan PreciousBlockRes)} }}, 
+	"reconsiderblock":{ 
+		Fn: HandleReconsiderBlock, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan ReconsiderBlockRes)} }}, 
+	"reloadconfig":{ 
+		Fn: HandleReloadConfig, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan ReloadConfigRes)} }}, 
 	"resetchain":{ 
 		Fn: HandleResetChain, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan ResetChainRes)} }}, 
@@ -280,6 +357,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"sendrawtransaction":{ 
 		Fn: HandleSendRawTransaction, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan SendRawTransactionRes)} }}, 
+	"setban":{ 
+		Fn: HandleSetBan, Call: make(chan API, 32), 
+		Result: func() API { return API{Ch: make(chan SetBanRes)} }}, 
 	"setgenerate":{ 
 		Fn: HandleSetGenerate, Call: make(chan API, 32), 
 		Result: func() API { return API{Ch: make(chan SetGenerateRes)} }}, 
@@ -355,6 +435,47 @@ func (a API) AddNodeWait(cmd *btcjson.AddNodeCmd) (out *None, err error) {
 	return
 }
 
+// ClearBanned calls the method with the given parameters
+func (a API) ClearBanned(cmd *None) (err error) {
+	RPCHandlers["clearbanned"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// ClearBannedCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ClearBannedCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ClearBannedRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ClearBannedGetRes returns a pointer to the value in the Result field
+func (a API) ClearBannedGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// ClearBannedWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ClearBannedWait(cmd *None) (out *None, err error) {
+	RPCHandlers["clearbanned"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan ClearBannedRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // CreateRawTransaction calls the method with the given parameters
 func (a API) CreateRawTransaction(cmd *btcjson.CreateRawTransactionCmd) (err error) {
 	RPCHandlers["createrawtransaction"].Call <-API{a.Ch, cmd, nil}
@@ -1011,6 +1132,47 @@ func (a API) GetCFilterHeaderWait(cmd *btcjson.GetCFilterHeaderCmd) (out *string
 	return
 }
 
+// GetChainTips calls the method with the given parameters
+func (a API) GetChainTips(cmd *None) (err error) {
+	RPCHandlers["getchaintips"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetChainTipsCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetChainTipsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetChainTipsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetChainTipsGetRes returns a pointer to the value in the Result field
+func (a API) GetChainTipsGetRes() (out *[]btcjson.GetChainTipsResult, err error) {
+	out, _ = a.Result.(*[]btcjson.GetChainTipsResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetChainTipsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetChainTipsWait(cmd *None) (out *[]btcjson.GetChainTipsResult, err error) {
+	RPCHandlers["getchaintips"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetChainTipsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // GetConnectionCount calls the method with the given parameters
 func (a API) GetConnectionCount(cmd *None) (err error) {
 	RPCHandlers["getconnectioncount"].Call <-API{a.Ch, cmd, nil}
@@ -1134,6 +1296,47 @@ func (a API) GetDifficultyWait(cmd *btcjson.GetDifficultyCmd) (out *float64, err
 	return
 }
 
+// GetForkInfo calls the method with the given parameters
+func (a API) GetForkInfo(cmd *btcjson.GetForkInfoCmd) (err error) {
+	RPCHandlers["getforkinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetForkInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetForkInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetForkInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetForkInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetForkInfoGetRes() (out *btcjson.GetForkInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetForkInfoResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetForkInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetForkInfoWait(cmd *btcjson.GetForkInfoCmd) (out *btcjson.GetForkInfoResult, err error) {
+	RPCHandlers["getforkinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetForkInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // GetGenerate calls the method with the given parameters
 func (a API) GetGenerate(cmd *btcjson.GetHeadersCmd) (err error) {
 	RPCHandlers["getgenerate"].Call <-API{a.Ch, cmd, nil}
@@ -1257,6 +1460,47 @@ func (a API) GetHeadersWait(cmd *btcjson.GetHeadersCmd) (out *[]string, err erro
 	return
 }
 
+// GetIndexInfo calls the method with the given parameters
+func (a API) GetIndexInfo(cmd *btcjson.GetIndexInfoCmd) (err error) {
+	RPCHandlers["getindexinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetIndexInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetIndexInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetIndexInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetIndexInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetIndexInfoGetRes() (out *map[string]btcjson.GetIndexInfoResult, err error) {
+	out, _ = a.Result.(*map[string]btcjson.GetIndexInfoResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetIndexInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetIndexInfoWait(cmd *btcjson.GetIndexInfoCmd) (out *map[string]btcjson.GetIndexInfoResult, err error) {
+	RPCHandlers["getindexinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetIndexInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // GetInfo calls the method with the given parameters
 func (a API) GetInfo(cmd *None) (err error) {
 	RPCHandlers["getinfo"].Call <-API{a.Ch, cmd, nil}
@@ -1298,6 +1542,129 @@ func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoChainResult0, err error) {
 	return
 }
 
+// GetMempoolAncestors calls the method with the given parameters
+func (a API) GetMempoolAncestors(cmd *btcjson.GetMempoolAncestorsCmd) (err error) {
+	RPCHandlers["getmempoolancestors"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMempoolAncestorsCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMempoolAncestorsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMempoolAncestorsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMempoolAncestorsGetRes returns a pointer to the value in the Result field
+func (a API) GetMempoolAncestorsGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetMempoolAncestorsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMempoolAncestorsWait(cmd *btcjson.GetMempoolAncestorsCmd) (out *[]string, err error) {
+	RPCHandlers["getmempoolancestors"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetMempoolAncestorsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetMempoolDescendants calls the method with the given parameters
+func (a API) GetMempoolDescendants(cmd *btcjson.GetMempoolDescendantsCmd) (err error) {
+	RPCHandlers["getmempooldescendants"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMempoolDescendantsCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMempoolDescendantsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMempoolDescendantsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMempoolDescendantsGetRes returns a pointer to the value in the Result field
+func (a API) GetMempoolDescendantsGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetMempoolDescendantsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMempoolDescendantsWait(cmd *btcjson.GetMempoolDescendantsCmd) (out *[]string, err error) {
+	RPCHandlers["getmempooldescendants"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetMempoolDescendantsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetMempoolEntry calls the method with the given parameters
+func (a API) GetMempoolEntry(cmd *btcjson.GetMempoolEntryCmd) (err error) {
+	RPCHandlers["getmempoolentry"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMempoolEntryCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMempoolEntryCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMempoolEntryRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMempoolEntryGetRes returns a pointer to the value in the Result field
+func (a API) GetMempoolEntryGetRes() (out *btcjson.GetMempoolEntryResult, err error) {
+	out, _ = a.Result.(*btcjson.GetMempoolEntryResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetMempoolEntryWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMempoolEntryWait(cmd *btcjson.GetMempoolEntryCmd) (out *btcjson.GetMempoolEntryResult, err error) {
+	RPCHandlers["getmempoolentry"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetMempoolEntryRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // GetMempoolInfo calls the method with the given parameters
 func (a API) GetMempoolInfo(cmd *None) (err error) {
 	RPCHandlers["getmempoolinfo"].Call <-API{a.Ch, cmd, nil}
@@ -1462,6 +1829,47 @@ func (a API) GetNetworkHashPSWait(cmd *btcjson.GetNetworkHashPSCmd) (out *[]btcj
 	return
 }
 
+// GetNetworkInfo calls the method with the given parameters
+func (a API) GetNetworkInfo(cmd *None) (err error) {
+	RPCHandlers["getnetworkinfo"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// GetNetworkInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetNetworkInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetNetworkInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetNetworkInfoGetRes() (out *btcjson.GetNetworkInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetNetworkInfoResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetNetworkInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetworkInfoWait(cmd *None) (out *btcjson.GetNetworkInfoResult, err error) {
+	RPCHandlers["getnetworkinfo"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // GetPeerInfo calls the method with the given parameters
 func (a API) GetPeerInfo(cmd *None) (err error) {
 	RPCHandlers["getpeerinfo"].Call <-API{a.Ch, cmd, nil}
@@ -1626,17 +2034,17 @@ func (a API) GetTxOutWait(cmd *btcjson.GetTxOutCmd) (out *string, err error) {
 	return
 }
 
-// Help calls the method with the given parameters
-func (a API) Help(cmd *btcjson.HelpCmd) (err error) {
-	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+// GetTxSpendingPrevOut calls the method with the given parameters
+func (a API) GetTxSpendingPrevOut(cmd *btcjson.GetTxSpendingPrevOutCmd) (err error) {
+	RPCHandlers["gettxspendingprevout"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// HelpCheck checks if a new message arrived on the result channel and 
+// GetTxSpendingPrevOutCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) HelpCheck() (isNew bool) {
+func (a API) GetTxSpendingPrevOutCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan HelpRes):
+	case o := <-a.Ch.(chan GetTxSpendingPrevOutRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1648,36 +2056,36 @@ func (a API) HelpCheck() (isNew bool) {
 	return
 }
 
-// HelpGetRes returns a pointer to the value in the Result field
-func (a API) HelpGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// GetTxSpendingPrevOutGetRes returns a pointer to the value in the Result field
+func (a API) GetTxSpendingPrevOutGetRes() (out *[]btcjson.GetTxSpendingPrevOutResult, err error) {
+	out, _ = a.Result.(*[]btcjson.GetTxSpendingPrevOutResult)
 	err, _ = a.Result.(error)
 	return 
 }
 
-// HelpWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) HelpWait(cmd *btcjson.HelpCmd) (out *string, err error) {
-	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+// GetTxSpendingPrevOutWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetTxSpendingPrevOutWait(cmd *btcjson.GetTxSpendingPrevOutCmd) (out *[]btcjson.GetTxSpendingPrevOutResult, err error) {
+	RPCHandlers["gettxspendingprevout"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan HelpRes):
+	case o := <-a.Ch.(chan GetTxSpendingPrevOutRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// Node calls the method with the given parameters
-func (a API) Node(cmd *btcjson.NodeCmd) (err error) {
-	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+// GetWorkerShares calls the method with the given parameters
+func (a API) GetWorkerShares(cmd *btcjson.GetWorkerSharesCmd) (err error) {
+	RPCHandlers["getworkershares"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
-// NodeCheck checks if a new message arrived on the result channel and 
+// GetWorkerSharesCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) NodeCheck() (isNew bool) {
+func (a API) GetWorkerSharesCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan NodeRes):
+	case o := <-a.Ch.(chan GetWorkerSharesRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1689,28 +2097,192 @@ func (a API) NodeCheck() (isNew bool) {
 	return
 }
 
-// NodeGetRes returns a pointer to the value in the Result field
-func (a API) NodeGetRes() (out *None, err error) {
-	out, _ = a.Result.(*None)
+// GetWorkerSharesGetRes returns a pointer to the value in the Result field
+func (a API) GetWorkerSharesGetRes() (out *btcjson.GetWorkerSharesResult, err error) {
+	out, _ = a.Result.(*btcjson.GetWorkerSharesResult)
 	err, _ = a.Result.(error)
 	return 
 }
 
-// NodeWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) NodeWait(cmd *btcjson.NodeCmd) (out *None, err error) {
-	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+// GetWorkerSharesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetWorkerSharesWait(cmd *btcjson.GetWorkerSharesCmd) (out *btcjson.GetWorkerSharesResult, err error) {
+	RPCHandlers["getworkershares"].Call <-API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <-a.Ch.(chan NodeRes):
+	case o := <-a.Ch.(chan GetWorkerSharesRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// Ping calls the method with the given parameters
-func (a API) Ping(cmd *None) (err error) {
-	RPCHandlers["ping"].Call <-API{a.Ch, cmd, nil}
+// Help calls the method with the given parameters
+func (a API) Help(cmd *btcjson.HelpCmd) (err error) {
+	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// HelpCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) HelpCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan HelpRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// HelpGetRes returns a pointer to the value in the Result field
+func (a API) HelpGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// HelpWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) HelpWait(cmd *btcjson.HelpCmd) (out *string, err error) {
+	RPCHandlers["help"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan HelpRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// InvalidateBlock calls the method with the given parameters
+func (a API) InvalidateBlock(cmd *btcjson.InvalidateBlockCmd) (err error) {
+	RPCHandlers["invalidateblock"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// InvalidateBlockCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) InvalidateBlockCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan InvalidateBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// InvalidateBlockGetRes returns a pointer to the value in the Result field
+func (a API) InvalidateBlockGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// InvalidateBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) InvalidateBlockWait(cmd *btcjson.InvalidateBlockCmd) (out *None, err error) {
+	RPCHandlers["invalidateblock"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan InvalidateBlockRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// ListBanned calls the method with the given parameters
+func (a API) ListBanned(cmd *None) (err error) {
+	RPCHandlers["listbanned"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// ListBannedCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ListBannedCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ListBannedRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListBannedGetRes returns a pointer to the value in the Result field
+func (a API) ListBannedGetRes() (out *[]btcjson.ListBannedResult, err error) {
+	out, _ = a.Result.(*[]btcjson.ListBannedResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// ListBannedWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListBannedWait(cmd *None) (out *[]btcjson.ListBannedResult, err error) {
+	RPCHandlers["listbanned"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan ListBannedRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// Node calls the method with the given parameters
+func (a API) Node(cmd *btcjson.NodeCmd) (err error) {
+	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// NodeCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) NodeCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan NodeRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// NodeGetRes returns a pointer to the value in the Result field
+func (a API) NodeGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// NodeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) NodeWait(cmd *btcjson.NodeCmd) (out *None, err error) {
+	RPCHandlers["node"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan NodeRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// Ping calls the method with the given parameters
+func (a API) Ping(cmd *None) (err error) {
+	RPCHandlers["ping"].Call <-API{a.Ch, cmd, nil}
 	return
 }
 
@@ -1749,6 +2321,129 @@ func (a API) PingWait(cmd *None) (out *None, err error) {
 	return
 }
 
+// PreciousBlock calls the method with the given parameters
+func (a API) PreciousBlock(cmd *btcjson.PreciousBlockCmd) (err error) {
+	RPCHandlers["preciousblock"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// PreciousBlockCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) PreciousBlockCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan PreciousBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// PreciousBlockGetRes returns a pointer to the value in the Result field
+func (a API) PreciousBlockGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// PreciousBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PreciousBlockWait(cmd *btcjson.PreciousBlockCmd) (out *None, err error) {
+	RPCHandlers["preciousblock"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan PreciousBlockRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// ReconsiderBlock calls the method with the given parameters
+func (a API) ReconsiderBlock(cmd *btcjson.ReconsiderBlockCmd) (err error) {
+	RPCHandlers["reconsiderblock"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// ReconsiderBlockCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ReconsiderBlockCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ReconsiderBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ReconsiderBlockGetRes returns a pointer to the value in the Result field
+func (a API) ReconsiderBlockGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// ReconsiderBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ReconsiderBlockWait(cmd *btcjson.ReconsiderBlockCmd) (out *None, err error) {
+	RPCHandlers["reconsiderblock"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan ReconsiderBlockRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// ReloadConfig calls the method with the given parameters
+func (a API) ReloadConfig(cmd *None) (err error) {
+	RPCHandlers["reloadconfig"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// ReloadConfigCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ReloadConfigCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ReloadConfigRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ReloadConfigGetRes returns a pointer to the value in the Result field
+func (a API) ReloadConfigGetRes() (out *btcjson.ReloadConfigResult, err error) {
+	out, _ = a.Result.(*btcjson.ReloadConfigResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// ReloadConfigWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ReloadConfigWait(cmd *None) (out *btcjson.ReloadConfigResult, err error) {
+	RPCHandlers["reloadconfig"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan ReloadConfigRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // ResetChain calls the method with the given parameters
 func (a API) ResetChain(cmd *None) (err error) {
 	RPCHandlers["resetchain"].Call <-API{a.Ch, cmd, nil}
@@ -1913,6 +2608,47 @@ func (a API) SendRawTransactionWait(cmd *btcjson.SendRawTransactionCmd) (out *No
 	return
 }
 
+// SetBan calls the method with the given parameters
+func (a API) SetBan(cmd *btcjson.SetBanCmd) (err error) {
+	RPCHandlers["setban"].Call <-API{a.Ch, cmd, nil}
+	return
+}
+
+// SetBanCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SetBanCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SetBanRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetBanGetRes returns a pointer to the value in the Result field
+func (a API) SetBanGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// SetBanWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetBanWait(cmd *btcjson.SetBanCmd) (out *None, err error) {
+	RPCHandlers["setban"].Call <-API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <-a.Ch.(chan SetBanRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // SetGenerate calls the method with the given parameters
 func (a API) SetGenerate(cmd *btcjson.SetGenerateCmd) (err error) {
 	RPCHandlers["setgenerate"].Call <-API{a.Ch, cmd, nil}
@@ -2259,6 +2995,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan AddNodeRes) <-AddNodeRes{&r, err} } 
+			case msg := <-nrh["clearbanned"].Call:
+				if res, err = nrh["clearbanned"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ClearBannedRes) <-ClearBannedRes{&r, err} } 
 			case msg := <-nrh["createrawtransaction"].Call:
 				if res, err = nrh["createrawtransaction"].
 					Fn(server, msg.Params.(*btcjson.CreateRawTransactionCmd), nil); Check(err) {
@@ -2355,6 +3097,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan GetCFilterHeaderRes) <-GetCFilterHeaderRes{&r, err} } 
+			case msg := <-nrh["getchaintips"].Call:
+				if res, err = nrh["getchaintips"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.GetChainTipsResult); ok { 
+					msg.Ch.(chan GetChainTipsRes) <-GetChainTipsRes{&r, err} } 
 			case msg := <-nrh["getconnectioncount"].Call:
 				if res, err = nrh["getconnectioncount"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2373,6 +3121,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(float64); ok { 
 					msg.Ch.(chan GetDifficultyRes) <-GetDifficultyRes{&r, err} } 
+			case msg := <-nrh["getforkinfo"].Call:
+				if res, err = nrh["getforkinfo"].
+					Fn(server, msg.Params.(*btcjson.GetForkInfoCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetForkInfoResult); ok { 
+					msg.Ch.(chan GetForkInfoRes) <-GetForkInfoRes{&r, err} } 
 			case msg := <-nrh["getgenerate"].Call:
 				if res, err = nrh["getgenerate"].
 					Fn(server, msg.Params.(*btcjson.GetHeadersCmd), nil); Check(err) {
@@ -2391,12 +3145,36 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.([]string); ok { 
 					msg.Ch.(chan GetHeadersRes) <-GetHeadersRes{&r, err} } 
+			case msg := <-nrh["getindexinfo"].Call:
+				if res, err = nrh["getindexinfo"].
+					Fn(server, msg.Params.(*btcjson.GetIndexInfoCmd), nil); Check(err) {
+				}
+				if r, ok := res.(map[string]btcjson.GetIndexInfoResult); ok { 
+					msg.Ch.(chan GetIndexInfoRes) <-GetIndexInfoRes{&r, err} } 
 			case msg := <-nrh["getinfo"].Call:
 				if res, err = nrh["getinfo"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
 				}
 				if r, ok := res.(btcjson.InfoChainResult0); ok { 
 					msg.Ch.(chan GetInfoRes) <-GetInfoRes{&r, err} } 
+			case msg := <-nrh["getmempoolancestors"].Call:
+				if res, err = nrh["getmempoolancestors"].
+					Fn(server, msg.Params.(*btcjson.GetMempoolAncestorsCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]string); ok { 
+					msg.Ch.(chan GetMempoolAncestorsRes) <-GetMempoolAncestorsRes{&r, err} } 
+			case msg := <-nrh["getmempooldescendants"].Call:
+				if res, err = nrh["getmempooldescendants"].
+					Fn(server, msg.Params.(*btcjson.GetMempoolDescendantsCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]string); ok { 
+					msg.Ch.(chan GetMempoolDescendantsRes) <-GetMempoolDescendantsRes{&r, err} } 
+			case msg := <-nrh["getmempoolentry"].Call:
+				if res, err = nrh["getmempoolentry"].
+					Fn(server, msg.Params.(*btcjson.GetMempoolEntryCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetMempoolEntryResult); ok { 
+					msg.Ch.(chan GetMempoolEntryRes) <-GetMempoolEntryRes{&r, err} } 
 			case msg := <-nrh["getmempoolinfo"].Call:
 				if res, err = nrh["getmempoolinfo"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2421,6 +3199,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.([]btcjson.GetPeerInfoResult); ok { 
 					msg.Ch.(chan GetNetworkHashPSRes) <-GetNetworkHashPSRes{&r, err} } 
+			case msg := <-nrh["getnetworkinfo"].Call:
+				if res, err = nrh["getnetworkinfo"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetNetworkInfoResult); ok { 
+					msg.Ch.(chan GetNetworkInfoRes) <-GetNetworkInfoRes{&r, err} } 
 			case msg := <-nrh["getpeerinfo"].Call:
 				if res, err = nrh["getpeerinfo"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2445,12 +3229,36 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan GetTxOutRes) <-GetTxOutRes{&r, err} } 
+			case msg := <-nrh["gettxspendingprevout"].Call:
+				if res, err = nrh["gettxspendingprevout"].
+					Fn(server, msg.Params.(*btcjson.GetTxSpendingPrevOutCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.GetTxSpendingPrevOutResult); ok { 
+					msg.Ch.(chan GetTxSpendingPrevOutRes) <-GetTxSpendingPrevOutRes{&r, err} } 
+			case msg := <-nrh["getworkershares"].Call:
+				if res, err = nrh["getworkershares"].
+					Fn(server, msg.Params.(*btcjson.GetWorkerSharesCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetWorkerSharesResult); ok { 
+					msg.Ch.(chan GetWorkerSharesRes) <-GetWorkerSharesRes{&r, err} } 
 			case msg := <-nrh["help"].Call:
 				if res, err = nrh["help"].
 					Fn(server, msg.Params.(*btcjson.HelpCmd), nil); Check(err) {
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan HelpRes) <-HelpRes{&r, err} } 
+			case msg := <-nrh["invalidateblock"].Call:
+				if res, err = nrh["invalidateblock"].
+					Fn(server, msg.Params.(*btcjson.InvalidateBlockCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan InvalidateBlockRes) <-InvalidateBlockRes{&r, err} } 
+			case msg := <-nrh["listbanned"].Call:
+				if res, err = nrh["listbanned"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.ListBannedResult); ok { 
+					msg.Ch.(chan ListBannedRes) <-ListBannedRes{&r, err} } 
 			case msg := <-nrh["node"].Call:
 				if res, err = nrh["node"].
 					Fn(server, msg.Params.(*btcjson.NodeCmd), nil); Check(err) {
@@ -2463,6 +3271,24 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan PingRes) <-PingRes{&r, err} } 
+			case msg := <-nrh["preciousblock"].Call:
+				if res, err = nrh["preciousblock"].
+					Fn(server, msg.Params.(*btcjson.PreciousBlockCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan PreciousBlockRes) <-PreciousBlockRes{&r, err} } 
+			case msg := <-nrh["reconsiderblock"].Call:
+				if res, err = nrh["reconsiderblock"].
+					Fn(server, msg.Params.(*btcjson.ReconsiderBlockCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ReconsiderBlockRes) <-ReconsiderBlockRes{&r, err} } 
+			case msg := <-nrh["reloadconfig"].Call:
+				if res, err = nrh["reloadconfig"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.ReloadConfigResult); ok { 
+					msg.Ch.(chan ReloadConfigRes) <-ReloadConfigRes{&r, err} } 
 			case msg := <-nrh["resetchain"].Call:
 				if res, err = nrh["resetchain"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2487,6 +3313,12 @@ func RunAPI(server *Server, quit qu.C) {
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan SendRawTransactionRes) <-SendRawTransactionRes{&r, err} } 
+			case msg := <-nrh["setban"].Call:
+				if res, err = nrh["setban"].
+					Fn(server, msg.Params.(*btcjson.SetBanCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan SetBanRes) <-SetBanRes{&r, err} } 
 			case msg := <-nrh["setgenerate"].Call:
 				if res, err = nrh["setgenerate"].
 					Fn(server, msg.Params.(*btcjson.SetGenerateCmd), nil); Check(err) {
@@ -2558,6 +3390,19 @@ func (c *CAPI) AddNode(req *btcjson.AddNodeCmd, resp None) (err error) {
 	return 
 }
 
+func (c *CAPI) ClearBanned(req *None, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["clearbanned"].Result()
+	res.Params = req
+	nrh["clearbanned"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) CreateRawTransaction(req *btcjson.CreateRawTransactionCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["createrawtransaction"].Result()
@@ -2744,7 +3589,20 @@ func (c *CAPI) GetCFilter(req *btcjson.GetCFilterCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getcfilter"].Result()
 	res.Params = req
-	nrh["getcfilter"].Call <- res
+	nrh["getcfilter"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetCFilterHeader(req *btcjson.GetCFilterHeaderCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getcfilterheader"].Result()
+	res.Params = req
+	nrh["getcfilterheader"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan string):
 	case <-time.After(c.Timeout):
@@ -2753,13 +3611,13 @@ func (c *CAPI) GetCFilter(req *btcjson.GetCFilterCmd, resp string) (err error) {
 	return 
 }
 
-func (c *CAPI) GetCFilterHeader(req *btcjson.GetCFilterHeaderCmd, resp string) (err error) {
+func (c *CAPI) GetChainTips(req *None, resp []btcjson.GetChainTipsResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["getcfilterheader"].Result()
+	res := nrh["getchaintips"].Result()
 	res.Params = req
-	nrh["getcfilterheader"].Call <- res
+	nrh["getchaintips"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan []btcjson.GetChainTipsResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
@@ -2805,6 +3663,19 @@ func (c *CAPI) GetDifficulty(req *btcjson.GetDifficultyCmd, resp float64) (err e
 	return 
 }
 
+func (c *CAPI) GetForkInfo(req *btcjson.GetForkInfoCmd, resp btcjson.GetForkInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getforkinfo"].Result()
+	res.Params = req
+	nrh["getforkinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetForkInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetGenerate(req *btcjson.GetHeadersCmd, resp bool) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getgenerate"].Result()
@@ -2844,6 +3715,19 @@ func (c *CAPI) GetHeaders(req *btcjson.GetHeadersCmd, resp []string) (err error)
 	return 
 }
 
+func (c *CAPI) GetIndexInfo(req *btcjson.GetIndexInfoCmd, resp map[string]btcjson.GetIndexInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getindexinfo"].Result()
+	res.Params = req
+	nrh["getindexinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan map[string]btcjson.GetIndexInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetInfo(req *None, resp btcjson.InfoChainResult0) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getinfo"].Result()
@@ -2857,6 +3741,45 @@ func (c *CAPI) GetInfo(req *None, resp btcjson.InfoChainResult0) (err error) {
 	return 
 }
 
+func (c *CAPI) GetMempoolAncestors(req *btcjson.GetMempoolAncestorsCmd, resp []string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getmempoolancestors"].Result()
+	res.Params = req
+	nrh["getmempoolancestors"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetMempoolDescendants(req *btcjson.GetMempoolDescendantsCmd, resp []string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getmempooldescendants"].Result()
+	res.Params = req
+	nrh["getmempooldescendants"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetMempoolEntry(req *btcjson.GetMempoolEntryCmd, resp btcjson.GetMempoolEntryResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getmempoolentry"].Result()
+	res.Params = req
+	nrh["getmempoolentry"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetMempoolEntryResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetMempoolInfo(req *None, resp btcjson.GetMempoolInfoResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getmempoolinfo"].Result()
@@ -2909,6 +3832,19 @@ func (c *CAPI) GetNetworkHashPS(req *btcjson.GetNetworkHashPSCmd, resp []btcjson
 	return 
 }
 
+func (c *CAPI) GetNetworkInfo(req *None, resp btcjson.GetNetworkInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getnetworkinfo"].Result()
+	res.Params = req
+	nrh["getnetworkinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetNetworkInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetPeerInfo(req *None, resp []btcjson.GetPeerInfoResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getpeerinfo"].Result()
@@ -2961,6 +3897,32 @@ func (c *CAPI) GetTxOut(req *btcjson.GetTxOutCmd, resp string) (err error) {
 	return 
 }
 
+func (c *CAPI) GetTxSpendingPrevOut(req *btcjson.GetTxSpendingPrevOutCmd, resp []btcjson.GetTxSpendingPrevOutResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["gettxspendingprevout"].Result()
+	res.Params = req
+	nrh["gettxspendingprevout"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.GetTxSpendingPrevOutResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) GetWorkerShares(req *btcjson.GetWorkerSharesCmd, resp btcjson.GetWorkerSharesResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getworkershares"].Result()
+	res.Params = req
+	nrh["getworkershares"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetWorkerSharesResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) Help(req *btcjson.HelpCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["help"].Result()
@@ -2974,6 +3936,32 @@ func (c *CAPI) Help(req *btcjson.HelpCmd, resp string) (err error) {
 	return 
 }
 
+func (c *CAPI) InvalidateBlock(req *btcjson.InvalidateBlockCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["invalidateblock"].Result()
+	res.Params = req
+	nrh["invalidateblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ListBanned(req *None, resp []btcjson.ListBannedResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["listbanned"].Result()
+	res.Params = req
+	nrh["listbanned"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.ListBannedResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) Node(req *btcjson.NodeCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["node"].Result()
@@ -3000,6 +3988,45 @@ func (c *CAPI) Ping(req *None, resp None) (err error) {
 	return 
 }
 
+func (c *CAPI) PreciousBlock(req *btcjson.PreciousBlockCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["preciousblock"].Result()
+	res.Params = req
+	nrh["preciousblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ReconsiderBlock(req *btcjson.ReconsiderBlockCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["reconsiderblock"].Result()
+	res.Params = req
+	nrh["reconsiderblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) ReloadConfig(req *None, resp btcjson.ReloadConfigResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["reloadconfig"].Result()
+	res.Params = req
+	nrh["reloadconfig"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.ReloadConfigResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) ResetChain(req *None, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["resetchain"].Result()
@@ -3052,6 +4079,19 @@ func (c *CAPI) SendRawTransaction(req *btcjson.SendRawTransactionCmd, resp None)
 	return 
 }
 
+func (c *CAPI) SetBan(req *btcjson.SetBanCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["setban"].Result()
+	res.Params = req
+	nrh["setban"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) SetGenerate(req *btcjson.SetGenerateCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["setgenerate"].Result()
@@ -3168,6 +4208,16 @@ func (r *CAPIClient) AddNode(cmd ...*btcjson.AddNodeCmd) (res None, err error) {
 	return
 }
 
+func (r *CAPIClient) ClearBanned(cmd ...*None) (res None, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ClearBanned", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) CreateRawTransaction(cmd ...*btcjson.CreateRawTransactionCmd) (res string, err error) {
 	var c *btcjson.CreateRawTransactionCmd
 	if len(cmd) > 0 {
@@ -3328,6 +4378,16 @@ func (r *CAPIClient) GetCFilterHeader(cmd ...*btcjson.GetCFilterHeaderCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetChainTips(cmd ...*None) (res []btcjson.GetChainTipsResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetChainTips", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetConnectionCount(cmd ...*None) (res int32, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3358,6 +4418,16 @@ func (r *CAPIClient) GetDifficulty(cmd ...*btcjson.GetDifficultyCmd) (res float6
 	return
 }
 
+func (r *CAPIClient) GetForkInfo(cmd ...*btcjson.GetForkInfoCmd) (res btcjson.GetForkInfoResult, err error) {
+	var c *btcjson.GetForkInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetForkInfo", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetGenerate(cmd ...*btcjson.GetHeadersCmd) (res bool, err error) {
 	var c *btcjson.GetHeadersCmd
 	if len(cmd) > 0 {
@@ -3388,6 +4458,16 @@ func (r *CAPIClient) GetHeaders(cmd ...*btcjson.GetHeadersCmd) (res []string, er
 	return
 }
 
+func (r *CAPIClient) GetIndexInfo(cmd ...*btcjson.GetIndexInfoCmd) (res map[string]btcjson.GetIndexInfoResult, err error) {
+	var c *btcjson.GetIndexInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetIndexInfo", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetInfo(cmd ...*None) (res btcjson.InfoChainResult0, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3398,6 +4478,36 @@ func (r *CAPIClient) GetInfo(cmd ...*None) (res btcjson.InfoChainResult0, err er
 	return
 }
 
+func (r *CAPIClient) GetMempoolAncestors(cmd ...*btcjson.GetMempoolAncestorsCmd) (res []string, err error) {
+	var c *btcjson.GetMempoolAncestorsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetMempoolAncestors", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetMempoolDescendants(cmd ...*btcjson.GetMempoolDescendantsCmd) (res []string, err error) {
+	var c *btcjson.GetMempoolDescendantsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetMempoolDescendants", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetMempoolEntry(cmd ...*btcjson.GetMempoolEntryCmd) (res btcjson.GetMempoolEntryResult, err error) {
+	var c *btcjson.GetMempoolEntryCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetMempoolEntry", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetMempoolInfo(cmd ...*None) (res btcjson.GetMempoolInfoResult, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3438,6 +4548,16 @@ func (r *CAPIClient) GetNetworkHashPS(cmd ...*btcjson.GetNetworkHashPSCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetNetworkInfo(cmd ...*None) (res btcjson.GetNetworkInfoResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetNetworkInfo", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetPeerInfo(cmd ...*None) (res []btcjson.GetPeerInfoResult, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3478,6 +4598,26 @@ func (r *CAPIClient) GetTxOut(cmd ...*btcjson.GetTxOutCmd) (res string, err erro
 	return
 }
 
+func (r *CAPIClient) GetTxSpendingPrevOut(cmd ...*btcjson.GetTxSpendingPrevOutCmd) (res []btcjson.GetTxSpendingPrevOutResult, err error) {
+	var c *btcjson.GetTxSpendingPrevOutCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetTxSpendingPrevOut", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetWorkerShares(cmd ...*btcjson.GetWorkerSharesCmd) (res btcjson.GetWorkerSharesResult, err error) {
+	var c *btcjson.GetWorkerSharesCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetWorkerShares", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) Help(cmd ...*btcjson.HelpCmd) (res string, err error) {
 	var c *btcjson.HelpCmd
 	if len(cmd) > 0 {
@@ -3488,6 +4628,26 @@ func (r *CAPIClient) Help(cmd ...*btcjson.HelpCmd) (res string, err error) {
 	return
 }
 
+func (r *CAPIClient) InvalidateBlock(cmd ...*btcjson.InvalidateBlockCmd) (res None, err error) {
+	var c *btcjson.InvalidateBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.InvalidateBlock", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ListBanned(cmd ...*None) (res []btcjson.ListBannedResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ListBanned", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) Node(cmd ...*btcjson.NodeCmd) (res None, err error) {
 	var c *btcjson.NodeCmd
 	if len(cmd) > 0 {
@@ -3508,6 +4668,36 @@ func (r *CAPIClient) Ping(cmd ...*None) (res None, err error) {
 	return
 }
 
+func (r *CAPIClient) PreciousBlock(cmd ...*btcjson.PreciousBlockCmd) (res None, err error) {
+	var c *btcjson.PreciousBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.PreciousBlock", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ReconsiderBlock(cmd ...*btcjson.ReconsiderBlockCmd) (res None, err error) {
+	var c *btcjson.ReconsiderBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ReconsiderBlock", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ReloadConfig(cmd ...*None) (res btcjson.ReloadConfigResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ReloadConfig", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) ResetChain(cmd ...*None) (res None, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3548,6 +4738,16 @@ func (r *CAPIClient) SendRawTransaction(cmd ...*btcjson.SendRawTransactionCmd) (
 	return
 }
 
+func (r *CAPIClient) SetBan(cmd ...*btcjson.SetBanCmd) (res None, err error) {
+	var c *btcjson.SetBanCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.SetBan", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) SetGenerate(cmd ...*btcjson.SetGenerateCmd) (res None, err error) {
 	var c *btcjson.SetGenerateCmd
 	if len(cmd) > 0 {
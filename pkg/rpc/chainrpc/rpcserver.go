@@ -38,6 +38,8 @@ import (
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	p "github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/addrmgr"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
@@ -147,17 +149,25 @@ type ServerConfig struct {
 	// CPUMiner  *cpuminer.CPUMiner
 	//
 	// These fields define any optional indexes the RPC server can make use of to provide additional data when queried.
-	TxIndex   *indexers.TxIndex
-	AddrIndex *indexers.AddrIndex
-	CfIndex   *indexers.CFIndex
+	TxIndex    *indexers.TxIndex
+	AddrIndex  *indexers.AddrIndex
+	CfIndex    *indexers.CFIndex
+	SpendIndex *indexers.SpendIndex
+	// IndexManager reports whether the optional indexes have caught up with the chain.
+	IndexManager *indexers.Manager
 	// The fee estimator keeps track of how long transactions are left in the mempool before they are mined into blocks.
 	FeeEstimator *mempool.FeeEstimator
+	// ShareLedger holds the *ledger.Ledger recording the shares found by the miners of the kopach controller, if it is
+	// running. The controller stores it after the RPC server has started, so it is read atomically.
+	ShareLedger uberatomic.Value
 	// Algo sets the algorithm expected from the RPC endpoint. This allows multiple ports to serve multiple types of
 	// miners with one main node per algorithm. Currently 514 for Scrypt and anything else passes for SHA256d.
 	Algo string
 	// CPUMiner *exec.Cmd
 	Hashrate uberatomic.Uint64
-	Quit     qu.C
+	// Hooks reloads the configuration for the reloadconfig command.
+	Hooks *pod.Hooks
+	Quit  qu.C
 }
 
 // ServerConnManager represents a connection manager for use with the RPC server. The interface contract requires that
@@ -185,10 +195,24 @@ type ServerConnManager interface {
 	//
 	// Attempting to remove an address that does not exist will return an error.
 	DisconnectByAddr(addr string) error
+	// Ban bans a subnet until the given time and disconnects the connected peers in it.
+	Ban(subnet *net.IPNet, until time.Time, reason string) error
+	// Unban lifts the ban of a subnet.
+	//
+	// Attempting to unban a subnet that is not banned will return an error.
+	Unban(subnet *net.IPNet) error
+	// Banned returns the bans that have not expired.
+	Banned() []connmgr.Ban
+	// ClearBanned lifts all bans.
+	ClearBanned() error
 	// ConnectedCount returns the number of currently connected peers.
 	ConnectedCount() int32
 	// NetTotals returns the sum of all bytes received and sent across the network for all peers.
 	NetTotals() (uint64, uint64)
+	// LocalAddresses returns the local addresses that are advertised to peers.
+	LocalAddresses() []addrmgr.LocalAddress
+	// Services returns the services offered by this node to its peers.
+	Services() wire.ServiceFlag
 	// ConnectedPeers returns an array consisting of all connected peers.
 	ConnectedPeers() []ServerPeer
 	// PersistentPeers returns an array consisting of all the persistent peers.
@@ -659,15 +683,24 @@ var (
 		"getblockheader":        {},
 		"getcfilter":            {},
 		"getcfilterheader":      {},
+		"getchaintips":          {},
 		"getcurrentnet":         {},
 		"getdifficulty":         {},
+		"getforkinfo":           {},
 		"getheaders":            {},
+		"getindexinfo":          {},
 		"getinfo":               {},
+		"getmempoolancestors":   {},
+		"getmempooldescendants": {},
+		"getmempoolentry":       {},
 		"getnettotals":          {},
 		"getnetworkhashps":      {},
+		"getnetworkinfo":        {},
 		"getrawmempool":         {},
 		"getrawtransaction":     {},
 		"gettxout":              {},
+		"gettxspendingprevout":  {},
+		"getworkershares":       {},
 		"searchrawtransactions": {},
 		"sendrawtransaction":    {},
 		"submitblock":           {},
@@ -679,13 +712,7 @@ var (
 	// RPCUnimplemented is commands that are currently unimplemented, but should ultimately be.
 	RPCUnimplemented = map[string]struct{}{
 		"estimatepriority": {},
-		"getchaintips":     {},
-		"getmempoolentry":  {},
-		"getnetworkinfo":   {},
 		"getwork":          {},
-		"invalidateblock":  {},
-		"preciousblock":    {},
-		"reconsiderblock":  {},
 	}
 )
 
@@ -2041,6 +2068,29 @@ func JSONAuthFail(w http.ResponseWriter) {
 	http.Error(w, "401 Unauthorized.", http.StatusUnauthorized)
 }
 
+// MempoolPackageResult converts a set of memory pool transactions, such as the ancestors or descendants of a
+// transaction, into the result for the getmempoolancestors and getmempooldescendants commands. This is a list of
+// transaction hashes, or a map of hashes to mempool entries when verbose is set.
+func MempoolPackageResult(s *Server, descs []*mempool.TxDesc, verbose bool) interface{} {
+	if !verbose {
+		hashStrings := make([]string, len(descs))
+		for i := range descs {
+			hashStrings[i] = descs[i].Tx.Hash().String()
+		}
+		return hashStrings
+	}
+	result := make(map[string]*btcjson.GetMempoolEntryResult, len(descs))
+	for _, desc := range descs {
+		// The transaction may have been mined or evicted since the package was collected, in which case it is skipped.
+		entry, err := s.Cfg.TxMemPool.MempoolEntry(desc.Tx.Hash())
+		if err != nil {
+			continue
+		}
+		result[desc.Tx.Hash().String()] = entry
+	}
+	return result
+}
+
 // MessageToHex serializes a message to the wire protocol encoding using the latest protocol version and returns a
 // hex-encoded string of the result.
 func MessageToHex(msg wire.Message) (string, error) {
@@ -2159,6 +2209,25 @@ func NoTxInfoError(txHash *chainhash.Hash) *btcjson.RPCError {
 	)
 }
 
+// IndexSyncingError is a convenience function for returning a nicely formatted RPC error which indicates the provided
+// index is still catching up with the chain. It returns nil when the index is ready to be used.
+func IndexSyncingError(s *Server, indexer indexers.Indexer) *btcjson.RPCError {
+	if s.Cfg.IndexManager == nil {
+		return nil
+	}
+	status, ok := s.Cfg.IndexManager.IndexStatus(indexer)
+	if !ok || status.Synced {
+		return nil
+	}
+	return btcjson.NewRPCError(
+		btcjson.ErrRPCClientInInitialDownload,
+		fmt.Sprintf(
+			"%s syncing, height %d of %d",
+			status.Name, status.Height, s.Cfg.Chain.BestSnapshot().Height,
+		),
+	)
+}
+
 // SoftForkStatus converts a ThresholdState state into a human readable string corresponding to the particular state.
 func SoftForkStatus(state blockchain.ThresholdState) (string, error) {
 	switch state {
@@ -2177,6 +2246,12 @@ func SoftForkStatus(state blockchain.ThresholdState) (string, error) {
 	}
 }
 
+// TxNotInMempoolError is a convenience function for returning a nicely formatted RPC error which indicates a
+// transaction is not in the memory pool.
+func TxNotInMempoolError() *btcjson.RPCError {
+	return btcjson.NewRPCError(btcjson.ErrRPCNoTxInfo, "Transaction not in mempool")
+}
+
 // VerifyChain does?
 func VerifyChain(s *Server, level, depth int32) error {
 	best := s.Cfg.Chain.BestSnapshot()
@@ -36,6 +36,8 @@ var HelpDescsEnUS = map[string]string{
 	"node-target": "Either the IP address and port of the peer to" +
 		" operate on, or a valid peer ID.",
 	"node-connectsubcmd": "'perm' to make the connected peer a permanent one, 'temp' to try a single connect to a peer",
+	// ClearBannedCmd help.
+	"clearbanned--synopsis": "Lifts all bans of peers and subnets.",
 	// TransactionInput help.
 	"transactioninput-txid": "The hash of the input transaction",
 	"transactioninput-vout": "The specific output of the input transaction to redeem",
@@ -358,16 +360,26 @@ var HelpDescsEnUS = map[string]string{
 
 	// GetCFilterCmd help.
 	"getcfilter--synopsis":  "Returns a block's committed filter given its hash.",
-	"getcfilter-filtertype": "The type of filter to return (0=regular)",
+	"getcfilter-filtertype": "The type of filter to return (0=regular, 1=extended)",
 	"getcfilter-hash":       "The hash of the block",
 	"getcfilter--result0":   "The block's committed filter",
 
 	// GetCFilterHeaderCmd help.
 	"getcfilterheader--synopsis":  "Returns a block's compact filter header given its hash.",
-	"getcfilterheader-filtertype": "The type of filter header to return (0=regular)",
+	"getcfilterheader-filtertype": "The type of filter header to return (0=regular, 1=extended)",
 	"getcfilterheader-hash":       "The hash of the block",
 	"getcfilterheader--result0":   "The block's gcs filter header",
 
+	// GetChainTipsCmd help.
+	"getchaintips--synopsis": "Returns information about all known tips in the block tree, including the main chain as well as orphaned branches.",
+
+	// GetChainTipsResult help.
+	"getchaintipsresult-height":    "The height of the chain tip",
+	"getchaintipsresult-hash":      "The block hash of the chain tip",
+	"getchaintipsresult-branchlen": "Length of the branch connecting the tip to the main chain (zero for the main chain)",
+	"getchaintipsresult-pow_algo":  "The proof-of-work algorithm of the tip block",
+	"getchaintipsresult-status":    "Status of the chain (active, valid-fork, valid-headers, headers-only, invalid)",
+
 	// GetConnectionCountCmd help.
 	"getconnectioncount--synopsis": "Returns the number of active connections to other peers.",
 	"getconnectioncount--result0":  "The number of connections",
@@ -380,6 +392,42 @@ var HelpDescsEnUS = map[string]string{
 	"getdifficulty--synopsis": "Returns the proof-of-work difficulty as a multiple of the minimum difficulty.",
 	"getdifficulty--result0":  "The difficulty",
 
+	// GetForkInfoCmd help.
+	"getforkinfo--synopsis": "Returns the hard fork schedule, disbursement and blacklist in force, and which hard fork is active at the current height.",
+
+	// GetForkInfoResult help.
+	"getforkinforesult-network":     "The network the schedule is for",
+	"getforkinforesult-version":     "The version of the schedule format",
+	"getforkinforesult-checksum":    "The checksum of the schedule",
+	"getforkinforesult-file":        "The file the schedule was loaded from, empty if it is compiled in",
+	"getforkinforesult-height":      "The height of the best block",
+	"getforkinforesult-currentfork": "The number of the hard fork active at the current height",
+	"getforkinforesult-forks":       "The hard forks and when they activate",
+	"getforkinforesult-payees":      "The payees of the hard fork disbursement",
+	"getforkinforesult-corepubkeys": "The public keys of the multisig paid by the hard fork disbursement",
+	"getforkinforesult-coreamount":  "The amount in DUO paid to the multisig",
+	"getforkinforesult-blacklist":   "The addresses that are suspended",
+
+	// ForkInfoResult help.
+	"forkinforesult-number":             "The number of the hard fork",
+	"forkinforesult-name":               "The name of the hard fork",
+	"forkinforesult-activationheight":   "The height the hard fork activates at",
+	"forkinforesult-targettimeperblock": "The target number of seconds between blocks",
+	"forkinforesult-averaginginterval":  "The number of blocks the difficulty adjustment averages over",
+	"forkinforesult-active":             "Whether this is the hard fork active at the current height",
+	"forkinforesult-algos":              "The mining algorithms of the hard fork",
+
+	// ForkAlgoResult help.
+	"forkalgoresult-name":            "The name of the algorithm",
+	"forkalgoresult-version":         "The block version of the algorithm",
+	"forkalgoresult-minbits":         "The minimum difficulty of the algorithm in compact form",
+	"forkalgoresult-algoid":          "The algorithm identifier",
+	"forkalgoresult-versioninterval": "The target number of seconds between blocks of the algorithm",
+
+	// ForkPayeeResult help.
+	"forkpayeeresult-address": "The address of the payee",
+	"forkpayeeresult-amount":  "The amount in DUO paid",
+
 	// GetGenerateCmd help.
 	"getgenerate--synopsis": "Returns if the server is set to generate coins (mine) or not.",
 	"getgenerate--result0":  "True if mining, false if not",
@@ -424,9 +472,58 @@ var HelpDescsEnUS = map[string]string{
 	"getheaders-hashstop":      "Block hash to stop including block headers for; if not found, all headers to the latest known block are returned.",
 	"getheaders--result0":      "Serialized block headers of all located blocks, limited to some arbitrary maximum number of hashes (currently 2000, which matches the wire protocol headers message, but this is not guaranteed)",
 
+	// GetIndexInfoCmd help.
+	"getindexinfo--synopsis":       "Returns how far each of the optional indexes has got through the chain. An index that is not synced is still catching up in the background and can't be used until it is.",
+	"getindexinfo-indexname":       "Only return the status of the index with this name",
+	"getindexinfo--result0--desc":  "The status of each index keyed by its name",
+	"getindexinfo--result0--key":   "The name of the index",
+	"getindexinfo--result0--value": "Object containing the status of the index",
+
+	// GetIndexInfoResult help.
+	"getindexinforesult-synced":            "Whether the index has caught up with the chain",
+	"getindexinforesult-best_block_height": "The height of the last block added to the index",
+
 	// GetInfoCmd help.
 	"getinfo--synopsis": "Returns a JSON object containing various state info.",
 
+	// GetMempoolAncestorsCmd help.
+	"getmempoolancestors--synopsis":   "Returns all the in-mempool ancestors of a transaction in the memory pool.",
+	"getmempoolancestors-txid":        "The hash of the transaction",
+	"getmempoolancestors-verbose":     "Returns JSON object when true or an array of transaction hashes when false",
+	"getmempoolancestors--condition0": "verbose=false",
+	"getmempoolancestors--condition1": "verbose=true",
+	"getmempoolancestors--result0":    "Array of transaction hashes",
+
+	// GetMempoolDescendantsCmd help.
+	"getmempooldescendants--synopsis":   "Returns all the in-mempool descendants of a transaction in the memory pool.",
+	"getmempooldescendants-txid":        "The hash of the transaction",
+	"getmempooldescendants-verbose":     "Returns JSON object when true or an array of transaction hashes when false",
+	"getmempooldescendants--condition0": "verbose=false",
+	"getmempooldescendants--condition1": "verbose=true",
+	"getmempooldescendants--result0":    "Array of transaction hashes",
+
+	// GetMempoolEntryCmd help.
+	"getmempoolentry--synopsis": "Returns memory pool data for a transaction, including its ancestor and descendant packages.",
+	"getmempoolentry-txid":      "The hash of the transaction",
+
+	// GetMempoolEntryResult help.
+	"getmempoolentryresult-size":             "Transaction size in bytes",
+	"getmempoolentryresult-vsize":            "The virtual size of the transaction",
+	"getmempoolentryresult-fee":              "Transaction fee in DUO",
+	"getmempoolentryresult-modifiedfee":      "Transaction fee in DUO with fee deltas used for mining priority",
+	"getmempoolentryresult-time":             "Local time transaction entered pool in seconds since 1 Jan 1970 GMT",
+	"getmempoolentryresult-height":           "Block height when transaction entered the pool",
+	"getmempoolentryresult-startingpriority": "Priority when transaction entered the pool",
+	"getmempoolentryresult-currentpriority":  "Current priority",
+	"getmempoolentryresult-descendantcount":  "Number of in-mempool descendant transactions, including this one",
+	"getmempoolentryresult-descendantsize":   "Virtual size of in-mempool descendants, including this one",
+	"getmempoolentryresult-descendantfees":   "Fees in DUO of in-mempool descendants, including this one",
+	"getmempoolentryresult-ancestorcount":    "Number of in-mempool ancestor transactions, including this one",
+	"getmempoolentryresult-ancestorsize":     "Virtual size of in-mempool ancestors, including this one",
+	"getmempoolentryresult-ancestorfees":     "Fees in DUO of in-mempool ancestors, including this one",
+	"getmempoolentryresult-depends":          "Unconfirmed transactions used as inputs for this transaction",
+	"getmempoolentryresult-spentby":          "Unconfirmed transactions spending outputs from this transaction",
+
 	// GetMempoolInfoCmd help.
 	"getmempoolinfo--synopsis": "Returns memory pool information",
 
@@ -457,6 +554,38 @@ var HelpDescsEnUS = map[string]string{
 	"getnetworkhashps-height":    "Perform estimate ending with this height or -1 for current best chain block height",
 	"getnetworkhashps--result0":  "Estimated hashes per second",
 
+	// GetNetworkInfoCmd help.
+	"getnetworkinfo--synopsis": "Returns a JSON object containing information about the peer to peer networking of the node.",
+
+	// GetNetworkInfoResult help.
+	"getnetworkinforesult-version":         "The version of the server",
+	"getnetworkinforesult-subversion":      "The user agent advertised to peers",
+	"getnetworkinforesult-protocolversion": "The latest supported protocol version",
+	"getnetworkinforesult-localservices":   "The services offered to peers as a hex string",
+	"getnetworkinforesult-localrelay":      "Whether transactions are relayed to peers",
+	"getnetworkinforesult-timeoffset":      "The time offset in seconds",
+	"getnetworkinforesult-connections":     "The total number of connected peers",
+	"getnetworkinforesult-connections_in":  "The number of inbound connections",
+	"getnetworkinforesult-connections_out": "The number of outbound connections",
+	"getnetworkinforesult-networkactive":   "Whether peer to peer networking is enabled",
+	"getnetworkinforesult-networks":        "Information about each network",
+	"getnetworkinforesult-relayfee":        "Minimum fee rate in DUO/kB for transactions to be relayed",
+	"getnetworkinforesult-incrementalfee":  "Minimum fee rate increase in DUO/kB for replacing a transaction",
+	"getnetworkinforesult-localaddresses":  "The local addresses advertised to peers",
+	"getnetworkinforesult-warnings":        "Any network and chain warnings",
+
+	// NetworksResult help.
+	"networksresult-name":                        "The network name (ipv4, ipv6 or onion)",
+	"networksresult-limited":                     "Whether the network is disabled",
+	"networksresult-reachable":                   "Whether peers on the network can be connected to",
+	"networksresult-proxy":                       "The proxy used for the network, if any",
+	"networksresult-proxy_randomize_credentials": "Whether proxy credentials are randomized for each connection",
+
+	// LocalAddressesResult help.
+	"localaddressesresult-address": "The local address",
+	"localaddressesresult-port":    "The port of the local address",
+	"localaddressesresult-score":   "The relative priority of the local address",
+
 	// GetNetTotalsCmd help.
 	"getnettotals--synopsis": "Returns a JSON object containing network traffic statistics.",
 
@@ -466,27 +595,29 @@ var HelpDescsEnUS = map[string]string{
 	"getnettotalsresult-timemillis":     "Number of milliseconds since 1 Jan 1970 GMT",
 
 	// GetPeerInfoResult help.
-	"getpeerinforesult-id":             "A unique node ID",
-	"getpeerinforesult-addr":           "The ip address and port of the peer",
-	"getpeerinforesult-addrlocal":      "Local address",
-	"getpeerinforesult-services":       "Services bitmask which represents the services supported by the peer",
-	"getpeerinforesult-relaytxes":      "Peer has requested transactions be relayed to it",
-	"getpeerinforesult-lastsend":       "Time the last message was received in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-lastrecv":       "Time the last message was sent in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-bytessent":      "Total bytes sent",
-	"getpeerinforesult-bytesrecv":      "Total bytes received",
-	"getpeerinforesult-conntime":       "Time the connection was made in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-timeoffset":     "The time offset of the peer",
-	"getpeerinforesult-pingtime":       "Number of microseconds the last ping took",
-	"getpeerinforesult-pingwait":       "Number of microseconds a queued ping has been waiting for a response",
-	"getpeerinforesult-version":        "The protocol version of the peer",
-	"getpeerinforesult-subver":         "The user agent of the peer",
-	"getpeerinforesult-inbound":        "Whether or not the peer is an inbound connection",
-	"getpeerinforesult-startingheight": "The latest block height the peer knew about when the connection was established",
-	"getpeerinforesult-currentheight":  "The current height of the peer",
-	"getpeerinforesult-banscore":       "The ban score",
-	"getpeerinforesult-feefilter":      "The requested minimum fee a transaction must have to be announced to the peer",
-	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
+	"getpeerinforesult-id":                      "A unique node ID",
+	"getpeerinforesult-addr":                    "The ip address and port of the peer",
+	"getpeerinforesult-addrlocal":               "Local address",
+	"getpeerinforesult-services":                "Services bitmask which represents the services supported by the peer",
+	"getpeerinforesult-relaytxes":               "Peer has requested transactions be relayed to it",
+	"getpeerinforesult-lastsend":                "Time the last message was received in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-lastrecv":                "Time the last message was sent in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-bytessent":               "Total bytes sent",
+	"getpeerinforesult-bytesrecv":               "Total bytes received",
+	"getpeerinforesult-conntime":                "Time the connection was made in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-timeoffset":              "The time offset of the peer",
+	"getpeerinforesult-pingtime":                "Number of microseconds the last ping took",
+	"getpeerinforesult-pingwait":                "Number of microseconds a queued ping has been waiting for a response",
+	"getpeerinforesult-version":                 "The protocol version of the peer",
+	"getpeerinforesult-subver":                  "The user agent of the peer",
+	"getpeerinforesult-inbound":                 "Whether or not the peer is an inbound connection",
+	"getpeerinforesult-startingheight":          "The latest block height the peer knew about when the connection was established",
+	"getpeerinforesult-currentheight":           "The current height of the peer",
+	"getpeerinforesult-banscore":                "The ban score",
+	"getpeerinforesult-feefilter":               "The requested minimum fee a transaction must have to be announced to the peer",
+	"getpeerinforesult-syncnode":                "Whether or not the peer is the sync peer",
+	"getpeerinforesult-transport_protocol_type": "The transport of the connection, v2 if it is encrypted and authenticated or v1 if it is plaintext",
+	"getpeerinforesult-session_id":              "The session ID of a v2 connection, which matches the one of the peer unless the connection is intercepted, or empty for v1",
 
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
@@ -531,6 +662,46 @@ var HelpDescsEnUS = map[string]string{
 	"gettxout-vout":           "The index of the output",
 	"gettxout-includemempool": "Include the mempool when true",
 
+	// GetTxSpendingPrevOutResult help.
+	"gettxspendingprevoutresult-txid":         "The hash of the transaction of the output",
+	"gettxspendingprevoutresult-vout":         "The index of the output",
+	"gettxspendingprevoutresult-spendingtxid": "The hash of the transaction spending the output, omitted if it is unspent",
+	"gettxspendingprevoutresult-spendingvin":  "The index of the input of the spending transaction, omitted if the output is unspent",
+	"gettxspendingprevoutresult-blockheight":  "The height of the block the spending transaction is in, omitted if it is unspent or in the mempool",
+
+	// GetTxSpendingPrevOutCmd help.
+	"gettxspendingprevout--synopsis": "Returns the transactions spending the given outputs, from the mempool or, with the spend index enabled (--spendindex), the main chain.",
+	"gettxspendingprevout-outputs":   "The transaction outputs to look up",
+
+	// GetWorkerSharesCmd help.
+	"getworkershares--synopsis": "Returns the totals of the shares found by each worker of the miner controller, and optionally splits a reward between the workers of the most recent shares (pay per last N shares).",
+	"getworkershares-window":    "The number of most recent shares to split the reward over, at most the 100000 the ledger keeps, or 0 to leave out the payouts",
+	"getworkershares-reward":    "The reward in DUO to split between the workers of the window",
+
+	// GetWorkerSharesResult help.
+	"getworkersharesresult-shares":  "The number of shares in the ledger",
+	"getworkersharesresult-workers": "The totals of the shares of each worker",
+	"getworkersharesresult-payouts": "The part of the reward for each worker of the window",
+
+	// WorkerSharesResult help.
+	"workersharesresult-worker":     "The id of the worker",
+	"workersharesresult-shares":     "The number of shares found by the worker",
+	"workersharesresult-difficulty": "The total difficulty of the shares, relative to the minimum difficulty of their algorithm",
+	"workersharesresult-weight":     "The total of the shares as a fraction of a block solution",
+	"workersharesresult-algos":        "JSON object with the algorithms as keys and share counts as values",
+	"workersharesresult-algos--key":   "algorithm",
+	"workersharesresult-algos--value": "n",
+	"workersharesresult-algos--desc":  "The number of shares found with each algorithm",
+	"workersharesresult-blocks":     "The hashes of the blocks found by the worker",
+	"workersharesresult-lastshare":  "The time of the most recent share of the worker in seconds since 1 Jan 1970 GMT",
+
+	// SharePayoutResult help.
+	"sharepayoutresult-worker":   "The id of the worker",
+	"sharepayoutresult-shares":   "The number of shares of the worker in the window",
+	"sharepayoutresult-weight":   "The total of the shares of the worker as a fraction of a block solution",
+	"sharepayoutresult-fraction": "The fraction of the reward the worker gets",
+	"sharepayoutresult-amount":   "The amount of the reward the worker gets in DUO",
+
 	// HelpCmd help.
 	"help--synopsis":   "Returns a list of all commands or help for a specified command.",
 	"help-command":     "The command to retrieve help for",
@@ -539,10 +710,41 @@ var HelpDescsEnUS = map[string]string{
 	"help--result0":    "List of commands",
 	"help--result1":    "Help for specified command",
 
+	// InvalidateBlockCmd help.
+	"invalidateblock--synopsis": "Permanently marks a block and all of its descendants as invalid, as if it violated a consensus rule.\n" +
+		"If the block is in the main chain the chain is reorganized onto the valid branch with the most work.",
+	"invalidateblock-blockhash": "The hash of the block to mark as invalid",
+
+	// ListBannedCmd help.
+	"listbanned--synopsis": "Returns the banned subnets, including the peers banned for misbehaving, with the reasons they were banned for.",
+
+	// ListBannedResult help.
+	"listbannedresult-address":     "The banned subnet",
+	"listbannedresult-bancreated":  "The time the ban was made in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-banneduntil": "The time the ban expires in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-banreason":   "The reason the subnet was banned for",
+
 	// PingCmd help.
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getpeerinfo via the pingtime and pingwait fields.",
 
+	// PreciousBlockCmd help.
+	"preciousblock--synopsis": "Treats a block as if it were received before others with the same work.\n" +
+		"A block on a branch with less work than the main chain is left alone.",
+	"preciousblock-blockhash": "The hash of the block to mark as precious",
+
+	// ReconsiderBlockCmd help.
+	"reconsiderblock--synopsis": "Removes the invalid status from a block, its ancestors and descendants, reversing the effect of invalidateblock.\n" +
+		"The chain is then reorganized onto the valid branch with the most work.",
+	"reconsiderblock-blockhash": "The hash of the block to reconsider",
+
+	// ReloadConfigCmd help.
+	"reloadconfig--synopsis": "Reloads the configuration file, applying the changed settings that can be changed while running and restarting for the others.",
+
+	// ReloadConfigResult help.
+	"reloadconfigresult-changed": "The names of the changed settings",
+	"reloadconfigresult-restart": "Whether any of the changed settings needs a restart to take effect",
+
 	// SearchRawTransactionsCmd help.
 	"searchrawtransactions--synopsis": "Returns raw data for transactions involving the passed address.\n" +
 		"Returned transactions are pulled from both the database, and transactions currently in the mempool.\n" +
@@ -567,6 +769,15 @@ var HelpDescsEnUS = map[string]string{
 	"sendrawtransaction-maxfeerate":    "Used by bitcoind on or after v0.19.0",
 	"sendrawtransaction--result0":      "The hash of the transaction",
 
+	// SetBanCmd help.
+	"setban--synopsis": "Bans a subnet, disconnecting the peers in it and refusing connections from it, or lifts its ban.\n" +
+		"Bans are kept in the data directory and outlast restarts.",
+	"setban-subnet":   "The IP address, with an optional /netmask, of the subnet to operate on",
+	"setban-subcmd":   "'add' to ban the subnet or 'remove' to lift its ban",
+	"setban-bantime":  "The number of seconds the ban lasts for, or 0 for the configured ban duration",
+	"setban-absolute": "Whether bantime is the time the ban expires in seconds since 1 Jan 1970 GMT",
+	"setban-reason":   "The reason the subnet is banned for",
+
 	// SetGenerateCmd help.
 	"setgenerate--synopsis":    "Set the server to generate coins (mine) or not.",
 	"setgenerate-generate":     "Use true to enable generation, false to disable it",
@@ -701,6 +912,7 @@ var HelpDescsEnUS = map[string]string{
 // pointer to the type (or nil to indicate no return value).
 var ResultTypes = map[string][]interface{}{
 	"addnode":               nil,
+	"clearbanned":           nil,
 	"createrawtransaction":  {(*string)(nil)},
 	"debuglevel":            {(*string)(nil), (*string)(nil)},
 	"decoderawtransaction":  {(*btcjson.TxRawDecodeResult)(nil)},
@@ -718,26 +930,41 @@ var ResultTypes = map[string][]interface{}{
 	"getblockchaininfo":     {(*btcjson.GetBlockChainInfoResult)(nil)},
 	"getcfilter":            {(*string)(nil)},
 	"getcfilterheader":      {(*string)(nil)},
+	"getchaintips":          {(*[]btcjson.GetChainTipsResult)(nil)},
 	"getconnectioncount":    {(*int32)(nil)},
 	"getcurrentnet":         {(*uint32)(nil)},
 	"getdifficulty":         {(*float64)(nil)},
+	"getforkinfo":           {(*btcjson.GetForkInfoResult)(nil)},
 	"getgenerate":           {(*bool)(nil)},
 	"gethashespersec":       {(*float64)(nil)},
 	"getheaders":            {(*[]string)(nil)},
+	"getindexinfo":          {(*map[string]btcjson.GetIndexInfoResult)(nil)},
 	"getinfo":               {(*btcjson.InfoChainResult)(nil)},
+	"getmempoolancestors":   {(*[]string)(nil), (*btcjson.GetMempoolEntryResult)(nil)},
+	"getmempooldescendants": {(*[]string)(nil), (*btcjson.GetMempoolEntryResult)(nil)},
+	"getmempoolentry":       {(*btcjson.GetMempoolEntryResult)(nil)},
 	"getmempoolinfo":        {(*btcjson.GetMempoolInfoResult)(nil)},
 	"getmininginfo":         {(*btcjson.GetMiningInfoResult)(nil)},
 	"getnettotals":          {(*btcjson.GetNetTotalsResult)(nil)},
 	"getnetworkhashps":      {(*int64)(nil)},
+	"getnetworkinfo":        {(*btcjson.GetNetworkInfoResult)(nil)},
 	"getpeerinfo":           {(*[]btcjson.GetPeerInfoResult)(nil)},
 	"getrawmempool":         {(*[]string)(nil), (*btcjson.GetRawMempoolVerboseResult)(nil)},
 	"getrawtransaction":     {(*string)(nil), (*btcjson.TxRawResult)(nil)},
 	"gettxout":              {(*btcjson.GetTxOutResult)(nil)},
+	"gettxspendingprevout":  {(*[]btcjson.GetTxSpendingPrevOutResult)(nil)},
+	"getworkershares":       {(*btcjson.GetWorkerSharesResult)(nil)},
 	"node":                  nil,
 	"help":                  {(*string)(nil), (*string)(nil)},
+	"invalidateblock":       nil,
+	"listbanned":            {(*[]btcjson.ListBannedResult)(nil)},
 	"ping":                  nil,
+	"preciousblock":         nil,
+	"reconsiderblock":       nil,
+	"reloadconfig":          {(*btcjson.ReloadConfigResult)(nil)},
 	"searchrawtransactions": {(*string)(nil), (*[]btcjson.SearchRawTransactionsResult)(nil)},
 	"sendrawtransaction":    {(*string)(nil)},
+	"setban":                nil,
 	"setgenerate":           nil,
 	"stop":                  {(*string)(nil)},
 	"restart":               {(*string)(nil)},
@@ -11,6 +11,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
@@ -62,6 +63,11 @@ type (
 		Message      wire.Message
 		ExcludePeers []*NodePeer
 	}
+	// BanPeerMsg is a peer to ban with the reason it is banned for.
+	BanPeerMsg struct {
+		Peer   *NodePeer
+		Reason string
+	}
 	// CFHeaderKV is a tuple of a filter header and its associated block hash. The struct is used to cache cfcheckpt
 	// responses.
 	CFHeaderKV struct {
@@ -96,12 +102,11 @@ type (
 	OnionAddr struct {
 		Addr string
 	}
-	// PeerState maintains state of inbound, persistent, outbound peers as well as banned peers and outbound groups.
+	// PeerState maintains state of inbound, persistent, outbound peers as well as outbound groups.
 	PeerState struct {
 		InboundPeers    map[int32]*NodePeer
 		OutboundPeers   map[int32]*NodePeer
 		PersistentPeers map[int32]*NodePeer
-		Banned          map[string]time.Time
 		OutboundGroups  map[string]int
 	}
 	// RelayMsg packages an inventory vector along with the newly discovered inventory so the relay has access to that
@@ -124,6 +129,7 @@ type (
 		ChainParams          *netparams.Params
 		AddrManager          *addrmgr.AddrManager
 		ConnManager          *connmgr.ConnManager
+		Bans                 *connmgr.BanList
 		SigCache             *txscript.SigCache
 		HashCache            *txscript.HashCache
 		RPCServers           []*Server
@@ -134,7 +140,7 @@ type (
 		ModifyRebroadcastInv chan interface{}
 		NewPeers             chan *NodePeer
 		DonePeers            chan *NodePeer
-		BanPeers             chan *NodePeer
+		BanPeers             chan BanPeerMsg
 		Query                chan interface{}
 		RelayInv             chan RelayMsg
 		Broadcast            chan BroadcastMsg
@@ -149,9 +155,12 @@ type (
 		//
 		// These fields are set during initial creation of the server and never changed afterwards, so they do not need
 		// to be protected for concurrent access.
-		TxIndex   *indexers.TxIndex
-		AddrIndex *indexers.AddrIndex
-		CFIndex   *indexers.CFIndex
+		TxIndex    *indexers.TxIndex
+		AddrIndex  *indexers.AddrIndex
+		CFIndex    *indexers.CFIndex
+		SpendIndex *indexers.SpendIndex
+		// IndexManager keeps the optional indexes up to date with the chain, and is nil if none are enabled.
+		IndexManager *indexers.Manager
 		// The fee estimator keeps track of how long transactions are left in the mempool before they are mined into
 		// blocks.
 		FeeEstimator *mempool.FeeEstimator
@@ -166,6 +175,10 @@ type (
 		Shutdown           int32
 		ShutdownSched      int32
 		HighestKnown       uberatomic.Int32
+		// V1Only holds the addresses of peers that closed the connection on our v2 transport handshake and when they
+		// did, which are connected to with v1 until V1OnlyExpiry has passed.
+		V1Only    map[string]time.Time
+		V1OnlyMtx sync.Mutex
 	}
 	// NodePeer extends the peer to maintain state shared by the server and the blockmanager.
 	NodePeer struct {
@@ -216,6 +229,13 @@ const (
 	// ConnectionRetryInterval is the base amount of time to wait in between retries when connecting to persistent
 	// peers. It is adjusted by the number of retries such that there is a retry backoff.
 	ConnectionRetryInterval = time.Minute
+	// MaxCmpctBlockDepth is the number of blocks below the tip of the chain a block requested as a compact block may
+	// be before it is sent in full instead, as the peer can't have the transactions of older blocks in its mempool.
+	MaxCmpctBlockDepth = 10
+	// V1OnlyExpiry is how long a peer that refused the v2 transport is connected to with v1 before v2 is tried again.
+	V1OnlyExpiry = time.Hour * 24
+	// MaxV1Only is the most peers that refused the v2 transport that are remembered, the oldest are forgotten first.
+	MaxV1Only = 1000
 )
 
 var (
@@ -311,8 +331,8 @@ func (n *Node) AnnounceNewTransactions(txns []*mempool.TxDesc) {
 }
 
 // BanPeer bans a peer that has already been connected to the server by ip.
-func (n *Node) BanPeer(sp *NodePeer) {
-	n.BanPeers <- sp
+func (n *Node) BanPeer(sp *NodePeer, reason string) {
+	n.BanPeers <- BanPeerMsg{Peer: sp, Reason: reason}
 }
 
 // BroadcastMessage sends msg to all peers currently connected to the server except those in the passed peers to
@@ -544,17 +564,10 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 		sp.Disconnect()
 		return false
 	}
-	if banEnd, ok := state.Banned[host]; ok {
-		if time.Now().Before(banEnd) {
-			Debugf(
-				"peer %n is banned for another %v - disconnecting %n",
-				host, time.Until(banEnd),
-			)
-			sp.Disconnect()
-			return false
-		}
-		Infof("peer %n is no longer banned", host)
-		delete(state.Banned, host)
+	if ban, ok := n.Bans.Banned(net.ParseIP(host)); ok {
+		Debugf("peer %s is banned for another %v - disconnecting", host, time.Until(ban.Until))
+		sp.Disconnect()
+		return false
 	}
 	// TODO: Check for max peers from a single IP.
 	
@@ -581,16 +594,24 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 	return true
 }
 
-// HandleBanPeerMsg deals with banning peers. It is invoked from the peerHandler goroutine.
-func (n *Node) HandleBanPeerMsg(state *PeerState, sp *NodePeer) {
+// HandleBanPeerMsg deals with banning peers, adding their address to the ban list. It is invoked from the peerHandler
+// goroutine.
+func (n *Node) HandleBanPeerMsg(state *PeerState, msg BanPeerMsg) {
+	sp := msg.Peer
 	host, _, err := net.SplitHostPort(sp.Addr())
 	if err != nil {
-		Errorf("can't split ban peer %n %v %n", sp.Addr(), err)
+		Errorf("can't split ban peer %s %v", sp.Addr(), err)
+		return
+	}
+	subnet, err := connmgr.ParseSubnet(host)
+	if err != nil {
+		Errorf("can't ban peer %s: %v", host, err)
 		return
 	}
 	direction := log.DirectionString(sp.Inbound())
-	Infof("banned peer %n (%n) for %v", host, direction, *n.Config.BanDuration)
-	state.Banned[host] = time.Now().Add(*n.Config.BanDuration)
+	Infof("banned peer %s (%s) for %v: %s", host, direction, *n.Config.BanDuration, msg.Reason)
+	if err = n.Bans.Add(subnet, time.Now().Add(*n.Config.BanDuration), msg.Reason); Check(err) {
+	}
 }
 
 // HandleBroadcastMsg deals with broadcasting messages to peers. It is invoked from the peerHandler goroutine.
@@ -612,6 +633,11 @@ func (n *Node) HandleBroadcastMsg(state *PeerState, bmsg *BroadcastMsg) {
 
 // HandleDonePeerMsg deals with peers that have signalled they are done. It is invoked from the peerHandler goroutine.
 func (n *Node) HandleDonePeerMsg(state *PeerState, sp *NodePeer) {
+	// A peer that refused the v2 transport is connected to again with v1 straight away.
+	disconnect := n.ConnManager.Disconnect
+	if sp.V2HandshakeRefused() {
+		disconnect = n.ConnManager.Redial
+	}
 	var list map[int32]*NodePeer
 	switch {
 	case sp.Persistent:
@@ -626,14 +652,14 @@ func (n *Node) HandleDonePeerMsg(state *PeerState, sp *NodePeer) {
 			state.OutboundGroups[addrmgr.GroupKey(sp.NA())]--
 		}
 		if !sp.Inbound() && sp.ConnReq != nil {
-			n.ConnManager.Disconnect(sp.ConnReq.ID())
+			disconnect(sp.ConnReq.ID())
 		}
 		delete(list, sp.ID())
 		Trace("removed peer ", sp)
 		return
 	}
 	if sp.ConnReq != nil {
-		n.ConnManager.Disconnect(sp.ConnReq.ID())
+		disconnect(sp.ConnReq.ID())
 	}
 	// Update the address' last seen time if the peer has acknowledged our version and has sent us its version as well.
 	if sp.VerAckReceived() && sp.VersionKnown() && sp.NA() != nil {
@@ -783,11 +809,32 @@ func (n *Node) HandleQuery(state *PeerState, querymsg interface{}) {
 // HandleRelayInvMsg deals with relaying inventory to peers that are not already known to have it. It is invoked from
 // the peerHandler goroutine.
 func (n *Node) HandleRelayInvMsg(state *PeerState, msg RelayMsg) {
+	// Peers that asked for new blocks to be announced as compact blocks are all sent the same one, made when the first
+	// of them is found.
+	var cmpctBlock *wire.MsgCmpctBlock
 	state.ForAllPeers(
 		func(sp *NodePeer) {
 			if !sp.Connected() {
 				return
 			}
+			if msg.InvVect.Type == wire.InvTypeBlock && sp.WantsCmpctBlocks() {
+				if cmpctBlock == nil {
+					block, err := n.Chain.BlockByHash(&msg.InvVect.Hash)
+					if err != nil {
+						Error("failed to fetch block to relay as a compact block:", err)
+						return
+					}
+					nonce, err := wire.RandomUint64()
+					if err != nil {
+						Error(err)
+						return
+					}
+					cmpctBlock = wire.NewMsgCmpctBlock(block.MsgBlock(), nonce)
+				}
+				sp.AddKnownInventory(msg.InvVect)
+				sp.QueueMessage(cmpctBlock, nil)
+				return
+			}
 			// If the inventory is a block and the peer prefers headers, generate and send a headers message instead of an
 			// inventory message.
 			if msg.InvVect.Type == wire.InvTypeBlock && sp.WantsHeaders() {
@@ -876,7 +923,9 @@ func (n *Node) InboundPeerConnected(conn net.Conn) {
 // instance and the connection itself, and finally notifies the address manager of the attempt.
 func (n *Node) OutboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	sp := NewServerPeer(n, c.Permanent)
-	p, err := peer.NewOutboundPeer(NewPeerConfig(sp), c.Addr.String())
+	cfg := NewPeerConfig(sp)
+	cfg.V2Transport = n.UseV2Transport(c)
+	p, err := peer.NewOutboundPeer(cfg, c.Addr.String())
 	if err != nil {
 		Errorf("cannot create outbound peer %n: %v %n", c.Addr, err)
 		n.ConnManager.Disconnect(c.ID())
@@ -889,10 +938,84 @@ func (n *Node) OutboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	n.AddrManager.Attempt(sp.NA())
 }
 
+// UseV2Transport returns whether an outbound connection should start with the v2 transport handshake. This is the case
+// when v2 is enabled and the peer is either a persistent peer or known to support v2, unless it refused v2 before.
+func (n *Node) UseV2Transport(c *connmgr.ConnReq) bool {
+	if !*n.Config.V2Transport {
+		return false
+	}
+	addr := c.Addr.String()
+	n.V1OnlyMtx.Lock()
+	refused, v1Only := n.V1Only[addr]
+	if v1Only && time.Since(refused) >= V1OnlyExpiry {
+		delete(n.V1Only, addr)
+		v1Only = false
+	}
+	n.V1OnlyMtx.Unlock()
+	if v1Only {
+		return false
+	}
+	if c.Permanent {
+		return true
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return false
+	}
+	na, err := n.AddrManager.HostToNetAddress(host, uint16(port), 0)
+	if err != nil {
+		return false
+	}
+	return n.AddrManager.Services(na)&wire.SFNodeP2PV2 != 0
+}
+
+// CFIndexSyncing returns whether the committed filter index is still catching up with the chain, in which case it can't
+// serve complete filters and filter headers to peers yet.
+func (n *Node) CFIndexSyncing() bool {
+	if n.IndexManager == nil || n.CFIndex == nil {
+		return false
+	}
+	status, ok := n.IndexManager.IndexStatus(n.CFIndex)
+	return ok && !status.Synced
+}
+
+// AddV1Only records that the peer at an address refused the v2 transport. When MaxV1Only peers are already recorded,
+// the expired ones are forgotten, and if there are none the one that refused the longest time ago.
+func (n *Node) AddV1Only(addr string) {
+	n.V1OnlyMtx.Lock()
+	defer n.V1OnlyMtx.Unlock()
+	if _, ok := n.V1Only[addr]; !ok && len(n.V1Only) >= MaxV1Only {
+		var oldest string
+		var oldestTime time.Time
+		for a, t := range n.V1Only {
+			if time.Since(t) >= V1OnlyExpiry {
+				delete(n.V1Only, a)
+				continue
+			}
+			if oldest == "" || t.Before(oldestTime) {
+				oldest, oldestTime = a, t
+			}
+		}
+		if len(n.V1Only) >= MaxV1Only {
+			delete(n.V1Only, oldest)
+		}
+	}
+	n.V1Only[addr] = time.Now()
+}
+
 // PeerDoneHandler handles peer disconnects by notifiying the server that it's done along with other performing other
 // desirable cleanup.
 func (n *Node) PeerDoneHandler(sp *NodePeer) {
 	sp.WaitForDisconnect()
+	// A peer that only speaks v1 closes the connection on the v2 handshake, so connect to it with v1 next time.
+	if sp.V2HandshakeRefused() && sp.ConnReq != nil {
+		Debug("peer", sp, "refused the v2 transport, falling back to v1")
+		n.AddV1Only(sp.ConnReq.Addr.String())
+	}
 	n.DonePeers <- sp
 	// Only tell sync manager we are gone if we ever told it we existed.
 	if sp.VersionKnown() {
@@ -923,7 +1046,6 @@ func (n *Node) PeerHandler() {
 		InboundPeers:    make(map[int32]*NodePeer),
 		PersistentPeers: make(map[int32]*NodePeer),
 		OutboundPeers:   make(map[int32]*NodePeer),
-		Banned:          make(map[string]time.Time),
 		OutboundGroups:  make(map[string]int),
 	}
 	if !*n.Config.DisableDNSSeed || len(*n.Config.ConnectPeers) < 0 {
@@ -1071,6 +1193,44 @@ func (n *Node) PushBlockMsg(
 	return nil
 }
 
+// PushCmpctBlockMsg sends a cmpctblock message for the provided block hash to the connected peer, or a block message if
+// the block is more than MaxCmpctBlockDepth blocks below the tip of the chain. An error is returned if the block hash is
+// not known.
+func (n *Node) PushCmpctBlockMsg(
+	sp *NodePeer, hash *chainhash.Hash,
+	doneChan chan<- struct{}, waitChan qu.C,
+) error {
+	height, err := sp.Server.Chain.BlockHeightByHash(hash)
+	if err == nil && sp.Server.Chain.BestSnapshot().Height-height > MaxCmpctBlockDepth {
+		return n.PushBlockMsg(sp, hash, doneChan, waitChan, wire.BaseEncoding)
+	}
+	blk, err := sp.Server.Chain.BlockByHash(hash)
+	if err != nil {
+		Errorf(
+			"unable to fetch requested block hash %v: %v",
+			hash, err,
+		)
+		if doneChan != nil {
+			doneChan <- struct{}{}
+		}
+		return err
+	}
+	nonce, err := wire.RandomUint64()
+	if err != nil {
+		Error(err)
+		if doneChan != nil {
+			doneChan <- struct{}{}
+		}
+		return err
+	}
+	// Once we have fetched data wait for any previous operation to finish.
+	if waitChan != nil {
+		<-waitChan
+	}
+	sp.QueueMessage(wire.NewMsgCmpctBlock(blk.MsgBlock(), nonce), doneChan)
+	return nil
+}
+
 // PushMerkleBlockMsg sends a merkleblock message for the provided block hash to the connected peer. Since a merkle
 // block requires the peer to have a filter loaded, this call will simply be ignored if there is no filter loaded.
 //
@@ -1273,6 +1433,21 @@ func (np *NodePeer) OnAddr(
 	_ *peer.Peer,
 	msg *wire.MsgAddr,
 ) {
+	np.HandleAddrList(msg.Command(), msg.AddrList)
+}
+
+// OnAddrV2 is invoked when a peer receives an addrv2 bitcoin message, which can also advertise Tor v3 and I2P
+// addresses, and is used to notify the server about advertised addresses.
+func (np *NodePeer) OnAddrV2(
+	_ *peer.Peer,
+	msg *wire.MsgAddrV2,
+) {
+	np.HandleAddrList(msg.Command(), msg.AddrList)
+}
+
+// HandleAddrList adds the addresses advertised in an addr or addrv2 message to the known addresses of the peer and to
+// the address manager.
+func (np *NodePeer) HandleAddrList(command string, addrList []*wire.NetAddress) {
 	// Ignore addresses when running on the simulation test network. This helps prevent the network from becoming
 	// another public test network since it will not be able to learn about other peers that have not specifically been
 	// provided.
@@ -1284,15 +1459,15 @@ func (np *NodePeer) OnAddr(
 		return
 	}
 	// A message that has no addresses is invalid.
-	if len(msg.AddrList) == 0 {
+	if len(addrList) == 0 {
 		Errorf(
 			"command [%s] from %s does not contain any addresses",
-			msg.Command(), np.Peer,
+			command, np.Peer,
 		)
 		np.Disconnect()
 		return
 	}
-	for _, na := range msg.AddrList {
+	for _, na := range addrList {
 		// Don't add more address if we're disconnecting.
 		if !np.Connected() {
 			return
@@ -1309,7 +1484,7 @@ func (np *NodePeer) OnAddr(
 	// Add addresses to server address manager. The address manager handles the details of things such as preventing
 	// duplicate addresses, max addresses, and last seen updates. XXX bitcoind gives a 2 hour time penalty here, do we
 	// want to do the same?
-	np.Server.AddrManager.AddAddresses(msg.AddrList, np.NA())
+	np.Server.AddrManager.AddAddresses(addrList, np.NA())
 }
 
 // OnBlock is invoked when a peer receives a block bitcoin message. It blocks until the bitcoin block has been fully
@@ -1334,6 +1509,20 @@ func (np *NodePeer) OnBlock(_ *peer.Peer, msg *wire.MsgBlock, buf []byte) {
 	<-np.BlockProcessed
 }
 
+// OnBlockTxn is invoked when a peer receives a blocktxn bitcoin message with the transactions of a compact block that
+// were not in the mempool. Like OnBlock it blocks until the block they complete has been processed.
+func (np *NodePeer) OnBlockTxn(_ *peer.Peer, msg *wire.MsgBlockTxn) {
+	np.Server.SyncManager.QueueBlockTxn(msg, np.Peer, np.BlockProcessed)
+	<-np.BlockProcessed
+}
+
+// OnCmpctBlock is invoked when a peer receives a cmpctblock bitcoin message. Like OnBlock it blocks until the compact
+// block has been rebuilt and processed, or its missing transactions have been requested.
+func (np *NodePeer) OnCmpctBlock(_ *peer.Peer, msg *wire.MsgCmpctBlock) {
+	np.Server.SyncManager.QueueCmpctBlock(msg, np.Peer, np.BlockProcessed)
+	<-np.BlockProcessed
+}
+
 // OnFeeFilter is invoked when a peer receives a feefilter bitcoin message and is used by remote peers to request that
 // no transactions which have a fee rate lower than provided value are inventoried to them. The peer will be
 // disconnected if an invalid fee filter value is provided.
@@ -1436,6 +1625,54 @@ func (np *NodePeer) OnGetAddr(
 	addrCache := np.Server.AddrManager.AddressCache()
 	// Push the addresses.
 	np.PreparePushAddrMsg(addrCache)
+	// The address cache only holds the addresses of other nodes, so the local address is sent along with it.
+	np.pushLocalAddress()
+}
+
+// OnVerAck is invoked when a peer receives a verack bitcoin message and is used to advertise the local address to
+// outbound peers. This is done here rather than on the version message because a peer sends sendaddrv2 between its
+// version and verack, and until it has, Tor v3 and I2P addresses can not be sent to it.
+func (np *NodePeer) OnVerAck(_ *peer.Peer, _ *wire.MsgVerAck) {
+	// This is skipped when running on the simulation test network since it actively avoids advertising to
+	// discovered peers.
+	if (*np.Server.Config.Network)[0] == 's' || np.Inbound() {
+		return
+	}
+	np.pushLocalAddress()
+}
+
+// pushLocalAddress sends the peer the local address that suits it best when the server accepts incoming connections
+// and it believes itself to be close to the best known tip.
+func (np *NodePeer) pushLocalAddress() {
+	if *np.Server.Config.DisableListen || !np.Server.SyncManager.IsCurrent() {
+		return
+	}
+	lna := np.Server.AddrManager.GetBestLocalAddress(np.NA())
+	if addrmgr.IsRoutable(lna) {
+		// Filter addresses the peer already knows about.
+		np.PreparePushAddrMsg([]*wire.NetAddress{lna})
+	}
+}
+
+// OnGetBlockTxn is invoked when a peer receives a getblocktxn bitcoin message and is used to send the transactions of
+// a compact block that the peer could not find in its mempool. The peer is banned if it asks for transactions that are
+// not in the block.
+func (np *NodePeer) OnGetBlockTxn(_ *peer.Peer, msg *wire.MsgGetBlockTxn) {
+	blk, err := np.Server.Chain.BlockByHash(&msg.BlockHash)
+	if err != nil {
+		Debug("peer", np, "asked for transactions of unknown block", msg.BlockHash)
+		return
+	}
+	blkTransactions := blk.MsgBlock().Transactions
+	txs := make([]*wire.MsgTx, 0, len(msg.Indexes))
+	for _, index := range msg.Indexes {
+		if index >= uint32(len(blkTransactions)) {
+			np.AddBanScore(100, 0, "getblocktxn index out of range")
+			return
+		}
+		txs = append(txs, blkTransactions[index])
+	}
+	np.QueueMessage(wire.NewMsgBlockTxn(&msg.BlockHash, txs), nil)
 }
 
 // OnGetBlocks is invoked when a peer receives a getblocks bitcoin message.
@@ -1485,10 +1722,14 @@ func (np *NodePeer) OnGetCFCheckpt(
 	if !np.Server.SyncManager.IsCurrent() {
 		return
 	}
+	// Ignore getcfcheckpt requests while the filter index is catching up, as the headers are not all there yet.
+	if np.Server.CFIndexSyncing() {
+		return
+	}
 	// We'll also ensure that the remote party is requesting a set of checkpoints for filters that we actually currently
 	// maintain.
 	switch msg.FilterType {
-	case wire.GCSFilterRegular:
+	case wire.GCSFilterRegular, wire.GCSFilterExtended:
 		break
 	default:
 		Debug(
@@ -1611,10 +1852,14 @@ func (np *NodePeer) OnGetCFHeaders(
 	if !np.Server.SyncManager.IsCurrent() {
 		return
 	}
+	// Ignore getcfilterheader requests while the filter index is catching up, as the headers are not all there yet.
+	if np.Server.CFIndexSyncing() {
+		return
+	}
 	// We'll also ensure that the remote party is requesting a set of headers for filters that we actually currently
 	// maintain.
 	switch msg.FilterType {
-	case wire.GCSFilterRegular:
+	case wire.GCSFilterRegular, wire.GCSFilterExtended:
 		break
 	default:
 		Debug("filter request for unknown headers for filter:", msg.FilterType)
@@ -1710,9 +1955,13 @@ func (np *NodePeer) OnGetCFilters(
 	if !np.Server.SyncManager.IsCurrent() {
 		return
 	}
+	// Ignore getcfilters requests while the filter index is catching up, as the filters are not all there yet.
+	if np.Server.CFIndexSyncing() {
+		return
+	}
 	// We'll also ensure that the remote party is requesting a set of filters that we actually currently maintain.
 	switch msg.FilterType {
-	case wire.GCSFilterRegular:
+	case wire.GCSFilterRegular, wire.GCSFilterExtended:
 		break
 	default:
 		Debug("filter request for unknown filter:", msg.FilterType)
@@ -1802,6 +2051,8 @@ func (np *NodePeer) OnGetData(_ *peer.Peer, msg *wire.MsgGetData) {
 				np, &iv.Hash, c, waitChan,
 				wire.BaseEncoding,
 			)
+		case wire.InvTypeCmpctBlock:
+			err = np.Server.PushCmpctBlockMsg(np, &iv.Hash, c, waitChan)
 		case wire.InvTypeFilteredWitnessBlock:
 			err = np.Server.PushMerkleBlockMsg(
 				np, &iv.Hash, c, waitChan,
@@ -2051,17 +2302,8 @@ func (np *NodePeer) OnVersion(
 			np.Disconnect()
 			return nil
 		}
-		// Advertise the local address when the server accepts incoming connections and it believes itself to be close
-		// to the best known tip.
-		if !*np.Server.Config.DisableListen && np.Server.SyncManager.IsCurrent() {
-			// Get address that best matches.
-			lna := addrManager.GetBestLocalAddress(remoteAddr)
-			if addrmgr.IsRoutable(lna) {
-				// Filter addresses the peer already knows about.
-				addresses := []*wire.NetAddress{lna}
-				np.PreparePushAddrMsg(addresses)
-			}
-		}
+		// The local address is advertised in OnVerAck, once it is known whether the peer takes addrv2 messages.
+		//
 		// Request known addresses if the server address manager needs more and the peer has a protocol version new
 		// enough to include a timestamp with addresses.
 		hasTimestamp := np.ProtocolVersion() >= wire.NetAddressTimeVersion
@@ -2122,7 +2364,7 @@ func (np *NodePeer) AddBanScore(persistent, transient uint32, reason string) boo
 		Warnf("misbehaving peer %s: %s -- ban score increased to %d", np, reason, score)
 		if int(score) > *np.Server.Config.BanThreshold {
 			Warnf("misbehaving peer %s -- banning and disconnecting", np)
-			np.Server.BanPeer(np)
+			np.Server.BanPeer(np, fmt.Sprintf("misbehaving, ban score %d: %s", score, reason))
 			np.Disconnect()
 			return true
 		}
@@ -2458,6 +2700,25 @@ func InitListeners(
 			}
 		}
 	}
+	// The onion service of the node is advertised alongside its other addresses, as it is reached through tor.
+	if *config.ExternalOnion != "" {
+		eport := activeNet.DefaultPort
+		host, portstr, err := net.SplitHostPort(*config.ExternalOnion)
+		if err != nil {
+			// no port, use default.
+			host = *config.ExternalOnion
+		} else {
+			eport = portstr
+		}
+		port, err := strconv.ParseUint(eport, 10, 16)
+		if err != nil {
+			Errorf("can not parse port from %s for externalonion: %v", *config.ExternalOnion, err)
+		} else if na, err := aMgr.HostToNetAddress(host, uint16(port), services); err != nil {
+			Errorf("not adding %s as externalonion: %v", *config.ExternalOnion, err)
+		} else if err = aMgr.AddLocalAddress(na, addrmgr.ManualPrio); err != nil {
+			Errorf("skipping specified external onion: %v", err)
+		}
+	}
 	return listeners, nat, nil
 }
 
@@ -2521,13 +2782,17 @@ NewPeerConfig(sp *NodePeer) *peer.Config {
 	return &peer.Config{
 		Listeners: peer.MessageListeners{
 			OnVersion:      sp.OnVersion,
+			OnVerAck:       sp.OnVerAck,
 			OnMemPool:      sp.OnMemPool,
 			OnTx:           sp.OnTx,
 			OnBlock:        sp.OnBlock,
+			OnCmpctBlock:   sp.OnCmpctBlock,
+			OnBlockTxn:     sp.OnBlockTxn,
 			OnInv:          sp.OnInv,
 			OnHeaders:      sp.OnHeaders,
 			OnGetData:      sp.OnGetData,
 			OnGetBlocks:    sp.OnGetBlocks,
+			OnGetBlockTxn:  sp.OnGetBlockTxn,
 			OnGetHeaders:   sp.OnGetHeaders,
 			OnGetCFilters:  sp.OnGetCFilters,
 			OnGetCFHeaders: sp.OnGetCFHeaders,
@@ -2538,6 +2803,7 @@ NewPeerConfig(sp *NodePeer) *peer.Config {
 			OnFilterLoad:   sp.OnFilterLoad,
 			OnGetAddr:      sp.OnGetAddr,
 			OnAddr:         sp.OnAddr,
+			OnAddrV2:       sp.OnAddrV2,
 			OnRead:         sp.OnRead,
 			OnWrite:        sp.OnWrite,
 			// Note: The reference client currently bans peers that send alerts not signed with its key. We could verify
@@ -2556,6 +2822,7 @@ NewPeerConfig(sp *NodePeer) *peer.Config {
 		DisableRelayTx:    *sp.Server.Config.BlocksOnly,
 		ProtocolVersion:   peer.MaxProtocolVersion,
 		TrickleInterval:   *sp.Server.Config.TrickleInterval,
+		V2Transport:       *sp.Server.Config.V2Transport,
 	}
 }
 
@@ -2568,6 +2835,8 @@ type Context struct {
 	ActiveNet *netparams.Params
 	// Hashrate is the hash counter
 	Hashrate uberatomic.Uint64
+	// Hooks applies the settings changed in the configuration file while running
+	Hooks *pod.Hooks
 }
 
 // NewNode returns a new pod server configured to listen on addr for the bitcoin network type specified by chainParams.
@@ -2583,6 +2852,9 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 	if *cx.Config.NoCFilters {
 		services &^= wire.SFNodeCF
 	}
+	if *cx.Config.V2Transport {
+		services |= wire.SFNodeP2PV2
+	}
 	aMgr := addrmgr.New(*cx.Config.DataDir+string(os.PathSeparator)+cx.ActiveNet.Name, Lookup(cx.StateCfg))
 	var listeners []net.Listener
 	var nat upnp.NAT
@@ -2608,9 +2880,10 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 	s := Node{
 		ChainParams:          cx.ActiveNet,
 		AddrManager:          aMgr,
+		Bans:                 connmgr.NewBanList(filepath.Join(*cx.Config.DataDir, cx.ActiveNet.Name, "banlist.json")),
 		NewPeers:             make(chan *NodePeer, *cx.Config.MaxPeers),
 		DonePeers:            make(chan *NodePeer, *cx.Config.MaxPeers),
-		BanPeers:             make(chan *NodePeer, *cx.Config.MaxPeers),
+		BanPeers:             make(chan BanPeerMsg, *cx.Config.MaxPeers),
 		Query:                make(chan interface{}),
 		RelayInv:             make(chan RelayMsg, *cx.Config.MaxPeers),
 		Broadcast:            make(chan BroadcastMsg, *cx.Config.MaxPeers),
@@ -2624,6 +2897,7 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 		SigCache:             txscript.NewSigCache(uint(*cx.Config.SigCacheMaxSize)),
 		HashCache:            txscript.NewHashCache(uint(*cx.Config.SigCacheMaxSize)),
 		CFCheckptCaches:      make(map[wire.FilterType][]CFHeaderKV),
+		V1Only:               make(map[string]time.Time),
 		GenThreads:           uint32(thr),
 		Config:               cx.Config,
 		StateCfg:             cx.StateCfg,
@@ -2658,10 +2932,16 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 		s.CFIndex = indexers.NewCfIndex(db, cx.ActiveNet)
 		indexes = append(indexes, s.CFIndex)
 	}
+	if *cx.Config.SpendIndex {
+		Info("spent output index is enabled")
+		s.SpendIndex = indexers.NewSpendIndex(db)
+		indexes = append(indexes, s.SpendIndex)
+	}
 	// Create an index manager if any of the optional indexes are enabled.
 	var indexManager blockchain.IndexManager
 	if len(indexes) > 0 {
-		indexManager = indexers.NewManager(db, indexes)
+		s.IndexManager = indexers.NewManager(db, indexes)
+		indexManager = s.IndexManager
 	}
 	// Merge given checkpoints with the default ones unless they are disabled.
 	var checkpoints []chaincfg.Checkpoint
@@ -2735,6 +3015,7 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 			MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost / 4,
 			MinRelayTxFee:        cx.StateCfg.ActiveMinRelayTxFee,
 			MaxTxVersion:         2,
+			RejectReplacement:    *cx.Config.RejectReplacement,
 		},
 		ChainParams:   cx.ActiveNet,
 		FetchUtxoView: s.Chain.FetchUtxoView,
@@ -2821,6 +3102,20 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 				if s.OutboundGroupCount(key) != 0 {
 					continue
 				}
+				// Skip addresses of networks that can't be dialled. There is no I2P support, and onion services need
+				// tor to be enabled.
+				switch addr.NetID() {
+				case wire.NetI2P:
+					continue
+				case wire.NetTorV2, wire.NetTorV3:
+					if !*cx.Config.Onion {
+						continue
+					}
+				}
+				// Skip addresses in banned subnets, which would be disconnected as soon as they connect.
+				if _, banned := s.Bans.Banned(addr.NetAddress().IP); banned {
+					continue
+				}
 				// only allow recent nodes (10 min) after we failed 30 times
 				if tries < 30 && time.Since(addr.LastAttempt()) < 10*time.Minute {
 					continue
@@ -2846,6 +3141,7 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 			&connmgr.Config{
 				Listeners:      listeners,
 				OnAccept:       s.InboundPeerConnected,
+				Bans:           s.Bans,
 				RetryDuration:  ConnectionRetryInterval,
 				TargetOutbound: uint32(targetOutbound),
 				Dial:           Dial(cx.StateCfg),
@@ -2906,9 +3202,12 @@ func NewNode(listenAddrs []string, db database.DB, interruptChan qu.C, cx *Conte
 					TxIndex:      s.TxIndex,
 					AddrIndex:    s.AddrIndex,
 					CfIndex:      s.CFIndex,
+					SpendIndex:   s.SpendIndex,
+					IndexManager: s.IndexManager,
 					FeeEstimator: s.FeeEstimator,
 					Algo:         l,
 					Hashrate:     cx.Hashrate,
+					Hooks:        cx.Hooks,
 					Quit:         s.Quit,
 				}, cx.StateCfg, cx.Config,
 			)
@@ -414,6 +414,117 @@ func (c *Client) GetMempoolEntry(txHash string) (*btcjson.GetMempoolEntryResult,
 	return c.GetMempoolEntryAsync(txHash).Receive()
 }
 
+// FutureGetMempoolPackageResult is a future promise to deliver the result of a GetMempoolAncestorsAsync or
+// GetMempoolDescendantsAsync RPC invocation (or an applicable error).
+type FutureGetMempoolPackageResult chan *response
+
+// Receive waits for the response promised by the future and returns the hashes of the related transactions in the
+// memory pool.
+func (r FutureGetMempoolPackageResult) Receive() ([]*chainhash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal the result as an array of strings.
+	var txHashStrs []string
+	err = js.Unmarshal(res, &txHashStrs)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Create a slice of ShaHash arrays from the string slice.
+	txHashes := make([]*chainhash.Hash, 0, len(txHashStrs))
+	for _, hashStr := range txHashStrs {
+		txHash, err := chainhash.NewHashFromStr(hashStr)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		txHashes = append(txHashes, txHash)
+	}
+	return txHashes, nil
+}
+
+// FutureGetMempoolPackageVerboseResult is a future promise to deliver the result of a GetMempoolAncestorsVerboseAsync
+// or GetMempoolDescendantsVerboseAsync RPC invocation (or an applicable error).
+type FutureGetMempoolPackageVerboseResult chan *response
+
+// Receive waits for the response promised by the future and returns a map of transaction hashes to an associated
+// data structure with information about the related transactions in the memory pool.
+func (r FutureGetMempoolPackageVerboseResult) Receive() (map[string]btcjson.GetMempoolEntryResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal the result as a map of strings (tx shas) to their detailed results.
+	var mempoolItems map[string]btcjson.GetMempoolEntryResult
+	err = js.Unmarshal(res, &mempoolItems)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return mempoolItems, nil
+}
+
+// GetMempoolAncestorsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetMempoolAncestors for the blocking version and
+// more details.
+func (c *Client) GetMempoolAncestorsAsync(txHash string) FutureGetMempoolPackageResult {
+	cmd := btcjson.NewGetMempoolAncestorsCmd(txHash, btcjson.Bool(false))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolAncestors returns the hashes of all the in-mempool ancestors of the given transaction. See
+// GetMempoolAncestorsVerbose to retrieve data structures with information about the transactions instead.
+func (c *Client) GetMempoolAncestors(txHash string) ([]*chainhash.Hash, error) {
+	return c.GetMempoolAncestorsAsync(txHash).Receive()
+}
+
+// GetMempoolAncestorsVerboseAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance. See GetMempoolAncestorsVerbose for the
+// blocking version and more details.
+func (c *Client) GetMempoolAncestorsVerboseAsync(txHash string) FutureGetMempoolPackageVerboseResult {
+	cmd := btcjson.NewGetMempoolAncestorsCmd(txHash, btcjson.Bool(true))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolAncestorsVerbose returns a map of transaction hashes to an associated data structure with information
+// about each in-mempool ancestor of the given transaction. See GetMempoolAncestors to retrieve only the hashes instead.
+func (c *Client) GetMempoolAncestorsVerbose(txHash string) (map[string]btcjson.GetMempoolEntryResult, error) {
+	return c.GetMempoolAncestorsVerboseAsync(txHash).Receive()
+}
+
+// GetMempoolDescendantsAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance. See GetMempoolDescendants for the blocking
+// version and more details.
+func (c *Client) GetMempoolDescendantsAsync(txHash string) FutureGetMempoolPackageResult {
+	cmd := btcjson.NewGetMempoolDescendantsCmd(txHash, btcjson.Bool(false))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolDescendants returns the hashes of all the in-mempool descendants of the given transaction. See
+// GetMempoolDescendantsVerbose to retrieve data structures with information about the transactions instead.
+func (c *Client) GetMempoolDescendants(txHash string) ([]*chainhash.Hash, error) {
+	return c.GetMempoolDescendantsAsync(txHash).Receive()
+}
+
+// GetMempoolDescendantsVerboseAsync returns an instance of a type that can be used to get the result of the RPC at
+// some future time by invoking the Receive function on the returned instance. See GetMempoolDescendantsVerbose for the
+// blocking version and more details.
+func (c *Client) GetMempoolDescendantsVerboseAsync(txHash string) FutureGetMempoolPackageVerboseResult {
+	cmd := btcjson.NewGetMempoolDescendantsCmd(txHash, btcjson.Bool(true))
+	return c.sendCmd(cmd)
+}
+
+// GetMempoolDescendantsVerbose returns a map of transaction hashes to an associated data structure with information
+// about each in-mempool descendant of the given transaction. See GetMempoolDescendants to retrieve only the hashes
+// instead.
+func (c *Client) GetMempoolDescendantsVerbose(txHash string) (map[string]btcjson.GetMempoolEntryResult, error) {
+	return c.GetMempoolDescendantsVerboseAsync(txHash).Receive()
+}
+
 // FutureGetRawMempoolResult is a future promise to deliver the result of a GetRawMempoolAsync RPC invocation (or an
 // applicable error).
 type FutureGetRawMempoolResult chan *response
@@ -708,6 +819,91 @@ func (c *Client) InvalidateBlock(blockHash *chainhash.Hash) error {
 	return c.InvalidateBlockAsync(blockHash).Receive()
 }
 
+// FutureReconsiderBlockResult is a future promise to deliver the result of a ReconsiderBlockAsync RPC invocation (or
+// an applicable error).
+type FutureReconsiderBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the block could not be reconsidered.
+func (r FutureReconsiderBlockResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ReconsiderBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See ReconsiderBlock for the blocking version and more
+// details.
+func (c *Client) ReconsiderBlockAsync(blockHash *chainhash.Hash) FutureReconsiderBlockResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+	cmd := btcjson.NewReconsiderBlockCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// ReconsiderBlock removes the invalid status from a block previously marked with InvalidateBlock.
+func (c *Client) ReconsiderBlock(blockHash *chainhash.Hash) error {
+	return c.ReconsiderBlockAsync(blockHash).Receive()
+}
+
+// FuturePreciousBlockResult is a future promise to deliver the result of a PreciousBlockAsync RPC invocation (or an
+// applicable error).
+type FuturePreciousBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the block could not be preferred.
+func (r FuturePreciousBlockResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// PreciousBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See PreciousBlock for the blocking version and more
+// details.
+func (c *Client) PreciousBlockAsync(blockHash *chainhash.Hash) FuturePreciousBlockResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+	cmd := btcjson.NewPreciousBlockCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// PreciousBlock treats a block as if it were received before others with the same amount of work.
+func (c *Client) PreciousBlock(blockHash *chainhash.Hash) error {
+	return c.PreciousBlockAsync(blockHash).Receive()
+}
+
+// FutureGetChainTipsResult is a future promise to deliver the result of a GetChainTipsAsync RPC invocation (or an
+// applicable error).
+type FutureGetChainTipsResult chan *response
+
+// Receive waits for the response promised by the future and returns the known chain tips.
+func (r FutureGetChainTipsResult) Receive() ([]btcjson.GetChainTipsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var tips []btcjson.GetChainTipsResult
+	if err = js.Unmarshal(res, &tips); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return tips, nil
+}
+
+// GetChainTipsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetChainTips for the blocking version and more details.
+func (c *Client) GetChainTipsAsync() FutureGetChainTipsResult {
+	cmd := btcjson.NewGetChainTipsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetChainTips returns information about all known tips in the block tree.
+func (c *Client) GetChainTips() ([]btcjson.GetChainTipsResult, error) {
+	return c.GetChainTipsAsync().Receive()
+}
+
 // FutureGetCFilterResult is a future promise to deliver the result of a GetCFilterAsync RPC invocation (or an
 // applicable error).
 type FutureGetCFilterResult chan *response
@@ -281,6 +281,31 @@ func (c *Client) ListSinceBlockMinConf(blockHash *chainhash.Hash, minConfirms in
 	return c.ListSinceBlockMinConfAsync(blockHash, minConfirms).Receive()
 }
 
+// FutureSetTxLabelResult is a future promise to deliver the result of a SetTxLabelAsync RPC invocation (or an
+// applicable error).
+type FutureSetTxLabelResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of setting the comment kept with a
+// transaction.
+func (r FutureSetTxLabelResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetTxLabelAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SetTxLabel for the blocking version and more details.
+func (c *Client) SetTxLabelAsync(txHash *chainhash.Hash, label string) FutureSetTxLabelResult {
+	cmd := btcjson.NewSetTxLabelCmd(txHash.String(), label)
+	return c.sendCmd(cmd)
+}
+
+// SetTxLabel replaces the comment kept with a wallet transaction. An empty label removes it.
+func (c *Client) SetTxLabel(txHash *chainhash.Hash, label string) error {
+	return c.SetTxLabelAsync(txHash, label).Receive()
+}
+
 // **************************
 // Transaction Send Functions
 // **************************
@@ -429,7 +454,7 @@ func (r FutureSendToAddressResult) Receive() (*chainhash.Hash, error) {
 // See SendToAddress for the blocking version and more details.
 func (c *Client) SendToAddressAsync(address util.Address, amount util.Amount) FutureSendToAddressResult {
 	addr := address.EncodeAddress()
-	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil)
+	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -454,7 +479,7 @@ func (c *Client) SendToAddressCommentAsync(address util.Address,
 	commentTo string) FutureSendToAddressResult {
 	addr := address.EncodeAddress()
 	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), &comment,
-		&commentTo)
+		&commentTo, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -472,6 +497,26 @@ func (c *Client) SendToAddressComment(address util.Address, amount util.Amount,
 		commentTo).Receive()
 }
 
+// SendToAddressCoinControlAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See SendToAddressCoinControl for the blocking version and more details.
+func (c *Client) SendToAddressCoinControlAsync(address util.Address, amount util.Amount, comment, commentTo string,
+	options *btcjson.CoinControlOpts) FutureSendToAddressResult {
+	addr := address.EncodeAddress()
+	cmd := btcjson.NewSendToAddressCmd(addr, amount.ToDUO(), &comment, &commentTo, options)
+	return c.sendCmd(cmd)
+}
+
+// SendToAddressCoinControl sends the passed amount to the given address like SendToAddressComment, spending the
+// outputs of the wallet chosen by the options, or chosen with the strategy they name.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendToAddressCoinControl(address util.Address, amount util.Amount, comment, commentTo string,
+	options *btcjson.CoinControlOpts) (*chainhash.Hash, error) {
+	return c.SendToAddressCoinControlAsync(address, amount, comment, commentTo, options).Receive()
+}
+
 // FutureSendFromResult is a future promise to deliver the result of a SendFromAsync, SendFromMinConfAsync, or
 // SendFromCommentAsync RPC invocation (or an applicable error).
 type FutureSendFromResult chan *response
@@ -596,7 +641,7 @@ func (c *Client) SendManyAsync(fromAccount string, amounts map[util.Address]util
 	for addr, amount := range amounts {
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
-	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil)
+	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -622,7 +667,7 @@ func (c *Client) SendManyMinConfAsync(fromAccount string,
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
 	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts,
-		&minConfirms, nil)
+		&minConfirms, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -650,7 +695,7 @@ func (c *Client) SendManyCommentAsync(fromAccount string,
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
 	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts,
-		&minConfirms, &comment)
+		&minConfirms, &comment, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -668,6 +713,29 @@ func (c *Client) SendManyComment(fromAccount string,
 		comment).Receive()
 }
 
+// SendManyCoinControlAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See SendManyCoinControl for the blocking version and more details.
+func (c *Client) SendManyCoinControlAsync(fromAccount string, amounts map[util.Address]util.Amount, minConfirms int,
+	comment string, options *btcjson.CoinControlOpts) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, &minConfirms, &comment, options)
+	return c.sendCmd(cmd)
+}
+
+// SendManyCoinControl sends multiple amounts to multiple addresses like SendManyComment, spending the outputs of the
+// wallet chosen by the options, or chosen with the strategy they name.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendManyCoinControl(fromAccount string, amounts map[util.Address]util.Amount, minConfirms int,
+	comment string, options *btcjson.CoinControlOpts) (*chainhash.Hash, error) {
+	return c.SendManyCoinControlAsync(fromAccount, amounts, minConfirms, comment, options).Receive()
+}
+
 // *************************
 // Address/Account Functions
 // *************************
@@ -985,6 +1053,32 @@ func (c *Client) SetAccount(address util.Address, account string) error {
 	return c.SetAccountAsync(address, account).Receive()
 }
 
+// FutureSetLabelResult is a future promise to deliver the result of a SetLabelAsync RPC invocation (or an applicable
+// error).
+type FutureSetLabelResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of setting the label of the passed
+// address.
+func (r FutureSetLabelResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetLabelAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SetLabel for the blocking version and more details.
+func (c *Client) SetLabelAsync(address util.Address, label string) FutureSetLabelResult {
+	cmd := btcjson.NewSetLabelCmd(address.EncodeAddress(), label)
+	return c.sendCmd(cmd)
+}
+
+// SetLabel sets the label of the passed address, which does not need to belong to the wallet. An empty label removes
+// it.
+func (c *Client) SetLabel(address util.Address, label string) error {
+	return c.SetLabelAsync(address, label).Receive()
+}
+
 // FutureGetAddressesByAccountResult is a future promise to deliver the result of a GetAddressesByAccountAsync RPC
 // invocation (or an applicable error).
 type FutureGetAddressesByAccountResult chan *response
@@ -2069,12 +2163,200 @@ func (c *Client) GetInfo() (*btcjson.InfoWalletResult, error) {
 	return c.GetInfoAsync().Receive()
 }
 
+// FutureGetWalletInfoResult is a future promise to deliver the result of a GetWalletInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetWalletInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the state of the wallet.
+func (r FutureGetWalletInfoResult) Receive() (*btcjson.GetWalletInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var infoRes btcjson.GetWalletInfoResult
+	err = js.Unmarshal(res, &infoRes)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &infoRes, nil
+}
+
+// GetWalletInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetWalletInfo for the blocking version and more details.
+func (c *Client) GetWalletInfoAsync() FutureGetWalletInfoResult {
+	cmd := btcjson.NewGetWalletInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetWalletInfo returns the balances, transaction count and lock state of the wallet.
+func (c *Client) GetWalletInfo() (*btcjson.GetWalletInfoResult, error) {
+	return c.GetWalletInfoAsync().Receive()
+}
+
+// FutureListAddressGroupingsResult is a future promise to deliver the result of a ListAddressGroupingsAsync RPC
+// invocation (or an applicable error).
+type FutureListAddressGroupingsResult chan *response
+
+// Receive waits for the response promised by the future and returns the groups of addresses whose common ownership is
+// visible on the block chain.
+func (r FutureListAddressGroupingsResult) Receive() ([][]btcjson.ListAddressGroupingsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var groups [][]btcjson.ListAddressGroupingsResult
+	err = js.Unmarshal(res, &groups)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return groups, nil
+}
+
+// ListAddressGroupingsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListAddressGroupings for the blocking version and more details.
+func (c *Client) ListAddressGroupingsAsync() FutureListAddressGroupingsResult {
+	cmd := btcjson.NewListAddressGroupingsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListAddressGroupings returns the addresses of the wallet grouped by the common ownership made visible by spending
+// them together or receiving change, with the balance and label of each.
+func (c *Client) ListAddressGroupings() ([][]btcjson.ListAddressGroupingsResult, error) {
+	return c.ListAddressGroupingsAsync().Receive()
+}
+
+// FutureBackupWalletResult is a future promise to deliver the result of a BackupWalletAsync RPC invocation (or an
+// applicable error).
+type FutureBackupWalletResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of backing up the wallet.
+func (r FutureBackupWalletResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// BackupWalletAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See BackupWallet for the blocking version and more details.
+func (c *Client) BackupWalletAsync(destination string) FutureBackupWalletResult {
+	cmd := btcjson.NewBackupWalletCmd(destination)
+	return c.sendCmd(cmd)
+}
+
+// BackupWallet makes the wallet server write a consistent copy of its database to the passed path, or into it when it
+// is a directory. The path is on the host of the wallet server.
+func (c *Client) BackupWallet(destination string) error {
+	return c.BackupWalletAsync(destination).Receive()
+}
+
+// FutureDumpWalletResult is a future promise to deliver the result of a DumpWalletAsync RPC invocation (or an
+// applicable error).
+type FutureDumpWalletResult chan *response
+
+// Receive waits for the response promised by the future and returns the path of the file the wallet was dumped to.
+func (r FutureDumpWalletResult) Receive() (string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	var dumpRes btcjson.DumpWalletResult
+	err = js.Unmarshal(res, &dumpRes)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	return dumpRes.Filename, nil
+}
+
+// DumpWalletAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See DumpWallet for the blocking version and more details.
+func (c *Client) DumpWalletAsync(filename string) FutureDumpWalletResult {
+	cmd := btcjson.NewDumpWalletCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// DumpWallet makes the wallet server write all of its keys, with their HD paths, labels and birthdays, to a new file
+// on its host. The wallet must be unlocked. The absolute path of the file is returned.
+func (c *Client) DumpWallet(filename string) (string, error) {
+	return c.DumpWalletAsync(filename).Receive()
+}
+
+// FutureImportWalletResult is a future promise to deliver the result of a ImportWalletAsync RPC invocation (or an
+// applicable error).
+type FutureImportWalletResult chan *response
+
+// Receive waits for the response promised by the future and returns the result of importing the wallet dump.
+func (r FutureImportWalletResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ImportWalletAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See ImportWallet for the blocking version and more details.
+func (c *Client) ImportWalletAsync(filename string) FutureImportWalletResult {
+	cmd := btcjson.NewImportWalletCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// ImportWallet makes the wallet server import the keys and labels of a file written by DumpWallet on its host, and
+// start a rescan for them. The wallet must be unlocked.
+func (c *Client) ImportWallet(filename string) error {
+	return c.ImportWalletAsync(filename).Receive()
+}
+
+// FutureBumpFeeResult is a future promise to deliver the result of a BumpFeeAsync RPC invocation (or an applicable
+// error).
+type FutureBumpFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash and fees of the replacement transaction.
+func (r FutureBumpFeeResult) Receive() (*btcjson.BumpFeeResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var bumpRes btcjson.BumpFeeResult
+	err = js.Unmarshal(res, &bumpRes)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &bumpRes, nil
+}
+
+// BumpFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See BumpFee for the blocking version and more details.
+func (c *Client) BumpFeeAsync(txHash *chainhash.Hash, options *btcjson.BumpFeeOpts) FutureBumpFeeResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+	cmd := btcjson.NewBumpFeeCmd(hash, options)
+	return c.sendCmd(cmd)
+}
+
+// BumpFee makes the wallet server replace one of its unconfirmed transactions that signals replaceability with one
+// paying a higher fee from its change, and publish it. Passing nil options uses the least fee the replacement needs.
+func (c *Client) BumpFee(txHash *chainhash.Hash, options *btcjson.BumpFeeOpts) (*btcjson.BumpFeeResult, error) {
+	return c.BumpFeeAsync(txHash, options).Receive()
+}
+
 // TODO(davec): Implement
-//  backupwallet (NYI in btcwallet)
 //  encryptwallet (Won't be supported by btcwallet since it's always encrypted)
-//  getwalletinfo (NYI in btcwallet or json)
-//  listaddressgroupings (NYI in btcwallet)
 //  listreceivedbyaccount (NYI in btcwallet)
-//  DUMP
-//  importwallet (NYI in btcwallet)
-//  dumpwallet (NYI in btcwallet)
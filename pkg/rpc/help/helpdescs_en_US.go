@@ -9,6 +9,23 @@ var helpDescsEnUS = map[string]string{
 	"addmultisigaddress-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
 	"addmultisigaddress-nrequired": "The number of signatures required to redeem outputs paid to this address",
 	"addmultisigaddress--result0":  "The imported pay-to-script-hash address",
+	// BackupWalletCmd help.
+	"backupwallet--synopsis":   "Writes a consistent copy of the wallet database while the wallet keeps running. The copy is complete once the call returns.",
+	"backupwallet-destination": "The path of the copy on the host of the wallet, or a directory to write it into",
+	// BumpFeeCmd help.
+	"bumpfee--synopsis": "Replaces an unconfirmed transaction of the wallet that signals replaceability with one paying a higher fee, taken from its change output.\n" +
+		"The replacement spends the same inputs to the same outputs and is published in place of the original.",
+	"bumpfee-txid":        "The hash of the transaction to replace",
+	"bumpfee-options":     "Options for the replacement",
+	"bumpfeeopts-feeRate": "The fee rate valued in bitcoin per kilobyte, by default the fee rate of the original increased by the minimum relay fee rate",
+	// BumpFeeResult help.
+	"bumpfeeresult-txid":    "The hash of the replacement transaction",
+	"bumpfeeresult-origfee": "The fee paid by the original transaction valued in bitcoin",
+	"bumpfeeresult-fee":     "The fee paid by the replacement transaction valued in bitcoin",
+	// CombinePsbtCmd help.
+	"combinepsbt--synopsis": "Combines several partially signed transactions of the same transaction into one, merging their signatures and other input and output data.",
+	"combinepsbt-psbts":     "The base64-encoded partially signed transactions to combine",
+	"combinepsbt--result0":  "The combined partially signed transaction encoded as a base64 string",
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -16,10 +33,95 @@ var helpDescsEnUS = map[string]string{
 	// CreateMultisigResult help.
 	"createmultisigresult-address":      "The generated pay-to-script-hash address",
 	"createmultisigresult-redeemScript": "The script required to redeem outputs paid to the multisig address",
+	// DecodePsbtCmd help.
+	"decodepsbt--synopsis": "Returns a JSON object describing a base64-encoded partially signed transaction.",
+	"decodepsbt-psbt":      "The partially signed transaction encoded as a base64 string",
+	// DecodePsbtResult help.
+	"decodepsbtresult-tx":             "The unsigned transaction",
+	"decodepsbtresult-unknown":        "Global entries of unknown type",
+	"decodepsbtresult-unknown--desc":  "JSON object with hex-encoded keys and values",
+	"decodepsbtresult-unknown--key":   "The hex-encoded key",
+	"decodepsbtresult-unknown--value": "The hex-encoded value",
+	"decodepsbtresult-inputs":         "The data about each input",
+	"decodepsbtresult-outputs":        "The data about each output",
+	"decodepsbtresult-fee":            "The fee paid by the transaction valued in bitcoin (only when the outputs spent by all inputs are known)",
+	// DecodePsbtInput help.
+	"decodepsbtinput-non_witness_utxo":          "The whole transaction the input spends an output of",
+	"decodepsbtinput-witness_utxo":              "The output the input spends",
+	"decodepsbtinput-partial_signatures":        "The signatures made so far",
+	"decodepsbtinput-partial_signatures--desc":  "JSON object with public keys as keys and signatures as values",
+	"decodepsbtinput-partial_signatures--key":   "The hex-encoded public key",
+	"decodepsbtinput-partial_signatures--value": "The hex-encoded signature",
+	"decodepsbtinput-sighash":                   "The signature hash type signatures must be made with",
+	"decodepsbtinput-redeem_script":             "The redeem script of a pay-to-script-hash output",
+	"decodepsbtinput-witness_script":            "The witness script of a witness script hash output",
+	"decodepsbtinput-bip32_derivs":              "The derivation paths of the public keys the input can be signed with",
+	"decodepsbtinput-final_scriptSig":           "The final signature script",
+	"decodepsbtinput-final_scriptwitness":       "The hex-encoded items of the final witness",
+	"decodepsbtinput-unknown":                   "Entries of unknown type",
+	"decodepsbtinput-unknown--desc":             "JSON object with hex-encoded keys and values",
+	"decodepsbtinput-unknown--key":              "The hex-encoded key",
+	"decodepsbtinput-unknown--value":            "The hex-encoded value",
+	// DecodePsbtOutput help.
+	"decodepsbtoutput-redeem_script":  "The redeem script of a pay-to-script-hash output",
+	"decodepsbtoutput-witness_script": "The witness script of a witness script hash output",
+	"decodepsbtoutput-bip32_derivs":   "The derivation paths of the public keys in the output script",
+	"decodepsbtoutput-unknown":        "Entries of unknown type",
+	"decodepsbtoutput-unknown--desc":  "JSON object with hex-encoded keys and values",
+	"decodepsbtoutput-unknown--key":   "The hex-encoded key",
+	"decodepsbtoutput-unknown--value": "The hex-encoded value",
+	// DecodePsbtUtxo help.
+	"decodepsbtutxo-amount":       "The value of the output valued in bitcoin",
+	"decodepsbtutxo-scriptPubKey": "The public key script of the output",
+	// DecodePsbtBip32Deriv help.
+	"decodepsbtbip32deriv-pubkey":             "The hex-encoded public key",
+	"decodepsbtbip32deriv-master_fingerprint": "The hex-encoded fingerprint of the master key",
+	"decodepsbtbip32deriv-path":               "The derivation path of the key from the master key",
+	// TxRawDecodeResult help.
+	"txrawdecoderesult-txid":     "The hash of the transaction",
+	"txrawdecoderesult-version":  "The transaction version",
+	"txrawdecoderesult-locktime": "The transaction lock time",
+	"txrawdecoderesult-vin":      "The transaction inputs as JSON objects",
+	"txrawdecoderesult-vout":     "The transaction outputs as JSON objects",
+	// Vin help.
+	"vin-coinbase":    "The hex-encoded bytes of the signature script (coinbase txns only)",
+	"vin-txid":        "The hash of the origin transaction (non-coinbase txns only)",
+	"vin-vout":        "The index of the output being redeemed from the origin transaction (non-coinbase txns only)",
+	"vin-scriptSig":   "The signature script used to redeem the origin transaction as a JSON object (non-coinbase txns only)",
+	"vin-txinwitness": "The witness stack of the input (only when it has one)",
+	"vin-sequence":    "The script sequence number",
+	// Vout help.
+	"vout-value":        "The amount in bitcoin",
+	"vout-n":            "The index of this transaction output",
+	"vout-scriptPubKey": "The public key script used to pay coins as a JSON object",
+	// ScriptSig help.
+	"scriptsig-asm": "Disassembly of the script",
+	"scriptsig-hex": "Hex-encoded bytes of the script",
+	// ScriptPubKeyResult help.
+	"scriptpubkeyresult-asm":       "Disassembly of the script",
+	"scriptpubkeyresult-hex":       "Hex-encoded bytes of the script",
+	"scriptpubkeyresult-reqSigs":   "The number of required signatures",
+	"scriptpubkeyresult-type":      "The type of the script (e.g. 'pubkeyhash')",
+	"scriptpubkeyresult-addresses": "The bitcoin addresses associated with this script",
 	// DumpPrivKeyCmd help.
 	"dumpprivkey--synopsis": "Returns the private key in WIF encoding that controls some wallet address.",
 	"dumpprivkey-address":   "The address to return a private key for",
 	"dumpprivkey--result0":  "The WIF-encoded private key",
+	// DumpWalletCmd help.
+	"dumpwallet--synopsis": "Writes all keys and redeem scripts of the wallet, with their HD key paths, labels and birthdays, to a new file that importwallet can read.\n" +
+		"The wallet must be unlocked, and an existing file is never overwritten.",
+	"dumpwallet-filename": "The path of the dump on the host of the wallet",
+	// DumpWalletResult help.
+	"dumpwalletresult-filename": "The absolute path of the dump",
+	// FinalizePsbtCmd help.
+	"finalizepsbt--synopsis": "Builds the final signature scripts of the inputs of a partially signed transaction that have all the signatures they need.\n" +
+		"When every input is finalized and extract is true, the signed transaction is returned ready to be broadcast.",
+	"finalizepsbt-psbt":    "The partially signed transaction encoded as a base64 string",
+	"finalizepsbt-extract": "Return the signed transaction instead of the partially signed one when it is complete",
+	// FinalizePsbtResult help.
+	"finalizepsbtresult-psbt":     "The partially signed transaction encoded as a base64 string (when the transaction was not extracted)",
+	"finalizepsbtresult-hex":      "The signed transaction encoded as a hexadecimal string (when the transaction was extracted)",
+	"finalizepsbtresult-complete": "Whether all inputs have been finalized",
 	// GetAccountCmd help.
 	"getaccount--synopsis": "DEPRECATED -- Lookup the account name that some wallet address belongs to.",
 	"getaccount-address":   "The address to query the account for",
@@ -108,6 +210,8 @@ var helpDescsEnUS = map[string]string{
 	"gettransactionresult-timereceived":    "The earliest Unix time this transaction was known to exist",
 	"gettransactionresult-details":         "Additional details for each recorded wallet credit and debit",
 	"gettransactionresult-hex":             "The transaction encoded as a hexadecimal string",
+	"gettransactionresult-comment":         "The comment kept with the transaction, if any",
+	"gettransactionresult-to":              "The name of who the transaction pays, if one was kept",
 	// GetTransactionDetailsResult help.
 	"gettransactiondetailsresult-account":           "DEPRECATED -- Unset",
 	"gettransactiondetailsresult-address":           "The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input",
@@ -116,11 +220,29 @@ var helpDescsEnUS = map[string]string{
 	"gettransactiondetailsresult-fee":               "The included fee for a sent transaction",
 	"gettransactiondetailsresult-vout":              "The transaction output index",
 	"gettransactiondetailsresult-involveswatchonly": "Unset",
+	"gettransactiondetailsresult-label":             "The label of the address an output was paid to, if any",
+	// GetWalletInfoCmd help.
+	"getwalletinfo--synopsis": "Returns the balances, transaction count and state of the wallet.",
+	// GetWalletInfoResult help.
+	"getwalletinforesult-walletversion":        "The version of the wallet database",
+	"getwalletinforesult-balance":              "The balance of outputs with at least one confirmation valued in bitcoin",
+	"getwalletinforesult-unconfirmed_balance":  "The balance of unconfirmed outputs valued in bitcoin",
+	"getwalletinforesult-immature_balance":     "The balance of coinbase outputs that are not mature yet valued in bitcoin",
+	"getwalletinforesult-txcount":              "The number of transactions known to the wallet",
+	"getwalletinforesult-paytxfee":             "The transaction fee rate in DUO/KB",
+	"getwalletinforesult-private_keys_enabled": "Whether the wallet holds private keys, false for a watching-only wallet",
+	"getwalletinforesult-unlocked":             "Whether the wallet is unlocked",
+	"getwalletinforesult-birthday":             "The earliest time a key of the wallet could have been used, in seconds since 1 Jan 1970 GMT",
+	"getwalletinforesult-syncedto":             "The height of the block the wallet is synced to",
 	// ImportPrivKeyCmd help.
 	"importprivkey--synopsis": "Imports a WIF-encoded private key to the 'imported' account.",
 	"importprivkey-privkey":   "The WIF-encoded private key",
 	"importprivkey-label":     "Unused (must be unset or 'imported')",
 	"importprivkey-rescan":    "Rescan the blockchain (since the genesis block) for outputs controlled by the imported key",
+	// ImportWalletCmd help.
+	"importwallet--synopsis": "Imports the keys, redeem scripts and labels of a file written by dumpwallet and starts a rescan for the keys that were not in the wallet yet.\n" +
+		"The wallet must be unlocked.",
+	"importwallet-filename": "The path of the dump on the host of the wallet",
 	// KeypoolRefillCmd help.
 	"keypoolrefill--synopsis": "DEPRECATED -- This request does nothing since no keypool is maintained.",
 	"keypoolrefill-newsize":   "Unused",
@@ -130,6 +252,13 @@ var helpDescsEnUS = map[string]string{
 	"listaccounts--result0--desc":  "JSON object with account names as keys and bitcoin amounts as values",
 	"listaccounts--result0--key":   "The account name",
 	"listaccounts--result0--value": "The account balance valued in bitcoin",
+	// ListAddressGroupingsCmd help.
+	"listaddressgroupings--synopsis": "Returns the addresses of the wallet grouped by the common ownership made visible on the block chain by spending them together or receiving change.\n" +
+		"The result is an array of groups, each of which is an array of the objects below.",
+	// ListAddressGroupingsResult help.
+	"listaddressgroupingsresult-address": "The payment address",
+	"listaddressgroupingsresult-amount":  "The unspent balance of the address valued in bitcoin",
+	"listaddressgroupingsresult-label":   "The label of the address, if it has one",
 	// ListLockUnspentCmd help.
 	"listlockunspent--synopsis": "Returns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.",
 	// TransactionInput help.
@@ -185,7 +314,9 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-time":               "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-timereceived":       "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-involveswatchonly":  "Unset",
-	"listtransactionsresult-comment":            "Unset",
+	"listtransactionsresult-comment":            "The comment kept with the transaction, if any",
+	"listtransactionsresult-to":                 "The name of who the transaction pays, if one was kept",
+	"listtransactionsresult-label":              "The label of the payment address, if any",
 	"listtransactionsresult-otheraccount":       "Unset",
 	"listtransactionsresult-trusted":            "Unset",
 	"listtransactionsresult-bip125-replaceable": "Unset",
@@ -226,8 +357,8 @@ var helpDescsEnUS = map[string]string{
 	"sendfrom-toaddress":   "Address to pay",
 	"sendfrom-amount":      "Amount to send to the payment address valued in bitcoin",
 	"sendfrom-minconf":     "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendfrom-comment":     "Unused",
-	"sendfrom-commentto":   "Unused",
+	"sendfrom-comment":     "A comment to keep with the transaction",
+	"sendfrom-commentto":   "The name of who the transaction pays, kept with the transaction",
 	"sendfrom--result0":    "The transaction hash of the sent transaction",
 	// SendManyCmd help.
 	"sendmany--synopsis": "Authors, signs, and sends a transaction that outputs to many payment addresses.\n" +
@@ -238,21 +369,36 @@ var helpDescsEnUS = map[string]string{
 	"sendmany-amounts--key":   "Address to pay",
 	"sendmany-amounts--value": "Amount to send to the payment address valued in bitcoin",
 	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendmany-comment":        "Unused",
+	"sendmany-comment":        "A comment to keep with the transaction",
+	"sendmany-options":        "Options choosing the unspent outputs the transaction spends",
 	"sendmany--result0":       "The transaction hash of the sent transaction",
+	// CoinControlOpts help.
+	"coincontrolopts-inputs": "Unspent outputs of the wallet to spend instead of choosing them. Exactly these are spent, whatever their number of confirmations, as giving inputs sets minconf to 0",
+	"coincontrolopts-strategy": "How to choose the outputs to spend when no inputs are given: largest-first (the default), " +
+		"branch-and-bound to avoid a change output, privacy to link as few addresses as possible, " +
+		"or consolidate-dust to spend the smallest outputs first",
 	// SendToAddressCmd help.
 	"sendtoaddress--synopsis": "Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"Unlike sendfrom, outputs are always chosen from the default account.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
 	"sendtoaddress-address":   "Address to pay",
 	"sendtoaddress-amount":    "Amount to send to the payment address valued in bitcoin",
-	"sendtoaddress-comment":   "Unused",
-	"sendtoaddress-commentto": "Unused",
+	"sendtoaddress-comment":   "A comment to keep with the transaction",
+	"sendtoaddress-commentto": "The name of who the transaction pays, kept with the transaction",
+	"sendtoaddress-options":   "Options choosing the unspent outputs the transaction spends",
 	"sendtoaddress--result0":  "The transaction hash of the sent transaction",
+	// SetLabelCmd help.
+	"setlabel--synopsis": "Sets the label of an address, which does not need to belong to the wallet. An empty label removes it.",
+	"setlabel-address":   "The address to label",
+	"setlabel-label":     "The label",
 	// SetTxFeeCmd help.
 	"settxfee--synopsis": "Modify the increment used each time more fee is required for an authored transaction.",
 	"settxfee-amount":    "The new fee increment valued in bitcoin",
 	"settxfee--result0":  "The boolean 'true'",
+	// SetTxLabelCmd help.
+	"settxlabel--synopsis": "Replaces the comment kept with a wallet transaction. An empty label removes it.",
+	"settxlabel-txid":      "The hash of the transaction",
+	"settxlabel-label":     "The comment to keep with the transaction",
 	// SignMessageCmd help.
 	"signmessage--synopsis": "Signs a message using the private key of a payment address.",
 	"signmessage-address":   "Payment address of private key used to sign the message with",
@@ -312,6 +458,34 @@ var helpDescsEnUS = map[string]string{
 	"walletpassphrasechange--synopsis":     "Change the wallet passphrase.",
 	"walletpassphrasechange-oldpassphrase": "The old wallet passphrase",
 	"walletpassphrasechange-newpassphrase": "The new wallet passphrase",
+	// WalletCreateFundedPsbtCmd help.
+	"walletcreatefundedpsbt--synopsis": "Creates a partially signed transaction paying the outputs, adding inputs from the wallet and a change output as needed to pay for them and the fee.\n" +
+		"The inputs are not signed; use walletprocesspsbt to sign them.",
+	"walletcreatefundedpsbt-inputs":            "Inputs that must be spent by the transaction",
+	"walletcreatefundedpsbt-outputs":           "Pairs of payment addresses and the output amount to pay each",
+	"walletcreatefundedpsbt-outputs--desc":     "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"walletcreatefundedpsbt-outputs--key":      "Address to pay",
+	"walletcreatefundedpsbt-outputs--value":    "Amount to send to the payment address valued in bitcoin",
+	"walletcreatefundedpsbt-locktime":          "The transaction lock time",
+	"walletcreatefundedpsbt-options":           "Options for choosing the inputs and change output",
+	"walletcreatefundedpsbtopts-account":       "The account to pick unspent outputs from and create the change address in",
+	"walletcreatefundedpsbtopts-changeAddress": "Address to pay the change to instead of a new change address",
+	"walletcreatefundedpsbtopts-feeRate":       "The fee rate valued in bitcoin per kilobyte, instead of the wallet fee rate",
+	"walletcreatefundedpsbtopts-lockUnspents":  "Lock the unspent outputs chosen as inputs",
+	"walletcreatefundedpsbtopts-minconf":       "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	// WalletCreateFundedPsbtResult help.
+	"walletcreatefundedpsbtresult-psbt":      "The partially signed transaction encoded as a base64 string",
+	"walletcreatefundedpsbtresult-fee":       "The fee paid by the transaction valued in bitcoin",
+	"walletcreatefundedpsbtresult-changepos": "The index of the change output, or -1 if there is none",
+	// WalletProcessPsbtCmd help.
+	"walletprocesspsbt--synopsis": "Adds the data the wallet has about the inputs and outputs of a partially signed transaction, and signs the inputs it has the keys for.\n" +
+		"The valid sighashtype options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.",
+	"walletprocesspsbt-psbt":        "The partially signed transaction encoded as a base64 string",
+	"walletprocesspsbt-sign":        "Sign the inputs as well as adding data to them",
+	"walletprocesspsbt-sighashtype": "The signature hash type to sign with",
+	// WalletProcessPsbtResult help.
+	"walletprocesspsbtresult-psbt":     "The partially signed transaction encoded as a base64 string",
+	"walletprocesspsbtresult-complete": "Whether all input signatures have been created",
 	// CreateNewAccountCmd help.
 	"createnewaccount--synopsis": "Creates a new account.\n" +
 		"The wallet must be unlocked for this request to succeed.",
@@ -21,8 +21,14 @@ var Methods = []struct {
 	ResultTypes []interface{}
 }{
 	{"addmultisigaddress", returnsString},
+	{"backupwallet", nil},
+	{"bumpfee", []interface{}{(*btcjson.BumpFeeResult)(nil)}},
+	{"combinepsbt", returnsString},
 	{"createmultisig", []interface{}{(*btcjson.CreateMultiSigResult)(nil)}},
+	{"decodepsbt", []interface{}{(*btcjson.DecodePsbtResult)(nil)}},
 	{"dumpprivkey", returnsString},
+	{"dumpwallet", []interface{}{(*btcjson.DumpWalletResult)(nil)}},
+	{"finalizepsbt", []interface{}{(*btcjson.FinalizePsbtResult)(nil)}},
 	{"getaccount", returnsString},
 	{"getaccountaddress", returnsString},
 	{"getaddressesbyaccount", returnsStringArray},
@@ -35,10 +41,13 @@ var Methods = []struct {
 	{"getreceivedbyaccount", returnsNumber},
 	{"getreceivedbyaddress", returnsNumber},
 	{"gettransaction", []interface{}{(*btcjson.GetTransactionResult)(nil)}},
+	{"getwalletinfo", []interface{}{(*btcjson.GetWalletInfoResult)(nil)}},
 	{"help", append(returnsString, returnsString[0])},
 	{"importprivkey", nil},
+	{"importwallet", nil},
 	{"keypoolrefill", nil},
 	{"listaccounts", []interface{}{(*map[string]float64)(nil)}},
+	{"listaddressgroupings", []interface{}{(*[][]btcjson.ListAddressGroupingsResult)(nil)}},
 	{"listlockunspent", []interface{}{(*[]btcjson.TransactionInput)(nil)}},
 	{"listreceivedbyaccount", []interface{}{(*[]btcjson.ListReceivedByAccountResult)(nil)}},
 	{"listreceivedbyaddress", []interface{}{(*[]btcjson.ListReceivedByAddressResult)(nil)}},
@@ -49,7 +58,9 @@ var Methods = []struct {
 	{"sendfrom", returnsString},
 	{"sendmany", returnsString},
 	{"sendtoaddress", returnsString},
+	{"setlabel", nil},
 	{"settxfee", returnsBool},
+	{"settxlabel", nil},
 	{"signmessage", returnsString},
 	{"signrawtransaction", []interface{}{(*btcjson.SignRawTransactionResult)(nil)}},
 	{"validateaddress", []interface{}{(*btcjson.ValidateAddressWalletResult)(nil)}},
@@ -57,6 +68,8 @@ var Methods = []struct {
 	{"walletlock", nil},
 	{"walletpassphrase", nil},
 	{"walletpassphrasechange", nil},
+	{"walletcreatefundedpsbt", []interface{}{(*btcjson.WalletCreateFundedPsbtResult)(nil)}},
+	{"walletprocesspsbt", []interface{}{(*btcjson.WalletProcessPsbtResult)(nil)}},
 	{"createnewaccount", nil},
 	{"exportwatchingwallet", returnsString},
 	{"getbestblock", []interface{}{(*btcjson.GetBestBlockResult)(nil)}},
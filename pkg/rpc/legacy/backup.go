@@ -0,0 +1,118 @@
+package legacy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// BackupWallet handles a backupwallet request by writing a consistent copy of the wallet database to the requested
+// path, or into it when it is a directory, without stopping the wallet.
+func BackupWallet(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.BackupWalletCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["backupwallet"],
+		}
+	}
+	if _, err := w.BackupWallet(cmd.Destination); err != nil {
+		Error(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "Error: wallet backup failed: " + err.Error(),
+		}
+	}
+	return nil, nil
+}
+
+// DumpWallet handles a dumpwallet request by writing all of the keys of the wallet, with their HD paths, labels and
+// birthdays, to a new file that importwallet can read back. An existing file is never overwritten.
+func DumpWallet(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.DumpWalletCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["dumpwallet"],
+		}
+	}
+	if w.Locked() {
+		return nil, &ErrWalletUnlockNeeded
+	}
+	filename, err := filepath.Abs(cmd.Filename)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		Error(err)
+		msg := err.Error()
+		if os.IsExist(err) {
+			msg = fmt.Sprintf("%s already exists. If you are sure this is what you want, move it out of the way first", filename)
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: msg,
+		}
+	}
+	if err = w.DumpWallet(f); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		Error(err)
+		// Leave no partial dump behind to be mistaken for a complete one.
+		_ = os.Remove(filename)
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, err
+	}
+	return btcjson.DumpWalletResult{Filename: filename}, nil
+}
+
+// ImportWallet handles an importwallet request by importing the keys, redeem scripts and labels of a file written by
+// dumpwallet and starting a rescan for the keys that were not in the wallet yet.
+func ImportWallet(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ImportWalletCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["importwallet"],
+		}
+	}
+	if w.Locked() {
+		return nil, &ErrWalletUnlockNeeded
+	}
+	f, err := os.Open(cmd.Filename)
+	if err != nil {
+		Error(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Cannot open wallet dump file: " + err.Error(),
+		}
+	}
+	defer func() {
+		if err := f.Close(); Check(err) {
+		}
+	}()
+	if _, err = w.ImportWallet(f); err != nil {
+		Error(err)
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: err.Error(),
+		}
+	}
+	return nil, nil
+}
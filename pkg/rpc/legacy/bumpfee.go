@@ -0,0 +1,56 @@
+package legacy
+
+import (
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// BumpFee handles a bumpfee request by replacing an unconfirmed transaction of the wallet with one that pays a higher
+// fee from its change, and publishing it.
+func BumpFee(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.BumpFeeCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["bumpfee"],
+		}
+	}
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+	if err != nil {
+		Error(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
+	}
+	var feeRate util.Amount
+	if cmd.Options != nil && cmd.Options.FeeRate != nil {
+		if feeRate, err = util.NewAmount(*cmd.Options.FeeRate); err != nil {
+			Error(err)
+			return nil, InvalidParameterError{err}
+		}
+	}
+	bumped, err := w.BumpFee(txHash, feeRate)
+	if err != nil {
+		Error(err)
+		switch {
+		case err == wallet.ErrBumpFeeUnknownTx:
+			return nil, &ErrNoTransactionInfo
+		case waddrmgr.IsError(err, waddrmgr.ErrLocked):
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: err.Error(),
+		}
+	}
+	return btcjson.BumpFeeResult{
+		TxID:    bumped.Tx.TxHash().String(),
+		OrigFee: bumped.OrigFee.ToDUO(),
+		Fee:     bumped.Fee.ToDUO(),
+	}, nil
+}
@@ -182,11 +182,16 @@ var handlers = handlersT{
 		ResType: "[]btcjson.ListUnspentResult",
 	},
 	{
-		Method:           "sendfrom",
-		Handler:          "LockUnspent",
-		HandlerWithChain: "LockUnspent",
-		Cmd:              "btcjson.LockUnspentCmd",
-		ResType:          "bool",
+		Method:  "lockunspent",
+		Handler: "LockUnspent",
+		Cmd:     "*btcjson.LockUnspentCmd",
+		ResType: "bool",
+	},
+	{
+		Method:  "sendfrom",
+		Handler: "SendFrom",
+		Cmd:     "*btcjson.SendFromCmd",
+		ResType: "string",
 	},
 	{
 		Method:  "sendmany",
@@ -200,12 +205,24 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SendToAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "setlabel",
+		Handler: "SetLabel",
+		Cmd:     "*btcjson.SetLabelCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "settxfee",
 		Handler: "SetTxFee",
 		Cmd:     "*btcjson.SetTxFeeCmd",
 		ResType: "bool",
 	},
+	{
+		Method:  "settxlabel",
+		Handler: "SetTxLabel",
+		Cmd:     "*btcjson.SetTxLabelCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "signmessage",
 		Handler: "SignMessage",
@@ -291,6 +308,72 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "bool",
 	},
+	{
+		Method:  "combinepsbt",
+		Handler: "CombinePsbt",
+		Cmd:     "*btcjson.CombinePsbtCmd",
+		ResType: "string",
+	},
+	{
+		Method:  "decodepsbt",
+		Handler: "DecodePsbt",
+		Cmd:     "*btcjson.DecodePsbtCmd",
+		ResType: "btcjson.DecodePsbtResult",
+	},
+	{
+		Method:  "finalizepsbt",
+		Handler: "FinalizePsbt",
+		Cmd:     "*btcjson.FinalizePsbtCmd",
+		ResType: "btcjson.FinalizePsbtResult",
+	},
+	{
+		Method:  "walletcreatefundedpsbt",
+		Handler: "WalletCreateFundedPsbt",
+		Cmd:     "*btcjson.WalletCreateFundedPsbtCmd",
+		ResType: "btcjson.WalletCreateFundedPsbtResult",
+	},
+	{
+		Method:  "walletprocesspsbt",
+		Handler: "WalletProcessPsbt",
+		Cmd:     "*btcjson.WalletProcessPsbtCmd",
+		ResType: "btcjson.WalletProcessPsbtResult",
+	},
+	{
+		Method:  "backupwallet",
+		Handler: "BackupWallet",
+		Cmd:     "*btcjson.BackupWalletCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "bumpfee",
+		Handler: "BumpFee",
+		Cmd:     "*btcjson.BumpFeeCmd",
+		ResType: "btcjson.BumpFeeResult",
+	},
+	{
+		Method:  "dumpwallet",
+		Handler: "DumpWallet",
+		Cmd:     "*btcjson.DumpWalletCmd",
+		ResType: "btcjson.DumpWalletResult",
+	},
+	{
+		Method:  "getwalletinfo",
+		Handler: "GetWalletInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetWalletInfoResult",
+	},
+	{
+		Method:  "importwallet",
+		Handler: "ImportWallet",
+		Cmd:     "*btcjson.ImportWalletCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "listaddressgroupings",
+		Handler: "ListAddressGroupings",
+		Cmd:     "*None",
+		ResType: "[][]btcjson.ListAddressGroupingsResult",
+	},
 	{
 		Method:  "dropwallethistory",
 		Handler: "HandleDropWalletHistory",
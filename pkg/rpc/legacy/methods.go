@@ -245,12 +245,6 @@ func Confirms(txHeight, curHeight int32) int32 {
 // 		Params:  make(chan btcjson.WalletPassphraseChangeCmd),
 // 		Return:  func() interface{} { return make(chan WalletPassphraseChangeRes) },
 // 	},
-// 	// Reference implementation methods (still unimplemented)
-// 	"backupwallet":         {Handler: Unimplemented, NoHelp: true},
-// 	"dumpwallet":           {Handler: Unimplemented, NoHelp: true},
-// 	"getwalletinfo":        {Handler: Unimplemented, NoHelp: true},
-// 	"importwallet":         {Handler: Unimplemented, NoHelp: true},
-// 	"listaddressgroupings": {Handler: Unimplemented, NoHelp: true},
 // 	// Reference methods which can't be implemented by btcwallet due to
 // 	// design decision differences
 // 	"encryptwallet": {Handler: Unsupported, NoHelp: true},
@@ -592,17 +586,6 @@ func DumpPrivKey(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCCl
 	return key, err
 }
 
-// // dumpWallet handles a dumpwallet request by returning  all private
-// // keys in a wallet, or an appropiate error if the wallet is locked.
-// // TODO: finish this to match bitcoind by writing the dump to a file.
-// func dumpWallet(// 	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
-// 	keys, err := w.DumpPrivKeys()
-// 	if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
-// 		return nil, &ErrWalletUnlockNeeded
-// 	}
-// 	return keys, err
-// }
-
 // GetAddressesByAccount handles a getaddressesbyaccount request by returning all addresses for an account, or an error
 // if the requested account does not exist.
 func GetAddressesByAccount(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
@@ -726,6 +709,33 @@ func GetInfo(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient
 	return info, nil
 }
 
+// GetWalletInfo handles a getwalletinfo request by returning the balances, transaction count and state of the wallet,
+// which unlike getinfo does not need the chain server.
+func GetWalletInfo(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	bals, err := w.CalculateWalletBalances(1)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	txCount, err := w.TxCount()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return btcjson.GetWalletInfoResult{
+		WalletVersion:      int32(waddrmgr.LatestMgrVersion),
+		Balance:            bals.Spendable.ToDUO(),
+		UnconfirmedBalance: (bals.Total - bals.Spendable - bals.ImmatureReward).ToDUO(),
+		ImmatureBalance:    bals.ImmatureReward.ToDUO(),
+		TxCount:            txCount,
+		PaytxFee:           txrules.DefaultRelayFeePerKb.ToDUO(),
+		PrivateKeysEnabled: !w.Manager.WatchOnly(),
+		Unlocked:           !w.Locked(),
+		Birthday:           w.Manager.Birthday().Unix(),
+		SyncedTo:           w.Manager.SyncedTo().Height,
+	}, nil
+}
+
 func DecodeAddress(s string, params *netparams.Params) (util.Address, error) {
 	addr, err := util.DecodeAddress(s, params)
 	if err != nil {
@@ -1096,12 +1106,19 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RP
 	}
 	// TODO: Add a "generated" field to this result type.  "generated":true
 	// is only added if the transaction is a coinbase.
+	comment, err := w.TxComment(txHash)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
 	ret := btcjson.GetTransactionResult{
 		TxID:            cmd.Txid,
 		Hex:             hex.EncodeToString(txBuf.Bytes()),
 		Time:            details.Received.Unix(),
 		TimeReceived:    details.Received.Unix(),
 		WalletConflicts: []string{}, // Not saved
+		Comment:         comment.Comment,
+		To:              comment.To,
 		// Generated:     blockchain.IsCoinBaseTx(&details.MsgTx),
 	}
 	if details.Block.Height != -1 {
@@ -1164,6 +1181,7 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RP
 		}
 		var address string
 		var accountName string
+		var label string
 		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
 			details.MsgTx.TxOut[cred.Index].PkScript, w.ChainParams(),
 		)
@@ -1177,6 +1195,8 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RP
 					accountName = name
 				}
 			}
+			if label, err = w.AddressLabel(addr); Check(err) {
+			}
 		}
 		ret.Details = append(
 			ret.Details, btcjson.GetTransactionDetailsResult{
@@ -1188,6 +1208,7 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RP
 				Category: credCat,
 				Amount:   cred.Amount.ToDUO(),
 				Vout:     cred.Index,
+				Label:    label,
 			},
 		)
 	}
@@ -1326,6 +1347,31 @@ func Help(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (int
 	}
 }
 
+// ListAddressGroupings handles a listaddressgroupings request by returning the addresses of the wallet grouped by the
+// common ownership that spending them together, or receiving change, makes visible on the block chain.
+func ListAddressGroupings(
+	icmd interface{}, w *wallet.Wallet,
+	chainClient ...*chain.RPCClient,
+) (interface{}, error) {
+	groups, err := w.AddressGroupings()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	res := make([][]btcjson.ListAddressGroupingsResult, len(groups))
+	for i, group := range groups {
+		res[i] = make([]btcjson.ListAddressGroupingsResult, len(group))
+		for j, a := range group {
+			res[i][j] = btcjson.ListAddressGroupingsResult{
+				Address: a.Address,
+				Amount:  a.Balance.ToDUO(),
+				Label:   a.Label,
+			}
+		}
+	}
+	return res, nil
+}
+
 // ListAccounts handles a listaccounts request by returning a map of account names to their balances.
 func ListAccounts(
 	icmd interface{}, w *wallet.Wallet,
@@ -1768,18 +1814,43 @@ func MakeOutputs(pairs map[string]util.Amount, chainParams *netparams.Params) ([
 	return outputs, nil
 }
 
+// ParseCoinControl converts the coin control options of a send command to the choice of outputs the wallet spends. Nil
+// options give nil, the default choice.
+func ParseCoinControl(opts *btcjson.CoinControlOpts) (*wallet.CoinControl, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	var cc wallet.CoinControl
+	var err error
+	if opts.Strategy != nil {
+		if cc.Strategy, err = wallet.ParseCoinSelectionStrategy(*opts.Strategy); err != nil {
+			return nil, InvalidParameterError{err}
+		}
+	}
+	for _, input := range opts.Inputs {
+		txHash, err := chainhash.NewHashFromStr(input.Txid)
+		if err != nil {
+			Error(err)
+			return nil, DeserializationError{err}
+		}
+		cc.Inputs = append(cc.Inputs, wire.OutPoint{Hash: *txHash, Index: input.Vout})
+	}
+	return &cc, nil
+}
+
 // SendPairs creates and sends payment transactions. It returns the transaction hash in string format upon success All
-// errors are returned in json.RPCError format
+// errors are returned in json.RPCError format. A non-nil coinControl chooses the outputs to spend, or how to choose them.
 func SendPairs(
 	w *wallet.Wallet, amounts map[string]util.Amount,
 	account uint32, minconf int32, feeSatPerKb util.Amount,
+	coinControl *wallet.CoinControl,
 ) (string, error) {
 	outputs, err := MakeOutputs(amounts, w.ChainParams())
 	if err != nil {
 		Error(err)
 		return "", err
 	}
-	txHash, err := w.SendOutputs(outputs, account, minconf, feeSatPerKb)
+	txHash, err := w.SendOutputs(outputs, account, minconf, feeSatPerKb, coinControl)
 	if err != nil {
 		Error(err)
 		if err == txrules.ErrAmountNegative {
@@ -1805,10 +1876,35 @@ func IsNilOrEmpty(s *string) bool {
 	return s == nil || *s == ""
 }
 
+// SaveTxComment keeps the comments given with a send request for the transaction it created. The transaction has
+// already been sent by then, so a failure is only logged rather than returned in place of the transaction hash.
+func SaveTxComment(w *wallet.Wallet, txHashStr string, comment, commentTo *string) {
+	if IsNilOrEmpty(comment) && IsNilOrEmpty(commentTo) {
+		return
+	}
+	txHash, err := chainhash.NewHashFromStr(txHashStr)
+	if err != nil {
+		Error(err)
+		return
+	}
+	var c wallet.TxComment
+	if comment != nil {
+		c.Comment = *comment
+	}
+	if commentTo != nil {
+		c.To = *commentTo
+	}
+	if err = w.SetTxComment(txHash, c); Check(err) {
+	}
+}
+
 // SendFrom handles a sendfrom RPC request by creating a new transaction spending unspent transaction outputs for a
 // wallet to another payment address. Leftover inputs not sent to the payment address or a fee for the miner are sent
 // back to a new address in the wallet. Upon success, the TxID for the created transaction is returned.
-func SendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+func SendFrom(
+	icmd interface{}, w *wallet.Wallet,
+	chainClient ...*chain.RPCClient,
+) (interface{}, error) {
 	cmd, ok := icmd.(*btcjson.SendFromCmd)
 	if !ok {
 		return nil, &btcjson.RPCError{
@@ -1817,13 +1913,6 @@ func SendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient)
 			// "invalid subcommand for addnode",
 		}
 	}
-	// Transaction comments are not yet supported. ScriptError instead of pretending to save them.
-	if !IsNilOrEmpty(cmd.Comment) || !IsNilOrEmpty(cmd.CommentTo) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
 	account, err := w.AccountNumber(
 		waddrmgr.KeyScopeBIP0044, cmd.FromAccount,
 	)
@@ -1848,10 +1937,15 @@ func SendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient)
 	pairs := map[string]util.Amount{
 		cmd.ToAddress: amt,
 	}
-	return SendPairs(
+	txHashStr, err := SendPairs(
 		w, pairs, account, minConf,
-		txrules.DefaultRelayFeePerKb,
+		txrules.DefaultRelayFeePerKb, nil,
 	)
+	if err != nil {
+		return nil, err
+	}
+	SaveTxComment(w, txHashStr, cmd.Comment, cmd.CommentTo)
+	return txHashStr, nil
 }
 
 // SendMany handles a sendmany RPC request by creating a new transaction spending unspent transaction outputs for a
@@ -1871,13 +1965,6 @@ func SendMany(
 			// "invalid subcommand for addnode",
 		}
 	}
-	// Transaction comments are not yet supported. ScriptError instead of pretending to save them.
-	if !IsNilOrEmpty(cmd.Comment) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
 	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
 	if err != nil {
 		Error(err)
@@ -1898,7 +1985,16 @@ func SendMany(
 		}
 		pairs[k] = amt
 	}
-	return SendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb)
+	coinControl, err := ParseCoinControl(cmd.Options)
+	if err != nil {
+		return nil, err
+	}
+	txHashStr, err := SendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb, coinControl)
+	if err != nil {
+		return nil, err
+	}
+	SaveTxComment(w, txHashStr, cmd.Comment, nil)
+	return txHashStr, nil
 }
 
 // SendToAddress handles a sendtoaddress RPC request by creating a new transaction spending unspent transaction outputs
@@ -1918,14 +2014,6 @@ func SendToAddress(
 			// "invalid subcommand for addnode",
 		}
 	}
-	// Transaction comments are not yet supported.  ScriptError instead of
-	// pretending to save them.
-	if !IsNilOrEmpty(cmd.Comment) || !IsNilOrEmpty(cmd.CommentTo) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
 	amt, err := util.NewAmount(cmd.Amount)
 	if err != nil {
 		Error(err)
@@ -1939,11 +2027,20 @@ func SendToAddress(
 	pairs := map[string]util.Amount{
 		cmd.Address: amt,
 	}
+	coinControl, err := ParseCoinControl(cmd.Options)
+	if err != nil {
+		return nil, err
+	}
 	// sendtoaddress always spends from the default account, this matches bitcoind
-	return SendPairs(
+	txHashStr, err := SendPairs(
 		w, pairs, waddrmgr.DefaultAccountNum, 1,
-		txrules.DefaultRelayFeePerKb,
+		txrules.DefaultRelayFeePerKb, coinControl,
 	)
+	if err != nil {
+		return nil, err
+	}
+	SaveTxComment(w, txHashStr, cmd.Comment, cmd.CommentTo)
+	return txHashStr, nil
 }
 
 // SetTxFee sets the transaction fee per kilobyte added to transactions.
@@ -1967,6 +2064,65 @@ func SetTxFee(
 	return true, nil
 }
 
+// SetLabel handles a setlabel request by setting the label of an address, which does not need to belong to the wallet.
+// An empty label removes it.
+func SetLabel(
+	icmd interface{}, w *wallet.Wallet,
+	chainClient ...*chain.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SetLabelCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["setlabel"],
+		}
+	}
+	addr, err := DecodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return nil, w.SetAddressLabel(addr, cmd.Label)
+}
+
+// SetTxLabel handles a settxlabel request by replacing the comment kept for a wallet transaction. An empty label
+// removes it.
+func SetTxLabel(
+	icmd interface{}, w *wallet.Wallet,
+	chainClient ...*chain.RPCClient,
+) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SetTxLabelCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["settxlabel"],
+		}
+	}
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+	if err != nil {
+		Error(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
+	}
+	details, err := wallet.ExposeUnstableAPI(w).TxDetails(txHash)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if details == nil {
+		return nil, &ErrNoTransactionInfo
+	}
+	c, err := w.TxComment(txHash)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	c.Comment = cmd.Label
+	return nil, w.SetTxComment(txHash, c)
+}
+
 // SignMessage signs the given message with the private key for the given address
 func SignMessage(
 	icmd interface{}, w *wallet.Wallet,
@@ -2013,6 +2169,16 @@ func SignMessage(
 	return base64.StdEncoding.EncodeToString(sigbytes), nil
 }
 
+// SigHashTypes maps the names of the signature hash types accepted by the signing commands to the types.
+var SigHashTypes = map[string]txscript.SigHashType{
+	"ALL":                 txscript.SigHashAll,
+	"NONE":                txscript.SigHashNone,
+	"SINGLE":              txscript.SigHashSingle,
+	"ALL|ANYONECANPAY":    txscript.SigHashAll | txscript.SigHashAnyOneCanPay,
+	"NONE|ANYONECANPAY":   txscript.SigHashNone | txscript.SigHashAnyOneCanPay,
+	"SINGLE|ANYONECANPAY": txscript.SigHashSingle | txscript.SigHashAnyOneCanPay,
+}
+
 // SignRawTransaction handles the signrawtransaction command.
 func SignRawTransaction(
 	icmd interface{}, w *wallet.Wallet,
@@ -2045,21 +2211,8 @@ func SignRawTransaction(
 		e := errors.New("TX decode failed")
 		return nil, DeserializationError{e}
 	}
-	var hashType txscript.SigHashType
-	switch *cmd.Flags {
-	case "ALL":
-		hashType = txscript.SigHashAll
-	case "NONE":
-		hashType = txscript.SigHashNone
-	case "SINGLE":
-		hashType = txscript.SigHashSingle
-	case "ALL|ANYONECANPAY":
-		hashType = txscript.SigHashAll | txscript.SigHashAnyOneCanPay
-	case "NONE|ANYONECANPAY":
-		hashType = txscript.SigHashNone | txscript.SigHashAnyOneCanPay
-	case "SINGLE|ANYONECANPAY":
-		hashType = txscript.SigHashSingle | txscript.SigHashAnyOneCanPay
-	default:
+	hashType, ok := SigHashTypes[*cmd.Flags]
+	if !ok {
 		e := errors.New("invalid sighash parameter")
 		return nil, InvalidParameterError{e}
 	}
@@ -0,0 +1,456 @@
+package legacy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// decodePsbt decodes a base64 encoded partially signed transaction passed to a command.
+func decodePsbt(s string) (*psbt.Packet, error) {
+	p, err := psbt.NewFromRawBytes(strings.NewReader(s), true)
+	if err != nil {
+		Error(err)
+		return nil, DeserializationError{fmt.Errorf("PSBT decode failed: %v", err)}
+	}
+	return p, nil
+}
+
+// encodePsbt returns the base64 encoding of a partially signed transaction returned by a command.
+func encodePsbt(p *psbt.Packet) (string, error) {
+	s, err := p.B64Encode()
+	if err != nil {
+		Error(err)
+		return "", &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInternal.Code,
+			Message: err.Error(),
+		}
+	}
+	return s, nil
+}
+
+// WalletCreateFundedPsbt handles the walletcreatefundedpsbt command by creating a partially signed transaction paying
+// to the requested outputs, funded from the outputs of the wallet, that can then be passed to the signers.
+func WalletCreateFundedPsbt(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{},
+	error) {
+	cmd, ok := icmd.(*btcjson.WalletCreateFundedPsbtCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["walletcreatefundedpsbt"],
+		}
+	}
+	inputs := make([]wire.OutPoint, 0, len(cmd.Inputs))
+	for _, input := range cmd.Inputs {
+		txHash, err := chainhash.NewHashFromStr(input.Txid)
+		if err != nil {
+			Error(err)
+			return nil, ParseError{err}
+		}
+		inputs = append(inputs, wire.OutPoint{Hash: *txHash, Index: input.Vout})
+	}
+	pairs := make(map[string]util.Amount, len(cmd.Outputs))
+	for k, v := range cmd.Outputs {
+		amt, err := util.NewAmount(v)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		if amt <= 0 {
+			return nil, ErrNeedPositiveAmount
+		}
+		pairs[k] = amt
+	}
+	outputs, err := MakeOutputs(pairs, w.ChainParams())
+	if err != nil {
+		Error(err)
+		return nil, InvalidParameterError{err}
+	}
+	var lockTime uint32
+	if cmd.LockTime != nil {
+		lockTime = *cmd.LockTime
+	}
+	opts := cmd.Options
+	if opts == nil {
+		opts = &btcjson.WalletCreateFundedPsbtOpts{}
+	}
+	account := uint32(waddrmgr.DefaultAccountNum)
+	if opts.Account != nil {
+		if account, err = w.AccountNumber(waddrmgr.KeyScopeBIP0044, *opts.Account); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	minConf := int32(1)
+	if opts.MinConf != nil {
+		if minConf = int32(*opts.MinConf); minConf < 0 {
+			return nil, ErrNeedPositiveMinconf
+		}
+	}
+	feeRate := txrules.DefaultRelayFeePerKb
+	if opts.FeeRate != nil {
+		if feeRate, err = util.NewAmount(*opts.FeeRate); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	var changeScript []byte
+	if opts.ChangeAddress != nil {
+		addr, err := DecodeAddress(*opts.ChangeAddress, w.ChainParams())
+		if err != nil {
+			return nil, err
+		}
+		if changeScript, err = txscript.PayToAddrScript(addr); err != nil {
+			Error(err)
+			return nil, InvalidParameterError{err}
+		}
+	}
+	lockUnspents := opts.LockUnspents != nil && *opts.LockUnspents
+	p, fee, changePos, err := w.FundPsbt(inputs, outputs, lockTime, account, minConf, feeRate, changeScript,
+		lockUnspents)
+	if err != nil {
+		Error(err)
+		switch {
+		case waddrmgr.IsError(err, waddrmgr.ErrLocked):
+			return nil, &ErrWalletUnlockNeeded
+		case err == wallet.ErrInsufficientFunds:
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCWalletInsufficientFunds,
+				Message: err.Error(),
+			}
+		}
+		return nil, err
+	}
+	encoded, err := encodePsbt(p)
+	if err != nil {
+		return nil, err
+	}
+	return btcjson.WalletCreateFundedPsbtResult{
+		Psbt:      encoded,
+		Fee:       fee.ToDUO(),
+		ChangePos: int64(changePos),
+	}, nil
+}
+
+// WalletProcessPsbt handles the walletprocesspsbt command by adding what the wallet knows about the inputs of a
+// partially signed transaction and, unless asked not to, signing the inputs it holds keys for.
+func WalletProcessPsbt(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.WalletProcessPsbtCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["walletprocesspsbt"],
+		}
+	}
+	p, err := decodePsbt(cmd.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	hashType, ok := SigHashTypes[*cmd.SighashType]
+	if !ok {
+		e := errors.New("invalid sighash parameter")
+		return nil, InvalidParameterError{e}
+	}
+	complete, err := w.ProcessPsbt(p, *cmd.Sign, hashType)
+	if err != nil {
+		Error(err)
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, err
+	}
+	encoded, err := encodePsbt(p)
+	if err != nil {
+		return nil, err
+	}
+	return btcjson.WalletProcessPsbtResult{
+		Psbt:     encoded,
+		Complete: complete,
+	}, nil
+}
+
+// FinalizePsbt handles the finalizepsbt command by building the final scripts of the inputs that have all their
+// signatures and, once every input has them, returning the signed transaction ready to be broadcast.
+func FinalizePsbt(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.FinalizePsbtCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["finalizepsbt"],
+		}
+	}
+	p, err := decodePsbt(cmd.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	if err = psbt.MaybeFinalizeAll(p); err != nil {
+		Error(err)
+		return nil, InvalidParameterError{err}
+	}
+	result := btcjson.FinalizePsbtResult{Complete: p.IsComplete()}
+	if result.Complete && *cmd.Extract {
+		tx, err := psbt.Extract(p)
+		if err != nil {
+			Error(err)
+			return nil, InvalidParameterError{err}
+		}
+		var buf bytes.Buffer
+		if err = tx.Serialize(&buf); err != nil {
+			Error(err)
+			return nil, err
+		}
+		result.Hex = hex.EncodeToString(buf.Bytes())
+		return result, nil
+	}
+	if result.Psbt, err = encodePsbt(p); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CombinePsbt handles the combinepsbt command by merging copies of a partially signed transaction that have been
+// signed separately, such as by each of the holders of the keys to a multisig address.
+func CombinePsbt(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CombinePsbtCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["combinepsbt"],
+		}
+	}
+	if len(cmd.Psbts) == 0 {
+		e := errors.New("at least one partially signed transaction is required")
+		return nil, InvalidParameterError{e}
+	}
+	packets := make([]*psbt.Packet, len(cmd.Psbts))
+	for i, s := range cmd.Psbts {
+		var err error
+		if packets[i], err = decodePsbt(s); err != nil {
+			return nil, err
+		}
+	}
+	combined, err := psbt.Combine(packets...)
+	if err != nil {
+		Error(err)
+		return nil, InvalidParameterError{err}
+	}
+	return encodePsbt(combined)
+}
+
+// DecodePsbt handles the decodepsbt command by returning the contents of a partially signed transaction as JSON, so
+// the signers can check what they are signing.
+func DecodePsbt(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.DecodePsbtCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["decodepsbt"],
+		}
+	}
+	p, err := decodePsbt(cmd.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	params := w.ChainParams()
+	result := btcjson.DecodePsbtResult{
+		Tx:      DecodeTx(p.UnsignedTx, params),
+		Unknown: decodeUnknowns(p.Unknowns),
+		Inputs:  make([]btcjson.DecodePsbtInput, len(p.Inputs)),
+		Outputs: make([]btcjson.DecodePsbtOutput, len(p.Outputs)),
+	}
+	if result.Unknown == nil {
+		result.Unknown = map[string]string{}
+	}
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		res := &result.Inputs[i]
+		if in.NonWitnessUtxo != nil {
+			tx := DecodeTx(in.NonWitnessUtxo, params)
+			res.NonWitnessUtxo = &tx
+		}
+		if in.WitnessUtxo != nil {
+			res.WitnessUtxo = &btcjson.DecodePsbtUtxo{
+				Amount:       util.Amount(in.WitnessUtxo.Value).ToDUO(),
+				ScriptPubKey: ScriptPubKeyResult(in.WitnessUtxo.PkScript, params),
+			}
+		}
+		if len(in.PartialSigs) > 0 {
+			res.PartialSignatures = make(map[string]string, len(in.PartialSigs))
+			for _, ps := range in.PartialSigs {
+				res.PartialSignatures[hex.EncodeToString(ps.PubKey)] = hex.EncodeToString(ps.Signature)
+			}
+		}
+		if in.SighashType != 0 {
+			res.Sighash = SigHashTypeName(in.SighashType)
+		}
+		if in.RedeemScript != nil {
+			script := ScriptPubKeyResult(in.RedeemScript, params)
+			res.RedeemScript = &script
+		}
+		if in.WitnessScript != nil {
+			script := ScriptPubKeyResult(in.WitnessScript, params)
+			res.WitnessScript = &script
+		}
+		res.Bip32Derivs = decodeBip32Derivations(in.Bip32Derivation)
+		if in.FinalScriptSig != nil {
+			disbuf, _ := txscript.DisasmString(in.FinalScriptSig)
+			res.FinalScriptSig = &btcjson.ScriptSig{
+				Asm: disbuf,
+				Hex: hex.EncodeToString(in.FinalScriptSig),
+			}
+		}
+		if in.FinalScriptWitness != nil {
+			if witness, err := psbt.DeserializeTxWitness(in.FinalScriptWitness); err == nil {
+				res.FinalScriptWitness = witnessToHex(witness)
+			}
+		}
+		res.Unknown = decodeUnknowns(in.Unknowns)
+	}
+	for i := range p.Outputs {
+		out := &p.Outputs[i]
+		res := &result.Outputs[i]
+		if out.RedeemScript != nil {
+			script := ScriptPubKeyResult(out.RedeemScript, params)
+			res.RedeemScript = &script
+		}
+		if out.WitnessScript != nil {
+			script := ScriptPubKeyResult(out.WitnessScript, params)
+			res.WitnessScript = &script
+		}
+		res.Bip32Derivs = decodeBip32Derivations(out.Bip32Derivation)
+		res.Unknown = decodeUnknowns(out.Unknowns)
+	}
+	if fee, err := p.Fee(); err == nil {
+		duo := fee.ToDUO()
+		result.Fee = &duo
+	}
+	return result, nil
+}
+
+// SigHashTypeName returns the name of a signature hash type as the signing commands accept it, or the number for types
+// that have no name.
+func SigHashTypeName(hashType txscript.SigHashType) string {
+	for name, t := range SigHashTypes {
+		if t == hashType {
+			return name
+		}
+	}
+	return fmt.Sprint(uint32(hashType))
+}
+
+// DecodeTx returns the JSON form of a transaction, as the decoderawtransaction command of the node returns it.
+func DecodeTx(tx *wire.MsgTx, params *netparams.Params) btcjson.TxRawDecodeResult {
+	result := btcjson.TxRawDecodeResult{
+		Txid:     tx.TxHash().String(),
+		Version:  tx.Version,
+		Locktime: tx.LockTime,
+		Vin:      make([]btcjson.Vin, len(tx.TxIn)),
+		Vout:     make([]btcjson.Vout, len(tx.TxOut)),
+	}
+	isCoinBase := blockchain.IsCoinBaseTx(tx)
+	for i, txIn := range tx.TxIn {
+		vin := &result.Vin[i]
+		vin.Sequence = txIn.Sequence
+		vin.Witness = witnessToHex(txIn.Witness)
+		if isCoinBase {
+			vin.Coinbase = hex.EncodeToString(txIn.SignatureScript)
+			continue
+		}
+		vin.Txid = txIn.PreviousOutPoint.Hash.String()
+		vin.Vout = txIn.PreviousOutPoint.Index
+		disbuf, _ := txscript.DisasmString(txIn.SignatureScript)
+		vin.ScriptSig = &btcjson.ScriptSig{
+			Asm: disbuf,
+			Hex: hex.EncodeToString(txIn.SignatureScript),
+		}
+	}
+	for i, txOut := range tx.TxOut {
+		result.Vout[i] = btcjson.Vout{
+			Value:        util.Amount(txOut.Value).ToDUO(),
+			N:            uint32(i),
+			ScriptPubKey: ScriptPubKeyResult(txOut.PkScript, params),
+		}
+	}
+	return result
+}
+
+// ScriptPubKeyResult returns the JSON form of a script, with its disassembly, type and the addresses it pays to.
+func ScriptPubKeyResult(script []byte, params *netparams.Params) btcjson.ScriptPubKeyResult {
+	disbuf, _ := txscript.DisasmString(script)
+	// Ignore the error here since an error means the script couldn't parse and there is no additional information
+	// about it anyways.
+	class, addrs, reqSigs, _ := txscript.ExtractPkScriptAddrs(script, params)
+	addresses := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addresses[i] = addr.EncodeAddress()
+	}
+	return btcjson.ScriptPubKeyResult{
+		Asm:       disbuf,
+		Hex:       hex.EncodeToString(script),
+		ReqSigs:   int32(reqSigs),
+		Type:      class.String(),
+		Addresses: addresses,
+	}
+}
+
+// witnessToHex returns the items of a witness as hex strings.
+func witnessToHex(witness wire.TxWitness) []string {
+	if len(witness) == 0 {
+		return nil
+	}
+	items := make([]string, len(witness))
+	for i, item := range witness {
+		items[i] = hex.EncodeToString(item)
+	}
+	return items
+}
+
+// decodeBip32Derivations returns the JSON form of the key derivations of an input or output.
+func decodeBip32Derivations(derivations []*psbt.Bip32Derivation) []btcjson.DecodePsbtBip32Deriv {
+	var result []btcjson.DecodePsbtBip32Deriv
+	for _, d := range derivations {
+		path := "m"
+		for _, child := range d.Bip32Path {
+			if child >= 0x80000000 {
+				path += fmt.Sprintf("/%d'", child-0x80000000)
+			} else {
+				path += fmt.Sprintf("/%d", child)
+			}
+		}
+		fingerprint := []byte{byte(d.MasterKeyFingerprint), byte(d.MasterKeyFingerprint >> 8),
+			byte(d.MasterKeyFingerprint >> 16), byte(d.MasterKeyFingerprint >> 24)}
+		result = append(result, btcjson.DecodePsbtBip32Deriv{
+			PubKey:            hex.EncodeToString(d.PubKey),
+			MasterFingerprint: hex.EncodeToString(fingerprint),
+			Path:              path,
+		})
+	}
+	return result
+}
+
+// decodeUnknowns returns the key/value pairs of unknown type with hex encoded keys and values.
+func decodeUnknowns(unknowns []*psbt.Unknown) map[string]string {
+	if len(unknowns) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(unknowns))
+	for _, u := range unknowns {
+		result[hex.EncodeToString(u.Key)] = hex.EncodeToString(u.Value)
+	}
+	return result
+}
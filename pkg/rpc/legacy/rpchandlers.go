@@ -56,14 +56,26 @@ type (
 	None struct{} 
 	// AddMultiSigAddressRes is the result from a call to AddMultiSigAddress
 	AddMultiSigAddressRes struct { Res *string; Err error }
+	// BackupWalletRes is the result from a call to BackupWallet
+	BackupWalletRes struct { Res *None; Err error }
+	// BumpFeeRes is the result from a call to BumpFee
+	BumpFeeRes struct { Res *btcjson.BumpFeeResult; Err error }
+	// CombinePsbtRes is the result from a call to CombinePsbt
+	CombinePsbtRes struct { Res *string; Err error }
 	// CreateMultiSigRes is the result from a call to CreateMultiSig
 	CreateMultiSigRes struct { Res *btcjson.CreateMultiSigResult; Err error }
 	// CreateNewAccountRes is the result from a call to CreateNewAccount
 	CreateNewAccountRes struct { Res *None; Err error }
+	// DecodePsbtRes is the result from a call to DecodePsbt
+	DecodePsbtRes struct { Res *btcjson.DecodePsbtResult; Err error }
 	// HandleDropWalletHistoryRes is the result from a call to HandleDropWalletHistory
 	HandleDropWalletHistoryRes struct { Res *string; Err error }
 	// DumpPrivKeyRes is the result from a call to DumpPrivKey
 	DumpPrivKeyRes struct { Res *string; Err error }
+	// DumpWalletRes is the result from a call to DumpWallet
+	DumpWalletRes struct { Res *btcjson.DumpWalletResult; Err error }
+	// FinalizePsbtRes is the result from a call to FinalizePsbt
+	FinalizePsbtRes struct { Res *btcjson.FinalizePsbtResult; Err error }
 	// GetAccountRes is the result from a call to GetAccount
 	GetAccountRes struct { Res *string; Err error }
 	// GetAccountAddressRes is the result from a call to GetAccountAddress
@@ -92,14 +104,20 @@ type (
 	GetTransactionRes struct { Res *btcjson.GetTransactionResult; Err error }
 	// GetUnconfirmedBalanceRes is the result from a call to GetUnconfirmedBalance
 	GetUnconfirmedBalanceRes struct { Res *float64; Err error }
+	// GetWalletInfoRes is the result from a call to GetWalletInfo
+	GetWalletInfoRes struct { Res *btcjson.GetWalletInfoResult; Err error }
 	// HelpNoChainRPCRes is the result from a call to HelpNoChainRPC
 	HelpNoChainRPCRes struct { Res *string; Err error }
 	// ImportPrivKeyRes is the result from a call to ImportPrivKey
 	ImportPrivKeyRes struct { Res *None; Err error }
+	// ImportWalletRes is the result from a call to ImportWallet
+	ImportWalletRes struct { Res *None; Err error }
 	// KeypoolRefillRes is the result from a call to KeypoolRefill
 	KeypoolRefillRes struct { Res *None; Err error }
 	// ListAccountsRes is the result from a call to ListAccounts
 	ListAccountsRes struct { Res *map[string]float64; Err error }
+	// ListAddressGroupingsRes is the result from a call to ListAddressGroupings
+	ListAddressGroupingsRes struct { Res *[][]btcjson.ListAddressGroupingsResult; Err error }
 	// ListAddressTransactionsRes is the result from a call to ListAddressTransactions
 	ListAddressTransactionsRes struct { Res *[]btcjson.ListTransactionsResult; Err error }
 	// ListAllTransactionsRes is the result from a call to ListAllTransactions
@@ -116,16 +134,22 @@ type (
 	ListTransactionsRes struct { Res *[]btcjson.ListTransactionsResult; Err error }
 	// ListUnspentRes is the result from a call to ListUnspent
 	ListUnspentRes struct { Res *[]btcjson.ListUnspentResult; Err error }
-	// RenameAccountRes is the result from a call to RenameAccount
-	RenameAccountRes struct { Res *None; Err error }
 	// LockUnspentRes is the result from a call to LockUnspent
 	LockUnspentRes struct { Res *bool; Err error }
+	// RenameAccountRes is the result from a call to RenameAccount
+	RenameAccountRes struct { Res *None; Err error }
+	// SendFromRes is the result from a call to SendFrom
+	SendFromRes struct { Res *string; Err error }
 	// SendManyRes is the result from a call to SendMany
 	SendManyRes struct { Res *string; Err error }
 	// SendToAddressRes is the result from a call to SendToAddress
 	SendToAddressRes struct { Res *string; Err error }
+	// SetLabelRes is the result from a call to SetLabel
+	SetLabelRes struct { Res *None; Err error }
 	// SetTxFeeRes is the result from a call to SetTxFee
 	SetTxFeeRes struct { Res *bool; Err error }
+	// SetTxLabelRes is the result from a call to SetTxLabel
+	SetTxLabelRes struct { Res *None; Err error }
 	// SignMessageRes is the result from a call to SignMessage
 	SignMessageRes struct { Res *string; Err error }
 	// SignRawTransactionRes is the result from a call to SignRawTransaction
@@ -134,6 +158,8 @@ type (
 	ValidateAddressRes struct { Res *btcjson.ValidateAddressWalletResult; Err error }
 	// VerifyMessageRes is the result from a call to VerifyMessage
 	VerifyMessageRes struct { Res *bool; Err error }
+	// WalletCreateFundedPsbtRes is the result from a call to WalletCreateFundedPsbt
+	WalletCreateFundedPsbtRes struct { Res *btcjson.WalletCreateFundedPsbtResult; Err error }
 	// WalletIsLockedRes is the result from a call to WalletIsLocked
 	WalletIsLockedRes struct { Res *bool; Err error }
 	// WalletLockRes is the result from a call to WalletLock
@@ -142,6 +168,8 @@ type (
 	WalletPassphraseRes struct { Res *None; Err error }
 	// WalletPassphraseChangeRes is the result from a call to WalletPassphraseChange
 	WalletPassphraseChangeRes struct { Res *None; Err error }
+	// WalletProcessPsbtRes is the result from a call to WalletProcessPsbt
+	WalletProcessPsbtRes struct { Res *btcjson.WalletProcessPsbtResult; Err error }
 )
 
 // RequestHandler is a handler function to handle an unmarshaled and parsed request into a marshalable response.  If the 
@@ -180,18 +208,36 @@ var RPCHandlers = map[string]struct {
 	"addmultisigaddress":{ 
 		Handler: AddMultiSigAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan AddMultiSigAddressRes)} }}, 
+	"backupwallet":{ 
+		Handler: BackupWallet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan BackupWalletRes)} }}, 
+	"bumpfee":{ 
+		Handler: BumpFee, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan BumpFeeRes)} }}, 
+	"combinepsbt":{ 
+		Handler: CombinePsbt, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CombinePsbtRes)} }}, 
 	"createmultisig":{ 
 		Handler: CreateMultiSig, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateMultiSigRes)} }}, 
 	"createnewaccount":{ 
 		Handler: CreateNewAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateNewAccountRes)} }}, 
+	"decodepsbt":{ 
+		Handler: DecodePsbt, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan DecodePsbtRes)} }}, 
 	"dropwallethistory":{ 
 		Handler: HandleDropWalletHistory, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HandleDropWalletHistoryRes)} }}, 
 	"dumpprivkey":{ 
 		Handler: DumpPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan DumpPrivKeyRes)} }}, 
+	"dumpwallet":{ 
+		Handler: DumpWallet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan DumpWalletRes)} }}, 
+	"finalizepsbt":{ 
+		Handler: FinalizePsbt, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan FinalizePsbtRes)} }}, 
 	"getaccount":{ 
 		Handler: GetAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAccountRes)} }}, 
@@ -234,18 +280,27 @@ var RPCHandlers = map[string]struct {
 	"getunconfirmedbalance":{ 
 		Handler: GetUnconfirmedBalance, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetUnconfirmedBalanceRes)} }}, 
+	"getwalletinfo":{ 
+		Handler: GetWalletInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetWalletInfoRes)} }}, 
 	"help":{ 
 		Handler: HelpNoChainRPC, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HelpNoChainRPCRes)} }}, 
 	"importprivkey":{ 
 		Handler: ImportPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ImportPrivKeyRes)} }}, 
+	"importwallet":{ 
+		Handler: ImportWallet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ImportWalletRes)} }}, 
 	"keypoolrefill":{ 
 		Handler: KeypoolRefill, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan KeypoolRefillRes)} }}, 
 	"listaccounts":{ 
 		Handler: ListAccounts, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAccountsRes)} }}, 
+	"listaddressgroupings":{ 
+		Handler: ListAddressGroupings, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListAddressGroupingsRes)} }}, 
 	"listaddresstransactions":{ 
 		Handler: ListAddressTransactions, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListAddressTransactionsRes)} }}, 
@@ -270,21 +325,30 @@ var RPCHandlers = map[string]struct {
 	"listunspent":{ 
 		Handler: ListUnspent, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListUnspentRes)} }}, 
+	"lockunspent":{ 
+		Handler: LockUnspent, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan LockUnspentRes)} }}, 
 	"renameaccount":{ 
 		Handler: RenameAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan RenameAccountRes)} }}, 
 	"sendfrom":{ 
-		Handler: LockUnspent, Call: make(chan API, 32),
-		Result: func() API { return API{Ch: make(chan LockUnspentRes)} }}, 
+		Handler: SendFrom, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SendFromRes)} }}, 
 	"sendmany":{ 
 		Handler: SendMany, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SendManyRes)} }}, 
 	"sendtoaddress":{ 
 		Handler: SendToAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SendToAddressRes)} }}, 
+	"setlabel":{ 
+		Handler: SetLabel, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SetLabelRes)} }}, 
 	"settxfee":{ 
 		Handler: SetTxFee, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SetTxFeeRes)} }}, 
+	"settxlabel":{ 
+		Handler: SetTxLabel, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SetTxLabelRes)} }}, 
 	"signmessage":{ 
 		Handler: SignMessage, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SignMessageRes)} }}, 
@@ -297,6 +361,9 @@ var RPCHandlers = map[string]struct {
 	"verifymessage":{ 
 		Handler: VerifyMessage, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan VerifyMessageRes)} }}, 
+	"walletcreatefundedpsbt":{ 
+		Handler: WalletCreateFundedPsbt, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan WalletCreateFundedPsbtRes)} }}, 
 	"walletislocked":{ 
 		Handler: WalletIsLocked, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan WalletIsLockedRes)} }}, 
@@ -309,6 +376,9 @@ var RPCHandlers = map[string]struct {
 	"walletpassphrasechange":{ 
 		Handler: WalletPassphraseChange, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan WalletPassphraseChangeRes)} }}, 
+	"walletprocesspsbt":{ 
+		Handler: WalletProcessPsbt, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan WalletProcessPsbtRes)} }}, 
 
 }
 
@@ -359,6 +429,129 @@ func (a API) AddMultiSigAddressWait(cmd *btcjson.AddMultisigAddressCmd) (out *st
 	return
 }
 
+// BackupWallet calls the method with the given parameters
+func (a API) BackupWallet(cmd *btcjson.BackupWalletCmd) (err error) {
+	RPCHandlers["backupwallet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// BackupWalletCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) BackupWalletCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan BackupWalletRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// BackupWalletGetRes returns a pointer to the value in the Result field
+func (a API) BackupWalletGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// BackupWalletWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) BackupWalletWait(cmd *btcjson.BackupWalletCmd) (out *None, err error) {
+	RPCHandlers["backupwallet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan BackupWalletRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// BumpFee calls the method with the given parameters
+func (a API) BumpFee(cmd *btcjson.BumpFeeCmd) (err error) {
+	RPCHandlers["bumpfee"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// BumpFeeCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) BumpFeeCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan BumpFeeRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// BumpFeeGetRes returns a pointer to the value in the Result field
+func (a API) BumpFeeGetRes() (out *btcjson.BumpFeeResult, err error) {
+	out, _ = a.Result.(*btcjson.BumpFeeResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// BumpFeeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) BumpFeeWait(cmd *btcjson.BumpFeeCmd) (out *btcjson.BumpFeeResult, err error) {
+	RPCHandlers["bumpfee"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan BumpFeeRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// CombinePsbt calls the method with the given parameters
+func (a API) CombinePsbt(cmd *btcjson.CombinePsbtCmd) (err error) {
+	RPCHandlers["combinepsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// CombinePsbtCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) CombinePsbtCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan CombinePsbtRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// CombinePsbtGetRes returns a pointer to the value in the Result field
+func (a API) CombinePsbtGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// CombinePsbtWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CombinePsbtWait(cmd *btcjson.CombinePsbtCmd) (out *string, err error) {
+	RPCHandlers["combinepsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan CombinePsbtRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // CreateMultiSig calls the method with the given parameters
 func (a API) CreateMultiSig(cmd *btcjson.CreateMultisigCmd) (err error) {
 	RPCHandlers["createmultisig"].Call <- API{a.Ch, cmd, nil}
@@ -441,6 +634,47 @@ func (a API) CreateNewAccountWait(cmd *btcjson.CreateNewAccountCmd) (out *None,
 	return
 }
 
+// DecodePsbt calls the method with the given parameters
+func (a API) DecodePsbt(cmd *btcjson.DecodePsbtCmd) (err error) {
+	RPCHandlers["decodepsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// DecodePsbtCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) DecodePsbtCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan DecodePsbtRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// DecodePsbtGetRes returns a pointer to the value in the Result field
+func (a API) DecodePsbtGetRes() (out *btcjson.DecodePsbtResult, err error) {
+	out, _ = a.Result.(*btcjson.DecodePsbtResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// DecodePsbtWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DecodePsbtWait(cmd *btcjson.DecodePsbtCmd) (out *btcjson.DecodePsbtResult, err error) {
+	RPCHandlers["decodepsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan DecodePsbtRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // HandleDropWalletHistory calls the method with the given parameters
 func (a API) HandleDropWalletHistory(cmd *None) (err error) {
 	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
@@ -523,6 +757,88 @@ func (a API) DumpPrivKeyWait(cmd *btcjson.DumpPrivKeyCmd) (out *string, err erro
 	return
 }
 
+// DumpWallet calls the method with the given parameters
+func (a API) DumpWallet(cmd *btcjson.DumpWalletCmd) (err error) {
+	RPCHandlers["dumpwallet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// DumpWalletCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) DumpWalletCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan DumpWalletRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// DumpWalletGetRes returns a pointer to the value in the Result field
+func (a API) DumpWalletGetRes() (out *btcjson.DumpWalletResult, err error) {
+	out, _ = a.Result.(*btcjson.DumpWalletResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// DumpWalletWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DumpWalletWait(cmd *btcjson.DumpWalletCmd) (out *btcjson.DumpWalletResult, err error) {
+	RPCHandlers["dumpwallet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan DumpWalletRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// FinalizePsbt calls the method with the given parameters
+func (a API) FinalizePsbt(cmd *btcjson.FinalizePsbtCmd) (err error) {
+	RPCHandlers["finalizepsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// FinalizePsbtCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) FinalizePsbtCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan FinalizePsbtRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// FinalizePsbtGetRes returns a pointer to the value in the Result field
+func (a API) FinalizePsbtGetRes() (out *btcjson.FinalizePsbtResult, err error) {
+	out, _ = a.Result.(*btcjson.FinalizePsbtResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// FinalizePsbtWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) FinalizePsbtWait(cmd *btcjson.FinalizePsbtCmd) (out *btcjson.FinalizePsbtResult, err error) {
+	RPCHandlers["finalizepsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan FinalizePsbtRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // GetAccount calls the method with the given parameters
 func (a API) GetAccount(cmd *btcjson.GetAccountCmd) (err error) {
 	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
@@ -1097,6 +1413,47 @@ func (a API) GetUnconfirmedBalanceWait(cmd *btcjson.GetUnconfirmedBalanceCmd) (o
 	return
 }
 
+// GetWalletInfo calls the method with the given parameters
+func (a API) GetWalletInfo(cmd *None) (err error) {
+	RPCHandlers["getwalletinfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetWalletInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetWalletInfoCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan GetWalletInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetWalletInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetWalletInfoGetRes() (out *btcjson.GetWalletInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetWalletInfoResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// GetWalletInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetWalletInfoWait(cmd *None) (out *btcjson.GetWalletInfoResult, err error) {
+	RPCHandlers["getwalletinfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan GetWalletInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // HelpNoChainRPC calls the method with the given parameters
 func (a API) HelpNoChainRPC(cmd btcjson.HelpCmd) (err error) {
 	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
@@ -1179,6 +1536,47 @@ func (a API) ImportPrivKeyWait(cmd *btcjson.ImportPrivKeyCmd) (out *None, err er
 	return
 }
 
+// ImportWallet calls the method with the given parameters
+func (a API) ImportWallet(cmd *btcjson.ImportWalletCmd) (err error) {
+	RPCHandlers["importwallet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ImportWalletCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ImportWalletCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ImportWalletRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ImportWalletGetRes returns a pointer to the value in the Result field
+func (a API) ImportWalletGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// ImportWalletWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ImportWalletWait(cmd *btcjson.ImportWalletCmd) (out *None, err error) {
+	RPCHandlers["importwallet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ImportWalletRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // KeypoolRefill calls the method with the given parameters
 func (a API) KeypoolRefill(cmd *None) (err error) {
 	RPCHandlers["keypoolrefill"].Call <- API{a.Ch, cmd, nil}
@@ -1261,6 +1659,47 @@ func (a API) ListAccountsWait(cmd *btcjson.ListAccountsCmd) (out *map[string]flo
 	return
 }
 
+// ListAddressGroupings calls the method with the given parameters
+func (a API) ListAddressGroupings(cmd *None) (err error) {
+	RPCHandlers["listaddressgroupings"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAddressGroupingsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAddressGroupingsCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan ListAddressGroupingsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAddressGroupingsGetRes returns a pointer to the value in the Result field
+func (a API) ListAddressGroupingsGetRes() (out *[][]btcjson.ListAddressGroupingsResult, err error) {
+	out, _ = a.Result.(*[][]btcjson.ListAddressGroupingsResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// ListAddressGroupingsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAddressGroupingsWait(cmd *None) (out *[][]btcjson.ListAddressGroupingsResult, err error) {
+	RPCHandlers["listaddressgroupings"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan ListAddressGroupingsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // ListAddressTransactions calls the method with the given parameters
 func (a API) ListAddressTransactions(cmd *btcjson.ListAddressTransactionsCmd) (err error) {
 	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
@@ -1589,6 +2028,47 @@ func (a API) ListUnspentWait(cmd *btcjson.ListUnspentCmd) (out *[]btcjson.ListUn
 	return
 }
 
+// LockUnspent calls the method with the given parameters
+func (a API) LockUnspent(cmd *btcjson.LockUnspentCmd) (err error) {
+	RPCHandlers["lockunspent"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// LockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) LockUnspentCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan LockUnspentRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// LockUnspentGetRes returns a pointer to the value in the Result field
+func (a API) LockUnspentGetRes() (out *bool, err error) {
+	out, _ = a.Result.(*bool)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// LockUnspentWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) LockUnspentWait(cmd *btcjson.LockUnspentCmd) (out *bool, err error) {
+	RPCHandlers["lockunspent"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan LockUnspentRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // RenameAccount calls the method with the given parameters
 func (a API) RenameAccount(cmd *btcjson.RenameAccountCmd) (err error) {
 	RPCHandlers["renameaccount"].Call <- API{a.Ch, cmd, nil}
@@ -1630,17 +2110,17 @@ func (a API) RenameAccountWait(cmd *btcjson.RenameAccountCmd) (out *None, err er
 	return
 }
 
-// LockUnspent calls the method with the given parameters
-func (a API) LockUnspent(cmd btcjson.LockUnspentCmd) (err error) {
+// SendFrom calls the method with the given parameters
+func (a API) SendFrom(cmd *btcjson.SendFromCmd) (err error) {
 	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// LockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// SendFromCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) LockUnspentCheck() (isNew bool) {
+func (a API) SendFromCheck() (isNew bool) {
 	select {
-	case o := <- a.Ch.(chan LockUnspentRes):
+	case o := <- a.Ch.(chan SendFromRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1652,20 +2132,20 @@ func (a API) LockUnspentCheck() (isNew bool) {
 	return
 }
 
-// LockUnspentGetRes returns a pointer to the value in the Result field
-func (a API) LockUnspentGetRes() (out *bool, err error) {
-	out, _ = a.Result.(*bool)
+// SendFromGetRes returns a pointer to the value in the Result field
+func (a API) SendFromGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return 
 }
 
-// LockUnspentWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) LockUnspentWait(cmd btcjson.LockUnspentCmd) (out *bool, err error) {
+// SendFromWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SendFromWait(cmd *btcjson.SendFromCmd) (out *string, err error) {
 	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second*5):
 		break
-	case o := <- a.Ch.(chan LockUnspentRes):
+	case o := <- a.Ch.(chan SendFromRes):
 		out, err = o.Res, o.Err
 	}
 	return
@@ -1753,6 +2233,47 @@ func (a API) SendToAddressWait(cmd *btcjson.SendToAddressCmd) (out *string, err
 	return
 }
 
+// SetLabel calls the method with the given parameters
+func (a API) SetLabel(cmd *btcjson.SetLabelCmd) (err error) {
+	RPCHandlers["setlabel"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetLabelCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SetLabelCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SetLabelRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetLabelGetRes returns a pointer to the value in the Result field
+func (a API) SetLabelGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// SetLabelWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetLabelWait(cmd *btcjson.SetLabelCmd) (out *None, err error) {
+	RPCHandlers["setlabel"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SetLabelRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // SetTxFee calls the method with the given parameters
 func (a API) SetTxFee(cmd *btcjson.SetTxFeeCmd) (err error) {
 	RPCHandlers["settxfee"].Call <- API{a.Ch, cmd, nil}
@@ -1794,6 +2315,47 @@ func (a API) SetTxFeeWait(cmd *btcjson.SetTxFeeCmd) (out *bool, err error) {
 	return
 }
 
+// SetTxLabel calls the method with the given parameters
+func (a API) SetTxLabel(cmd *btcjson.SetTxLabelCmd) (err error) {
+	RPCHandlers["settxlabel"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetTxLabelCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SetTxLabelCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan SetTxLabelRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetTxLabelGetRes returns a pointer to the value in the Result field
+func (a API) SetTxLabelGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// SetTxLabelWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetTxLabelWait(cmd *btcjson.SetTxLabelCmd) (out *None, err error) {
+	RPCHandlers["settxlabel"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan SetTxLabelRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // SignMessage calls the method with the given parameters
 func (a API) SignMessage(cmd *btcjson.SignMessageCmd) (err error) {
 	RPCHandlers["signmessage"].Call <- API{a.Ch, cmd, nil}
@@ -1958,6 +2520,47 @@ func (a API) VerifyMessageWait(cmd *btcjson.VerifyMessageCmd) (out *bool, err er
 	return
 }
 
+// WalletCreateFundedPsbt calls the method with the given parameters
+func (a API) WalletCreateFundedPsbt(cmd *btcjson.WalletCreateFundedPsbtCmd) (err error) {
+	RPCHandlers["walletcreatefundedpsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletCreateFundedPsbtCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletCreateFundedPsbtCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletCreateFundedPsbtRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletCreateFundedPsbtGetRes returns a pointer to the value in the Result field
+func (a API) WalletCreateFundedPsbtGetRes() (out *btcjson.WalletCreateFundedPsbtResult, err error) {
+	out, _ = a.Result.(*btcjson.WalletCreateFundedPsbtResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// WalletCreateFundedPsbtWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletCreateFundedPsbtWait(cmd *btcjson.WalletCreateFundedPsbtCmd) (out *btcjson.WalletCreateFundedPsbtResult, err error) {
+	RPCHandlers["walletcreatefundedpsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletCreateFundedPsbtRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // WalletIsLocked calls the method with the given parameters
 func (a API) WalletIsLocked(cmd *None) (err error) {
 	RPCHandlers["walletislocked"].Call <- API{a.Ch, cmd, nil}
@@ -2122,6 +2725,47 @@ func (a API) WalletPassphraseChangeWait(cmd *btcjson.WalletPassphraseChangeCmd)
 	return
 }
 
+// WalletProcessPsbt calls the method with the given parameters
+func (a API) WalletProcessPsbt(cmd *btcjson.WalletProcessPsbtCmd) (err error) {
+	RPCHandlers["walletprocesspsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletProcessPsbtCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletProcessPsbtCheck() (isNew bool) {
+	select {
+	case o := <- a.Ch.(chan WalletProcessPsbtRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletProcessPsbtGetRes returns a pointer to the value in the Result field
+func (a API) WalletProcessPsbtGetRes() (out *btcjson.WalletProcessPsbtResult, err error) {
+	out, _ = a.Result.(*btcjson.WalletProcessPsbtResult)
+	err, _ = a.Result.(error)
+	return 
+}
+
+// WalletProcessPsbtWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletProcessPsbtWait(cmd *btcjson.WalletProcessPsbtCmd) (out *btcjson.WalletProcessPsbtResult, err error) {
+	RPCHandlers["walletprocesspsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second*5):
+		break
+	case o := <- a.Ch.(chan WalletProcessPsbtRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 
 // RunAPI starts up the api handler server that receives rpc.API messages and runs the handler and returns the result
 // Note that the parameters are type asserted to prevent the consumer of the API from sending wrong message types not
@@ -2142,6 +2786,27 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan AddMultiSigAddressRes) <- AddMultiSigAddressRes{&r, err} } 
+			case msg := <-nrh["backupwallet"].Call:
+				if res, err = nrh["backupwallet"].
+					Handler(msg.Params.(*btcjson.BackupWalletCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan BackupWalletRes) <- BackupWalletRes{&r, err} } 
+			case msg := <-nrh["bumpfee"].Call:
+				if res, err = nrh["bumpfee"].
+					Handler(msg.Params.(*btcjson.BumpFeeCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.BumpFeeResult); ok { 
+					msg.Ch.(chan BumpFeeRes) <- BumpFeeRes{&r, err} } 
+			case msg := <-nrh["combinepsbt"].Call:
+				if res, err = nrh["combinepsbt"].
+					Handler(msg.Params.(*btcjson.CombinePsbtCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan CombinePsbtRes) <- CombinePsbtRes{&r, err} } 
 			case msg := <-nrh["createmultisig"].Call:
 				if res, err = nrh["createmultisig"].
 					Handler(msg.Params.(*btcjson.CreateMultisigCmd), wallet, 
@@ -2156,6 +2821,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan CreateNewAccountRes) <- CreateNewAccountRes{&r, err} } 
+			case msg := <-nrh["decodepsbt"].Call:
+				if res, err = nrh["decodepsbt"].
+					Handler(msg.Params.(*btcjson.DecodePsbtCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.DecodePsbtResult); ok { 
+					msg.Ch.(chan DecodePsbtRes) <- DecodePsbtRes{&r, err} } 
 			case msg := <-nrh["dropwallethistory"].Call:
 				if res, err = nrh["dropwallethistory"].
 					Handler(msg.Params.(*None), wallet, 
@@ -2170,6 +2842,20 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan DumpPrivKeyRes) <- DumpPrivKeyRes{&r, err} } 
+			case msg := <-nrh["dumpwallet"].Call:
+				if res, err = nrh["dumpwallet"].
+					Handler(msg.Params.(*btcjson.DumpWalletCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.DumpWalletResult); ok { 
+					msg.Ch.(chan DumpWalletRes) <- DumpWalletRes{&r, err} } 
+			case msg := <-nrh["finalizepsbt"].Call:
+				if res, err = nrh["finalizepsbt"].
+					Handler(msg.Params.(*btcjson.FinalizePsbtCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.FinalizePsbtResult); ok { 
+					msg.Ch.(chan FinalizePsbtRes) <- FinalizePsbtRes{&r, err} } 
 			case msg := <-nrh["getaccount"].Call:
 				if res, err = nrh["getaccount"].
 					Handler(msg.Params.(*btcjson.GetAccountCmd), wallet, 
@@ -2268,6 +2954,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(float64); ok { 
 					msg.Ch.(chan GetUnconfirmedBalanceRes) <- GetUnconfirmedBalanceRes{&r, err} } 
+			case msg := <-nrh["getwalletinfo"].Call:
+				if res, err = nrh["getwalletinfo"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetWalletInfoResult); ok { 
+					msg.Ch.(chan GetWalletInfoRes) <- GetWalletInfoRes{&r, err} } 
 			case msg := <-nrh["help"].Call:
 				if res, err = nrh["help"].
 					Handler(msg.Params.(btcjson.HelpCmd), wallet, 
@@ -2282,6 +2975,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan ImportPrivKeyRes) <- ImportPrivKeyRes{&r, err} } 
+			case msg := <-nrh["importwallet"].Call:
+				if res, err = nrh["importwallet"].
+					Handler(msg.Params.(*btcjson.ImportWalletCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan ImportWalletRes) <- ImportWalletRes{&r, err} } 
 			case msg := <-nrh["keypoolrefill"].Call:
 				if res, err = nrh["keypoolrefill"].
 					Handler(msg.Params.(*None), wallet, 
@@ -2296,6 +2996,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(map[string]float64); ok { 
 					msg.Ch.(chan ListAccountsRes) <- ListAccountsRes{&r, err} } 
+			case msg := <-nrh["listaddressgroupings"].Call:
+				if res, err = nrh["listaddressgroupings"].
+					Handler(msg.Params.(*None), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.([][]btcjson.ListAddressGroupingsResult); ok { 
+					msg.Ch.(chan ListAddressGroupingsRes) <- ListAddressGroupingsRes{&r, err} } 
 			case msg := <-nrh["listaddresstransactions"].Call:
 				if res, err = nrh["listaddresstransactions"].
 					Handler(msg.Params.(*btcjson.ListAddressTransactionsCmd), wallet, 
@@ -2352,6 +3059,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.([]btcjson.ListUnspentResult); ok { 
 					msg.Ch.(chan ListUnspentRes) <- ListUnspentRes{&r, err} } 
+			case msg := <-nrh["lockunspent"].Call:
+				if res, err = nrh["lockunspent"].
+					Handler(msg.Params.(*btcjson.LockUnspentCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(bool); ok { 
+					msg.Ch.(chan LockUnspentRes) <- LockUnspentRes{&r, err} } 
 			case msg := <-nrh["renameaccount"].Call:
 				if res, err = nrh["renameaccount"].
 					Handler(msg.Params.(*btcjson.RenameAccountCmd), wallet, 
@@ -2361,11 +3075,11 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 					msg.Ch.(chan RenameAccountRes) <- RenameAccountRes{&r, err} } 
 			case msg := <-nrh["sendfrom"].Call:
 				if res, err = nrh["sendfrom"].
-					Handler(msg.Params.(btcjson.LockUnspentCmd), wallet, 
+					Handler(msg.Params.(*btcjson.SendFromCmd), wallet, 
 						chainRPC); Check(err) {
 				}
-				if r, ok := res.(bool); ok { 
-					msg.Ch.(chan LockUnspentRes) <- LockUnspentRes{&r, err} } 
+				if r, ok := res.(string); ok { 
+					msg.Ch.(chan SendFromRes) <- SendFromRes{&r, err} } 
 			case msg := <-nrh["sendmany"].Call:
 				if res, err = nrh["sendmany"].
 					Handler(msg.Params.(*btcjson.SendManyCmd), wallet, 
@@ -2380,6 +3094,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(string); ok { 
 					msg.Ch.(chan SendToAddressRes) <- SendToAddressRes{&r, err} } 
+			case msg := <-nrh["setlabel"].Call:
+				if res, err = nrh["setlabel"].
+					Handler(msg.Params.(*btcjson.SetLabelCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan SetLabelRes) <- SetLabelRes{&r, err} } 
 			case msg := <-nrh["settxfee"].Call:
 				if res, err = nrh["settxfee"].
 					Handler(msg.Params.(*btcjson.SetTxFeeCmd), wallet, 
@@ -2387,6 +3108,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(bool); ok { 
 					msg.Ch.(chan SetTxFeeRes) <- SetTxFeeRes{&r, err} } 
+			case msg := <-nrh["settxlabel"].Call:
+				if res, err = nrh["settxlabel"].
+					Handler(msg.Params.(*btcjson.SetTxLabelCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(None); ok { 
+					msg.Ch.(chan SetTxLabelRes) <- SetTxLabelRes{&r, err} } 
 			case msg := <-nrh["signmessage"].Call:
 				if res, err = nrh["signmessage"].
 					Handler(msg.Params.(*btcjson.SignMessageCmd), wallet, 
@@ -2415,6 +3143,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(bool); ok { 
 					msg.Ch.(chan VerifyMessageRes) <- VerifyMessageRes{&r, err} } 
+			case msg := <-nrh["walletcreatefundedpsbt"].Call:
+				if res, err = nrh["walletcreatefundedpsbt"].
+					Handler(msg.Params.(*btcjson.WalletCreateFundedPsbtCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.WalletCreateFundedPsbtResult); ok { 
+					msg.Ch.(chan WalletCreateFundedPsbtRes) <- WalletCreateFundedPsbtRes{&r, err} } 
 			case msg := <-nrh["walletislocked"].Call:
 				if res, err = nrh["walletislocked"].
 					Handler(msg.Params.(*None), wallet, 
@@ -2443,6 +3178,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				}
 				if r, ok := res.(None); ok { 
 					msg.Ch.(chan WalletPassphraseChangeRes) <- WalletPassphraseChangeRes{&r, err} } 
+			case msg := <-nrh["walletprocesspsbt"].Call:
+				if res, err = nrh["walletprocesspsbt"].
+					Handler(msg.Params.(*btcjson.WalletProcessPsbtCmd), wallet, 
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.WalletProcessPsbtResult); ok { 
+					msg.Ch.(chan WalletProcessPsbtRes) <- WalletProcessPsbtRes{&r, err} } 
 			case <-quit.Wait():
 				Debug("stopping wallet cAPI")
 				return
@@ -2466,6 +3208,45 @@ func (c *CAPI) AddMultiSigAddress(req *btcjson.AddMultisigAddressCmd, resp strin
 	return 
 }
 
+func (c *CAPI) BackupWallet(req *btcjson.BackupWalletCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["backupwallet"].Result()
+	res.Params = req
+	nrh["backupwallet"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) BumpFee(req *btcjson.BumpFeeCmd, resp btcjson.BumpFeeResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["bumpfee"].Result()
+	res.Params = req
+	nrh["bumpfee"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.BumpFeeResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) CombinePsbt(req *btcjson.CombinePsbtCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["combinepsbt"].Result()
+	res.Params = req
+	nrh["combinepsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) CreateMultiSig(req *btcjson.CreateMultisigCmd, resp btcjson.CreateMultiSigResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["createmultisig"].Result()
@@ -2492,6 +3273,19 @@ func (c *CAPI) CreateNewAccount(req *btcjson.CreateNewAccountCmd, resp None) (er
 	return 
 }
 
+func (c *CAPI) DecodePsbt(req *btcjson.DecodePsbtCmd, resp btcjson.DecodePsbtResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["decodepsbt"].Result()
+	res.Params = req
+	nrh["decodepsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.DecodePsbtResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) HandleDropWalletHistory(req *None, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["dropwallethistory"].Result()
@@ -2518,6 +3312,32 @@ func (c *CAPI) DumpPrivKey(req *btcjson.DumpPrivKeyCmd, resp string) (err error)
 	return 
 }
 
+func (c *CAPI) DumpWallet(req *btcjson.DumpWalletCmd, resp btcjson.DumpWalletResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["dumpwallet"].Result()
+	res.Params = req
+	nrh["dumpwallet"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.DumpWalletResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
+func (c *CAPI) FinalizePsbt(req *btcjson.FinalizePsbtCmd, resp btcjson.FinalizePsbtResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["finalizepsbt"].Result()
+	res.Params = req
+	nrh["finalizepsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.FinalizePsbtResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) GetAccount(req *btcjson.GetAccountCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getaccount"].Result()
@@ -2700,6 +3520,19 @@ func (c *CAPI) GetUnconfirmedBalance(req *btcjson.GetUnconfirmedBalanceCmd, resp
 	return 
 }
 
+func (c *CAPI) GetWalletInfo(req *None, resp btcjson.GetWalletInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getwalletinfo"].Result()
+	res.Params = req
+	nrh["getwalletinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetWalletInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) HelpNoChainRPC(req btcjson.HelpCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["help"].Result()
@@ -2726,6 +3559,19 @@ func (c *CAPI) ImportPrivKey(req *btcjson.ImportPrivKeyCmd, resp None) (err erro
 	return 
 }
 
+func (c *CAPI) ImportWallet(req *btcjson.ImportWalletCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["importwallet"].Result()
+	res.Params = req
+	nrh["importwallet"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) KeypoolRefill(req *None, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["keypoolrefill"].Result()
@@ -2752,6 +3598,19 @@ func (c *CAPI) ListAccounts(req *btcjson.ListAccountsCmd, resp map[string]float6
 	return 
 }
 
+func (c *CAPI) ListAddressGroupings(req *None, resp [][]btcjson.ListAddressGroupingsResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["listaddressgroupings"].Result()
+	res.Params = req
+	nrh["listaddressgroupings"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan [][]btcjson.ListAddressGroupingsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) ListAddressTransactions(req *btcjson.ListAddressTransactionsCmd, resp []btcjson.ListTransactionsResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["listaddresstransactions"].Result()
@@ -2856,6 +3715,19 @@ func (c *CAPI) ListUnspent(req *btcjson.ListUnspentCmd, resp []btcjson.ListUnspe
 	return 
 }
 
+func (c *CAPI) LockUnspent(req *btcjson.LockUnspentCmd, resp bool) (err error) {
+	nrh := RPCHandlers
+	res := nrh["lockunspent"].Result()
+	res.Params = req
+	nrh["lockunspent"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan bool):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) RenameAccount(req *btcjson.RenameAccountCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["renameaccount"].Result()
@@ -2869,13 +3741,13 @@ func (c *CAPI) RenameAccount(req *btcjson.RenameAccountCmd, resp None) (err erro
 	return 
 }
 
-func (c *CAPI) LockUnspent(req btcjson.LockUnspentCmd, resp bool) (err error) {
+func (c *CAPI) SendFrom(req *btcjson.SendFromCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["sendfrom"].Result()
 	res.Params = req
 	nrh["sendfrom"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan bool):
+	case resp = <-res.Ch.(chan string):
 	case <-time.After(c.Timeout):
 	case <-c.quit.Wait():
 	} 
@@ -2908,6 +3780,19 @@ func (c *CAPI) SendToAddress(req *btcjson.SendToAddressCmd, resp string) (err er
 	return 
 }
 
+func (c *CAPI) SetLabel(req *btcjson.SetLabelCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["setlabel"].Result()
+	res.Params = req
+	nrh["setlabel"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) SetTxFee(req *btcjson.SetTxFeeCmd, resp bool) (err error) {
 	nrh := RPCHandlers
 	res := nrh["settxfee"].Result()
@@ -2921,6 +3806,19 @@ func (c *CAPI) SetTxFee(req *btcjson.SetTxFeeCmd, resp bool) (err error) {
 	return 
 }
 
+func (c *CAPI) SetTxLabel(req *btcjson.SetTxLabelCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["settxlabel"].Result()
+	res.Params = req
+	nrh["settxlabel"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) SignMessage(req *btcjson.SignMessageCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["signmessage"].Result()
@@ -2973,6 +3871,19 @@ func (c *CAPI) VerifyMessage(req *btcjson.VerifyMessageCmd, resp bool) (err erro
 	return 
 }
 
+func (c *CAPI) WalletCreateFundedPsbt(req *btcjson.WalletCreateFundedPsbtCmd, resp btcjson.WalletCreateFundedPsbtResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["walletcreatefundedpsbt"].Result()
+	res.Params = req
+	nrh["walletcreatefundedpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.WalletCreateFundedPsbtResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 func (c *CAPI) WalletIsLocked(req *None, resp bool) (err error) {
 	nrh := RPCHandlers
 	res := nrh["walletislocked"].Result()
@@ -3025,6 +3936,19 @@ func (c *CAPI) WalletPassphraseChange(req *btcjson.WalletPassphraseChangeCmd, re
 	return 
 }
 
+func (c *CAPI) WalletProcessPsbt(req *btcjson.WalletProcessPsbtCmd, resp btcjson.WalletProcessPsbtResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["walletprocesspsbt"].Result()
+	res.Params = req
+	nrh["walletprocesspsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.WalletProcessPsbtResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit.Wait():
+	} 
+	return 
+}
+
 // Client call wrappers for a CAPI client with a given Conn
 
 func (r *CAPIClient) AddMultiSigAddress(cmd ...*btcjson.AddMultisigAddressCmd) (res string, err error) {
@@ -3037,6 +3961,36 @@ func (r *CAPIClient) AddMultiSigAddress(cmd ...*btcjson.AddMultisigAddressCmd) (
 	return
 }
 
+func (r *CAPIClient) BackupWallet(cmd ...*btcjson.BackupWalletCmd) (res None, err error) {
+	var c *btcjson.BackupWalletCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.BackupWallet", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) BumpFee(cmd ...*btcjson.BumpFeeCmd) (res btcjson.BumpFeeResult, err error) {
+	var c *btcjson.BumpFeeCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.BumpFee", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) CombinePsbt(cmd ...*btcjson.CombinePsbtCmd) (res string, err error) {
+	var c *btcjson.CombinePsbtCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.CombinePsbt", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) CreateMultiSig(cmd ...*btcjson.CreateMultisigCmd) (res btcjson.CreateMultiSigResult, err error) {
 	var c *btcjson.CreateMultisigCmd
 	if len(cmd) > 0 {
@@ -3057,6 +4011,16 @@ func (r *CAPIClient) CreateNewAccount(cmd ...*btcjson.CreateNewAccountCmd) (res
 	return
 }
 
+func (r *CAPIClient) DecodePsbt(cmd ...*btcjson.DecodePsbtCmd) (res btcjson.DecodePsbtResult, err error) {
+	var c *btcjson.DecodePsbtCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.DecodePsbt", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) HandleDropWalletHistory(cmd ...*None) (res string, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3077,6 +4041,26 @@ func (r *CAPIClient) DumpPrivKey(cmd ...*btcjson.DumpPrivKeyCmd) (res string, er
 	return
 }
 
+func (r *CAPIClient) DumpWallet(cmd ...*btcjson.DumpWalletCmd) (res btcjson.DumpWalletResult, err error) {
+	var c *btcjson.DumpWalletCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.DumpWallet", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) FinalizePsbt(cmd ...*btcjson.FinalizePsbtCmd) (res btcjson.FinalizePsbtResult, err error) {
+	var c *btcjson.FinalizePsbtCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.FinalizePsbt", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetAccount(cmd ...*btcjson.GetAccountCmd) (res string, err error) {
 	var c *btcjson.GetAccountCmd
 	if len(cmd) > 0 {
@@ -3217,6 +4201,16 @@ func (r *CAPIClient) GetUnconfirmedBalance(cmd ...*btcjson.GetUnconfirmedBalance
 	return
 }
 
+func (r *CAPIClient) GetWalletInfo(cmd ...*None) (res btcjson.GetWalletInfoResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetWalletInfo", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) HelpNoChainRPC(cmd ...btcjson.HelpCmd) (res string, err error) {
 	var c btcjson.HelpCmd
 	if len(cmd) > 0 {
@@ -3237,6 +4231,16 @@ func (r *CAPIClient) ImportPrivKey(cmd ...*btcjson.ImportPrivKeyCmd) (res None,
 	return
 }
 
+func (r *CAPIClient) ImportWallet(cmd ...*btcjson.ImportWalletCmd) (res None, err error) {
+	var c *btcjson.ImportWalletCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ImportWallet", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) KeypoolRefill(cmd ...*None) (res None, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3257,6 +4261,16 @@ func (r *CAPIClient) ListAccounts(cmd ...*btcjson.ListAccountsCmd) (res map[stri
 	return
 }
 
+func (r *CAPIClient) ListAddressGroupings(cmd ...*None) (res [][]btcjson.ListAddressGroupingsResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ListAddressGroupings", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) ListAddressTransactions(cmd ...*btcjson.ListAddressTransactionsCmd) (res []btcjson.ListTransactionsResult, err error) {
 	var c *btcjson.ListAddressTransactionsCmd
 	if len(cmd) > 0 {
@@ -3337,6 +4351,16 @@ func (r *CAPIClient) ListUnspent(cmd ...*btcjson.ListUnspentCmd) (res []btcjson.
 	return
 }
 
+func (r *CAPIClient) LockUnspent(cmd ...*btcjson.LockUnspentCmd) (res bool, err error) {
+	var c *btcjson.LockUnspentCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.LockUnspent", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) RenameAccount(cmd ...*btcjson.RenameAccountCmd) (res None, err error) {
 	var c *btcjson.RenameAccountCmd
 	if len(cmd) > 0 {
@@ -3347,12 +4371,12 @@ func (r *CAPIClient) RenameAccount(cmd ...*btcjson.RenameAccountCmd) (res None,
 	return
 }
 
-func (r *CAPIClient) LockUnspent(cmd ...btcjson.LockUnspentCmd) (res bool, err error) {
-	var c btcjson.LockUnspentCmd
+func (r *CAPIClient) SendFrom(cmd ...*btcjson.SendFromCmd) (res string, err error) {
+	var c *btcjson.SendFromCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if err = r.Call("CAPI.LockUnspent", c, &res); Check(err) {
+	if err = r.Call("CAPI.SendFrom", c, &res); Check(err) {
 	}
 	return
 }
@@ -3377,6 +4401,16 @@ func (r *CAPIClient) SendToAddress(cmd ...*btcjson.SendToAddressCmd) (res string
 	return
 }
 
+func (r *CAPIClient) SetLabel(cmd ...*btcjson.SetLabelCmd) (res None, err error) {
+	var c *btcjson.SetLabelCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.SetLabel", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) SetTxFee(cmd ...*btcjson.SetTxFeeCmd) (res bool, err error) {
 	var c *btcjson.SetTxFeeCmd
 	if len(cmd) > 0 {
@@ -3387,6 +4421,16 @@ func (r *CAPIClient) SetTxFee(cmd ...*btcjson.SetTxFeeCmd) (res bool, err error)
 	return
 }
 
+func (r *CAPIClient) SetTxLabel(cmd ...*btcjson.SetTxLabelCmd) (res None, err error) {
+	var c *btcjson.SetTxLabelCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.SetTxLabel", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) SignMessage(cmd ...*btcjson.SignMessageCmd) (res string, err error) {
 	var c *btcjson.SignMessageCmd
 	if len(cmd) > 0 {
@@ -3427,6 +4471,16 @@ func (r *CAPIClient) VerifyMessage(cmd ...*btcjson.VerifyMessageCmd) (res bool,
 	return
 }
 
+func (r *CAPIClient) WalletCreateFundedPsbt(cmd ...*btcjson.WalletCreateFundedPsbtCmd) (res btcjson.WalletCreateFundedPsbtResult, err error) {
+	var c *btcjson.WalletCreateFundedPsbtCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.WalletCreateFundedPsbt", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) WalletIsLocked(cmd ...*None) (res bool, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3467,3 +4521,13 @@ func (r *CAPIClient) WalletPassphraseChange(cmd ...*btcjson.WalletPassphraseChan
 	return
 }
 
+func (r *CAPIClient) WalletProcessPsbt(cmd ...*btcjson.WalletProcessPsbtCmd) (res btcjson.WalletProcessPsbtResult, err error) {
+	var c *btcjson.WalletProcessPsbtCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.WalletProcessPsbt", c, &res); Check(err) {
+	}
+	return
+}
+
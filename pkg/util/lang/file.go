@@ -0,0 +1,120 @@
+package lang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileExt is the extension of catalogue files, which are named by the code of the language they hold, such as es.json.
+const FileExt = ".json"
+
+// files holds the messages loaded from catalogue files by language code and ID.
+var files = struct {
+	mx    sync.Mutex
+	texts map[string]map[string]Text
+}{texts: make(map[string]map[string]Text)}
+
+// LoadFile adds the messages in a catalogue file to the language the file is named for. The file is a JSON object
+// mapping the component and ID of each message, joined by an underscore, to its text, or to the list of its forms for
+// messages that depend on a count. Messages loaded from files take the place of built in ones with the same ID.
+func LoadFile(path string) (err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	var raw map[string]json.RawMessage
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("catalogue %s: %v", path, err)
+	}
+	texts := make(map[string]Text, len(raw))
+	for id, r := range raw {
+		var t Text
+		if t, err = decodeText(id, r); err != nil {
+			return fmt.Errorf("catalogue %s: %v", path, err)
+		}
+		texts[id] = t
+	}
+	code := strings.TrimSuffix(filepath.Base(path), FileExt)
+	files.mx.Lock()
+	if files.texts[code] == nil {
+		files.texts[code] = texts
+	} else {
+		for id, t := range texts {
+			files.texts[code][id] = t
+		}
+	}
+	files.mx.Unlock()
+	Debug("loaded", len(texts), "messages in language", code, "from", path)
+	return
+}
+
+// LoadDir loads every catalogue file in a directory. A directory that does not exist holds no catalogues.
+func LoadDir(dir string) (err error) {
+	var paths []string
+	if paths, err = filepath.Glob(filepath.Join(dir, "*"+FileExt)); err != nil {
+		return
+	}
+	for _, path := range paths {
+		if err = LoadFile(path); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// decodeText decodes a message of a catalogue file, which is either a string or a list of plural forms.
+func decodeText(id string, r json.RawMessage) (t Text, err error) {
+	t.ID = id
+	if err = json.Unmarshal(r, &t.Definition); err == nil {
+		return
+	}
+	if err = json.Unmarshal(r, &t.Plural); err != nil || len(t.Plural) == 0 {
+		return t, fmt.Errorf("message %s is neither a string nor a list of plural forms", id)
+	}
+	// the last form is the one for the counts that fall in no other category
+	t.Definition = t.Plural[len(t.Plural)-1]
+	return
+}
+
+// Template returns a catalogue file for a language holding every message, translated where the language has a
+// translation and in English where it does not, for translators to work from.
+func Template(code string) ([]byte, error) {
+	texts := catalogue(code)
+	out := make(map[string]interface{})
+	for id, t := range catalogue(DefaultLanguage) {
+		if tr, ok := texts[id]; ok {
+			t = tr
+		}
+		if len(t.Plural) > 0 {
+			out[id] = t.Plural
+		} else {
+			out[id] = t.Definition
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	err := enc.Encode(out)
+	return buf.Bytes(), err
+}
+
+// WriteTemplate writes the template of a language to a catalogue file in a directory, named for the language.
+func WriteTemplate(dir, code string) (path string, err error) {
+	var b []byte
+	if b, err = Template(code); err != nil {
+		return
+	}
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+	path = filepath.Join(dir, code+FileExt)
+	err = ioutil.WriteFile(path, b, 0600)
+	return
+}
@@ -0,0 +1,75 @@
+package lang
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/p9c/pod/pkg/util"
+)
+
+// numberFormat is how a language writes numbers.
+type numberFormat struct {
+	// group separates the groups of three digits of the whole part of a number
+	group string
+	// decimal separates the whole part of a number from its fraction
+	decimal string
+	// minGroup is the fewest digits a whole part has for its digits to be grouped
+	minGroup int
+}
+
+var numberFormats = map[string]numberFormat{
+	"en": {",", ".", 4},
+	"zh": {",", ".", 4},
+	"es": {".", ",", 5},
+	"de": {".", ",", 4},
+	"rs": {".", ",", 4},
+	"sr": {".", ",", 4},
+	"ru": {"\u00a0", ",", 5},
+	"uk": {"\u00a0", ",", 5},
+	"fr": {"\u202f", ",", 4},
+}
+
+// formatNumber joins the digits of the whole part and fraction of a number as a language writes them.
+func formatNumber(code string, negative bool, whole, fraction string) string {
+	nf, ok := numberFormats[base(code)]
+	if !ok {
+		nf = numberFormats[DefaultLanguage]
+	}
+	var b strings.Builder
+	if negative {
+		b.WriteByte('-')
+	}
+	for i := range whole {
+		if i > 0 && len(whole) >= nf.minGroup && (len(whole)-i)%3 == 0 {
+			b.WriteString(nf.group)
+		}
+		b.WriteByte(whole[i])
+	}
+	if fraction != "" {
+		b.WriteString(nf.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// FormatNumber returns a number with the given number of decimals as the language of the messages writes it.
+func (l *Lexicon) FormatNumber(f float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(f), 'f', decimals, 64)
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	return formatNumber(l.Code(), f < 0, whole, fraction)
+}
+
+// FormatAmount returns an amount in DUO with all of its decimals as the language of the messages writes it.
+func (l *Lexicon) FormatAmount(a util.Amount) string {
+	negative := a < 0
+	if negative {
+		a = -a
+	}
+	whole := strconv.FormatInt(int64(a/util.SatoshiPerBitcoin), 10)
+	fraction := strconv.FormatInt(int64(a%util.SatoshiPerBitcoin)+int64(util.SatoshiPerBitcoin), 10)[1:]
+	return formatNumber(l.Code(), negative, whole, fraction) + " DUO"
+}
@@ -0,0 +1,291 @@
+package lang
+
+func guiDict() Com {
+	return Com{
+		Component: "gui",
+		Languages: []Language{
+			{
+				Code: "en",
+				Definitions: []Text{
+					{
+						ID:         "HOME",
+						Definition: "home",
+					},
+					{
+						ID:         "SEND",
+						Definition: "send",
+					},
+					{
+						ID:         "RECEIVE",
+						Definition: "receive",
+					},
+					{
+						ID:         "HISTORY",
+						Definition: "history",
+					},
+					{
+						ID:         "CONSOLE",
+						Definition: "console",
+					},
+					{
+						ID:         "SETTINGS",
+						Definition: "settings",
+					},
+					{
+						ID:         "HELP",
+						Definition: "help",
+					},
+					{
+						ID:         "ARE_YOU_SURE",
+						Definition: "are you sure?",
+					},
+					{
+						ID:         "YES",
+						Definition: "yes!!!",
+					},
+					{
+						ID:         "LOADING",
+						Definition: "loading",
+					},
+					{
+						ID:         "LOCKED",
+						Definition: "locked",
+					},
+					{
+						ID:         "IDLE_TIMEOUT",
+						Definition: "%v idle timeout",
+					},
+					{
+						ID:         "IDLE_TIMEOUT_SECONDS",
+						Definition: "Idle timeout in seconds:",
+					},
+					{
+						ID:         "EXIT",
+						Definition: "exit",
+					},
+					{
+						ID:         "UNLOCK",
+						Definition: "unlock",
+					},
+					{
+						ID:         "UNLOCK_PASSWORD",
+						Definition: "enter password",
+					},
+					{
+						ID:         "CREATE_NEW_WALLET",
+						Definition: "create new wallet",
+					},
+					{
+						ID:         "USE_TESTNET",
+						Definition: "Use testnet?",
+					},
+					{
+						ID:         "YOUR_SEED",
+						Definition: "your seed",
+					},
+					{
+						ID: "SEED_STORED",
+						Definition: "I have stored the seed and password safely" +
+							" and understand it cannot be recovered",
+					},
+					{
+						ID:         "CREATE_WALLET",
+						Definition: "create wallet",
+					},
+					{
+						ID:         "BALANCES",
+						Definition: "balances",
+					},
+					{
+						ID:         "CONFIRMED",
+						Definition: "confirmed",
+					},
+					{
+						ID:         "UNCONFIRMED",
+						Definition: "unconfirmed",
+					},
+					{
+						ID:         "TOTAL",
+						Definition: "total",
+					},
+					{
+						ID:         "RECENT_TRANSACTIONS",
+						Definition: "recent transactions",
+					},
+					{
+						ID:         "CATEGORY_GENERATE",
+						Definition: "generate",
+					},
+					{
+						ID:         "CATEGORY_IMMATURE",
+						Definition: "immature",
+					},
+					{
+						ID:         "CATEGORY_RECEIVE",
+						Definition: "receive",
+					},
+					{
+						ID:         "CATEGORY_SEND",
+						Definition: "send",
+					},
+					{
+						ID:         "CATEGORY_ORPHAN",
+						Definition: "orphan",
+					},
+					{
+						ID:         "CATEGORY_UNKNOWN",
+						Definition: "unknown",
+					},
+					{
+						ID:         "SHOW",
+						Definition: "show",
+					},
+					{
+						ID:         "SENT",
+						Definition: "sent",
+					},
+					{
+						ID:         "RECEIVED",
+						Definition: "received",
+					},
+					{
+						ID:         "SAVE",
+						Definition: "save",
+					},
+					{
+						ID:         "SPEED_UP",
+						Definition: "speed up",
+					},
+					{
+						ID:         "ADD_COMMENT",
+						Definition: "add a comment",
+					},
+					{
+						ID:         "PASTE",
+						Definition: "paste",
+					},
+					{
+						ID:         "SEND_HISTORY",
+						Definition: "Send Address History",
+					},
+					{
+						ID:         "COINS",
+						Definition: "coins",
+					},
+					{
+						ID:         "COIN_CONTROL",
+						Definition: "choose outputs to spend, or how the wallet chooses them",
+					},
+					{
+						ID:         "COIN",
+						Definition: "%[2]s %[3]s (%[1]d confirmations)",
+						Plural: []string{
+							"%[2]s %[3]s (%[1]d confirmation)",
+							"%[2]s %[3]s (%[1]d confirmations)",
+						},
+					},
+					{
+						ID:         "STRATEGY_LARGEST_FIRST",
+						Definition: "largest first",
+					},
+					{
+						ID:         "STRATEGY_BRANCH_AND_BOUND",
+						Definition: "no change",
+					},
+					{
+						ID:         "STRATEGY_PRIVACY",
+						Definition: "privacy",
+					},
+					{
+						ID:         "STRATEGY_CONSOLIDATE_DUST",
+						Definition: "consolidate dust",
+					},
+					{
+						ID:         "SCAN_TO_SEND",
+						Definition: "Scan to send or click to copy",
+					},
+					{
+						ID:         "RECEIVE_HISTORY",
+						Definition: "Receive Address History",
+					},
+					{
+						ID:         "REGENERATE",
+						Definition: "regenerate",
+					},
+					{
+						ID:         "HELP_TITLE",
+						Definition: "ParallelCoin Pod Gio Wallet",
+					},
+					{
+						ID:         "BUILT_FROM",
+						Definition: "Built from git repository:",
+					},
+					{
+						ID:         "GIT_REF",
+						Definition: "GitRef:",
+					},
+					{
+						ID:         "GIT_COMMIT",
+						Definition: "GitCommit:",
+					},
+					{
+						ID:         "BUILD_TIME",
+						Definition: "BuildTime:",
+					},
+					{
+						ID:         "TAG",
+						Definition: "Tag:",
+					},
+					{
+						ID:         "POWERED_BY",
+						Definition: "powered by Gio",
+					},
+					{
+						ID:         "CONSOLE_WELCOME",
+						Definition: "Welcome to the Parallelcoin RPC console",
+					},
+					{
+						ID:         "CONSOLE_USAGE",
+						Definition: "Type 'help' to get available commands and 'clear' or 'cls' to clear the screen",
+					},
+					{
+						ID:         "CONSOLE_HINT",
+						Definition: "enter an rpc command",
+					},
+					{
+						ID:         "AMOUNT",
+						Definition: "Amount",
+					},
+					{
+						ID:         "DESCRIPTION",
+						Definition: "Description",
+					},
+					{
+						ID:         "ADDRESS",
+						Definition: "Parallelcoin Address",
+					},
+					{
+						ID:         "COMMENT",
+						Definition: "Comment",
+					},
+					{
+						ID:         "WALLET_SEED",
+						Definition: "wallet seed",
+					},
+					{
+						ID:         "PASSWORD",
+						Definition: "password",
+					},
+					{
+						ID:         "CONFIRM_PASSWORD",
+						Definition: "confirm",
+					},
+					{
+						ID:         "PUBLIC_PASSWORD",
+						Definition: "public password (optional)",
+					},
+				},
+			},
+		},
+	}
+}
@@ -1,8 +1,23 @@
+// Package lang is the message catalogue of the user interfaces of pod. Every message is defined in English by the
+// component that shows it, and may be translated into other languages, either built in or in catalogue files loaded at
+// startup. Messages without a translation in the chosen language are shown in English.
 package lang
 
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultLanguage is the language every message is defined in, and the one shown when a message has no translation.
+const DefaultLanguage = "en"
+
+// Text is a message in one language. Messages that depend on a count have a form for each plural category of the
+// language in Plural, and Definition is the form used for counts the language has no category for.
 type Text struct {
 	ID         string
 	Definition string
+	Plural     []string
 }
 
 type Language struct {
@@ -15,24 +30,175 @@ type Com struct {
 }
 type Dictionary []Com
 
-type Lexicon map[string]string
+// registered holds the messages of components that define them while running, such as the help of the command line.
+var registered = struct {
+	mx   sync.Mutex
+	dict Dictionary
+}{}
+
+// Register adds the messages of a component to the catalogue. Lexicons show them from the next time their language is
+// set.
+func Register(c Com) {
+	registered.mx.Lock()
+	registered.dict = append(registered.dict, c)
+	registered.mx.Unlock()
+}
 
-var dict Dictionary
+// dictionary returns the messages of all the components, built in and registered.
+func dictionary() Dictionary {
+	registered.mx.Lock()
+	defer registered.mx.Unlock()
+	return append(Dictionary{goAppDict(), guiDict()}, registered.dict...)
+}
 
+// Lexicon is the catalogue of messages in the language chosen for the user interface, keyed by the component and ID of
+// each message joined by an underscore. The language can be changed while it is in use.
+type Lexicon struct {
+	mx       sync.RWMutex
+	code     string
+	texts    map[string]Text
+	fallback map[string]Text
+	onChange []func(code string)
+}
+
+// ExportLanguage returns the catalogue of messages in a language, or in the default language if there are none in the
+// language given.
 func ExportLanguage(l string) *Lexicon {
-	lex := Lexicon{}
-	d := Dictionary{}
-	d = append(d, goAppDict())
-	for _, c := range d {
+	lex := &Lexicon{}
+	if err := lex.SetLanguage(l); Check(err) {
+		_ = lex.SetLanguage(DefaultLanguage)
+	}
+	return lex
+}
+
+// SetLanguage changes the language of the messages and tells the functions registered with OnChange about it.
+func (l *Lexicon) SetLanguage(code string) error {
+	texts := catalogue(code)
+	if len(texts) == 0 {
+		return fmt.Errorf("there are no messages in language %q", code)
+	}
+	if missing := Missing(code); len(missing) > 0 {
+		Warnf("%d messages have no translation in language %q and are shown in English", len(missing), code)
+		Debug("untranslated messages:", missing)
+	}
+	l.mx.Lock()
+	l.code = code
+	l.texts = texts
+	l.fallback = catalogue(DefaultLanguage)
+	onChange := l.onChange
+	l.mx.Unlock()
+	for _, fn := range onChange {
+		fn(code)
+	}
+	return nil
+}
+
+// Code returns the code of the language of the messages.
+func (l *Lexicon) Code() string {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+	return l.code
+}
+
+// OnChange registers a function to call with the new language code whenever the language is changed.
+func (l *Lexicon) OnChange(fn func(code string)) {
+	l.mx.Lock()
+	l.onChange = append(l.onChange, fn)
+	l.mx.Unlock()
+}
+
+// text returns a message and the language it is in, which is the default language if it has no translation.
+func (l *Lexicon) text(id string) (t Text, code string, ok bool) {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+	if t, ok = l.texts[id]; ok {
+		return t, l.code, true
+	}
+	t, ok = l.fallback[id]
+	return t, DefaultLanguage, ok
+}
+
+// RenderText returns a message, with the arguments formatted into it as by fmt.Sprintf when any are given. The ID is
+// returned for messages that are not in the catalogue.
+func (l *Lexicon) RenderText(id string, a ...interface{}) string {
+	t, _, ok := l.text(id)
+	if !ok {
+		Debug("no message with ID", id)
+		return id
+	}
+	if len(a) == 0 {
+		return t.Definition
+	}
+	return fmt.Sprintf(t.Definition, a...)
+}
+
+// RenderPlural returns the form of a message for a count in the language of the message. The count is the first
+// argument formatted into it, followed by the others given.
+func (l *Lexicon) RenderPlural(id string, n int, a ...interface{}) string {
+	t, code, ok := l.text(id)
+	if !ok {
+		Debug("no message with ID", id)
+		return id
+	}
+	form := t.Definition
+	if i := pluralForm(code, n); i < len(t.Plural) {
+		form = t.Plural[i]
+	}
+	return fmt.Sprintf(form, append([]interface{}{n}, a...)...)
+}
+
+// catalogue returns the messages in a language, built in and loaded from files, keyed by component and ID.
+func catalogue(code string) map[string]Text {
+	texts := make(map[string]Text)
+	for _, c := range dictionary() {
 		for _, lang := range c.Languages {
+			if lang.Code != code {
+				continue
+			}
 			for _, def := range lang.Definitions {
-				lex[c.Component+"_"+def.ID] = def.Definition
+				texts[c.Component+"_"+def.ID] = def
 			}
 		}
 	}
-	return &lex
+	files.mx.Lock()
+	for id, t := range files.texts[code] {
+		texts[id] = t
+	}
+	files.mx.Unlock()
+	return texts
 }
 
-func (l *Lexicon) RenderText(id string) string {
-	return (*l)[id]
+// Languages returns the codes of the languages there are messages in.
+func Languages() (codes []string) {
+	seen := make(map[string]struct{})
+	for _, c := range dictionary() {
+		for _, lang := range c.Languages {
+			seen[lang.Code] = struct{}{}
+		}
+	}
+	files.mx.Lock()
+	for code := range files.texts {
+		seen[code] = struct{}{}
+	}
+	files.mx.Unlock()
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return
+}
+
+// Missing returns the IDs of the messages that have no translation in a language, which are shown in English.
+func Missing(code string) (ids []string) {
+	if code == DefaultLanguage {
+		return nil
+	}
+	texts := catalogue(code)
+	for id := range catalogue(DefaultLanguage) {
+		if _, ok := texts[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return
 }
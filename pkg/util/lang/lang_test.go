@@ -0,0 +1,125 @@
+package lang
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestPluralForm ensures counts pick the plural forms of the CLDR categories of each kind of language.
+func TestPluralForm(t *testing.T) {
+	tests := []struct {
+		code string
+		n    int
+		want int
+	}{
+		{"en", 0, 1},
+		{"en", 1, 0},
+		{"en", 2, 1},
+		{"es_AR", 1, 0},
+		{"es_AR", 11, 1},
+		{"ru", 1, 0},
+		{"ru", 3, 1},
+		{"ru", 5, 2},
+		{"ru", 11, 2},
+		{"ru", 12, 2},
+		{"ru", 21, 0},
+		{"ru", 22, 1},
+		{"ru", 111, 2},
+		{"zh", 1, 0},
+		{"zh", 7, 0},
+	}
+	for _, test := range tests {
+		if got := pluralForm(test.code, test.n); got != test.want {
+			t.Errorf("pluralForm(%q, %d): got %d, want %d", test.code, test.n, got, test.want)
+		}
+	}
+}
+
+// TestFormat ensures numbers and amounts are written with the separators of the language.
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		code   string
+		f      float64
+		amount util.Amount
+		number string
+		duo    string
+	}{
+		{"en", 1234567.891, 123456789012, "1,234,567.89", "1,234.56789012 DUO"},
+		{"es", 1234.5, 100000000, "1234,50", "1,00000000 DUO"},
+		{"es", -12345.5, -1234500000000, "-12.345,50", "-12.345,00000000 DUO"},
+		{"ru", 1234567, 5, "1 234 567,00", "0,00000005 DUO"},
+		{"zh", 0.5, 0, "0.50", "0.00000000 DUO"},
+	}
+	for _, test := range tests {
+		l := &Lexicon{code: test.code}
+		if got := l.FormatNumber(test.f, 2); got != test.number {
+			t.Errorf("FormatNumber in %q: got %q, want %q", test.code, got, test.number)
+		}
+		if got := l.FormatAmount(test.amount); got != test.duo {
+			t.Errorf("FormatAmount in %q: got %q, want %q", test.code, got, test.duo)
+		}
+	}
+}
+
+// TestCatalogueFile ensures messages loaded from a file are shown in their language, that messages missing from it are
+// shown in English and reported, and that switching the language tells the registered functions.
+func TestCatalogueFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := `{
+	"gui_SEND": "enviar",
+	"gui_COIN": ["%[2]s %[3]s (%[1]d confirmación)", "%[2]s %[3]s (%[1]d confirmaciones)"]
+}`
+	if err = ioutil.WriteFile(filepath.Join(dir, "xx"+FileExt), []byte(file), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	lex := ExportLanguage(DefaultLanguage)
+	var changed string
+	lex.OnChange(func(code string) { changed = code })
+	if err = lex.SetLanguage("xx"); err != nil {
+		t.Fatalf("SetLanguage: %v", err)
+	}
+	if changed != "xx" {
+		t.Errorf("OnChange: got %q, want %q", changed, "xx")
+	}
+	if got := lex.RenderText("gui_SEND"); got != "enviar" {
+		t.Errorf("translated message: got %q", got)
+	}
+	if got := lex.RenderText("gui_RECEIVE"); got != "receive" {
+		t.Errorf("untranslated message: got %q", got)
+	}
+	if got := lex.RenderPlural("gui_COIN", 1, "1.5", "addr"); got != "1.5 addr (1 confirmación)" {
+		t.Errorf("plural message: got %q", got)
+	}
+	if got := lex.RenderPlural("gui_COIN", 3, "1.5", "addr"); got != "1.5 addr (3 confirmaciones)" {
+		t.Errorf("plural message: got %q", got)
+	}
+	if got := lex.RenderText("gui_NO_SUCH_MESSAGE"); got != "gui_NO_SUCH_MESSAGE" {
+		t.Errorf("unknown message: got %q", got)
+	}
+	missing := Missing("xx")
+	if len(missing) != len(catalogue(DefaultLanguage))-2 {
+		t.Errorf("Missing: got %d messages, want %d", len(missing), len(catalogue(DefaultLanguage))-2)
+	}
+	for _, id := range missing {
+		if id == "gui_SEND" || id == "gui_COIN" {
+			t.Errorf("Missing: reported translated message %s", id)
+		}
+	}
+	if err = lex.SetLanguage("yy"); err == nil {
+		t.Errorf("SetLanguage: accepted a language without messages")
+	}
+	if lex.Code() != "xx" {
+		t.Errorf("Code: got %q after failing to change the language", lex.Code())
+	}
+}
@@ -0,0 +1,48 @@
+package lang
+
+import (
+	"strings"
+)
+
+// base returns the language of a code that may also name a region, such as es for es_AR.
+func base(code string) string {
+	if i := strings.IndexAny(code, "_-."); i >= 0 {
+		return code[:i]
+	}
+	return code
+}
+
+// pluralForm returns the index of the plural form of a message to use for a count in a language, following the rules
+// of the Unicode CLDR for whole numbers. The forms of a message are in the order of the categories of its language.
+func pluralForm(code string, n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch base(code) {
+	case "zh", "ja", "ko", "vi", "th", "id":
+		// one form for every count
+		return 0
+	case "ru", "uk", "be", "rs", "sr", "hr", "bs":
+		// one, few and many
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return 0
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return 1
+		default:
+			return 2
+		}
+	case "fr", "pt":
+		// one for zero and one, and other
+		if n <= 1 {
+			return 0
+		}
+		return 1
+	default:
+		// one and other
+		if n == 1 {
+			return 0
+		}
+		return 1
+	}
+}
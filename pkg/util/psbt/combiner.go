@@ -0,0 +1,112 @@
+package psbt
+
+import (
+	"bytes"
+)
+
+// Combine merges packets for the same transaction that have been updated or signed separately into a new packet
+// containing the data of all of them. Where the packets disagree on a value, that of the first is kept.
+func Combine(packets ...*Packet) (*Packet, error) {
+	if len(packets) == 0 {
+		return nil, ErrInvalidPsbtFormat
+	}
+	txHash := packets[0].UnsignedTx.TxHash()
+	combined, err := NewFromUnsignedTx(packets[0].UnsignedTx.Copy())
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range packets {
+		if p.UnsignedTx.TxHash() != txHash {
+			return nil, ErrDifferentTransactions
+		}
+		if err := p.SanityCheck(); err != nil {
+			return nil, err
+		}
+		combined.Unknowns = mergeUnknowns(combined.Unknowns, p.Unknowns)
+		for i := range p.Inputs {
+			combined.Inputs[i].merge(&p.Inputs[i])
+		}
+		for i := range p.Outputs {
+			combined.Outputs[i].merge(&p.Outputs[i])
+		}
+	}
+	return combined, nil
+}
+
+// merge adds the data of another copy of the input that is missing from this one.
+func (pi *PInput) merge(other *PInput) {
+	if pi.NonWitnessUtxo == nil {
+		pi.NonWitnessUtxo = other.NonWitnessUtxo
+	}
+	if pi.WitnessUtxo == nil {
+		pi.WitnessUtxo = other.WitnessUtxo
+	}
+	if pi.FinalScriptSig == nil && pi.FinalScriptWitness == nil {
+		pi.FinalScriptSig = other.FinalScriptSig
+		pi.FinalScriptWitness = other.FinalScriptWitness
+	}
+	pi.Unknowns = mergeUnknowns(pi.Unknowns, other.Unknowns)
+	if pi.IsFinalized() {
+		pi.PartialSigs = nil
+		pi.SighashType = 0
+		pi.RedeemScript = nil
+		pi.WitnessScript = nil
+		pi.Bip32Derivation = nil
+		return
+	}
+	for _, ps := range other.PartialSigs {
+		if findPartialSig(pi, ps.PubKey) == nil {
+			pi.PartialSigs = append(pi.PartialSigs, ps)
+		}
+	}
+	if pi.SighashType == 0 {
+		pi.SighashType = other.SighashType
+	}
+	if pi.RedeemScript == nil {
+		pi.RedeemScript = other.RedeemScript
+	}
+	if pi.WitnessScript == nil {
+		pi.WitnessScript = other.WitnessScript
+	}
+	pi.Bip32Derivation = mergeBip32Derivations(pi.Bip32Derivation, other.Bip32Derivation)
+}
+
+// merge adds the data of another copy of the output that is missing from this one.
+func (po *POutput) merge(other *POutput) {
+	if po.RedeemScript == nil {
+		po.RedeemScript = other.RedeemScript
+	}
+	if po.WitnessScript == nil {
+		po.WitnessScript = other.WitnessScript
+	}
+	po.Bip32Derivation = mergeBip32Derivations(po.Bip32Derivation, other.Bip32Derivation)
+	po.Unknowns = mergeUnknowns(po.Unknowns, other.Unknowns)
+}
+
+// mergeBip32Derivations appends the derivations of keys that are not yet present.
+func mergeBip32Derivations(to, from []*Bip32Derivation) []*Bip32Derivation {
+next:
+	for _, d := range from {
+		for _, x := range to {
+			if bytes.Equal(x.PubKey, d.PubKey) {
+				continue next
+			}
+		}
+		to = append(to, d)
+	}
+	return to
+}
+
+// mergeUnknowns appends the key/value pairs with keys that are not yet present.
+func mergeUnknowns(to, from []*Unknown) []*Unknown {
+next:
+	for _, u := range from {
+		for _, x := range to {
+			if bytes.Equal(x.Key, u.Key) {
+				continue next
+			}
+		}
+		to = append(to, u)
+	}
+	return to
+}
@@ -0,0 +1,28 @@
+/*Package psbt provides an API for partially signed transactions (BIP0174).
+
+Overview
+
+A partially signed transaction carries an unsigned transaction together with everything the parties spending its inputs
+need in order to sign it: the outputs being spent, the redeem scripts of pay-to-script-hash inputs and the signatures
+collected so far. It lets a transaction be funded by one wallet, passed around to the holders of the keys - including
+machines that are never connected to the network - signed by each, and then combined, finalized and broadcast.
+
+Roles
+
+The functions in this package follow the roles described in the BIP. New and NewFromUnsignedTx create a packet from an
+unsigned transaction. The AddIn and AddOut methods of Packet add the data an updater knows about the inputs and outputs,
+and AddPartialSig adds a signature for one of the keys an input can be spent with. Combine merges the data of several
+copies of the same packet that have been signed separately. Finalize and MaybeFinalizeAll build the final signature
+scripts from the partial signatures, after which Extract returns the fully signed transaction.
+
+Serialization
+
+NewFromRawBytes decodes a packet from its binary or base64 encoding, and Serialize and B64Encode encode it again. Key
+types this package does not know about are preserved, so a packet passes through unchanged the data it cannot interpret.
+
+Script Types
+
+The finalizer supports pay-to-pubkey, pay-to-pubkey-hash and bare multisig outputs, as well as each of these wrapped in
+pay-to-script-hash. Witness inputs can be carried and combined, but not finalized.
+*/
+package psbt
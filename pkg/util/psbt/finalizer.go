@@ -0,0 +1,161 @@
+package psbt
+
+import (
+	"bytes"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// findPartialSig returns the signature of the input made with the provided public key, or nil if there is none.
+func findPartialSig(in *PInput, pubKey []byte) []byte {
+	for _, ps := range in.PartialSigs {
+		if bytes.Equal(ps.PubKey, pubKey) {
+			return ps.Signature
+		}
+	}
+	return nil
+}
+
+// Finalize builds the final signature script and, for pay-to-witness-pubkey-hash inputs, the final witness of an
+// input from its partial signatures, and removes the data that was only needed to produce them. ErrNotFinalizable is
+// returned when the input does not have enough signatures yet, or the data it needs is missing.
+func Finalize(p *Packet, inIndex int) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	if in.IsFinalized() {
+		return nil
+	}
+	utxo, err := p.Utxo(inIndex)
+	if err == ErrMissingInputUtxo {
+		return ErrNotFinalizable
+	} else if err != nil {
+		return err
+	}
+	script := utxo.PkScript
+	class := txscript.GetScriptClass(script)
+	if class == txscript.ScriptHashTy {
+		if in.RedeemScript == nil {
+			return ErrNotFinalizable
+		}
+		if !bytes.Equal(util.Hash160(in.RedeemScript), script[2:22]) {
+			return ErrInvalidPsbtFormat
+		}
+		script = in.RedeemScript
+		class = txscript.GetScriptClass(script)
+	}
+	pushes, err := txscript.PushedData(script)
+	if err != nil {
+		return ErrUnsupportedScriptType
+	}
+	builder := txscript.NewScriptBuilder()
+	var witness []byte
+	switch class {
+	case txscript.WitnessV0PubKeyHashTy:
+		// the signature and key go in the witness, leaving only the redeem script of a nested input for the
+		// signature script, and the key hash is the push after the version
+		for _, ps := range in.PartialSigs {
+			if bytes.Equal(util.Hash160(ps.PubKey), pushes[1]) {
+				if witness, err = SerializeTxWitness(wire.TxWitness{ps.Signature, ps.PubKey}); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		if witness == nil {
+			return ErrNotFinalizable
+		}
+	case txscript.PubKeyTy:
+		sig := findPartialSig(in, pushes[0])
+		if sig == nil {
+			return ErrNotFinalizable
+		}
+		builder.AddData(sig)
+	case txscript.PubKeyHashTy:
+		var found bool
+		for _, ps := range in.PartialSigs {
+			if bytes.Equal(util.Hash160(ps.PubKey), pushes[0]) {
+				builder.AddData(ps.Signature).AddData(ps.PubKey)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrNotFinalizable
+		}
+	case txscript.MultiSigTy:
+		_, nRequired, err := txscript.CalcMultiSigStats(script)
+		if err != nil {
+			return ErrUnsupportedScriptType
+		}
+		// the extra item consumed by OP_CHECKMULTISIG, followed by the signatures in the order of their keys
+		builder.AddOp(txscript.OP_FALSE)
+		var count int
+		for _, pubKey := range pushes {
+			if count == nRequired {
+				break
+			}
+			if sig := findPartialSig(in, pubKey); sig != nil {
+				builder.AddData(sig)
+				count++
+			}
+		}
+		if count < nRequired {
+			return ErrNotFinalizable
+		}
+	default:
+		return ErrUnsupportedScriptType
+	}
+	if in.RedeemScript != nil {
+		builder.AddData(in.RedeemScript)
+	}
+	sigScript, err := builder.Script()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	if len(sigScript) > 0 {
+		in.FinalScriptSig = sigScript
+	}
+	in.FinalScriptWitness = witness
+	in.PartialSigs = nil
+	in.SighashType = 0
+	in.RedeemScript = nil
+	in.WitnessScript = nil
+	in.Bip32Derivation = nil
+	return nil
+}
+
+// MaybeFinalizeAll finalizes every input that has all the signatures it needs, leaving the others as they are. The
+// packet is complete when every input could be finalized.
+func MaybeFinalizeAll(p *Packet) error {
+	for i := range p.Inputs {
+		if err := Finalize(p, i); err != nil && err != ErrNotFinalizable {
+			return err
+		}
+	}
+	return nil
+}
+
+// Extract returns the signed transaction of a complete packet, with the final scripts of its inputs filled in.
+func Extract(p *Packet) (*wire.MsgTx, error) {
+	if !p.IsComplete() {
+		return nil, ErrIncompletePSBT
+	}
+	tx := p.UnsignedTx.Copy()
+	for i := range tx.TxIn {
+		in := &p.Inputs[i]
+		tx.TxIn[i].SignatureScript = in.FinalScriptSig
+		if in.FinalScriptWitness != nil {
+			witness, err := DeserializeTxWitness(in.FinalScriptWitness)
+			if err != nil {
+				return nil, err
+			}
+			tx.TxIn[i].Witness = witness
+		}
+	}
+	return tx, nil
+}
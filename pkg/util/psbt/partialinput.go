@@ -0,0 +1,176 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// PInput is the map of data about one input of a packet. A zero SighashType means none has been set.
+type PInput struct {
+	NonWitnessUtxo     *wire.MsgTx
+	WitnessUtxo        *wire.TxOut
+	PartialSigs        []*PartialSig
+	SighashType        txscript.SigHashType
+	RedeemScript       []byte
+	WitnessScript      []byte
+	Bip32Derivation    []*Bip32Derivation
+	FinalScriptSig     []byte
+	FinalScriptWitness []byte
+	Unknowns           []*Unknown
+}
+
+// IsFinalized returns true when the final scripts of the input have been built.
+func (pi *PInput) IsFinalized() bool {
+	return pi.FinalScriptSig != nil || pi.FinalScriptWitness != nil
+}
+
+// deserialize reads the map of the input from the reader, up to and including the separator.
+func (pi *PInput) deserialize(r io.Reader) error {
+	seen := make(map[string]struct{})
+	for {
+		key, value, err := readKVPair(r)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return nil
+		}
+		if _, ok := seen[string(key)]; ok {
+			return ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+		keyData := key[1:]
+		switch key[0] {
+		case nonWitnessUtxoType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			tx := wire.NewMsgTx(1)
+			if err := tx.Deserialize(bytes.NewReader(value)); err != nil {
+				return ErrInvalidPsbtFormat
+			}
+			pi.NonWitnessUtxo = tx
+		case witnessUtxoType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			if pi.WitnessUtxo, err = readTxOut(value); err != nil {
+				return err
+			}
+		case partialSigType:
+			sig := &PartialSig{PubKey: keyData, Signature: value}
+			if !sig.checkValid() {
+				return ErrInvalidPsbtFormat
+			}
+			pi.PartialSigs = append(pi.PartialSigs, sig)
+		case sighashType:
+			if len(keyData) != 0 || len(value) != 4 {
+				return ErrInvalidPsbtFormat
+			}
+			pi.SighashType = txscript.SigHashType(binary.LittleEndian.Uint32(value))
+		case inRedeemScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			pi.RedeemScript = value
+		case inWitnessScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			pi.WitnessScript = value
+		case inBip32DerivationType:
+			d, err := readBip32Derivation(keyData, value)
+			if err != nil {
+				return err
+			}
+			pi.Bip32Derivation = append(pi.Bip32Derivation, d)
+		case finalScriptSigType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			pi.FinalScriptSig = value
+		case finalScriptWitnessType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			if _, err := DeserializeTxWitness(value); err != nil {
+				return err
+			}
+			pi.FinalScriptWitness = value
+		default:
+			pi.Unknowns = append(pi.Unknowns, &Unknown{Key: key, Value: value})
+		}
+	}
+}
+
+// serialize writes the map of the input to the writer, followed by the separator.
+func (pi *PInput) serialize(w io.Writer) error {
+	if pi.NonWitnessUtxo != nil {
+		var tx bytes.Buffer
+		if err := pi.NonWitnessUtxo.Serialize(&tx); err != nil {
+			Error(err)
+			return err
+		}
+		if err := writeKVPair(w, nonWitnessUtxoType, nil, tx.Bytes()); err != nil {
+			return err
+		}
+	}
+	if pi.WitnessUtxo != nil {
+		txOut, err := serializeTxOut(pi.WitnessUtxo)
+		if err != nil {
+			return err
+		}
+		if err := writeKVPair(w, witnessUtxoType, nil, txOut); err != nil {
+			return err
+		}
+	}
+	if !pi.IsFinalized() {
+		sortPartialSigs(pi.PartialSigs)
+		for _, sig := range pi.PartialSigs {
+			if err := writeKVPair(w, partialSigType, sig.PubKey, sig.Signature); err != nil {
+				return err
+			}
+		}
+		if pi.SighashType != 0 {
+			var value [4]byte
+			binary.LittleEndian.PutUint32(value[:], uint32(pi.SighashType))
+			if err := writeKVPair(w, sighashType, nil, value[:]); err != nil {
+				return err
+			}
+		}
+		if pi.RedeemScript != nil {
+			if err := writeKVPair(w, inRedeemScriptType, nil, pi.RedeemScript); err != nil {
+				return err
+			}
+		}
+		if pi.WitnessScript != nil {
+			if err := writeKVPair(w, inWitnessScriptType, nil, pi.WitnessScript); err != nil {
+				return err
+			}
+		}
+		sortBip32Derivations(pi.Bip32Derivation)
+		for _, d := range pi.Bip32Derivation {
+			if err := writeKVPair(w, inBip32DerivationType, d.PubKey, d.serialize()); err != nil {
+				return err
+			}
+		}
+	}
+	if pi.FinalScriptSig != nil {
+		if err := writeKVPair(w, finalScriptSigType, nil, pi.FinalScriptSig); err != nil {
+			return err
+		}
+	}
+	if pi.FinalScriptWitness != nil {
+		if err := writeKVPair(w, finalScriptWitnessType, nil, pi.FinalScriptWitness); err != nil {
+			return err
+		}
+	}
+	if err := writeUnknowns(w, pi.Unknowns); err != nil {
+		return err
+	}
+	return writeSeparator(w)
+}
@@ -0,0 +1,77 @@
+package psbt
+
+import (
+	"io"
+)
+
+// POutput is the map of data about one output of a packet, which lets the signers recognise an output paying back to
+// them, such as change.
+type POutput struct {
+	RedeemScript    []byte
+	WitnessScript   []byte
+	Bip32Derivation []*Bip32Derivation
+	Unknowns        []*Unknown
+}
+
+// deserialize reads the map of the output from the reader, up to and including the separator.
+func (po *POutput) deserialize(r io.Reader) error {
+	seen := make(map[string]struct{})
+	for {
+		key, value, err := readKVPair(r)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return nil
+		}
+		if _, ok := seen[string(key)]; ok {
+			return ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+		keyData := key[1:]
+		switch key[0] {
+		case outRedeemScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			po.RedeemScript = value
+		case outWitnessScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidPsbtFormat
+			}
+			po.WitnessScript = value
+		case outBip32DerivationType:
+			d, err := readBip32Derivation(keyData, value)
+			if err != nil {
+				return err
+			}
+			po.Bip32Derivation = append(po.Bip32Derivation, d)
+		default:
+			po.Unknowns = append(po.Unknowns, &Unknown{Key: key, Value: value})
+		}
+	}
+}
+
+// serialize writes the map of the output to the writer, followed by the separator.
+func (po *POutput) serialize(w io.Writer) error {
+	if po.RedeemScript != nil {
+		if err := writeKVPair(w, outRedeemScriptType, nil, po.RedeemScript); err != nil {
+			return err
+		}
+	}
+	if po.WitnessScript != nil {
+		if err := writeKVPair(w, outWitnessScriptType, nil, po.WitnessScript); err != nil {
+			return err
+		}
+	}
+	sortBip32Derivations(po.Bip32Derivation)
+	for _, d := range po.Bip32Derivation {
+		if err := writeKVPair(w, outBip32DerivationType, d.PubKey, d.serialize()); err != nil {
+			return err
+		}
+	}
+	if err := writeUnknowns(w, po.Unknowns); err != nil {
+		return err
+	}
+	return writeSeparator(w)
+}
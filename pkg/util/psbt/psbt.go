@@ -0,0 +1,305 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// MaxPsbtValueLength is the maximum length of the value of a key/value pair, which bounds the size of an encoded
+	// transaction or script that will be accepted.
+	MaxPsbtValueLength = 4000000
+	// MaxPsbtKeyLength is the maximum length of the key of a key/value pair.
+	MaxPsbtKeyLength = 10000
+)
+
+// psbtMagic is the sequence every encoded packet starts with, the string "psbt" followed by 0xff.
+var psbtMagic = [5]byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// The key types of the global, input and output maps of a packet.
+const (
+	unsignedTxType = 0x00
+
+	nonWitnessUtxoType     = 0x00
+	witnessUtxoType        = 0x01
+	partialSigType         = 0x02
+	sighashType            = 0x03
+	inRedeemScriptType     = 0x04
+	inWitnessScriptType    = 0x05
+	inBip32DerivationType  = 0x06
+	finalScriptSigType     = 0x07
+	finalScriptWitnessType = 0x08
+
+	outRedeemScriptType    = 0x00
+	outWitnessScriptType   = 0x01
+	outBip32DerivationType = 0x02
+)
+
+var (
+	// ErrInvalidMagic is returned when the data does not start with the magic bytes of a packet.
+	ErrInvalidMagic = errors.New("invalid magic bytes for partially signed transaction")
+	// ErrInvalidPsbtFormat is returned when the data is not a well formed packet.
+	ErrInvalidPsbtFormat = errors.New("invalid partially signed transaction format")
+	// ErrDuplicateKey is returned when a key appears more than once in a map, or a value is added for a key that is
+	// already present.
+	ErrDuplicateKey = errors.New("duplicate key in partially signed transaction")
+	// ErrInvalidRawTxSigned is returned when the unsigned transaction of a packet has signature scripts or witnesses.
+	ErrInvalidRawTxSigned = errors.New("unsigned transaction of a partially signed transaction has signatures")
+	// ErrInvalidPrevOutNonWitnessTransaction is returned when the transaction provided for an input is not the one
+	// whose output the input spends.
+	ErrInvalidPrevOutNonWitnessTransaction = errors.New("transaction provided for an input does not match its outpoint")
+	// ErrInvalidSignatureForInput is returned when a partial signature is malformed or does not use the signature hash
+	// type the input requires.
+	ErrInvalidSignatureForInput = errors.New("invalid signature for input")
+	// ErrInvalidIndex is returned when an input or output index is out of range.
+	ErrInvalidIndex = errors.New("input or output index out of range")
+	// ErrIncompletePSBT is returned when a transaction is extracted from a packet that has inputs which are not
+	// finalized.
+	ErrIncompletePSBT = errors.New("partially signed transaction is not complete")
+	// ErrNotFinalizable is returned when an input does not yet have the data needed to build its final scripts.
+	ErrNotFinalizable = errors.New("input cannot be finalized yet")
+	// ErrUnsupportedScriptType is returned when an input spends a script the finalizer does not support.
+	ErrUnsupportedScriptType = errors.New("unsupported script type")
+	// ErrMissingInputUtxo is returned when the output spent by an input is not known.
+	ErrMissingInputUtxo = errors.New("output spent by input is not known")
+	// ErrDifferentTransactions is returned when packets for different transactions are combined.
+	ErrDifferentTransactions = errors.New("partially signed transactions are for different transactions")
+)
+
+// Unknown is a key/value pair of a type this package does not interpret. It is kept so it can be written out again
+// unchanged.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// Packet is a partially signed transaction: the unsigned transaction, a map of data for each of its inputs and
+// outputs, and any global data of unknown type.
+type Packet struct {
+	UnsignedTx *wire.MsgTx
+	Inputs     []PInput
+	Outputs    []POutput
+	Unknowns   []*Unknown
+}
+
+// New creates a packet for a new transaction spending the provided outpoints to the provided outputs. The sequence
+// numbers default to the maximum when nil is passed.
+func New(inputs []*wire.OutPoint, outputs []*wire.TxOut, version int32, lockTime uint32, sequences []uint32) (
+	*Packet, error) {
+	if sequences != nil && len(sequences) != len(inputs) {
+		return nil, ErrInvalidPsbtFormat
+	}
+	tx := wire.NewMsgTx(version)
+	tx.LockTime = lockTime
+	for i, op := range inputs {
+		txIn := wire.NewTxIn(op, nil, nil)
+		if sequences != nil {
+			txIn.Sequence = sequences[i]
+		}
+		tx.AddTxIn(txIn)
+	}
+	for _, txOut := range outputs {
+		tx.AddTxOut(txOut)
+	}
+	return NewFromUnsignedTx(tx)
+}
+
+// NewFromUnsignedTx creates a packet with empty input and output maps for the provided transaction, which must not
+// have any signature scripts or witnesses.
+func NewFromUnsignedTx(tx *wire.MsgTx) (*Packet, error) {
+	for _, txIn := range tx.TxIn {
+		if len(txIn.SignatureScript) != 0 || len(txIn.Witness) != 0 {
+			return nil, ErrInvalidRawTxSigned
+		}
+	}
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}, nil
+}
+
+// NewFromRawBytes decodes a packet from the reader, which provides either the binary encoding or, when b64 is true,
+// the base64 encoding of it.
+func NewFromRawBytes(r io.Reader, b64 bool) (*Packet, error) {
+	if b64 {
+		encoded, err := ioutil.ReadAll(r)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		r = bytes.NewReader(decoded)
+	}
+	var magic [5]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil || magic != psbtMagic {
+		return nil, ErrInvalidMagic
+	}
+	var tx *wire.MsgTx
+	var unknowns []*Unknown
+	seen := make(map[string]struct{})
+	for {
+		key, value, err := readKVPair(r)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			break
+		}
+		if _, ok := seen[string(key)]; ok {
+			return nil, ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+		if key[0] == unsignedTxType && len(key) == 1 {
+			tx = wire.NewMsgTx(1)
+			if err := tx.DeserializeNoWitness(bytes.NewReader(value)); err != nil {
+				return nil, ErrInvalidPsbtFormat
+			}
+			continue
+		}
+		unknowns = append(unknowns, &Unknown{Key: key, Value: value})
+	}
+	if tx == nil {
+		return nil, ErrInvalidPsbtFormat
+	}
+	p, err := NewFromUnsignedTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	p.Unknowns = unknowns
+	for i := range p.Inputs {
+		if err := p.Inputs[i].deserialize(r); err != nil {
+			return nil, err
+		}
+	}
+	for i := range p.Outputs {
+		if err := p.Outputs[i].deserialize(r); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.SanityCheck(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Serialize writes the binary encoding of the packet to the writer.
+func (p *Packet) Serialize(w io.Writer) error {
+	if _, err := w.Write(psbtMagic[:]); err != nil {
+		Error(err)
+		return err
+	}
+	var tx bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+		Error(err)
+		return err
+	}
+	if err := writeKVPair(w, unsignedTxType, nil, tx.Bytes()); err != nil {
+		return err
+	}
+	if err := writeUnknowns(w, p.Unknowns); err != nil {
+		return err
+	}
+	if err := writeSeparator(w); err != nil {
+		return err
+	}
+	for i := range p.Inputs {
+		if err := p.Inputs[i].serialize(w); err != nil {
+			return err
+		}
+	}
+	for i := range p.Outputs {
+		if err := p.Outputs[i].serialize(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// B64Encode returns the base64 encoding of the packet, the form it is usually passed around in.
+func (p *Packet) B64Encode() (string, error) {
+	var b bytes.Buffer
+	if err := p.Serialize(&b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// IsComplete returns true when every input has been finalized, so the signed transaction can be extracted.
+func (p *Packet) IsComplete() bool {
+	for i := range p.Inputs {
+		if !p.Inputs[i].IsFinalized() {
+			return false
+		}
+	}
+	return true
+}
+
+// SanityCheck checks that the packet has a map for every input and output of its transaction, that the transaction is
+// unsigned, and that the data of each input is consistent with the outpoint it spends.
+func (p *Packet) SanityCheck() error {
+	if p.UnsignedTx == nil || len(p.Inputs) != len(p.UnsignedTx.TxIn) ||
+		len(p.Outputs) != len(p.UnsignedTx.TxOut) {
+		return ErrInvalidPsbtFormat
+	}
+	for i, txIn := range p.UnsignedTx.TxIn {
+		if len(txIn.SignatureScript) != 0 || len(txIn.Witness) != 0 {
+			return ErrInvalidRawTxSigned
+		}
+		in := &p.Inputs[i]
+		if in.NonWitnessUtxo != nil {
+			if in.NonWitnessUtxo.TxHash() != txIn.PreviousOutPoint.Hash ||
+				int(txIn.PreviousOutPoint.Index) >= len(in.NonWitnessUtxo.TxOut) {
+				return ErrInvalidPrevOutNonWitnessTransaction
+			}
+		}
+	}
+	return nil
+}
+
+// Utxo returns the output spent by the input with the provided index, taken from the transaction or the output that
+// has been added for it.
+func (p *Packet) Utxo(inIndex int) (*wire.TxOut, error) {
+	if inIndex < 0 || inIndex >= len(p.Inputs) {
+		return nil, ErrInvalidIndex
+	}
+	in := &p.Inputs[inIndex]
+	switch {
+	case in.WitnessUtxo != nil:
+		return in.WitnessUtxo, nil
+	case in.NonWitnessUtxo != nil:
+		op := p.UnsignedTx.TxIn[inIndex].PreviousOutPoint
+		if int(op.Index) >= len(in.NonWitnessUtxo.TxOut) {
+			return nil, ErrInvalidPrevOutNonWitnessTransaction
+		}
+		return in.NonWitnessUtxo.TxOut[op.Index], nil
+	}
+	return nil, ErrMissingInputUtxo
+}
+
+// Fee returns the fee paid by the transaction, which can only be calculated once the outputs spent by all of its
+// inputs are known.
+func (p *Packet) Fee() (util.Amount, error) {
+	var in, out int64
+	for i := range p.Inputs {
+		utxo, err := p.Utxo(i)
+		if err != nil {
+			return 0, err
+		}
+		in += utxo.Value
+	}
+	for _, txOut := range p.UnsignedTx.TxOut {
+		out += txOut.Value
+	}
+	return util.Amount(in - out), nil
+}
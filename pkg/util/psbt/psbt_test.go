@@ -0,0 +1,342 @@
+package psbt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// multiSigFixture is a transaction paying to a 2-of-3 multisig address and a packet spending its output.
+type multiSigFixture struct {
+	keys         []*ec.PrivateKey
+	redeemScript []byte
+	prevTx       *wire.MsgTx
+	packet       *Packet
+}
+
+func newMultiSigFixture(t *testing.T) *multiSigFixture {
+	f := &multiSigFixture{}
+	var pubKeys []*util.AddressPubKey
+	for i := 0; i < 3; i++ {
+		key, err := ec.NewPrivateKey(ec.S256())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKey, err := util.NewAddressPubKey(key.PubKey().SerializeCompressed(), &netparams.MainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.keys = append(f.keys, key)
+		pubKeys = append(pubKeys, pubKey)
+	}
+	var err error
+	if f.redeemScript, err = txscript.MultiSigScript(pubKeys, 2); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := util.NewAddressScriptHash(f.redeemScript, &netparams.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.prevTx = wire.NewMsgTx(1)
+	f.prevTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, []byte{txscript.OP_TRUE}, nil))
+	f.prevTx.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_TRUE}))
+	f.prevTx.AddTxOut(wire.NewTxOut(500000, pkScript))
+	prevHash := f.prevTx.TxHash()
+	f.packet, err = New([]*wire.OutPoint{wire.NewOutPoint(&prevHash, 1)},
+		[]*wire.TxOut{wire.NewTxOut(490000, []byte{txscript.OP_TRUE})}, 1, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.packet.AddInNonWitnessUtxo(f.prevTx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.packet.AddInRedeemScript(f.redeemScript, 0); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// sign returns a copy of the fixture's packet, passed through its base64 encoding, with a signature by one key added.
+func (f *multiSigFixture) sign(t *testing.T, key int) *Packet {
+	encoded, err := f.packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewFromRawBytes(strings.NewReader(encoded), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := txscript.RawTxInSignature(p.UnsignedTx, 0, f.redeemScript, txscript.SigHashAll, f.keys[key])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddPartialSig(0, sig, f.keys[key].PubKey().SerializeCompressed()); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// TestRoundTrip ensures a packet decodes to the same encoding it was read from, including data of unknown types.
+func TestRoundTrip(t *testing.T) {
+	f := newMultiSigFixture(t)
+	f.packet.Unknowns = append(f.packet.Unknowns, &Unknown{Key: []byte{0x70, 1, 2}, Value: []byte{3}})
+	f.packet.Inputs[0].Unknowns = append(f.packet.Inputs[0].Unknowns, &Unknown{Key: []byte{0x71}, Value: []byte{4}})
+	if err := f.packet.AddOutBip32Derivation(&Bip32Derivation{
+		PubKey:               f.keys[0].PubKey().SerializeCompressed(),
+		MasterKeyFingerprint: 0xdeadbeef,
+		Bip32Path:            []uint32{0x80000000, 1, 7},
+	}, 0); err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := f.packet.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewFromRawBytes(bytes.NewReader(b.Bytes()), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again bytes.Buffer
+	if err := p.Serialize(&again); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b.Bytes(), again.Bytes()) {
+		t.Fatal("packet encoding changed after decoding it")
+	}
+	if len(p.Unknowns) != 1 || len(p.Inputs[0].Unknowns) != 1 {
+		t.Fatal("unknown key/value pairs were not preserved")
+	}
+	if d := p.Outputs[0].Bip32Derivation; len(d) != 1 || d[0].MasterKeyFingerprint != 0xdeadbeef ||
+		len(d[0].Bip32Path) != 3 || d[0].Bip32Path[2] != 7 {
+		t.Fatalf("derivation decoded incorrectly: %v", d)
+	}
+	fee, err := p.Fee()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != 10000 {
+		t.Fatalf("fee is %v, expected 10000", fee)
+	}
+}
+
+// TestInvalid ensures malformed packets are rejected.
+func TestInvalid(t *testing.T) {
+	f := newMultiSigFixture(t)
+	var b bytes.Buffer
+	if err := f.packet.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	good := b.Bytes()
+	badMagic := append([]byte{0x70, 0x73, 0x62, 0x74, 0xfe}, good[5:]...)
+	if _, err := NewFromRawBytes(bytes.NewReader(badMagic), false); err != ErrInvalidMagic {
+		t.Fatalf("bad magic gave %v", err)
+	}
+	if _, err := NewFromRawBytes(bytes.NewReader(good[:len(good)-3]), false); err == nil {
+		t.Fatal("truncated packet was accepted")
+	}
+	// the redeem script key repeated in the input map
+	var dup bytes.Buffer
+	dup.Write(good[:len(good)-2])
+	if err := writeKVPair(&dup, inRedeemScriptType, nil, f.redeemScript); err != nil {
+		t.Fatal(err)
+	}
+	dup.Write([]byte{0, 0})
+	if _, err := NewFromRawBytes(bytes.NewReader(dup.Bytes()), false); err != ErrDuplicateKey {
+		t.Fatalf("duplicate key gave %v", err)
+	}
+	signed := f.packet.UnsignedTx.Copy()
+	signed.TxIn[0].SignatureScript = []byte{txscript.OP_TRUE}
+	if _, err := NewFromUnsignedTx(signed); err != ErrInvalidRawTxSigned {
+		t.Fatalf("signed transaction gave %v", err)
+	}
+	if err := f.packet.AddInNonWitnessUtxo(signed, 0); err != ErrInvalidPrevOutNonWitnessTransaction {
+		t.Fatalf("wrong previous transaction gave %v", err)
+	}
+}
+
+// TestMultiSigWorkflow signs a multisig input with two keys separately, combines the packets, finalizes and extracts
+// the transaction and checks that it executes.
+func TestMultiSigWorkflow(t *testing.T) {
+	f := newMultiSigFixture(t)
+	first := f.sign(t, 2)
+	if err := Finalize(first, 0); err != ErrNotFinalizable {
+		t.Fatalf("finalizing with one of two signatures gave %v", err)
+	}
+	if _, err := Extract(first); err != ErrIncompletePSBT {
+		t.Fatalf("extracting an incomplete packet gave %v", err)
+	}
+	second := f.sign(t, 0)
+	if err := second.AddPartialSig(0, second.Inputs[0].PartialSigs[0].Signature,
+		second.Inputs[0].PartialSigs[0].PubKey); err != ErrDuplicateKey {
+		t.Fatalf("adding a signature twice gave %v", err)
+	}
+	other := newMultiSigFixture(t)
+	if _, err := Combine(first, other.packet); err != ErrDifferentTransactions {
+		t.Fatalf("combining different transactions gave %v", err)
+	}
+	combined, err := Combine(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(combined.Inputs[0].PartialSigs) != 2 {
+		t.Fatalf("combined packet has %d signatures, expected 2", len(combined.Inputs[0].PartialSigs))
+	}
+	if err := MaybeFinalizeAll(combined); err != nil {
+		t.Fatal(err)
+	}
+	if !combined.IsComplete() {
+		t.Fatal("packet is not complete after finalizing")
+	}
+	if combined.Inputs[0].PartialSigs != nil || combined.Inputs[0].RedeemScript != nil {
+		t.Fatal("finalizing did not remove the signing data")
+	}
+	tx, err := Extract(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := txscript.NewEngine(f.prevTx.TxOut[1].PkScript, tx, 0, txscript.StandardVerifyFlags, nil,
+		nil, f.prevTx.TxOut[1].Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("extracted transaction does not execute: %v", err)
+	}
+}
+
+// TestPubKeyHash finalizes a pay-to-pubkey-hash input.
+func TestPubKeyHash(t *testing.T) {
+	key, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := key.PubKey().SerializeCompressed()
+	addr, err := util.NewAddressPubKeyHash(util.Hash160(pubKey), &netparams.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := New([]*wire.OutPoint{{Hash: chainhash.Hash{2}}}, []*wire.TxOut{wire.NewTxOut(1, nil)}, 1, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Finalize(p, 0); err != ErrNotFinalizable {
+		t.Fatalf("finalizing without the spent output gave %v", err)
+	}
+	if err := p.AddInWitnessUtxo(wire.NewTxOut(2, pkScript), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddInSighashType(txscript.SigHashSingle, 0); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := txscript.RawTxInSignature(p.UnsignedTx, 0, pkScript, txscript.SigHashAll, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddPartialSig(0, sig, pubKey); err != ErrInvalidSignatureForInput {
+		t.Fatalf("signature with the wrong hash type gave %v", err)
+	}
+	if sig, err = txscript.RawTxInSignature(p.UnsignedTx, 0, pkScript, txscript.SigHashSingle, key); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddPartialSig(0, sig, pubKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := Finalize(p, 0); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := Extract(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := txscript.NewEngine(pkScript, tx, 0, txscript.StandardVerifyFlags, nil, nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("extracted transaction does not execute: %v", err)
+	}
+}
+
+// TestWitnessPubKeyHash finalizes native and nested pay-to-witness-pubkey-hash inputs.
+func TestWitnessPubKeyHash(t *testing.T) {
+	key, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := key.PubKey().SerializeCompressed()
+	addr, err := util.NewAddressWitnessPubKeyHash(util.Hash160(pubKey), &netparams.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	witnessProgram, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptAddr, err := util.NewAddressScriptHash(witnessProgram, &netparams.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nestedScript, err := txscript.PayToAddrScript(scriptAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, nested := range []bool{false, true} {
+		pkScript := witnessProgram
+		if nested {
+			pkScript = nestedScript
+		}
+		p, err := New([]*wire.OutPoint{{Hash: chainhash.Hash{3}}}, []*wire.TxOut{wire.NewTxOut(1, nil)}, 1, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.AddInWitnessUtxo(wire.NewTxOut(2, pkScript), 0); err != nil {
+			t.Fatal(err)
+		}
+		if nested {
+			if err := p.AddInRedeemScript(witnessProgram, 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+		sig, err := txscript.RawTxInWitnessSignature(p.UnsignedTx, txscript.NewTxSigHashes(p.UnsignedTx), 0, 2,
+			witnessProgram, txscript.SigHashAll, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.AddPartialSig(0, sig, pubKey); err != nil {
+			t.Fatal(err)
+		}
+		if err := Finalize(p, 0); err != nil {
+			t.Fatalf("nested %v: %v", nested, err)
+		}
+		if !nested && p.Inputs[0].FinalScriptSig != nil {
+			t.Fatal("native witness input has a signature script")
+		}
+		tx, err := Extract(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vm, err := txscript.NewEngine(pkScript, tx, 0, txscript.StandardVerifyFlags, nil,
+			txscript.NewTxSigHashes(tx), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := vm.Execute(); err != nil {
+			t.Fatalf("nested %v: extracted transaction does not execute: %v", nested, err)
+		}
+	}
+}
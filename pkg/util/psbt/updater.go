@@ -0,0 +1,134 @@
+package psbt
+
+import (
+	"bytes"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// input returns the map of the input with the provided index, or an error when the index is out of range.
+func (p *Packet) input(inIndex int) (*PInput, error) {
+	if inIndex < 0 || inIndex >= len(p.Inputs) {
+		return nil, ErrInvalidIndex
+	}
+	return &p.Inputs[inIndex], nil
+}
+
+// AddInNonWitnessUtxo adds the transaction containing the output spent by an input. It must be the transaction the
+// outpoint of the input refers to.
+func (p *Packet) AddInNonWitnessUtxo(tx *wire.MsgTx, inIndex int) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	op := p.UnsignedTx.TxIn[inIndex].PreviousOutPoint
+	if tx.TxHash() != op.Hash || int(op.Index) >= len(tx.TxOut) {
+		return ErrInvalidPrevOutNonWitnessTransaction
+	}
+	in.NonWitnessUtxo = tx
+	return nil
+}
+
+// AddInWitnessUtxo adds the output spent by a witness input.
+func (p *Packet) AddInWitnessUtxo(txOut *wire.TxOut, inIndex int) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	in.WitnessUtxo = txOut
+	return nil
+}
+
+// AddInSighashType sets the signature hash type signers must use for an input.
+func (p *Packet) AddInSighashType(hashType txscript.SigHashType, inIndex int) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	in.SighashType = hashType
+	return nil
+}
+
+// AddInRedeemScript adds the redeem script of a pay-to-script-hash input.
+func (p *Packet) AddInRedeemScript(redeemScript []byte, inIndex int) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	in.RedeemScript = redeemScript
+	return nil
+}
+
+// AddInWitnessScript adds the witness script of a pay-to-witness-script-hash input.
+func (p *Packet) AddInWitnessScript(witnessScript []byte, inIndex int) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	in.WitnessScript = witnessScript
+	return nil
+}
+
+// AddInBip32Derivation records the derivation of one of the keys an input can be spent with.
+func (p *Packet) AddInBip32Derivation(d *Bip32Derivation, inIndex int) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	for _, x := range in.Bip32Derivation {
+		if bytes.Equal(x.PubKey, d.PubKey) {
+			return ErrDuplicateKey
+		}
+	}
+	in.Bip32Derivation = append(in.Bip32Derivation, d)
+	return nil
+}
+
+// AddOutRedeemScript adds the redeem script of a pay-to-script-hash output.
+func (p *Packet) AddOutRedeemScript(redeemScript []byte, outIndex int) error {
+	if outIndex < 0 || outIndex >= len(p.Outputs) {
+		return ErrInvalidIndex
+	}
+	p.Outputs[outIndex].RedeemScript = redeemScript
+	return nil
+}
+
+// AddOutBip32Derivation records the derivation of a key of an output, which lets signers recognise their change.
+func (p *Packet) AddOutBip32Derivation(d *Bip32Derivation, outIndex int) error {
+	if outIndex < 0 || outIndex >= len(p.Outputs) {
+		return ErrInvalidIndex
+	}
+	out := &p.Outputs[outIndex]
+	for _, x := range out.Bip32Derivation {
+		if bytes.Equal(x.PubKey, d.PubKey) {
+			return ErrDuplicateKey
+		}
+	}
+	out.Bip32Derivation = append(out.Bip32Derivation, d)
+	return nil
+}
+
+// AddPartialSig adds a signature made with the provided public key to an input. The signature must be DER encoded,
+// followed by the signature hash type, which must be the one set for the input if there is one. Adding a second
+// signature for the same key is an error.
+func (p *Packet) AddPartialSig(inIndex int, sig, pubKey []byte) error {
+	in, err := p.input(inIndex)
+	if err != nil {
+		return err
+	}
+	ps := &PartialSig{PubKey: pubKey, Signature: sig}
+	if !ps.checkValid() {
+		return ErrInvalidSignatureForInput
+	}
+	if in.SighashType != 0 && ps.hashType() != in.SighashType {
+		return ErrInvalidSignatureForInput
+	}
+	for _, x := range in.PartialSigs {
+		if bytes.Equal(x.PubKey, pubKey) {
+			return ErrDuplicateKey
+		}
+	}
+	in.PartialSigs = append(in.PartialSigs, ps)
+	return nil
+}
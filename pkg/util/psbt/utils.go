@@ -0,0 +1,183 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+)
+
+// readKVPair reads a key/value pair from a map of a packet. A nil key is returned for the separator that ends the map.
+func readKVPair(r io.Reader) (key, value []byte, err error) {
+	if key, err = wire.ReadVarBytes(r, 0, MaxPsbtKeyLength, "psbt key"); err != nil {
+		return nil, nil, ErrInvalidPsbtFormat
+	}
+	if len(key) == 0 {
+		return nil, nil, nil
+	}
+	if value, err = wire.ReadVarBytes(r, 0, MaxPsbtValueLength, "psbt value"); err != nil {
+		return nil, nil, ErrInvalidPsbtFormat
+	}
+	return key, value, nil
+}
+
+// writeKVPair writes a key/value pair with a key made up of the key type followed by the key data.
+func writeKVPair(w io.Writer, keyType byte, keyData, value []byte) error {
+	key := append([]byte{keyType}, keyData...)
+	if err := wire.WriteVarBytes(w, 0, key); err != nil {
+		return err
+	}
+	return wire.WriteVarBytes(w, 0, value)
+}
+
+// writeSeparator writes the separator that ends a map.
+func writeSeparator(w io.Writer) error {
+	_, err := w.Write([]byte{0x00})
+	if err != nil {
+		Error(err)
+	}
+	return err
+}
+
+// writeUnknowns writes the key/value pairs of unknown type, in the order they were read.
+func writeUnknowns(w io.Writer, unknowns []*Unknown) error {
+	for _, u := range unknowns {
+		if err := wire.WriteVarBytes(w, 0, u.Key); err != nil {
+			return err
+		}
+		if err := wire.WriteVarBytes(w, 0, u.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readTxOut decodes a transaction output from the value of a witness utxo.
+func readTxOut(b []byte) (*wire.TxOut, error) {
+	if len(b) < 9 {
+		return nil, ErrInvalidPsbtFormat
+	}
+	value := int64(binary.LittleEndian.Uint64(b))
+	script, err := wire.ReadVarBytes(bytes.NewReader(b[8:]), 0, MaxPsbtValueLength, "pkScript")
+	if err != nil {
+		return nil, ErrInvalidPsbtFormat
+	}
+	return wire.NewTxOut(value, script), nil
+}
+
+// serializeTxOut encodes a transaction output as the value of a witness utxo.
+func serializeTxOut(txOut *wire.TxOut) ([]byte, error) {
+	var b bytes.Buffer
+	if err := wire.WriteTxOut(&b, 0, 0, txOut); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// DeserializeTxWitness decodes a witness stack serialized as the final script witness of an input.
+func DeserializeTxWitness(b []byte) (wire.TxWitness, error) {
+	r := bytes.NewReader(b)
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil || count > uint64(len(b)) {
+		return nil, ErrInvalidPsbtFormat
+	}
+	witness := make(wire.TxWitness, count)
+	for i := range witness {
+		if witness[i], err = wire.ReadVarBytes(r, 0, MaxPsbtValueLength, "witness item"); err != nil {
+			return nil, ErrInvalidPsbtFormat
+		}
+	}
+	return witness, nil
+}
+
+// SerializeTxWitness encodes a witness stack in the form used for the final script witness of an input.
+func SerializeTxWitness(witness wire.TxWitness) ([]byte, error) {
+	var b bytes.Buffer
+	if err := wire.WriteVarInt(&b, 0, uint64(len(witness))); err != nil {
+		return nil, err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(&b, 0, item); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// PartialSig is a signature for one of the keys an input can be spent with. The signature is DER encoded and followed
+// by the signature hash type, as it appears in a signature script.
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// checkValid returns true when the public key and the signature can both be parsed.
+func (ps *PartialSig) checkValid() bool {
+	if _, err := ec.ParsePubKey(ps.PubKey, ec.S256()); err != nil {
+		return false
+	}
+	if len(ps.Signature) < 2 {
+		return false
+	}
+	_, err := ec.ParseDERSignature(ps.Signature[:len(ps.Signature)-1], ec.S256())
+	return err == nil
+}
+
+// hashType returns the signature hash type the signature was made with.
+func (ps *PartialSig) hashType() txscript.SigHashType {
+	return txscript.SigHashType(ps.Signature[len(ps.Signature)-1])
+}
+
+// Bip32Derivation records the master key fingerprint and derivation path of a public key, so a signer holding the
+// master key can find the key it should sign with.
+type Bip32Derivation struct {
+	PubKey               []byte
+	MasterKeyFingerprint uint32
+	Bip32Path            []uint32
+}
+
+// readBip32Derivation decodes the value of a derivation entry for the public key in its key data.
+func readBip32Derivation(pubKey, value []byte) (*Bip32Derivation, error) {
+	if _, err := ec.ParsePubKey(pubKey, ec.S256()); err != nil {
+		return nil, ErrInvalidPsbtFormat
+	}
+	if len(value) < 4 || len(value)%4 != 0 {
+		return nil, ErrInvalidPsbtFormat
+	}
+	d := &Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: binary.LittleEndian.Uint32(value),
+	}
+	for i := 4; i < len(value); i += 4 {
+		d.Bip32Path = append(d.Bip32Path, binary.LittleEndian.Uint32(value[i:]))
+	}
+	return d, nil
+}
+
+// serialize encodes the fingerprint and path of the derivation as the value of its entry.
+func (d *Bip32Derivation) serialize() []byte {
+	value := make([]byte, 4+4*len(d.Bip32Path))
+	binary.LittleEndian.PutUint32(value, d.MasterKeyFingerprint)
+	for i, child := range d.Bip32Path {
+		binary.LittleEndian.PutUint32(value[4+4*i:], child)
+	}
+	return value
+}
+
+// sortPartialSigs sorts signatures by public key so packets with the same content always encode the same way.
+func sortPartialSigs(sigs []*PartialSig) {
+	sort.Slice(sigs, func(i, j int) bool {
+		return bytes.Compare(sigs[i].PubKey, sigs[j].PubKey) < 0
+	})
+}
+
+// sortBip32Derivations sorts derivations by public key so packets with the same content always encode the same way.
+func sortBip32Derivations(derivations []*Bip32Derivation) {
+	sort.Slice(derivations, func(i, j int) bool {
+		return bytes.Compare(derivations[i].PubKey, derivations[j].PubKey) < 0
+	})
+}
@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"sort"
+
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// AddressGrouping is an address in one of the groups returned by AddressGroupings, with its unspent balance and label.
+type AddressGrouping struct {
+	Address string
+	Balance util.Amount
+	Label   string
+}
+
+// AddressGroupings returns the addresses of the wallet grouped by common ownership as it appears on the block chain.
+// Addresses whose outputs were spent together in one transaction are in the same group, as is the change of such a
+// transaction, since anyone watching the chain can link them. Groups and the addresses within them are sorted.
+func (w *Wallet) AddressGroupings() ([][]AddressGrouping, error) {
+	var groups [][]AddressGrouping
+	err := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			wmetaNs := tx.ReadBucket(wmetaNamespaceKey)
+			var details []wtxmgr.TxDetails
+			err := w.TxStore.RangeTransactions(
+				txmgrNs, 0, -1, func(d []wtxmgr.TxDetails) (bool, error) {
+					details = append(details, d...)
+					return false, nil
+				},
+			)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			// Find the address paid by every output credited to the wallet first, as unmined transactions are not
+			// ranged over in dependency order.
+			parent := make(map[string]string)
+			creditAddrs := make(map[wire.OutPoint]string)
+			for i := range details {
+				d := &details[i]
+				for _, c := range d.Credits {
+					addr := w.pkScriptAddress(d.MsgTx.TxOut[c.Index].PkScript)
+					if addr == "" {
+						continue
+					}
+					creditAddrs[wire.OutPoint{Hash: d.Hash, Index: c.Index}] = addr
+					parent[addr] = addr
+				}
+			}
+			var find func(string) string
+			find = func(a string) string {
+				if parent[a] != a {
+					parent[a] = find(parent[a])
+				}
+				return parent[a]
+			}
+			for i := range details {
+				d := &details[i]
+				var first string
+				for _, debit := range d.Debits {
+					addr, ok := creditAddrs[d.MsgTx.TxIn[debit.Index].PreviousOutPoint]
+					if !ok {
+						continue
+					}
+					if first == "" {
+						first = addr
+						continue
+					}
+					parent[find(addr)] = find(first)
+				}
+				if first == "" {
+					continue
+				}
+				for _, c := range d.Credits {
+					addr := creditAddrs[wire.OutPoint{Hash: d.Hash, Index: c.Index}]
+					if c.Change && addr != "" {
+						parent[find(addr)] = find(first)
+					}
+				}
+			}
+			unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			balances := make(map[string]util.Amount)
+			for i := range unspent {
+				balances[w.pkScriptAddress(unspent[i].PkScript)] += unspent[i].Amount
+			}
+			byRoot := make(map[string][]AddressGrouping)
+			for addr := range parent {
+				root := find(addr)
+				byRoot[root] = append(
+					byRoot[root], AddressGrouping{
+						Address: addr,
+						Balance: balances[addr],
+						Label:   fetchAddressLabel(wmetaNs, addr),
+					},
+				)
+			}
+			for _, group := range byRoot {
+				sort.Slice(group, func(i, j int) bool { return group[i].Address < group[j].Address })
+				groups = append(groups, group)
+			}
+			sort.Slice(groups, func(i, j int) bool { return groups[i][0].Address < groups[j][0].Address })
+			return nil
+		},
+	)
+	return groups, err
+}
+
+// pkScriptAddress returns the encoded address an output script pays to, or an empty string when it does not pay to a
+// single address.
+func (w *Wallet) pkScriptAddress(pkScript []byte) string {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+	if err != nil || len(addrs) != 1 {
+		return ""
+	}
+	return addrs[0].EncodeAddress()
+}
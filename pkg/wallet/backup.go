@@ -0,0 +1,418 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// The dump format follows the one written by bitcoind so that dumps can be read by people and tools that already know
+// it. Every key is written on its own line as
+//
+//	<WIF key> <birthday> <flags> # addr=<address> [hdkeypath=<path>]
+//
+// and every redeem script as a hex encoded script with the flag script=1. The flags are change=1 for internal
+// addresses and label=<percent encoded label> for the rest, and keys also carry addrtype=<type> naming the kind of
+// address they were dumped from, so they are imported as the same address. Keys carry no creation time of their own,
+// so the birthday of each is that of the wallet.
+const (
+	dumpTimeFormat = time.RFC3339
+	dumpEndMarker  = "# End of dump"
+	// birthdayRescanWindow is how far before a birthday a rescan starts, as block timestamps may run behind the time
+	// the keys were really created.
+	birthdayRescanWindow = 2 * time.Hour
+)
+
+// dumpAddrTypes are the names of the address types of keys in a wallet dump.
+var dumpAddrTypes = map[string]waddrmgr.AddressType{
+	"p2pkh":       waddrmgr.PubKeyHash,
+	"p2sh-p2wpkh": waddrmgr.NestedWitnessPubKey,
+	"p2wpkh":      waddrmgr.WitnessPubKey,
+}
+
+// dumpAddrTypeName returns the name of an address type in a wallet dump, or an empty string when keys of the type are
+// not dumped with one.
+func dumpAddrTypeName(addrType waddrmgr.AddressType) string {
+	for name, t := range dumpAddrTypes {
+		if t == addrType {
+			return name
+		}
+	}
+	return ""
+}
+
+// BackupWallet writes a consistent copy of the wallet database to dest while the wallet keeps running. The copy is
+// made inside a single read transaction and written to a temporary file beside dest, which is renamed over dest once it
+// has been synced, so dest never holds a partial copy. When dest is a directory the copy is named after the wallet
+// database inside it. The path of the copy is returned.
+func (w *Wallet) BackupWallet(dest string) (string, error) {
+	if fi, err := os.Stat(dest); err == nil && fi.IsDir() {
+		dest = filepath.Join(dest, DbName)
+	}
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(dest), filepath.Base(dest)+".tmp")
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	defer func() {
+		// Once renamed the temporary file is gone and this does nothing.
+		_ = os.Remove(tmp.Name())
+	}()
+	if err = w.db.Copy(tmp); err != nil {
+		Error(err)
+		_ = tmp.Close()
+		return "", err
+	}
+	if err = tmp.Sync(); err != nil {
+		Error(err)
+		_ = tmp.Close()
+		return "", err
+	}
+	if err = tmp.Close(); err != nil {
+		Error(err)
+		return "", err
+	}
+	if err = os.Rename(tmp.Name(), dest); err != nil {
+		Error(err)
+		return "", err
+	}
+	Info("wallet database backed up to", dest)
+	return dest, nil
+}
+
+// DumpWallet writes every private key and redeem script of the wallet to out, along with the HD derivation path and
+// label of each address, in the text format read by ImportWallet. The wallet must be unlocked.
+func (w *Wallet) DumpWallet(out io.Writer) error {
+	bw := bufio.NewWriter(out)
+	birthday := w.Manager.Birthday().UTC().Format(dumpTimeFormat)
+	synced := w.Manager.SyncedTo()
+	fmt.Fprintln(bw, "# Wallet dump created by pod")
+	fmt.Fprintf(bw, "# * Created on %s\n", time.Now().UTC().Format(dumpTimeFormat))
+	fmt.Fprintf(bw, "# * Best block at time of backup was %d (%v),\n", synced.Height, synced.Hash)
+	fmt.Fprintf(bw, "#   mined on %s\n", synced.Timestamp.UTC().Format(dumpTimeFormat))
+	fmt.Fprintf(bw, "# * Wallet birthday %s\n\n", birthday)
+	err := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+			wmetaNs := tx.ReadBucket(wmetaNamespaceKey)
+			return w.Manager.ForEachActiveAddress(
+				addrmgrNs, func(addr util.Address) error {
+					ma, err := w.Manager.Address(addrmgrNs, addr)
+					if err != nil {
+						Error(err)
+						return err
+					}
+					encoded := addr.EncodeAddress()
+					flags := "label=" + url.QueryEscape(fetchAddressLabel(wmetaNs, encoded))
+					if ma.Internal() {
+						flags = "change=1"
+					}
+					switch a := ma.(type) {
+					case waddrmgr.ManagedPubKeyAddress:
+						wif, err := a.ExportPrivKey()
+						if err != nil {
+							Error(err)
+							return err
+						}
+						if name := dumpAddrTypeName(a.AddrType()); name != "" {
+							flags += " addrtype=" + name
+						}
+						comment := "addr=" + encoded
+						if scope, path, ok := a.DerivationInfo(); ok {
+							comment += " hdkeypath=" + hdKeyPath(scope, path)
+						}
+						fmt.Fprintf(bw, "%s %s %s # %s\n", wif, birthday, flags, comment)
+					case waddrmgr.ManagedScriptAddress:
+						script, err := a.Script()
+						if err != nil {
+							Error(err)
+							return err
+						}
+						fmt.Fprintf(
+							bw, "%x %s script=1 %s # addr=%s\n", script, birthday, flags,
+							encoded,
+						)
+					}
+					return nil
+				},
+			)
+		},
+	)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	fmt.Fprintf(bw, "\n%s\n", dumpEndMarker)
+	return bw.Flush()
+}
+
+// hdKeyPath formats the derivation of a key from the HD root the way BIP0032 writes paths.
+func hdKeyPath(scope waddrmgr.KeyScope, path waddrmgr.DerivationPath) string {
+	return fmt.Sprintf(
+		"m/%d'/%d'/%d'/%d/%d", scope.Purpose, scope.Coin, path.Account, path.Branch,
+		path.Index,
+	)
+}
+
+// dumpEntry is a key or redeem script read from a wallet dump.
+type dumpEntry struct {
+	wif         *util.WIF
+	script      []byte
+	birthday    time.Time
+	address     string
+	label       string
+	hasLabel    bool
+	addrType    waddrmgr.AddressType
+	hasAddrType bool
+}
+
+// parseDumpLine reads the entry on a line of a wallet dump. Nil is returned for lines holding no entry.
+func parseDumpLine(line string) (*dumpEntry, error) {
+	line = strings.TrimSpace(line)
+	var comment string
+	if i := strings.Index(line, "#"); i >= 0 {
+		line, comment = line[:i], line[i+1:]
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("missing birthday after %q", fields[0])
+	}
+	e := &dumpEntry{}
+	if fields[1] != "0" {
+		var err error
+		if e.birthday, err = time.Parse(dumpTimeFormat, fields[1]); err != nil {
+			return nil, err
+		}
+	}
+	var isScript bool
+	for _, f := range fields[2:] {
+		switch {
+		case f == "script=1":
+			isScript = true
+		case strings.HasPrefix(f, "label="):
+			label, err := url.QueryUnescape(strings.TrimPrefix(f, "label="))
+			if err != nil {
+				return nil, err
+			}
+			e.label, e.hasLabel = label, true
+		case strings.HasPrefix(f, "addrtype="):
+			name := strings.TrimPrefix(f, "addrtype=")
+			addrType, ok := dumpAddrTypes[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown address type %q", name)
+			}
+			e.addrType, e.hasAddrType = addrType, true
+		}
+	}
+	for _, f := range strings.Fields(comment) {
+		if strings.HasPrefix(f, "addr=") {
+			e.address = strings.TrimPrefix(f, "addr=")
+		}
+	}
+	var err error
+	if isScript {
+		e.script, err = hex.DecodeString(fields[0])
+	} else {
+		e.wif, err = util.DecodeWIF(fields[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ImportWallet reads a dump written by DumpWallet, imports the keys and redeem scripts that are not in the wallet yet
+// and restores the labels of the dumped addresses. Each key is imported into the key scope of the type of address it was
+// dumped from, which for dumps without address types is worked out from the address in the comment. A rescan for the imported addresses is then started from the
+// earliest birthday in the dump, and is not waited for. The number of keys and scripts imported is returned.
+func (w *Wallet) ImportWallet(in io.Reader) (int, error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		Error(err)
+		return 0, err
+	}
+	var entries []*dumpEntry
+	scanner := bufio.NewScanner(in)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		e, err := parseDumpLine(scanner.Text())
+		if err != nil {
+			Error(err)
+			return 0, fmt.Errorf("line %d of wallet dump: %v", lineNo, err)
+		}
+		if e != nil {
+			entries = append(entries, e)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		Error(err)
+		return 0, err
+	}
+	// Scripts are imported into the legacy scope, the one making pay-to-pubkey-hash addresses.
+	managers := make(map[waddrmgr.AddressType]*waddrmgr.ScopedKeyManager)
+	scopedManager := func(addrType waddrmgr.AddressType) (*waddrmgr.ScopedKeyManager, error) {
+		if manager, ok := managers[addrType]; ok {
+			return manager, nil
+		}
+		scopes := w.Manager.ScopesForExternalAddrType(addrType)
+		if len(scopes) == 0 {
+			return nil, fmt.Errorf("no key scope for %s addresses", dumpAddrTypeName(addrType))
+		}
+		manager, err := w.Manager.FetchScopedKeyManager(scopes[0])
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		managers[addrType] = manager
+		return manager, nil
+	}
+	for _, e := range entries {
+		if e.wif == nil || e.hasAddrType {
+			continue
+		}
+		e.addrType = waddrmgr.PubKeyHash
+		if e.address == "" {
+			continue
+		}
+		addr, err := util.DecodeAddress(e.address, w.chainParams)
+		if err != nil {
+			Warn("importing key of", e.address, "as p2pkh:", err)
+			continue
+		}
+		switch addr.(type) {
+		case *util.AddressWitnessPubKeyHash:
+			e.addrType = waddrmgr.WitnessPubKey
+		case *util.AddressScriptHash:
+			e.addrType = waddrmgr.NestedWitnessPubKey
+		}
+	}
+	birthday := w.Manager.Birthday()
+	for _, e := range entries {
+		if !e.birthday.IsZero() && e.birthday.Before(birthday) {
+			birthday = e.birthday
+		}
+	}
+	bs, err := locateBirthdayBlock(chainClient, birthday.Add(-birthdayRescanWindow))
+	if err != nil {
+		Error(err)
+		return 0, err
+	}
+	var imported []util.Address
+	err = walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) error {
+			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			for _, e := range entries {
+				var ma waddrmgr.ManagedAddress
+				addrType := e.addrType
+				if e.script != nil {
+					addrType = waddrmgr.PubKeyHash
+				}
+				manager, err := scopedManager(addrType)
+				if err != nil {
+					return err
+				}
+				if e.script != nil {
+					ma, err = manager.ImportScript(addrmgrNs, e.script, bs)
+				} else {
+					ma, err = manager.ImportPrivateKey(addrmgrNs, e.wif, bs)
+				}
+				switch {
+				case waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress):
+				case err != nil:
+					Error(err)
+					return err
+				default:
+					imported = append(imported, ma.Address())
+				}
+			}
+			if len(imported) == 0 || !birthday.Before(w.Manager.Birthday()) {
+				return nil
+			}
+			return w.Manager.SetBirthday(addrmgrNs, birthday)
+		},
+	)
+	if err != nil {
+		Error(err)
+		return 0, err
+	}
+	for _, e := range entries {
+		if !e.hasLabel || e.label == "" || e.address == "" {
+			continue
+		}
+		addr, err := util.DecodeAddress(e.address, w.chainParams)
+		if err != nil {
+			Warn("not restoring label of", e.address, err)
+			continue
+		}
+		if err = w.SetAddressLabel(addr, e.label); err != nil {
+			Error(err)
+			return len(imported), err
+		}
+	}
+	Info("imported", len(imported), "keys and scripts from wallet dump")
+	if len(imported) == 0 {
+		return 0, nil
+	}
+	// The rescan reports its own progress and outcome, so its error channel does not need to be read.
+	_ = w.SubmitRescan(&RescanJob{Addrs: imported, BlockStamp: *bs})
+	return len(imported), nil
+}
+
+// locateBirthdayBlock finds the first block of the main chain mined at or after a time, by a binary search over block
+// heights. The best block is returned when every block was mined before it.
+func locateBirthdayBlock(chainClient chain.Interface, t time.Time) (*waddrmgr.BlockStamp, error) {
+	_, best, err := chainClient.GetBestBlock()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	lo, hi := int32(0), best
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		hash, err := chainClient.GetBlockHash(int64(mid))
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		header, err := chainClient.GetBlockHeader(hash)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		if header.Timestamp.Before(t) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	hash, err := chainClient.GetBlockHash(int64(lo))
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	header, err := chainClient.GetBlockHeader(hash)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &waddrmgr.BlockStamp{Height: lo, Hash: *hash, Timestamp: header.Timestamp}, nil
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/util"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// TestParseDumpLine ensures the lines written by DumpWallet are read back unchanged.
+func TestParseDumpLine(t *testing.T) {
+	key, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wif, err := util.NewWIF(key, &netparams.MainNetParams, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	birthday := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	label := "cold storage #2 & more"
+	path := hdKeyPath(waddrmgr.KeyScopeBIP0044, waddrmgr.DerivationPath{Account: 0, Branch: 0, Index: 7})
+	if path != "m/44'/0'/0'/0/7" {
+		t.Fatalf("unexpected key path %s", path)
+	}
+	line := fmt.Sprintf(
+		"%s %s label=%s # addr=1Address hdkeypath=%s", wif, birthday.Format(dumpTimeFormat),
+		url.QueryEscape(label), path,
+	)
+	e, err := parseDumpLine(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.wif.String() != wif.String() {
+		t.Errorf("key: got %s, want %s", e.wif, wif)
+	}
+	if !e.birthday.Equal(birthday) {
+		t.Errorf("birthday: got %v, want %v", e.birthday, birthday)
+	}
+	if !e.hasLabel || e.label != label {
+		t.Errorf("label: got %q, want %q", e.label, label)
+	}
+	if e.address != "1Address" {
+		t.Errorf("address: got %q, want %q", e.address, "1Address")
+	}
+	if e.hasAddrType {
+		t.Errorf("unexpected address type %v", e.addrType)
+	}
+	for name, addrType := range dumpAddrTypes {
+		if dumpAddrTypeName(addrType) != name {
+			t.Errorf("name of address type %v: got %q, want %q", addrType, dumpAddrTypeName(addrType), name)
+		}
+		e, err = parseDumpLine(fmt.Sprintf("%s 0 change=1 addrtype=%s # addr=bc1Address", wif, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !e.hasAddrType || e.addrType != addrType {
+			t.Errorf("address type %s: got %v", name, e.addrType)
+		}
+	}
+	if _, err = parseDumpLine(fmt.Sprintf("%s 0 addrtype=p2tr", wif)); err == nil {
+		t.Error("expected an error for an unknown address type")
+	}
+	script := []byte{0x51, 0x21, 0xae}
+	e, err = parseDumpLine(fmt.Sprintf("%x 0 script=1 change=1 # addr=3Address", script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(e.script, script) || e.wif != nil || !e.birthday.IsZero() || e.hasLabel {
+		t.Errorf("unexpected script entry %+v", e)
+	}
+	for _, line := range []string{"", "# Wallet dump created by pod", dumpEndMarker} {
+		if e, err = parseDumpLine(line); err != nil || e != nil {
+			t.Errorf("line %q: got entry %+v, error %v", line, e, err)
+		}
+	}
+	if _, err = parseDumpLine(wif.String()); err == nil {
+		t.Error("expected an error for a key without a birthday")
+	}
+}
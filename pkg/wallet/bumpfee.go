@@ -0,0 +1,235 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+	h "github.com/p9c/pod/pkg/util/helpers"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// replaceableSequence is the sequence number given to the inputs of the transactions the wallet authors. It signals
+// that they may be replaced by one paying a higher fee as defined by BIP 125, while leaving relative lock times off.
+const replaceableSequence = wire.MaxTxInSequenceNum - 2
+
+var (
+	// ErrBumpFeeUnknownTx is returned when the transaction to bump is not in the wallet.
+	ErrBumpFeeUnknownTx = errors.New("transaction is not in the wallet")
+	// ErrBumpFeeMined is returned when the transaction to bump has already been mined.
+	ErrBumpFeeMined = errors.New("transaction has already been mined")
+	// ErrBumpFeeNotReplaceable is returned when the transaction to bump does not signal replaceability.
+	ErrBumpFeeNotReplaceable = errors.New("transaction does not signal replaceability")
+	// ErrBumpFeeForeignInputs is returned when some inputs of the transaction to bump do not spend wallet outputs.
+	ErrBumpFeeForeignInputs = errors.New("transaction spends outputs that do not belong to the wallet")
+	// ErrBumpFeeDescendants is returned when an output of the transaction to bump has already been spent, as the
+	// replacement would invalidate the spender.
+	ErrBumpFeeDescendants = errors.New("transaction has descendants in the wallet")
+	// ErrBumpFeeNoChange is returned when the transaction to bump has no change output to take the higher fee from.
+	ErrBumpFeeNoChange = errors.New("transaction has no change output to pay a higher fee")
+)
+
+// BumpedTx is a replacement for an unconfirmed transaction created by BumpFee.
+type BumpedTx struct {
+	Tx      *wire.MsgTx
+	OrigFee util.Amount
+	Fee     util.Amount
+}
+
+// BumpFee replaces an unconfirmed transaction of the wallet with one spending the same inputs to the same outputs
+// with a higher fee, which is taken from its change. The replacement pays feeSatPerKb, or when it is zero, the fee rate
+// of the original increased by the default relay fee rate, which is the least the memory pool accepts. The replacement
+// is published and takes the place of the original in the wallet, keeping its comment.
+func (w *Wallet) BumpFee(txHash *chainhash.Hash, feeSatPerKb util.Amount) (*BumpedTx, error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var (
+		origRec *wtxmgr.TxRecord
+		tx      *txauthor.AuthoredTx
+		origFee util.Amount
+	)
+	err = walletdb.Update(
+		w.db, func(dbtx walletdb.ReadWriteTx) error {
+			addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+			txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+			details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			switch {
+			case details == nil:
+				return ErrBumpFeeUnknownTx
+			case details.Block.Height != -1:
+				return ErrBumpFeeMined
+			case !signalsReplacement(&details.MsgTx):
+				return ErrBumpFeeNotReplaceable
+			case len(details.Debits) != len(details.MsgTx.TxIn):
+				return ErrBumpFeeForeignInputs
+			}
+			origRec = &details.TxRecord
+			var changeScript []byte
+			changeIndex := -1
+			for _, c := range details.Credits {
+				if c.Spent {
+					return ErrBumpFeeDescendants
+				}
+				if c.Change && changeIndex < 0 {
+					changeIndex = int(c.Index)
+					changeScript = details.MsgTx.TxOut[c.Index].PkScript
+				}
+			}
+			if changeIndex < 0 {
+				return ErrBumpFeeNoChange
+			}
+			// The replacement spends exactly the inputs of the original, so that it conflicts with nothing else, and keeps
+			// their sequence numbers so that it can be bumped again.
+			inputs := make([]*wire.TxIn, len(details.MsgTx.TxIn))
+			inputValues := make([]util.Amount, len(details.MsgTx.TxIn))
+			scripts := make([][]byte, len(details.MsgTx.TxIn))
+			var totalInput util.Amount
+			for _, debit := range details.Debits {
+				txIn := details.MsgTx.TxIn[debit.Index]
+				prevOut := txIn.PreviousOutPoint
+				prev, err := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+				if err != nil {
+					Error(err)
+					return err
+				}
+				if prev == nil || int(prevOut.Index) >= len(prev.MsgTx.TxOut) {
+					return ErrBumpFeeForeignInputs
+				}
+				inputs[debit.Index] = wire.NewTxIn(&prevOut, nil, nil)
+				inputs[debit.Index].Sequence = txIn.Sequence
+				inputValues[debit.Index] = debit.Amount
+				scripts[debit.Index] = prev.MsgTx.TxOut[prevOut.Index].PkScript
+				totalInput += debit.Amount
+			}
+			var outputs []*wire.TxOut
+			var totalOutput util.Amount
+			for i, txOut := range details.MsgTx.TxOut {
+				totalOutput += util.Amount(txOut.Value)
+				if i != changeIndex {
+					outputs = append(outputs, wire.NewTxOut(txOut.Value, txOut.PkScript))
+				}
+			}
+			origFee = totalInput - totalOutput
+			origSize := txVirtualSize(&details.MsgTx)
+			minFeeRate := origFee*1000/util.Amount(origSize) + txrules.DefaultRelayFeePerKb
+			if feeSatPerKb == 0 {
+				feeSatPerKb = minFeeRate
+			} else if feeSatPerKb < minFeeRate {
+				return fmt.Errorf("fee rate %v/kB is below the minimum of %v/kB for a replacement",
+					feeSatPerKb, minFeeRate)
+			}
+			inputSource := func(util.Amount) (util.Amount, []*wire.TxIn, []util.Amount, [][]byte, error) {
+				return totalInput, inputs, inputValues, scripts, nil
+			}
+			changeSource := func() ([]byte, error) {
+				return changeScript, nil
+			}
+			tx, err = txauthor.NewUnsignedTransaction(outputs, feeSatPerKb, inputSource, changeSource)
+			if err != nil {
+				Error(err)
+				if _, ok := err.(txauthor.InputSourceError); ok {
+					return fmt.Errorf("change of %v is too small to pay a fee rate of %v/kB",
+						util.Amount(details.MsgTx.TxOut[changeIndex].Value), feeSatPerKb)
+				}
+				return err
+			}
+			tx.Tx.Version = details.MsgTx.Version
+			tx.Tx.LockTime = details.MsgTx.LockTime
+			if tx.ChangeIndex >= 0 {
+				tx.RandomizeChangePosition()
+			}
+			return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+		},
+	)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if err = validateMsgTx(tx.Tx, tx.PrevScripts, tx.PrevInputValues); err != nil {
+		Error(err)
+		return nil, err
+	}
+	fee := tx.TotalInput - h.SumOutputValues(tx.Tx.TxOut)
+	// The memory pool also requires the replacement to pay for its own relay on top of the fee it replaces.
+	if minFee := origFee + txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, txVirtualSize(tx.Tx)); fee < minFee {
+		return nil, fmt.Errorf("replacement fee %v is below the minimum of %v", fee, minFee)
+	}
+	if err = w.replaceUnminedTx(chainClient, origRec, tx.Tx); err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Keep the comment of the original with the transaction that replaces it.
+	if c, err := w.TxComment(txHash); err == nil && (c.Comment != "" || c.To != "") {
+		newHash := tx.Tx.TxHash()
+		if err := w.SetTxComment(&newHash, c); Check(err) {
+		}
+	}
+	return &BumpedTx{Tx: tx.Tx, OrigFee: origFee, Fee: fee}, nil
+}
+
+// replaceUnminedTx swaps an unmined transaction for its replacement in the transaction store and publishes the
+// replacement. The original is removed first, as the records of the inputs both spend are shared and would be lost
+// when removing it later. If the replacement is rejected the original is restored.
+func (w *Wallet) replaceUnminedTx(chainClient chain.Interface, origRec *wtxmgr.TxRecord, tx *wire.MsgTx) error {
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	if err != nil {
+		Error(err)
+		return err
+	}
+	swap := func(remove, add *wtxmgr.TxRecord) error {
+		return walletdb.Update(
+			w.db, func(dbtx walletdb.ReadWriteTx) error {
+				txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+				if err := w.TxStore.RemoveUnminedTx(txmgrNs, remove); err != nil {
+					Error(err)
+					return err
+				}
+				return w.addRelevantTx(dbtx, add, nil)
+			},
+		)
+	}
+	if err = swap(origRec, rec); err != nil {
+		Error(err)
+		return err
+	}
+	if _, err = chainClient.SendRawTransaction(tx, false); err != nil {
+		Error(err)
+		if dbErr := swap(rec, origRec); dbErr != nil {
+			return fmt.Errorf("unable to broadcast replacement: %v, unable to restore original: %v", err, dbErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// signalsReplacement returns whether a transaction opts in to replacement by fee with the sequence number of one of its
+// inputs.
+func signalsReplacement(msgTx *wire.MsgTx) bool {
+	for _, txIn := range msgTx.TxIn {
+		if txIn.Sequence <= replaceableSequence {
+			return true
+		}
+	}
+	return false
+}
+
+// txVirtualSize returns the virtual size of a transaction, its weight divided by the witness scale factor.
+func txVirtualSize(msgTx *wire.MsgTx) int {
+	weight := blockchain.GetTransactionWeight(util.NewTx(msgTx))
+	return int((weight + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor)
+}
@@ -0,0 +1,167 @@
+package wallet
+
+import (
+	"fmt"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txsizes "github.com/p9c/pod/pkg/chain/tx/sizes"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wallet/coinset"
+)
+
+// CoinSelectionStrategy names a way of choosing which unspent outputs of the wallet a new transaction spends.
+type CoinSelectionStrategy string
+
+const (
+	// CoinSelectionLargestFirst spends the largest outputs first. This is the default.
+	CoinSelectionLargestFirst CoinSelectionStrategy = "largest-first"
+	// CoinSelectionBranchAndBound looks for outputs adding up so closely to the amount and fee that the transaction
+	// needs no change output.
+	CoinSelectionBranchAndBound CoinSelectionStrategy = "branch-and-bound"
+	// CoinSelectionPrivacy spends outputs paid to as few addresses as possible, always spending all of the outputs paid
+	// to one address together, so that the transaction links as few addresses as it can.
+	CoinSelectionPrivacy CoinSelectionStrategy = "privacy"
+	// CoinSelectionConsolidateDust spends the smallest outputs first, to reduce the number of outputs the wallet holds
+	// while a transaction is being paid for anyway.
+	CoinSelectionConsolidateDust CoinSelectionStrategy = "consolidate-dust"
+)
+
+const (
+	// coinSelectionMaxInputs is the most inputs a coin selection strategy picks for one transaction, which keeps it well
+	// within the standard transaction size.
+	coinSelectionMaxInputs = 500
+	// coinSelectionMaxTries bounds the steps of the branch and bound search.
+	coinSelectionMaxTries = 100000
+)
+
+// CoinControl chooses the unspent outputs that a transaction created by the wallet spends. The zero value spends the
+// largest eligible outputs first.
+type CoinControl struct {
+	// Inputs are the outputs to spend. When any are given, the transaction spends exactly these and no others, whatever
+	// their number of confirmations, and fails if they do not cover the amount and fee.
+	Inputs []wire.OutPoint
+	// Strategy is how the outputs to spend are chosen when no Inputs are given. Creating the transaction fails when the
+	// strategy finds no suitable selection.
+	Strategy CoinSelectionStrategy
+}
+
+// ParseCoinSelectionStrategy returns the coin selection strategy with the given name. An empty name is the default
+// strategy.
+func ParseCoinSelectionStrategy(name string) (CoinSelectionStrategy, error) {
+	switch s := CoinSelectionStrategy(name); s {
+	case "":
+		return CoinSelectionLargestFirst, nil
+	case CoinSelectionLargestFirst, CoinSelectionBranchAndBound, CoinSelectionPrivacy, CoinSelectionConsolidateDust:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown coin selection strategy %q", name)
+}
+
+// selector returns the coin selector implementing the strategy, or nil for the default largest first selection.
+func (s CoinSelectionStrategy) selector(feeSatPerKb util.Amount) coinset.CoinSelector {
+	switch s {
+	case CoinSelectionBranchAndBound:
+		// A selection exceeding its target by no more than a dust change output needs no change, as the author leaves
+		// such an amount to the fee.
+		return coinset.BranchAndBoundCoinSelector{
+			MaxInputs:    coinSelectionMaxInputs,
+			CostOfChange: txrules.GetDustThreshold(txsizes.P2WPKHPkScriptSize, feeSatPerKb),
+			MaxTries:     coinSelectionMaxTries,
+		}
+	case CoinSelectionPrivacy:
+		return coinset.ScriptGroupCoinSelector{MaxInputs: coinSelectionMaxInputs}
+	case CoinSelectionConsolidateDust:
+		return coinset.MaxNumberCoinSelector{MaxInputs: coinSelectionMaxInputs}
+	}
+	return nil
+}
+
+// creditCoin adapts an unspent output of the wallet to the coinset.Coin interface.
+type creditCoin struct {
+	credit   *wtxmgr.Credit
+	numConfs int64
+}
+
+func (c *creditCoin) Hash() *chainhash.Hash { return &c.credit.Hash }
+func (c *creditCoin) Index() uint32         { return c.credit.Index }
+func (c *creditCoin) Value() util.Amount    { return c.credit.Amount }
+func (c *creditCoin) PkScript() []byte      { return c.credit.PkScript }
+func (c *creditCoin) NumConfs() int64       { return c.numConfs }
+func (c *creditCoin) ValueAge() int64       { return c.numConfs * int64(c.credit.Amount) }
+
+// makeSelectorInputSource creates an InputSource that spends the eligible outputs chosen by a coin selector for each
+// target, or returns the error of the selector when it finds no selection.
+func makeSelectorInputSource(
+	eligible []wtxmgr.Credit, selector coinset.CoinSelector, strategy CoinSelectionStrategy, height int32,
+) txauthor.InputSource {
+	coins := make([]coinset.Coin, len(eligible))
+	for i := range eligible {
+		coins[i] = &creditCoin{credit: &eligible[i], numConfs: int64(confirms(eligible[i].Height, height))}
+	}
+	return func(target util.Amount) (util.Amount, []*wire.TxIn, []util.Amount, [][]byte, error) {
+		selection, err := selector.CoinSelect(target, coins)
+		if err != nil {
+			return 0, nil, nil, nil, fmt.Errorf("%s coin selection found no inputs for %v: %v", strategy, target, err)
+		}
+		var total util.Amount
+		selected := selection.Coins()
+		inputs := make([]*wire.TxIn, 0, len(selected))
+		inputValues := make([]util.Amount, 0, len(selected))
+		scripts := make([][]byte, 0, len(selected))
+		for _, coin := range selected {
+			credit := coin.(*creditCoin).credit
+			input := wire.NewTxIn(&credit.OutPoint, nil, nil)
+			input.Sequence = replaceableSequence
+			total += credit.Amount
+			inputs = append(inputs, input)
+			inputValues = append(inputValues, credit.Amount)
+			scripts = append(scripts, credit.PkScript)
+		}
+		return total, inputs, inputValues, scripts, nil
+	}
+}
+
+// makeFixedInputSource creates an InputSource that always spends all of the given outputs.
+func makeFixedInputSource(credits []wtxmgr.Credit) txauthor.InputSource {
+	var total util.Amount
+	inputs := make([]*wire.TxIn, len(credits))
+	inputValues := make([]util.Amount, len(credits))
+	scripts := make([][]byte, len(credits))
+	for i := range credits {
+		inputs[i] = wire.NewTxIn(&credits[i].OutPoint, nil, nil)
+		inputs[i].Sequence = replaceableSequence
+		inputValues[i] = credits[i].Amount
+		scripts[i] = credits[i].PkScript
+		total += credits[i].Amount
+	}
+	return func(util.Amount) (util.Amount, []*wire.TxIn, []util.Amount, [][]byte, error) {
+		return total, inputs, inputValues, scripts, nil
+	}
+}
+
+// selectedCredits returns the credits among the outputs eligible to be spent that are the given outpoints, in the
+// order given, or an error naming an outpoint that is not eligible or is given more than once.
+func selectedCredits(eligible []wtxmgr.Credit, outPoints []wire.OutPoint) ([]wtxmgr.Credit, error) {
+	byOutPoint := make(map[wire.OutPoint]*wtxmgr.Credit, len(eligible))
+	for i := range eligible {
+		byOutPoint[eligible[i].OutPoint] = &eligible[i]
+	}
+	selected := make([]wtxmgr.Credit, 0, len(outPoints))
+	seen := make(map[wire.OutPoint]struct{}, len(outPoints))
+	for _, op := range outPoints {
+		credit, ok := byOutPoint[op]
+		if !ok {
+			return nil, fmt.Errorf("input %v is not a spendable output of the account", op)
+		}
+		if _, ok := seen[op]; ok {
+			return nil, fmt.Errorf("input %v is included more than once", op)
+		}
+		seen[op] = struct{}{}
+		selected = append(selected, *credit)
+	}
+	return selected, nil
+}
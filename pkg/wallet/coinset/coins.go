@@ -285,6 +285,128 @@ func (s MinPriorityCoinSelector) CoinSelect(targetValue util.Amount, coins []Coi
 	return nil, ErrCoinsNoSelectionAvailable
 }
 
+// MaxNumberCoinSelector is a CoinSelector that attempts to construct a selection of coins whose total value is at least
+// targetValue that uses as many of the inputs as possible, by spending the smallest ones first. This would be useful to
+// consolidate many small outputs while a transaction is being paid for anyway.
+type MaxNumberCoinSelector struct {
+	MaxInputs       int
+	MinChangeAmount util.Amount
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the MaxNumberCoinSelector struct.
+func (s MaxNumberCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+	sortedCoins := make([]Coin, 0, len(coins))
+	sortedCoins = append(sortedCoins, coins...)
+	sort.Sort(byAmount(sortedCoins))
+	return MinIndexCoinSelector(s).CoinSelect(targetValue, sortedCoins)
+}
+
+// BranchAndBoundCoinSelector is a CoinSelector that searches for a selection of coins whose total value is at least
+// targetValue and exceeds it by no more than CostOfChange, so that the selection needs no change output, preferring the
+// selection that exceeds it the least. The search is depth first over the coins from the largest down, and gives up
+// after MaxTries steps, returning the best selection found so far if any.
+type BranchAndBoundCoinSelector struct {
+	MaxInputs    int
+	CostOfChange util.Amount
+	MaxTries     int
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the BranchAndBoundCoinSelector struct.
+func (s BranchAndBoundCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+	sortedCoins := make([]Coin, 0, len(coins))
+	sortedCoins = append(sortedCoins, coins...)
+	sort.Sort(sort.Reverse(byAmount(sortedCoins)))
+	// remaining[i] is the total value of the coins from index i on, the most that adding any of them can reach.
+	remaining := make([]util.Amount, len(sortedCoins)+1)
+	for i := len(sortedCoins) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sortedCoins[i].Value()
+	}
+	var best, selected []int
+	var bestExcess util.Amount
+	tries := 0
+	var search func(i int, total util.Amount)
+	search = func(i int, total util.Amount) {
+		if tries >= s.MaxTries || (best != nil && bestExcess == 0) {
+			return
+		}
+		tries++
+		if total > targetValue+s.CostOfChange {
+			return
+		}
+		if total >= targetValue {
+			if excess := total - targetValue; best == nil || excess < bestExcess {
+				best = append(best[:0], selected...)
+				bestExcess = excess
+			}
+			return
+		}
+		if i == len(sortedCoins) || total+remaining[i] < targetValue || len(selected) >= s.MaxInputs {
+			return
+		}
+		selected = append(selected, i)
+		search(i+1, total+sortedCoins[i].Value())
+		selected = selected[:len(selected)-1]
+		// Leaving out this coin and including the next one of the same value gives the selections already tried.
+		next := i + 1
+		for next < len(sortedCoins) && sortedCoins[next].Value() == sortedCoins[i].Value() {
+			next++
+		}
+		search(next, total)
+	}
+	search(0, 0)
+	if best == nil {
+		return nil, ErrCoinsNoSelectionAvailable
+	}
+	cs := NewCoinSet(nil)
+	for _, i := range best {
+		cs.PushCoin(sortedCoins[i])
+	}
+	return cs, nil
+}
+
+// ScriptGroupCoinSelector is a CoinSelector that attempts to construct a selection of coins whose total value is at
+// least targetValue that links as few output scripts, and so addresses, together as possible. The coins paid to one
+// script are always selected together, as spending only some of them would link the script to the transaction all the
+// same. The smallest group that is enough on its own is preferred, otherwise the largest groups are combined.
+type ScriptGroupCoinSelector struct {
+	MaxInputs       int
+	MinChangeAmount util.Amount
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the ScriptGroupCoinSelector struct.
+func (s ScriptGroupCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+	var groups []*CoinSet
+	byScript := make(map[string]*CoinSet)
+	for _, coin := range coins {
+		group, ok := byScript[string(coin.PkScript())]
+		if !ok {
+			group = NewCoinSet(nil)
+			byScript[string(coin.PkScript())] = group
+			groups = append(groups, group)
+		}
+		group.PushCoin(coin)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].TotalValue() < groups[j].TotalValue() })
+	for _, group := range groups {
+		if group.Num() <= s.MaxInputs && satisfiesTargetValue(targetValue, s.MinChangeAmount, group.TotalValue()) {
+			return group, nil
+		}
+	}
+	cs := NewCoinSet(nil)
+	for i := len(groups) - 1; i >= 0; i-- {
+		if cs.Num()+groups[i].Num() > s.MaxInputs {
+			continue
+		}
+		for _, coin := range groups[i].Coins() {
+			cs.PushCoin(coin)
+		}
+		if satisfiesTargetValue(targetValue, s.MinChangeAmount, cs.TotalValue()) {
+			return cs, nil
+		}
+	}
+	return nil, ErrCoinsNoSelectionAvailable
+}
+
 type byValueAge []Coin
 
 func (a byValueAge) Len() int           { return len(a) }
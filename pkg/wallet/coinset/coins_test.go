@@ -211,6 +211,74 @@ func TestMinPrioritySelector(t *testing.T) {
 	testCoinSelector(minPriorityTests, t)
 }
 
+var maxNumberSelectors = []coinset.MaxNumberCoinSelector{
+	{MaxInputs: 10, MinChangeAmount: 10000},
+	{MaxInputs: 2, MinChangeAmount: 10000},
+}
+var maxNumberTests = []coinSelectTest{
+	{maxNumberSelectors[0], coins, 10000000, []coinset.Coin{coins[1]}, nil},
+	{maxNumberSelectors[0], coins, 20000000, []coinset.Coin{coins[1], coins[3]}, nil},
+	{maxNumberSelectors[0], coins, 185000000, []coinset.Coin{coins[1], coins[3], coins[2], coins[0]}, nil},
+	{maxNumberSelectors[0], coins, 200000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{maxNumberSelectors[1], coins, 40000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+}
+
+func TestMaxNumberSelector(t *testing.T) {
+	testCoinSelector(maxNumberTests, t)
+}
+
+var branchAndBoundSelectors = []coinset.BranchAndBoundCoinSelector{
+	{MaxInputs: 10, CostOfChange: 1000000, MaxTries: 100000},
+	{MaxInputs: 1, CostOfChange: 1000000, MaxTries: 100000},
+}
+var branchAndBoundTests = []coinSelectTest{
+	{branchAndBoundSelectors[0], coins, 75000000, []coinset.Coin{coins[2], coins[3]}, nil},
+	{branchAndBoundSelectors[0], coins, 35000000, []coinset.Coin{coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 34500000, []coinset.Coin{coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 160000000, []coinset.Coin{coins[0], coins[2], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 30000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[1], coins, 35000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[1], coins, 49500000, []coinset.Coin{coins[2]}, nil},
+}
+
+func TestBranchAndBoundSelector(t *testing.T) {
+	testCoinSelector(branchAndBoundTests, t)
+}
+
+// scriptCoin is a TestCoin paid to a given output script.
+type scriptCoin struct {
+	TestCoin
+	script []byte
+}
+
+func (c *scriptCoin) PkScript() []byte { return c.script }
+
+func newScriptCoin(index int64, value util.Amount, script byte) coinset.Coin {
+	return &scriptCoin{TestCoin: *NewCoin(index, value, 1).(*TestCoin), script: []byte{script}}
+}
+
+var scriptCoins = []coinset.Coin{
+	newScriptCoin(1, 30000000, 'a'),
+	newScriptCoin(2, 20000000, 'a'),
+	newScriptCoin(3, 40000000, 'b'),
+	newScriptCoin(4, 100000000, 'c'),
+}
+var scriptGroupSelectors = []coinset.ScriptGroupCoinSelector{
+	{MaxInputs: 10},
+	{MaxInputs: 1},
+}
+var scriptGroupTests = []coinSelectTest{
+	{scriptGroupSelectors[0], scriptCoins, 30000000, []coinset.Coin{scriptCoins[2]}, nil},
+	{scriptGroupSelectors[0], scriptCoins, 45000000, []coinset.Coin{scriptCoins[0], scriptCoins[1]}, nil},
+	{scriptGroupSelectors[0], scriptCoins, 120000000, []coinset.Coin{scriptCoins[3], scriptCoins[0], scriptCoins[1]}, nil},
+	{scriptGroupSelectors[0], scriptCoins, 200000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{scriptGroupSelectors[1], scriptCoins, 45000000, []coinset.Coin{scriptCoins[3]}, nil},
+}
+
+func TestScriptGroupSelector(t *testing.T) {
+	testCoinSelector(scriptGroupTests, t)
+}
+
 var (
 	// should be two outpoints, with 1st one having 0.035DUO value.
 	testSimpleCoinNumConfs            = int64(1)
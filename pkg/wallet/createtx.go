@@ -37,6 +37,7 @@ func makeInputSource(eligible []wtxmgr.Credit) txauthor.InputSource {
 			nextCredit := &eligible[0]
 			eligible = eligible[1:]
 			nextInput := wire.NewTxIn(&nextCredit.OutPoint, nil, nil)
+			nextInput.Sequence = replaceableSequence
 			currentTotal += nextCredit.Amount
 			currentInputs = append(currentInputs, nextInput)
 			currentScripts = append(currentScripts, nextCredit.PkScript)
@@ -89,9 +90,9 @@ func (s secretSource) GetScript(addr util.Address) ([]byte, error) {
 // txToOutputs creates a signed transaction which includes each output from outputs. Previous outputs to reedeem are
 // chosen from the passed account's UTXO set and minconf policy. An additional output may be added to return change to
 // the wallet. An appropriate fee is included based on the wallet's current relay fee. The wallet must be unlocked to
-// create the transaction.
+// create the transaction. A non-nil coinControl overrides how the previous outputs are chosen.
 func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
-	minconf int32, feeSatPerKb util.Amount) (tx *txauthor.AuthoredTx, err error) {
+	minconf int32, feeSatPerKb util.Amount, coinControl *CoinControl) (tx *txauthor.AuthoredTx, err error) {
 	chainClient, err := w.requireChainClient()
 	if err != nil {
 		Error(err)
@@ -105,12 +106,27 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
 			Error(err)
 			return err
 		}
+		if coinControl != nil && len(coinControl.Inputs) != 0 {
+			// Outputs chosen by the caller are spent whatever their number of confirmations.
+			minconf = 0
+		}
 		eligible, err := w.findEligibleOutputs(dbtx, account, minconf, bs)
 		if err != nil {
 			Error(err)
 			return err
 		}
 		inputSource := makeInputSource(eligible)
+		if coinControl != nil {
+			if len(coinControl.Inputs) != 0 {
+				selected, err := selectedCredits(eligible, coinControl.Inputs)
+				if err != nil {
+					return err
+				}
+				inputSource = makeFixedInputSource(selected)
+			} else if selector := coinControl.Strategy.selector(feeSatPerKb); selector != nil {
+				inputSource = makeSelectorInputSource(eligible, selector, coinControl.Strategy, bs.Height)
+			}
+		}
 		changeSource := func() ([]byte, error) {
 			// Derive the change output script. As a hack to allow spending from the imported account, change addresses
 			// are created from account 0.
@@ -0,0 +1,135 @@
+package wallet
+
+import (
+	"bytes"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// The metadata namespace holds data the user attaches to transactions and addresses, which has no meaning to the
+// address or transaction managers and so is kept apart from them.
+var (
+	txCommentsBucketKey    = []byte("txcomments")
+	addressLabelsBucketKey = []byte("addresslabels")
+)
+
+// TxComment is the note kept with a transaction, and the name of who it was paid to.
+type TxComment struct {
+	Comment string
+	To      string
+}
+
+// createMetadataBuckets creates the buckets of the metadata namespace that do not exist yet.
+func createMetadataBuckets(ns walletdb.ReadWriteBucket) error {
+	if _, err := ns.CreateBucketIfNotExists(txCommentsBucketKey); err != nil {
+		Error(err)
+		return err
+	}
+	if _, err := ns.CreateBucketIfNotExists(addressLabelsBucketKey); err != nil {
+		Error(err)
+		return err
+	}
+	return nil
+}
+
+// serializeTxComment encodes a comment as the comment followed by who it was paid to, each as a variable length
+// string.
+func serializeTxComment(c *TxComment) ([]byte, error) {
+	var b bytes.Buffer
+	if err := wire.WriteVarString(&b, 0, c.Comment); err != nil {
+		return nil, err
+	}
+	if err := wire.WriteVarString(&b, 0, c.To); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// fetchTxComment returns the comment kept for a transaction, which is empty when there is none.
+func fetchTxComment(ns walletdb.ReadBucket, txHash *chainhash.Hash) (c TxComment) {
+	if ns == nil {
+		return
+	}
+	v := ns.NestedReadBucket(txCommentsBucketKey).Get(txHash[:])
+	if v == nil {
+		return
+	}
+	r := bytes.NewReader(v)
+	var err error
+	if c.Comment, err = wire.ReadVarString(r, 0); err != nil {
+		Error(err)
+		return TxComment{}
+	}
+	if c.To, err = wire.ReadVarString(r, 0); err != nil {
+		Error(err)
+		return TxComment{}
+	}
+	return
+}
+
+// fetchAddressLabel returns the label of an encoded address, which is empty when there is none.
+func fetchAddressLabel(ns walletdb.ReadBucket, address string) string {
+	if ns == nil || address == "" {
+		return ""
+	}
+	return string(ns.NestedReadBucket(addressLabelsBucketKey).Get([]byte(address)))
+}
+
+// TxComment returns the comment kept for a transaction. An empty comment is returned when none has been set.
+func (w *Wallet) TxComment(txHash *chainhash.Hash) (c TxComment, err error) {
+	err = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			c = fetchTxComment(tx.ReadBucket(wmetaNamespaceKey), txHash)
+			return nil
+		},
+	)
+	return
+}
+
+// SetTxComment keeps a comment for a transaction, replacing the one kept before. Setting an empty comment removes it.
+// The transaction does not need to be known to the wallet yet, so a comment can be set before it is published.
+func (w *Wallet) SetTxComment(txHash *chainhash.Hash, c TxComment) error {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) error {
+			bucket := tx.ReadWriteBucket(wmetaNamespaceKey).NestedReadWriteBucket(txCommentsBucketKey)
+			if c.Comment == "" && c.To == "" {
+				return bucket.Delete(txHash[:])
+			}
+			v, err := serializeTxComment(&c)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			return bucket.Put(txHash[:], v)
+		},
+	)
+}
+
+// AddressLabel returns the label of an address. An empty label is returned when none has been set.
+func (w *Wallet) AddressLabel(addr util.Address) (label string, err error) {
+	err = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			label = fetchAddressLabel(tx.ReadBucket(wmetaNamespaceKey), addr.EncodeAddress())
+			return nil
+		},
+	)
+	return
+}
+
+// SetAddressLabel sets the label of an address, which does not need to belong to the wallet. Setting an empty label
+// removes it.
+func (w *Wallet) SetAddressLabel(addr util.Address, label string) error {
+	return walletdb.Update(
+		w.db, func(tx walletdb.ReadWriteTx) error {
+			bucket := tx.ReadWriteBucket(wmetaNamespaceKey).NestedReadWriteBucket(addressLabelsBucketKey)
+			key := []byte(addr.EncodeAddress())
+			if label == "" {
+				return bucket.Delete(key)
+			}
+			return bucket.Put(key, []byte(label))
+		},
+	)
+}
@@ -0,0 +1,382 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	txsizes "github.com/p9c/pod/pkg/chain/tx/sizes"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// ErrInsufficientFunds is returned when the outputs of the wallet do not cover the amount and fee of a transaction.
+var ErrInsufficientFunds = errors.New("insufficient funds available to construct transaction")
+
+// redeemScript returns the redeem script of a pay-to-script-hash output script when it belongs to the wallet, or nil
+// otherwise. The script of a multisig address is only available when the wallet is unlocked, while for a
+// pay-to-witness-pubkey-hash output nested in pay-to-script-hash it is the witness program of the key.
+func (w *Wallet) redeemScript(addrmgrNs walletdb.ReadBucket, pkScript []byte) []byte {
+	class, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+	if err != nil || class != txscript.ScriptHashTy || len(addrs) != 1 {
+		return nil
+	}
+	ma, err := w.Manager.Address(addrmgrNs, addrs[0])
+	if err != nil {
+		return nil
+	}
+	var script []byte
+	switch a := ma.(type) {
+	case waddrmgr.ManagedScriptAddress:
+		if script, err = a.Script(); err != nil {
+			return nil
+		}
+	case waddrmgr.ManagedPubKeyAddress:
+		if a.AddrType() != waddrmgr.NestedWitnessPubKey {
+			return nil
+		}
+		wa, err := util.NewAddressWitnessPubKeyHash(util.Hash160(a.PubKey().SerializeCompressed()), w.chainParams)
+		if err != nil {
+			return nil
+		}
+		if script, err = txscript.PayToAddrScript(wa); err != nil {
+			return nil
+		}
+	}
+	return script
+}
+
+// estimateInputSize returns the size of an input spending the provided output script once it has been signed. The
+// redeem script is used to size multisig inputs, which are much larger than those spending a single key.
+func estimateInputSize(pkScript, redeemScript []byte) int {
+	if redeemScript != nil && txscript.GetScriptClass(redeemScript) == txscript.MultiSigTy {
+		if _, nRequired, err := txscript.CalcMultiSigStats(redeemScript); err == nil {
+			// OP_0, the signatures and the push of the redeem script
+			sigScriptSize := 1 + nRequired*(1+73) + 3 + len(redeemScript)
+			return 32 + 4 + wire.VarIntSerializeSize(uint64(sigScriptSize)) + sigScriptSize + 4
+		}
+	}
+	if txscript.IsPayToWitnessPubKeyHash(pkScript) {
+		return txsizes.RedeemP2WPKHInputSize + (txsizes.RedeemP2WPKHInputWitnessWeight+3)/4
+	}
+	if redeemScript != nil && txscript.IsPayToWitnessPubKeyHash(redeemScript) {
+		return txsizes.RedeemNestedP2WPKHInputSize + (txsizes.RedeemP2WPKHInputWitnessWeight+3)/4
+	}
+	return txsizes.RedeemP2PKHInputSize
+}
+
+// FundPsbt creates a partially signed transaction paying to the provided outputs. The inputs passed in are always
+// spent, and when they do not cover the outputs and the fee, unspent outputs of the account with at least minconf
+// confirmations are added, largest first. Change is paid to changeScript, or to a new change address of the account
+// when it is nil.
+//
+// Every input is updated with the transaction it spends from and, where the wallet knows it, the redeem script, so that
+// the packet can be signed by wallets that hold the keys but do not track the outputs. When lockUnspents is true, the
+// spent outputs are locked so they are not used for another transaction before this one is broadcast.
+//
+// The packet is returned along with the fee it pays and the index of the change output, which is -1 when there is no
+// change.
+func (w *Wallet) FundPsbt(inputs []wire.OutPoint, outputs []*wire.TxOut, lockTime uint32, account uint32,
+	minconf int32, feeSatPerKb util.Amount, changeScript []byte, lockUnspents bool) (p *psbt.Packet, fee util.Amount,
+	changeIndex int, err error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		Error(err)
+		return nil, 0, 0, err
+	}
+	changeIndex = -1
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		bs, err := chainClient.BlockStamp()
+		if err != nil {
+			Error(err)
+			return err
+		}
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		credits := make(map[wire.OutPoint]*wtxmgr.Credit, len(unspent))
+		for i := range unspent {
+			credits[unspent[i].OutPoint] = &unspent[i]
+		}
+		required := make(map[wire.OutPoint]struct{}, len(inputs))
+		var selected []*wtxmgr.Credit
+		for _, op := range inputs {
+			credit, ok := credits[op]
+			if !ok {
+				return fmt.Errorf("input %v is not an unspent output of the wallet", op)
+			}
+			if _, ok := required[op]; ok {
+				return fmt.Errorf("input %v is included more than once", op)
+			}
+			required[op] = struct{}{}
+			selected = append(selected, credit)
+		}
+		eligible, err := w.findEligibleOutputs(dbtx, account, minconf, bs)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		sort.Sort(sort.Reverse(byAmount(eligible)))
+		var target, total util.Amount
+		for _, txOut := range outputs {
+			target += util.Amount(txOut.Value)
+		}
+		for _, credit := range selected {
+			total += credit.Amount
+		}
+		// The change address is derived before the fee is estimated so that the fee pays for the script the change
+		// output actually has. It is not kept when the packet can not be funded, as the update is then rolled back.
+		if changeScript == nil {
+			// As in txToOutputs, change from the imported account goes to the default account.
+			changeAccount := account
+			if account == waddrmgr.ImportedAddrAccount {
+				changeAccount = 0
+			}
+			changeAddr, err := w.newChangeAddress(addrmgrNs, changeAccount)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			if changeScript, err = txscript.PayToAddrScript(changeAddr); err != nil {
+				Error(err)
+				return err
+			}
+		}
+		changeScriptSize := len(changeScript)
+		redeemScripts := make(map[wire.OutPoint][]byte)
+		inputSize := 0
+		for _, credit := range selected {
+			redeemScripts[credit.OutPoint] = w.redeemScript(addrmgrNs, credit.PkScript)
+			inputSize += estimateInputSize(credit.PkScript, redeemScripts[credit.OutPoint])
+		}
+		// Add outputs until they cover the outputs and the fee of a transaction that includes a change output.
+		for {
+			size := 8 + wire.VarIntSerializeSize(uint64(len(selected))) +
+				wire.VarIntSerializeSize(uint64(len(outputs)+1)) + inputSize + 8 + 1 + changeScriptSize
+			for _, txOut := range outputs {
+				size += txOut.SerializeSize()
+			}
+			fee = txrules.FeeForSerializeSize(feeSatPerKb, size)
+			if total >= target+fee && len(selected) > 0 {
+				break
+			}
+			if len(eligible) == 0 {
+				return ErrInsufficientFunds
+			}
+			credit := &eligible[0]
+			eligible = eligible[1:]
+			if _, ok := required[credit.OutPoint]; ok {
+				continue
+			}
+			selected = append(selected, credit)
+			total += credit.Amount
+			redeemScripts[credit.OutPoint] = w.redeemScript(addrmgrNs, credit.PkScript)
+			inputSize += estimateInputSize(credit.PkScript, redeemScripts[credit.OutPoint])
+		}
+		txOuts := append([]*wire.TxOut(nil), outputs...)
+		changeAmount := total - target - fee
+		if txrules.IsDustAmount(changeAmount, changeScriptSize, feeSatPerKb) {
+			// the change is left to the miner
+			fee = total - target
+		} else {
+			txOuts = append(txOuts, wire.NewTxOut(int64(changeAmount), changeScript))
+			changeIndex = len(txOuts) - 1
+		}
+		if changeIndex >= 0 {
+			changeIndex = txauthor.RandomizeOutputPosition(txOuts, changeIndex)
+		}
+		ops := make([]*wire.OutPoint, len(selected))
+		for i := range selected {
+			ops[i] = &selected[i].OutPoint
+		}
+		if p, err = psbt.New(ops, txOuts, wire.TxVersion, lockTime, nil); err != nil {
+			Error(err)
+			return err
+		}
+		for i, credit := range selected {
+			details, err := w.TxStore.TxDetails(txmgrNs, &credit.Hash)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			if details == nil {
+				return fmt.Errorf("transaction %v is missing from the wallet", credit.Hash)
+			}
+			if err = p.AddInNonWitnessUtxo(&details.MsgTx, i); err != nil {
+				Error(err)
+				return err
+			}
+			if redeemScripts[credit.OutPoint] != nil {
+				if err = p.AddInRedeemScript(redeemScripts[credit.OutPoint], i); err != nil {
+					Error(err)
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, 0, 0, err
+	}
+	if lockUnspents {
+		for _, txIn := range p.UnsignedTx.TxIn {
+			w.LockOutpoint(txIn.PreviousOutPoint)
+		}
+	}
+	return p, fee, changeIndex, nil
+}
+
+// ProcessPsbt adds what the wallet knows about the inputs of a partially signed transaction: the transactions they
+// spend from and the redeem scripts of pay-to-script-hash outputs. When sign is true, it also adds signatures with the
+// provided hash type for every key of the wallet an input can be spent with, and finalizes the inputs that then have
+// all the signatures they need. Signing requires the wallet to be unlocked.
+//
+// The packet is modified in place, and true is returned when it is complete.
+func (w *Wallet) ProcessPsbt(p *psbt.Packet, sign bool, hashType txscript.SigHashType) (complete bool, err error) {
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		for i, txIn := range p.UnsignedTx.TxIn {
+			in := &p.Inputs[i]
+			if in.IsFinalized() {
+				continue
+			}
+			if in.NonWitnessUtxo == nil && in.WitnessUtxo == nil {
+				details, err := w.TxStore.TxDetails(txmgrNs, &txIn.PreviousOutPoint.Hash)
+				if err != nil {
+					Error(err)
+					return err
+				}
+				if details == nil {
+					// not an output of the wallet, another party will have to provide it
+					continue
+				}
+				if err = p.AddInNonWitnessUtxo(&details.MsgTx, i); err != nil {
+					Error(err)
+					return err
+				}
+			}
+			utxo, err := p.Utxo(i)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			script := utxo.PkScript
+			if txscript.IsPayToScriptHash(script) {
+				if in.RedeemScript == nil {
+					in.RedeemScript = w.redeemScript(addrmgrNs, script)
+				}
+				if in.RedeemScript == nil {
+					continue
+				}
+				script = in.RedeemScript
+			}
+			if !sign {
+				continue
+			}
+			if in.SighashType != 0 && in.SighashType != hashType {
+				return fmt.Errorf("input %d requires signature hash type %v", i, in.SighashType)
+			}
+			if err = w.addPartialSigs(addrmgrNs, p, i, script, utxo.Value, hashType); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return false, err
+	}
+	if sign {
+		if err = psbt.MaybeFinalizeAll(p); err != nil {
+			Error(err)
+			return false, err
+		}
+	}
+	return p.IsComplete(), nil
+}
+
+// addPartialSigs signs an input of a packet with every key of the wallet that the script it spends, which is the
+// redeem script for pay-to-script-hash outputs, can be spent with. Pay-to-witness-pubkey-hash inputs, which commit to
+// the amount they spend, are signed with the witness signature hash.
+func (w *Wallet) addPartialSigs(addrmgrNs walletdb.ReadBucket, p *psbt.Packet, inIndex int, script []byte,
+	amount int64, hashType txscript.SigHashType) error {
+	class, addrs, _, err := txscript.ExtractPkScriptAddrs(script, w.chainParams)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	switch class {
+	case txscript.PubKeyTy, txscript.PubKeyHashTy, txscript.MultiSigTy, txscript.WitnessV0PubKeyHashTy:
+	default:
+		return nil
+	}
+	var sigHashes *txscript.TxSigHashes
+	if class == txscript.WitnessV0PubKeyHashTy {
+		sigHashes = txscript.NewTxSigHashes(p.UnsignedTx)
+	}
+	for _, addr := range addrs {
+		ma, err := w.Manager.Address(addrmgrNs, addr)
+		if err != nil {
+			// not a key of the wallet
+			continue
+		}
+		mpka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+		if !ok {
+			continue
+		}
+		// The public key has to be encoded the way the script has it, which for pay-to-pubkey-hash is the way the
+		// wallet has it, and for pay-to-witness-pubkey-hash is always compressed.
+		var pubKey []byte
+		if pka, ok := addr.(*util.AddressPubKey); ok {
+			pubKey = pka.ScriptAddress()
+		} else if mpka.Compressed() || class == txscript.WitnessV0PubKeyHashTy {
+			pubKey = mpka.PubKey().SerializeCompressed()
+		} else {
+			pubKey = mpka.PubKey().SerializeUncompressed()
+		}
+		var signed bool
+		for _, ps := range p.Inputs[inIndex].PartialSigs {
+			if bytes.Equal(ps.PubKey, pubKey) {
+				signed = true
+			}
+		}
+		if signed {
+			continue
+		}
+		privKey, err := mpka.PrivKey()
+		if err != nil {
+			Error(err)
+			return err
+		}
+		var sig []byte
+		if class == txscript.WitnessV0PubKeyHashTy {
+			sig, err = txscript.RawTxInWitnessSignature(p.UnsignedTx, sigHashes, inIndex, amount, script, hashType,
+				privKey)
+		} else {
+			sig, err = txscript.RawTxInSignature(p.UnsignedTx, inIndex, script, hashType, privKey)
+		}
+		if err != nil {
+			Error(err)
+			return err
+		}
+		if err = p.AddPartialSig(inIndex, sig, pubKey); err != nil {
+			Error(err)
+			return err
+		}
+	}
+	return nil
+}
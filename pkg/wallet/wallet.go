@@ -54,6 +54,7 @@ var ErrNotSynced = errors.New("wallet is not synchronized with the chain server"
 var (
 	waddrmgrNamespaceKey = []byte("waddrmgr")
 	wtxmgrNamespaceKey   = []byte("wtxmgr")
+	wmetaNamespaceKey    = []byte("wmeta")
 )
 
 // Wallet is a structure containing all the components for a complete wallet. It contains the Armory-style key store
@@ -1005,6 +1006,7 @@ type (
 		outputs     []*wire.TxOut
 		minconf     int32
 		feeSatPerKB util.Amount
+		coinControl *CoinControl
 		resp        chan createTxResponse
 	}
 	createTxResponse struct {
@@ -1033,7 +1035,7 @@ out:
 			}
 			tx, err := w.txToOutputs(
 				txr.outputs, txr.account,
-				txr.minconf, txr.feeSatPerKB,
+				txr.minconf, txr.feeSatPerKB, txr.coinControl,
 			)
 			heldUnlock.release()
 			txr.resp <- createTxResponse{tx, err}
@@ -1047,16 +1049,17 @@ out:
 // CreateSimpleTx creates a new signed transaction spending unspent P2PKH outputs with at least minconf confirmations
 // spending to any number of address/amount pairs. Change and an appropriate transaction fee are automatically included,
 // if necessary. All transaction creation through this function is serialized to prevent the creation of many
-// transactions which spend the same outputs.
+// transactions which spend the same outputs. A non-nil coinControl chooses the outputs to spend, or how to choose them.
 func (w *Wallet) CreateSimpleTx(
 	account uint32, outputs []*wire.TxOut,
-	minconf int32, satPerKb util.Amount,
+	minconf int32, satPerKb util.Amount, coinControl *CoinControl,
 ) (*txauthor.AuthoredTx, error) {
 	req := createTxRequest{
 		account:     account,
 		outputs:     outputs,
 		minconf:     minconf,
 		feeSatPerKB: satPerKb,
+		coinControl: coinControl,
 		resp:        make(chan createTxResponse),
 	}
 	w.createTxRequests <- req
@@ -1388,6 +1391,53 @@ func (w *Wallet) CalculateAccountBalances(account uint32, confirms int32) (Balan
 	return bals, err
 }
 
+// CalculateWalletBalances sums the amounts of all unspent transaction outputs of the wallet, whichever account they
+// belong to, and returns the balance.
+func (w *Wallet) CalculateWalletBalances(confirms int32) (Balances, error) {
+	var bals Balances
+	err := walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			syncBlock := w.Manager.SyncedTo()
+			unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			for i := range unspent {
+				output := &unspent[i]
+				bals.Total += output.Amount
+				if output.FromCoinBase && !confirmed(
+					int32(w.chainParams.CoinbaseMaturity),
+					output.Height, syncBlock.Height,
+				) {
+					bals.ImmatureReward += output.Amount
+				} else if confirmed(confirms, output.Height, syncBlock.Height) {
+					bals.Spendable += output.Amount
+				}
+			}
+			return nil
+		},
+	)
+	return bals, err
+}
+
+// TxCount returns the number of transactions known to the wallet, mined or not.
+func (w *Wallet) TxCount() (count int64, err error) {
+	err = walletdb.View(
+		w.db, func(tx walletdb.ReadTx) error {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+			return w.TxStore.RangeTransactions(
+				txmgrNs, 0, -1, func(details []wtxmgr.TxDetails) (bool, error) {
+					count += int64(len(details))
+					return false, nil
+				},
+			)
+		},
+	)
+	return
+}
+
 // CurrentAddress gets the most recently requested Bitcoin payment address from a wallet for a particular key-chain
 // scope. If the address has already been used (there is at least one transaction spending to it in the blockchain or
 // pod mempool), the next chained address is returned.
@@ -1714,6 +1764,7 @@ func listTransactions(
 	syncHeight int32, net *netparams.Params,
 ) []btcjson.ListTransactionsResult {
 	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+	metaNs := tx.ReadBucket(wmetaNamespaceKey)
 	var (
 		blockHashStr  string
 		blockTime     int64
@@ -1726,10 +1777,16 @@ func listTransactions(
 	}
 	results := []btcjson.ListTransactionsResult{}
 	txHashStr := details.Hash.String()
+	comment := fetchTxComment(metaNs, &details.Hash)
 	received := details.Received.Unix()
 	generated := blockchain.IsCoinBaseTx(&details.MsgTx)
 	recvCat := RecvCategory(details, syncHeight, net).String()
 	send := len(details.Debits) != 0
+	// Mined transactions can no longer be replaced. Replaceability inherited from unconfirmed ancestors is not reported.
+	replaceable := "no"
+	if details.Block.Height == -1 && signalsReplacement(&details.MsgTx) {
+		replaceable = "yes"
+	}
 	// Fee can only be determined if every input is a debit.
 	var feeF64 float64
 	if len(details.Debits) == len(details.MsgTx.TxIn) {
@@ -1788,17 +1845,21 @@ outputs:
 			//   Category
 			//   Amount
 			//   Fee
-			Address:         address,
-			Vout:            uint32(i),
-			Confirmations:   confirmations,
-			Generated:       generated,
-			BlockHash:       blockHashStr,
-			BlockIndex:      blockIndex,
-			BlockTime:       blockTime,
-			TxID:            txHashStr,
-			WalletConflicts: []string{},
-			Time:            received,
-			TimeReceived:    received,
+			Address:           address,
+			Vout:              uint32(i),
+			Confirmations:     confirmations,
+			Generated:         generated,
+			BlockHash:         blockHashStr,
+			BlockIndex:        blockIndex,
+			BlockTime:         blockTime,
+			TxID:              txHashStr,
+			WalletConflicts:   []string{},
+			Time:              received,
+			TimeReceived:      received,
+			Comment:           comment.Comment,
+			To:                comment.To,
+			Label:             fetchAddressLabel(metaNs, address),
+			BIP125Replaceable: replaceable,
 		}
 		// Add a received/generated/immature result if this is a credit. If the output was spent, create a second result
 		// under the send category with the inverse of the output amount. It is therefore possible that a single output
@@ -2996,10 +3057,11 @@ func (w *Wallet) TotalReceivedForAddr(addr util.Address, minConf int32) (util.Am
 	return amount, err
 }
 
-// SendOutputs creates and sends payment transactions. It returns the transaction hash upon success.
+// SendOutputs creates and sends payment transactions. It returns the transaction hash upon success. A non-nil
+// coinControl chooses the outputs to spend, or how to choose them.
 func (w *Wallet) SendOutputs(
 	outputs []*wire.TxOut, account uint32,
-	minconf int32, satPerKb util.Amount,
+	minconf int32, satPerKb util.Amount, coinControl *CoinControl,
 ) (*chainhash.Hash, error) {
 	// Ensure the outputs to be created adhere to the network's consensus rules.
 	for _, output := range outputs {
@@ -3009,7 +3071,7 @@ func (w *Wallet) SendOutputs(
 	}
 	// Create the transaction and broadcast it to the network. The transaction will be added to the database in order to
 	// ensure that we continue to re-broadcast the transaction upon restarts until it has been confirmed.
-	createdTx, err := w.CreateSimpleTx(account, outputs, minconf, satPerKb)
+	createdTx, err := w.CreateSimpleTx(account, outputs, minconf, satPerKb, coinControl)
 	if err != nil {
 		Error(err)
 		return nil, err
@@ -3287,6 +3349,14 @@ func Create(
 				Error(err)
 				return err
 			}
+			metaNs, err := tx.CreateTopLevelBucket(wmetaNamespaceKey)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			if err = createMetadataBuckets(metaNs); err != nil {
+				return err
+			}
 			err = waddrmgr.Create(
 				addrmgrNs, seed, pubPass, privPass, params, nil,
 				birthday,
@@ -3347,6 +3417,24 @@ func Open(
 		Error(err)
 		return nil, err
 	}
+	// Wallets created before the metadata namespace existed gain it the first time they are opened.
+	err = walletdb.Update(
+		db, func(tx walletdb.ReadWriteTx) error {
+			metaNs := tx.ReadWriteBucket(wmetaNamespaceKey)
+			if metaNs == nil {
+				var err error
+				if metaNs, err = tx.CreateTopLevelBucket(wmetaNamespaceKey); err != nil {
+					Error(err)
+					return err
+				}
+			}
+			return createMetadataBuckets(metaNs)
+		},
+	)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
 	// Open database abstraction instances
 	var (
 		addrMgr *waddrmgr.Manager